@@ -16,15 +16,25 @@ DROP TABLE IF EXISTS `users`;
 CREATE TABLE `users` (
   `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '用户ID',
   `username` VARCHAR(50) NOT NULL COMMENT '用户名',
+  `username_canonical` VARCHAR(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT '规范化用户名（小写），用于唯一性检查和查找',
   `email` VARCHAR(255) NOT NULL COMMENT '邮箱',
   `password_hash` VARCHAR(255) NOT NULL COMMENT '密码哈希',
   `avatar_url` VARCHAR(500) DEFAULT '' COMMENT '头像URL',
+  `timezone` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '时区（IANA 名称），为空时使用服务器默认时区',
   `status` VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT '用户状态: active/inactive/suspended',
+  `role` VARCHAR(20) NOT NULL DEFAULT 'user' COMMENT '用户角色: user/admin',
+  `email_verified` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '邮箱是否已验证',
+  `pending_email` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '待确认的新邮箱',
+  `failed_login_attempts` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '统计窗口内登录失败次数',
+  `last_failed_login_at` DATETIME(3) DEFAULT NULL COMMENT '最近一次登录失败时间',
+  `locked_until` DATETIME(3) DEFAULT NULL COMMENT '登录锁定截止时间',
+  `username_changed_at` DATETIME(3) DEFAULT NULL COMMENT '最近一次修改用户名的时间',
   `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
   `deleted_at` DATETIME(3) DEFAULT NULL COMMENT '删除时间（软删除）',
   PRIMARY KEY (`id`),
   UNIQUE KEY `uk_username` (`username`),
+  UNIQUE KEY `uk_username_canonical` (`username_canonical`),
   UNIQUE KEY `uk_email` (`email`),
   KEY `idx_status` (`status`),
   KEY `idx_created_at` (`created_at`),
@@ -40,6 +50,8 @@ CREATE TABLE `daily_notes` (
   `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户ID',
   `note_date` DATE NOT NULL COMMENT '笔记日期',
   `content` TEXT NOT NULL COMMENT '笔记内容',
+  `version` INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '乐观锁版本号',
+  `is_pinned` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否置顶',
   `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新时间',
   PRIMARY KEY (`id`),
@@ -49,6 +61,34 @@ CREATE TABLE `daily_notes` (
   CONSTRAINT `fk_daily_notes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='每日笔记表';
 
+-- ====================================================================
+-- 创建 daily_note_tags 表（每日笔记标签）
+-- ====================================================================
+DROP TABLE IF EXISTS `daily_note_tags`;
+CREATE TABLE `daily_note_tags` (
+  `note_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '笔记ID',
+  `tag` VARCHAR(32) NOT NULL COMMENT '标签（小写）',
+  PRIMARY KEY (`note_id`, `tag`),
+  KEY `idx_tag_note` (`tag`, `note_id`) COMMENT '按标签筛选笔记',
+  CONSTRAINT `fk_daily_note_tags_note` FOREIGN KEY (`note_id`) REFERENCES `daily_notes` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='每日笔记标签表';
+
+-- ====================================================================
+-- 创建 audit_logs 表（敏感操作审计日志）
+-- 不设置外键，用户被删除后审计日志仍然保留
+-- ====================================================================
+DROP TABLE IF EXISTS `audit_logs`;
+CREATE TABLE `audit_logs` (
+  `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '日志ID',
+  `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '被操作的用户ID',
+  `actor_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT 0 COMMENT '操作者用户ID，0 表示未知',
+  `action` VARCHAR(50) NOT NULL COMMENT '操作类型',
+  `metadata` JSON DEFAULT NULL COMMENT '附加信息',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '操作时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_created` (`user_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='审计日志表';
+
 -- ====================================================================
 -- 创建 todos 表（待办事项）
 -- ====================================================================
@@ -97,9 +137,9 @@ CREATE TABLE `notes` (
 
 -- 插入测试用户（密码: 123456，使用 bcrypt 哈希）
 -- 注意：实际使用中应使用强密码
-INSERT INTO `users` (`username`, `email`, `password_hash`, `avatar_url`, `status`, `created_at`, `updated_at`) VALUES
-('admin', 'admin@todo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', '', 'active', NOW(3), NOW(3)),
-('test_user', 'test@todo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', '', 'active', NOW(3), NOW(3));
+INSERT INTO `users` (`username`, `username_canonical`, `email`, `password_hash`, `avatar_url`, `status`, `email_verified`, `created_at`, `updated_at`) VALUES
+('admin', 'admin', 'admin@todo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', '', 'active', 1, NOW(3), NOW(3)),
+('test_user', 'test_user', 'test@todo.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', '', 'active', 1, NOW(3), NOW(3));
 
 -- 插入测试每日笔记
 INSERT INTO `daily_notes` (`user_id`, `note_date`, `content`, `created_at`, `updated_at`) VALUES
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"todolist/internal/infrastructure/config"
+	migrations "todolist/internal/infrastructure/persistence/migrations"
+	"todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/pkg/logger"
+)
+
+func main() {
+	action := flag.String("action", "up", "migration action: up | down | status")
+	version := flag.Int64("version", -1, "target version: up applies through it, down rolls back until it is the latest applied (0 rolls back all)")
+	flag.Parse()
+
+	if *action != "up" && *action != "down" && *action != "status" {
+		fmt.Fprintf(os.Stderr, "Unknown action %q, expected up, down or status\n", *action)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 与服务端使用相同的配置来源（CONFIG_FILE + 环境变量）
+	appCfg, err := config.LoadAppConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Init(appCfg.Logger.Apply(logger.DefaultConfig()))
+
+	// 迁移必须连上数据库，不使用服务端的降级启动逻辑
+	client, err := mysql.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = run(context.Background(), migrations.NewMigrator(client.GetDB()), *action, *version)
+	client.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run 执行指定的迁移操作
+//
+// version 为负数时表示未指定目标版本：up 执行全部迁移，down 只回滚最后一个迁移。
+func run(ctx context.Context, m *migrations.Migrator, action string, version int64) error {
+	switch action {
+	case "up":
+		if version < 0 {
+			return m.Up(ctx)
+		}
+		return m.UpTo(ctx, version)
+	case "down":
+		if version < 0 {
+			return m.Down(ctx)
+		}
+		return m.DownTo(ctx, version)
+	default:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	}
+}
+
+// printStatus 以表格形式输出迁移状态
+func printStatus(statuses []migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	pending := 0
+	for _, s := range statuses {
+		state, at := "applied", s.AppliedAt
+		if !s.Applied {
+			state, at = "pending", "-"
+			pending++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d applied, %d pending\n", len(statuses)-pending, pending)
+}
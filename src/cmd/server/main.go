@@ -1,25 +1,201 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/captcha"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/infrastructure/eventbus"
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/infrastructure/persistence/sqlite"
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/interfaces/job"
+	"todolist/internal/pkg/logger"
+	"todolist/internal/pkg/scheduler"
 	"todolist/internal/routes"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
-	fmt.Println("Starting Todo List Server on :8080...")
+	// Load configuration (CONFIG_FILE + env overrides) and initialize logger
+	appCfg, err := config.LoadAppConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Init(appCfg.Logger.Apply(logger.DefaultConfig()))
+
+	// JWT 配置无效时无法签发和校验 Token，直接退出
+	if _, err := config.LoadJWTConfig(); err != nil {
+		logger.Error("启动失败：JWT 配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	// 监听地址和超时配置无效时直接退出，避免以不安全的默认值运行
+	serverCfg, err := config.GetServerConfig()
+	if err != nil {
+		logger.Error("启动失败：HTTP 服务配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	// 默认时区决定未设置时区用户的"今天"，名称无效时直接退出
+	if _, err := config.GetTimezoneConfig(); err != nil {
+		logger.Error("启动失败：时区配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	// 生命周期事件异步分发，发布方不等待订阅者处理完成
+	busCfg, err := config.GetEventBusConfig()
+	if err != nil {
+		logger.Error("启动失败：事件总线配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+	bus := eventbus.NewAsyncBus(busCfg.BufferSize, busCfg.Workers)
+	bus.Subscribe(eventbus.LogHandler)
+	eventbus.SetBus(bus)
+	defer bus.Close()
+
+	// 启用注册人机验证时密钥缺失直接退出，避免注册在运行时全部失败
+	captchaCfg, err := config.GetCaptchaConfig()
+	if err != nil {
+		logger.Error("启动失败：注册人机验证配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+	if captchaCfg.Enabled() {
+		captcha.SetGuard(captcha.NewSiteVerifier(captchaCfg.VerifyURL, captchaCfg.Secret, captchaCfg.Timeout))
+	}
+
+	if _, err := config.GetEmailVerificationConfig(); err != nil {
+		logger.Error("启动失败：邮箱验证配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	// 密码策略在注册、修改密码时生效
+	policyCfg, err := config.GetPasswordPolicyConfig()
+	if err != nil {
+		logger.Error("启动失败：密码策略配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+	user.SetPasswordPolicy(user.PasswordPolicy{
+		MinLength:       policyCfg.MinLength,
+		RequiredClasses: policyCfg.RequiredClasses,
+		DisallowCommon:  policyCfg.DisallowCommon,
+	})
+	// 保留用户名在注册、修改用户名时生效
+	user.SetReservedUsernames(config.GetReservedUsernameConfig().Usernames)
+
+	purgeCfg, err := config.GetAccountPurgeConfig()
+	if err != nil {
+		logger.Error("启动失败：已注销账户清理配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	if _, err := config.GetCompressionConfig(); err != nil {
+		logger.Error("启动失败：响应压缩配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	if _, err := config.GetSessionConfig(); err != nil {
+		logger.Error("启动失败：会话配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	if _, err := config.GetRateLimitConfig(); err != nil {
+		logger.Error("启动失败：限流配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	if _, err := config.GetBodyLimitConfig(); err != nil {
+		logger.Error("启动失败：请求体大小限制配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	avatarCfg, err := config.GetAvatarConfig()
+	if err != nil {
+		logger.Error("启动失败：头像配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	dbCfg, err := config.LoadDatabaseConfig()
+	if err != nil {
+		logger.Error("启动失败：数据库配置无效", logger.Err(err))
+		os.Exit(1)
+	}
+
+	if dbCfg.Driver == config.DatabaseDriverSQLite {
+		// 本地开发使用 SQLite，启动时自动创建表结构
+		client, err := sqlite.Open(dbCfg.SQLitePath)
+		if err != nil {
+			logger.Error("启动失败：无法打开 SQLite 数据库", logger.Err(err))
+			os.Exit(1)
+		}
+		defer client.Close()
+		persistence.SetFactory(persistence.NewClientFactory(client))
+		logger.Info("使用 SQLite 数据库", logger.String("path", dbCfg.SQLitePath))
+	} else if _, err := mysql.GetClient(); err != nil {
+		// 数据库不可用时继续启动，依赖数据库的接口返回 503，数据库恢复后自动重连
+		logger.Warn("数据库暂不可用，依赖数据库的接口将返回 503", logger.Err(err))
+	}
 
 	// Initialize HTTP server
 	mux := http.NewServeMux()
-	routes.InitUserRoute(mux)
-	routes.InitHealthRoute(mux)
-	// Setup routes and middleware
+	routes.InitRoutes(mux, avatarCfg)
+	mux.Handle("GET /metrics", promhttp.Handler())
+
+	// 请求整体超时，上传、导出等耗时接口单独放宽
+	requestTimeout := middleware.RouteTimeoutMiddleware(mux, serverCfg.RequestTimeout, routes.RequestTimeoutOverrides(serverCfg))
+	// 未匹配路由返回 JSON 格式的 404/405
+	appHandler := handler.WithFallback(mux)
 
 	// Start server
-	if err := http.ListenAndServe(":8080", mux); err != nil {
-		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
-		os.Exit(1)
+	// 设置读写和空闲超时，防止慢速连接（slow-loris）和挂起的连接长期占用资源
+	server := &http.Server{
+		Addr:              serverCfg.Addr,
+		Handler:           middleware.MetricsMiddleware(middleware.LoggingMiddleware(middleware.GzipMiddleware(middleware.APIVersionMiddleware(middleware.MaxBodyBytesMiddleware(requestTimeout(appHandler)))))),
+		ReadTimeout:       serverCfg.ReadTimeout,
+		ReadHeaderTimeout: serverCfg.ReadHeaderTimeout,
+		WriteTimeout:      serverCfg.WriteTimeout,
+		IdleTimeout:       serverCfg.IdleTimeout,
+	}
+
+	// 收到 SIGINT/SIGTERM 后停止后台任务并关闭服务
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 注销超过保留期的账户连同笔记一起永久删除
+	if purgeCfg.Enabled() {
+		purgeDone := scheduler.Every(ctx, "purge_deleted_users", purgeCfg.Interval, job.PurgeDeletedUsers(purgeCfg.Retention))
+		defer func() { <-purgeDone }()
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		fmt.Printf("Starting Todo List Server on %s...\n", serverCfg.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		logger.Info("收到退出信号，正在关闭服务")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverCfg.WriteTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("关闭 HTTP 服务失败", logger.Err(err))
+		}
 	}
 }
@@ -11,45 +11,123 @@ package daily_note
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"todolist/internal/domain/daily_note"
+	"todolist/internal/domain/event"
 	applogger "todolist/internal/pkg/logger"
+	"todolist/internal/pkg/markdown"
 
 	"todolist/internal/interfaces/dto"
 )
 
 type DailyNoteApplicationService interface {
 	// CreateDailyNote 创建每日笔记
-	CreateDailyNote(ctx context.Context, userID int64, content string) (*dto.DailyNoteDTO, error)
+	CreateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string) (*dto.DailyNoteDTO, error)
+
+	// CopyPreviousDayNote 复制最近一篇历史笔记为今日笔记
+	CopyPreviousDayNote(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteDTO, error)
+
+	// BatchCreateDailyNotes 批量导入历史每日笔记
+	BatchCreateDailyNotes(ctx context.Context, userID int64, notes []BatchNoteInput) (*dto.DailyNoteBatchResultDTO, error)
 
 	// GetTodayDailyNote 获取今日的每日笔记
-	GetTodayDailyNote(ctx context.Context, userID int64) (*dto.DailyNoteDTO, error)
+	GetTodayDailyNote(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteDTO, error)
 
 	// GetDailyNoteList 根据用户ID分页获取每日笔记列表
-	GetDailyNoteList(ctx context.Context, userID int64, page, pageSize int) (*dto.DailyNotePageDTO, error)
+	GetDailyNoteList(ctx context.Context, userID int64, sort string, page, pageSize int) (*dto.DailyNotePageDTO, error)
+
+	// GetDailyNoteListByCursor 根据用户ID按游标分页获取每日笔记列表，tag 非空时只返回带有该标签的笔记
+	GetDailyNoteListByCursor(ctx context.Context, userID int64, tag, cursor string, limit int) (*dto.DailyNoteCursorPageDTO, error)
+
+	// GetDailyNoteListByRange 根据用户ID和日期区间（YYYY-MM-DD）分页获取每日笔记列表
+	GetDailyNoteListByRange(ctx context.Context, userID int64, from, to string, page, pageSize int) (*dto.DailyNotePageDTO, error)
+
+	// GetNotesForMonth 获取指定月份每天的笔记概况，year、month 均为 0 时为用户时区的当前月份
+	GetNotesForMonth(ctx context.Context, userID int64, loc *time.Location, year, month int) ([]dto.DailyNoteCalendarDayDTO, error)
+
+	// GetStreak 获取连续写笔记天数统计
+	GetStreak(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteStreakDTO, error)
 
 	// UpdateDailyNote 更新今日的每日笔记
-	UpdateDailyNote(ctx context.Context, userID int64, content string) (*dto.DailyNoteDTO, error)
+	UpdateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string, expectedVersion int) (*dto.DailyNoteDTO, error)
 
 	// DeleteDailyNote 删除今日的每日笔记
-	DeleteDailyNote(ctx context.Context, userID int64) error
+	DeleteDailyNote(ctx context.Context, userID int64, loc *time.Location) error
+
+	// RenderDailyNote 将用户指定 ID 的每日笔记内容渲染为 HTML
+	RenderDailyNote(ctx context.Context, userID, noteID int64) (*dto.DailyNoteRenderDTO, error)
+
+	// DeleteDailyNoteByID 删除用户指定 ID 的每日笔记
+	DeleteDailyNoteByID(ctx context.Context, userID, noteID int64) error
+
+	// DeleteAllDailyNotes 永久删除用户的全部每日笔记，返回删除的笔记数
+	DeleteAllDailyNotes(ctx context.Context, userID int64, confirmed bool) (int64, error)
+
+	// SetDailyNotePinned 置顶或取消置顶用户指定 ID 的每日笔记
+	SetDailyNotePinned(ctx context.Context, userID, noteID int64, pinned bool) (*dto.DailyNoteDTO, error)
+
+	// SetDailyNoteTags 替换用户指定 ID 的每日笔记的标签
+	SetDailyNoteTags(ctx context.Context, userID, noteID int64, tags []string) (*dto.DailyNoteDTO, error)
+}
+
+// BatchNoteInput 批量导入的单条笔记输入
+type BatchNoteInput struct {
+	// Date 笔记日期，格式为 YYYY-MM-DD
+	Date string
+
+	// Content 笔记内容
+	Content string
 }
 
 // DailyNoteApplicationServiceImpl 每日笔记应用服务实现
 type DailyNoteApplicationServiceImpl struct {
 	dailyNoteService daily_note.DailyNoteService
+
+	// eventBus 领域事件发布，默认丢弃事件
+	eventBus event.EventBus
+
+	// renderer 笔记内容的 Markdown 渲染器
+	renderer markdown.Renderer
+}
+
+// Option 每日笔记应用服务可选配置
+type Option func(*DailyNoteApplicationServiceImpl)
+
+// WithEventBus 设置领域事件总线，创建今日笔记成功后发布事件
+func WithEventBus(bus event.EventBus) Option {
+	return func(s *DailyNoteApplicationServiceImpl) {
+		if bus != nil {
+			s.eventBus = bus
+		}
+	}
+}
+
+// WithRenderer 设置 Markdown 渲染器，默认使用 markdown.GetRenderer()
+func WithRenderer(renderer markdown.Renderer) Option {
+	return func(s *DailyNoteApplicationServiceImpl) {
+		if renderer != nil {
+			s.renderer = renderer
+		}
+	}
 }
 
 // NewDailyNoteApplicationService 创建每日笔记应用服务实例
-func NewDailyNoteApplicationService(dailyNoteService daily_note.DailyNoteService) DailyNoteApplicationService {
-	return &DailyNoteApplicationServiceImpl{
+func NewDailyNoteApplicationService(dailyNoteService daily_note.DailyNoteService, opts ...Option) DailyNoteApplicationService {
+	s := &DailyNoteApplicationServiceImpl{
 		dailyNoteService: dailyNoteService,
+		eventBus:         event.NopBus{},
+		renderer:         markdown.GetRenderer(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // CreateDailyNote 创建每日笔记用例
-func (s *DailyNoteApplicationServiceImpl) CreateDailyNote(ctx context.Context, userID int64, content string) (*dto.DailyNoteDTO, error) {
+func (s *DailyNoteApplicationServiceImpl) CreateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string) (*dto.DailyNoteDTO, error) {
 	startTime := time.Now()
 
 	// 记录请求开始
@@ -58,7 +136,7 @@ func (s *DailyNoteApplicationServiceImpl) CreateDailyNote(ctx context.Context, u
 	)
 
 	// 调用领域服务执行业务逻辑
-	entity, err := s.dailyNoteService.CreateDailyNote(ctx, userID, content)
+	entity, err := s.dailyNoteService.CreateDailyNote(ctx, userID, loc, content)
 	if err != nil {
 		applogger.ErrorContext(ctx, "创建每日笔记失败",
 			applogger.Int64("user_id", userID),
@@ -78,11 +156,93 @@ func (s *DailyNoteApplicationServiceImpl) CreateDailyNote(ctx context.Context, u
 		applogger.Duration("duration_ms", duration),
 	)
 
+	s.eventBus.Publish(ctx, event.New(event.TypeDailyNoteCreated, userID, dailyNoteDTO.ID))
+
+	return &dailyNoteDTO, nil
+}
+
+// CopyPreviousDayNote 复制最近一篇历史笔记为今日笔记用例
+func (s *DailyNoteApplicationServiceImpl) CopyPreviousDayNote(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteDTO, error) {
+	startTime := time.Now()
+
+	applogger.InfoContext(ctx, "开始处理复制历史笔记请求",
+		applogger.Int64("user_id", userID),
+	)
+
+	entity, err := s.dailyNoteService.CopyPreviousDayNote(ctx, userID, loc)
+	if err != nil {
+		applogger.ErrorContext(ctx, "复制历史笔记失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	dailyNoteDTO := dto.ToDailyNoteDTO(entity)
+
+	applogger.InfoContext(ctx, "复制历史笔记成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("daily_note_id", dailyNoteDTO.ID),
+		applogger.Duration("duration_ms", time.Since(startTime)),
+	)
+
+	s.eventBus.Publish(ctx, event.New(event.TypeDailyNoteCreated, userID, dailyNoteDTO.ID))
+
 	return &dailyNoteDTO, nil
 }
 
+// BatchCreateDailyNotes 批量导入历史每日笔记用例
+func (s *DailyNoteApplicationServiceImpl) BatchCreateDailyNotes(ctx context.Context, userID int64, notes []BatchNoteInput) (*dto.DailyNoteBatchResultDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理批量导入每日笔记请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int("count", len(notes)),
+	)
+
+	// 解析日期参数
+	batch := make([]daily_note.BatchNote, 0, len(notes))
+	for i, note := range notes {
+		noteDate, err := daily_note.ParseNoteDate(note.Date)
+		if err != nil {
+			applogger.WarnContext(ctx, "批量导入笔记日期格式无效",
+				applogger.Int64("user_id", userID),
+				applogger.Int("index", i),
+				applogger.String("date", note.Date),
+			)
+			return nil, err
+		}
+		batch = append(batch, daily_note.BatchNote{NoteDate: noteDate, Content: note.Content})
+	}
+
+	// 调用领域服务执行业务逻辑
+	result, err := s.dailyNoteService.BatchCreateDailyNotes(ctx, userID, batch)
+	if err != nil {
+		applogger.ErrorContext(ctx, "批量导入每日笔记失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	// 转换为DTO
+	resultDTO := dto.ToDailyNoteBatchResultDTO(result)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "批量导入每日笔记成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int("created", resultDTO.Created),
+		applogger.Int("skipped", resultDTO.Skipped),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &resultDTO, nil
+}
+
 // GetTodayDailyNote 获取今日的每日笔记用例
-func (s *DailyNoteApplicationServiceImpl) GetTodayDailyNote(ctx context.Context, userID int64) (*dto.DailyNoteDTO, error) {
+func (s *DailyNoteApplicationServiceImpl) GetTodayDailyNote(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteDTO, error) {
 	startTime := time.Now()
 
 	// 记录请求开始
@@ -91,7 +251,7 @@ func (s *DailyNoteApplicationServiceImpl) GetTodayDailyNote(ctx context.Context,
 	)
 
 	// 调用领域服务执行业务逻辑
-	entity, err := s.dailyNoteService.GetTodayDailyNote(ctx, userID)
+	entity, err := s.dailyNoteService.GetTodayDailyNote(ctx, userID, loc)
 	if err != nil {
 		// 对于"未找到"错误，使用Info级别而不是Warn，因为这是正常业务场景
 		if errors.Is(err, daily_note.ErrDailyNoteNotFound) {
@@ -123,18 +283,31 @@ func (s *DailyNoteApplicationServiceImpl) GetTodayDailyNote(ctx context.Context,
 }
 
 // GetDailyNoteList 根据用户ID分页获取每日笔记列表用例
-func (s *DailyNoteApplicationServiceImpl) GetDailyNoteList(ctx context.Context, userID int64, page, pageSize int) (*dto.DailyNotePageDTO, error) {
+//
+// sort 可选值为 date_asc、date_desc、updated_desc、pinned_first，为空时按日期降序。
+func (s *DailyNoteApplicationServiceImpl) GetDailyNoteList(ctx context.Context, userID int64, sort string, page, pageSize int) (*dto.DailyNotePageDTO, error) {
 	startTime := time.Now()
 
 	// 记录请求开始
 	applogger.InfoContext(ctx, "开始处理分页获取每日笔记列表请求",
 		applogger.Int64("user_id", userID),
+		applogger.String("sort", sort),
 		applogger.Int("page", page),
 		applogger.Int("page_size", pageSize),
 	)
 
+	// 解析排序方式
+	sortOrder, err := daily_note.ParseSortOrder(sort)
+	if err != nil {
+		applogger.WarnContext(ctx, "排序方式无效",
+			applogger.Int64("user_id", userID),
+			applogger.String("sort", sort),
+		)
+		return nil, err
+	}
+
 	// 调用领域服务执行业务逻辑
-	entities, total, err := s.dailyNoteService.GetDailyNoteList(ctx, userID, page, pageSize)
+	entities, total, err := s.dailyNoteService.GetDailyNoteList(ctx, userID, sortOrder, page, pageSize)
 	if err != nil {
 		applogger.ErrorContext(ctx, "分页获取每日笔记列表失败",
 			applogger.Int64("user_id", userID),
@@ -160,25 +333,225 @@ func (s *DailyNoteApplicationServiceImpl) GetDailyNoteList(ctx context.Context,
 	return &pageDTO, nil
 }
 
-// UpdateDailyNote 更新今日的每日笔记用例
-func (s *DailyNoteApplicationServiceImpl) UpdateDailyNote(ctx context.Context, userID int64, content string) (*dto.DailyNoteDTO, error) {
+// GetDailyNoteListByCursor 根据用户ID按游标分页获取每日笔记列表用例
+//
+// cursor 为上一页返回的 next_cursor，为空时从最新的笔记开始；
+// tag 为空白时不按标签筛选。
+func (s *DailyNoteApplicationServiceImpl) GetDailyNoteListByCursor(ctx context.Context, userID int64, tag, cursor string, limit int) (*dto.DailyNoteCursorPageDTO, error) {
 	startTime := time.Now()
+	tag = strings.TrimSpace(tag)
 
 	// 记录请求开始
-	applogger.InfoContext(ctx, "开始处理更新今日每日笔记请求",
+	applogger.InfoContext(ctx, "开始处理游标分页获取每日笔记列表请求",
+		applogger.Int64("user_id", userID),
+		applogger.String("tag", tag),
+		applogger.String("cursor", cursor),
+		applogger.Int("limit", limit),
+	)
+
+	// 解析游标
+	cursorDate, err := daily_note.ParseCursor(cursor)
+	if err != nil {
+		applogger.WarnContext(ctx, "分页游标无效",
+			applogger.Int64("user_id", userID),
+			applogger.String("cursor", cursor),
+		)
+		return nil, err
+	}
+
+	// 调用领域服务执行业务逻辑
+	var entities []daily_note.DailyNoteEntity
+	var next time.Time
+	if tag != "" {
+		entities, next, err = s.dailyNoteService.GetDailyNoteListByTagAfter(ctx, userID, tag, cursorDate, limit)
+	} else {
+		entities, next, err = s.dailyNoteService.GetDailyNoteListAfter(ctx, userID, cursorDate, limit)
+	}
+	if err != nil {
+		applogger.ErrorContext(ctx, "游标分页获取每日笔记列表失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	// 转换为游标分页DTO
+	pageDTO := dto.ToDailyNoteCursorPageDTO(entities, next)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "游标分页获取每日笔记列表成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int("count", len(pageDTO.Data)),
+		applogger.String("next_cursor", pageDTO.NextCursor),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &pageDTO, nil
+}
+
+// GetDailyNoteListByRange 根据用户ID和日期区间分页获取每日笔记列表用例
+func (s *DailyNoteApplicationServiceImpl) GetDailyNoteListByRange(ctx context.Context, userID int64, from, to string, page, pageSize int) (*dto.DailyNotePageDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理按日期区间获取每日笔记列表请求",
+		applogger.Int64("user_id", userID),
+		applogger.String("from", from),
+		applogger.String("to", to),
+		applogger.Int("page", page),
+		applogger.Int("page_size", pageSize),
+	)
+
+	// 解析日期参数
+	fromDate, err := daily_note.ParseNoteDate(from)
+	if err != nil {
+		applogger.WarnContext(ctx, "开始日期格式无效",
+			applogger.Int64("user_id", userID),
+			applogger.String("from", from),
+		)
+		return nil, err
+	}
+	toDate, err := daily_note.ParseNoteDate(to)
+	if err != nil {
+		applogger.WarnContext(ctx, "结束日期格式无效",
+			applogger.Int64("user_id", userID),
+			applogger.String("to", to),
+		)
+		return nil, err
+	}
+
+	// 调用领域服务执行业务逻辑
+	entities, total, err := s.dailyNoteService.GetDailyNoteListByRange(ctx, userID, fromDate, toDate, page, pageSize)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteDateRangeInvalid) {
+			applogger.WarnContext(ctx, "日期区间无效",
+				applogger.Int64("user_id", userID),
+				applogger.String("from", from),
+				applogger.String("to", to),
+			)
+		} else {
+			applogger.ErrorContext(ctx, "按日期区间获取每日笔记列表失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err),
+			)
+		}
+		return nil, err
+	}
+
+	// 转换为分页DTO
+	pageDTO := dto.ToDailyNotePageDTO(entities, total, page, pageSize)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "按日期区间获取每日笔记列表成功",
+		applogger.Int64("user_id", userID),
+		applogger.String("from", from),
+		applogger.String("to", to),
+		applogger.Int64("total", total),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &pageDTO, nil
+}
+
+// GetNotesForMonth 获取指定月份每天的笔记概况用例
+func (s *DailyNoteApplicationServiceImpl) GetNotesForMonth(ctx context.Context, userID int64, loc *time.Location, year, month int) ([]dto.DailyNoteCalendarDayDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理获取每日笔记月历请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int("year", year),
+		applogger.Int("month", month),
+	)
+
+	// 调用领域服务执行业务逻辑
+	days, err := s.dailyNoteService.GetNotesForMonth(ctx, userID, loc, year, month)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteMonthInvalid) {
+			applogger.WarnContext(ctx, "月历年月无效",
+				applogger.Int64("user_id", userID),
+				applogger.Int("year", year),
+				applogger.Int("month", month),
+			)
+		} else {
+			applogger.ErrorContext(ctx, "获取每日笔记月历失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err),
+			)
+		}
+		return nil, err
+	}
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "获取每日笔记月历成功",
 		applogger.Int64("user_id", userID),
+		applogger.Int("days", len(days)),
+		applogger.Duration("duration_ms", duration),
 	)
 
+	return dto.ToDailyNoteCalendarDTOs(days), nil
+}
+
+// GetStreak 获取连续写笔记天数统计用例
+func (s *DailyNoteApplicationServiceImpl) GetStreak(ctx context.Context, userID int64, loc *time.Location) (*dto.DailyNoteStreakDTO, error) {
+	startTime := time.Now()
+
 	// 调用领域服务执行业务逻辑
-	entity, err := s.dailyNoteService.UpdateDailyNote(ctx, userID, content)
+	streak, err := s.dailyNoteService.GetStreak(ctx, userID, loc)
 	if err != nil {
-		applogger.ErrorContext(ctx, "更新今日每日笔记失败",
+		applogger.ErrorContext(ctx, "获取每日笔记连续天数统计失败",
 			applogger.Int64("user_id", userID),
 			applogger.Err(err),
 		)
 		return nil, err
 	}
 
+	// 转换为DTO
+	streakDTO := dto.ToDailyNoteStreakDTO(streak)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "获取每日笔记连续天数统计成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int("current_streak", streakDTO.CurrentStreak),
+		applogger.Int("longest_streak", streakDTO.LongestStreak),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &streakDTO, nil
+}
+
+// UpdateDailyNote 更新今日的每日笔记用例
+func (s *DailyNoteApplicationServiceImpl) UpdateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string, expectedVersion int) (*dto.DailyNoteDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理更新今日每日笔记请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int("expected_version", expectedVersion),
+	)
+
+	// 调用领域服务执行业务逻辑
+	entity, err := s.dailyNoteService.UpdateDailyNote(ctx, userID, loc, content, expectedVersion)
+	if err != nil {
+		// 版本冲突属于正常并发场景，使用Warn级别
+		if errors.Is(err, daily_note.ErrDailyNoteConflict) {
+			applogger.WarnContext(ctx, "更新今日每日笔记版本冲突",
+				applogger.Int64("user_id", userID),
+				applogger.Int("expected_version", expectedVersion),
+			)
+		} else {
+			applogger.ErrorContext(ctx, "更新今日每日笔记失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err),
+			)
+		}
+		return nil, err
+	}
+
 	// 转换为DTO
 	dailyNoteDTO := dto.ToDailyNoteDTO(entity)
 
@@ -187,6 +560,7 @@ func (s *DailyNoteApplicationServiceImpl) UpdateDailyNote(ctx context.Context, u
 	applogger.InfoContext(ctx, "更新今日每日笔记成功",
 		applogger.Int64("user_id", userID),
 		applogger.Int64("daily_note_id", dailyNoteDTO.ID),
+		applogger.Int("version", dailyNoteDTO.Version),
 		applogger.Duration("duration_ms", duration),
 	)
 
@@ -194,7 +568,7 @@ func (s *DailyNoteApplicationServiceImpl) UpdateDailyNote(ctx context.Context, u
 }
 
 // DeleteDailyNote 删除今日的每日笔记用例
-func (s *DailyNoteApplicationServiceImpl) DeleteDailyNote(ctx context.Context, userID int64) error {
+func (s *DailyNoteApplicationServiceImpl) DeleteDailyNote(ctx context.Context, userID int64, loc *time.Location) error {
 	startTime := time.Now()
 
 	// 记录请求开始
@@ -203,7 +577,7 @@ func (s *DailyNoteApplicationServiceImpl) DeleteDailyNote(ctx context.Context, u
 	)
 
 	// 调用领域服务执行业务逻辑
-	err := s.dailyNoteService.DeleteDailyNote(ctx, userID)
+	err := s.dailyNoteService.DeleteDailyNote(ctx, userID, loc)
 	if err != nil {
 		applogger.ErrorContext(ctx, "删除今日每日笔记失败",
 			applogger.Int64("user_id", userID),
@@ -221,3 +595,225 @@ func (s *DailyNoteApplicationServiceImpl) DeleteDailyNote(ctx context.Context, u
 
 	return nil
 }
+
+// RenderDailyNote 将用户指定 ID 的每日笔记内容渲染为 HTML 用例
+func (s *DailyNoteApplicationServiceImpl) RenderDailyNote(ctx context.Context, userID, noteID int64) (*dto.DailyNoteRenderDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理渲染每日笔记请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+	)
+
+	// 调用领域服务获取笔记（含归属校验）
+	entity, err := s.dailyNoteService.GetDailyNoteByID(ctx, userID, noteID)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteNotFound) {
+			applogger.WarnContext(ctx, "渲染每日笔记失败：笔记不存在",
+				applogger.Int64("user_id", userID),
+				applogger.Int64("note_id", noteID),
+			)
+			return nil, err
+		}
+		applogger.ErrorContext(ctx, "渲染每日笔记失败",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("note_id", noteID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	html, err := s.renderer.Render(entity.GetContent())
+	if err != nil {
+		applogger.ErrorContext(ctx, "渲染每日笔记失败：Markdown 渲染出错",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("note_id", noteID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "渲染每日笔记成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Int("html_length", len(html)),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &dto.DailyNoteRenderDTO{
+		ID:       entity.GetID(),
+		NoteDate: dto.NewDate(entity.GetNoteDate()),
+		Version:  entity.GetVersion(),
+		HTML:     html,
+	}, nil
+}
+
+// DeleteDailyNoteByID 删除用户指定 ID 的每日笔记用例
+func (s *DailyNoteApplicationServiceImpl) DeleteDailyNoteByID(ctx context.Context, userID, noteID int64) error {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理删除每日笔记请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+	)
+
+	// 调用领域服务执行业务逻辑
+	err := s.dailyNoteService.DeleteDailyNoteByID(ctx, userID, noteID)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteNotFound) {
+			applogger.WarnContext(ctx, "删除每日笔记失败：笔记不存在",
+				applogger.Int64("user_id", userID),
+				applogger.Int64("note_id", noteID),
+			)
+			return err
+		}
+		applogger.ErrorContext(ctx, "删除每日笔记失败",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("note_id", noteID),
+			applogger.Err(err),
+		)
+		return err
+	}
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "删除每日笔记成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return nil
+}
+
+// DeleteAllDailyNotes 删除用户全部每日笔记用例
+func (s *DailyNoteApplicationServiceImpl) DeleteAllDailyNotes(ctx context.Context, userID int64, confirmed bool) (int64, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理删除全部每日笔记请求",
+		applogger.Int64("user_id", userID),
+		applogger.Bool("confirmed", confirmed),
+	)
+
+	// 调用领域服务执行业务逻辑
+	deleted, err := s.dailyNoteService.DeleteAllDailyNotes(ctx, userID, confirmed)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteDeleteAllNotConfirmed) {
+			applogger.WarnContext(ctx, "删除全部每日笔记失败：未确认",
+				applogger.Int64("user_id", userID),
+			)
+			return 0, err
+		}
+		applogger.ErrorContext(ctx, "删除全部每日笔记失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err),
+		)
+		return 0, err
+	}
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "删除全部每日笔记成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("deleted", deleted),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return deleted, nil
+}
+
+// SetDailyNotePinned 置顶或取消置顶用户指定 ID 的每日笔记用例
+func (s *DailyNoteApplicationServiceImpl) SetDailyNotePinned(ctx context.Context, userID, noteID int64, pinned bool) (*dto.DailyNoteDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理每日笔记置顶请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Bool("pinned", pinned),
+	)
+
+	// 调用领域服务执行业务逻辑
+	entity, err := s.dailyNoteService.SetDailyNotePinned(ctx, userID, noteID, pinned)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteNotFound) {
+			applogger.WarnContext(ctx, "每日笔记置顶失败：笔记不存在",
+				applogger.Int64("user_id", userID),
+				applogger.Int64("note_id", noteID),
+			)
+			return nil, err
+		}
+		applogger.ErrorContext(ctx, "每日笔记置顶失败",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("note_id", noteID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	// 转换为DTO
+	dailyNoteDTO := dto.ToDailyNoteDTO(entity)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "每日笔记置顶成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Bool("pinned", pinned),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &dailyNoteDTO, nil
+}
+
+// SetDailyNoteTags 替换用户指定 ID 的每日笔记的标签用例
+func (s *DailyNoteApplicationServiceImpl) SetDailyNoteTags(ctx context.Context, userID, noteID int64, tags []string) (*dto.DailyNoteDTO, error) {
+	startTime := time.Now()
+
+	// 记录请求开始
+	applogger.InfoContext(ctx, "开始处理设置每日笔记标签请求",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Int("tag_count", len(tags)),
+	)
+
+	// 调用领域服务执行业务逻辑
+	entity, err := s.dailyNoteService.SetDailyNoteTags(ctx, userID, noteID, tags)
+	if err != nil {
+		if errors.Is(err, daily_note.ErrDailyNoteNotFound) ||
+			errors.Is(err, daily_note.ErrDailyNoteTooManyTags) ||
+			errors.Is(err, daily_note.ErrDailyNoteTagTooLong) {
+			applogger.WarnContext(ctx, "设置每日笔记标签失败",
+				applogger.Int64("user_id", userID),
+				applogger.Int64("note_id", noteID),
+				applogger.Err(err),
+			)
+			return nil, err
+		}
+		applogger.ErrorContext(ctx, "设置每日笔记标签失败",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("note_id", noteID),
+			applogger.Err(err),
+		)
+		return nil, err
+	}
+
+	// 转换为DTO
+	dailyNoteDTO := dto.ToDailyNoteDTO(entity)
+
+	// 记录成功日志
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "设置每日笔记标签成功",
+		applogger.Int64("user_id", userID),
+		applogger.Int64("note_id", noteID),
+		applogger.Int("tag_count", len(dailyNoteDTO.Tags)),
+		applogger.Duration("duration_ms", duration),
+	)
+
+	return &dailyNoteDTO, nil
+}
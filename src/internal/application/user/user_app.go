@@ -10,10 +10,19 @@ package user
 
 import (
 	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"strings"
 	"time"
 
+	"todolist/internal/domain/audit"
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/domain/event"
 	"todolist/internal/domain/user"
+	"todolist/internal/pkg/contextx"
 	applogger "todolist/internal/pkg/logger"
+	"todolist/internal/pkg/metrics"
 
 	"todolist/internal/interfaces/dto"
 )
@@ -21,7 +30,9 @@ import (
 type UserApplicationService interface {
 	Login(ctx context.Context, email string, pwd string) (*dto.UserDTO, error)
 
-	RegisterUser(ctx context.Context, username string, email string, password string) (*dto.UserDTO, error)
+	ReactivateAccount(ctx context.Context, email string, password string) (*dto.UserDTO, error)
+
+	RegisterUser(ctx context.Context, username string, email string, password string, captchaToken string) (*dto.UserDTO, error)
 
 	AuthenticateUser(ctx context.Context, email string, password string) (*dto.UserDTO, error)
 
@@ -29,7 +40,61 @@ type UserApplicationService interface {
 
 	UpdateEmail(ctx context.Context, userID int64, newEmail string) error
 
+	ConfirmEmailChange(ctx context.Context, userID int64, newEmail string) error
+
+	ChangeUsername(ctx context.Context, userID int64, newUsername string) error
+
 	UpdateAvatar(ctx context.Context, userID int64, avatarURL string) error
+
+	UpdateTimezone(ctx context.Context, userID int64, timezone string) error
+
+	UploadAvatar(ctx context.Context, userID int64, data []byte) (string, error)
+
+	GetCurrentUser(ctx context.Context, userID int64) (*dto.UserDTO, error)
+
+	RefreshSession(ctx context.Context, userID int64) (*dto.UserDTO, error)
+
+	GetPublicProfile(ctx context.Context, username string) (*dto.PublicProfileDTO, error)
+
+	CheckAvailability(ctx context.Context, username string, email string) (bool, error)
+
+	VerifyEmail(ctx context.Context, userID int64) error
+
+	DeleteAccount(ctx context.Context, userID int64, password string) error
+
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+
+	ListUsers(ctx context.Context, createdFrom, createdTo string, page, pageSize int) (*dto.UserPageDTO, error)
+
+	ListDeletedUsers(ctx context.Context, page, pageSize int) (*dto.UserPageDTO, error)
+
+	SearchUsers(ctx context.Context, query string, page, pageSize int) (*dto.UserPageDTO, error)
+
+	RestoreUser(ctx context.Context, userID int64) (*dto.UserDTO, error)
+
+	ChangeUsersStatus(ctx context.Context, ids []int64, status string) error
+
+	BulkRegisterUsers(ctx context.Context, users []ImportUserInput, strict bool) (*dto.UserImportResultDTO, error)
+
+	GetUserSummary(ctx context.Context, userID int64) (*dto.UserSummaryDTO, error)
+
+	ExportUserData(ctx context.Context, userID int64) (*dto.UserExportDTO, error)
+
+	GetUserStats(ctx context.Context) (*dto.UserStatsDTO, error)
+
+	ListAuditLogs(ctx context.Context, userID int64, page, pageSize int) (*dto.AuditLogPageDTO, error)
+}
+
+// ImportUserInput 批量导入的单个用户输入
+type ImportUserInput struct {
+	// Username 用户名
+	Username string
+
+	// Email 邮箱
+	Email string
+
+	// Password 明文密码
+	Password string
 }
 
 // UserApplicationService 用户应用服务。
@@ -40,7 +105,72 @@ type UserApplicationService interface {
 //
 // 通过依赖注入接收领域服务，遵循依赖倒置原则。
 type UserApplicationServiceImpl struct {
-	userService user.UserService
+	userService      user.UserService
+	dailyNoteService daily_note.DailyNoteService
+
+	// auditLogger 敏感操作审计日志记录器，未设置时不记录
+	auditLogger audit.AuditLogger
+
+	// auditLogReader 审计日志查询，ListAuditLogs 依赖此接口
+	auditLogReader audit.AuditLogReader
+
+	// defaultLocation 用户未设置时区时使用的默认时区，未设置时使用 time.Local
+	defaultLocation *time.Location
+
+	// eventBus 领域事件发布，默认丢弃事件
+	eventBus event.EventBus
+
+	// registrationGuard 注册前的人机验证，默认不校验
+	registrationGuard user.RegistrationGuard
+}
+
+// Option 用户应用服务可选配置
+type Option func(*UserApplicationServiceImpl)
+
+// WithDailyNoteService 设置每日笔记领域服务，GetUserSummary 和 ExportUserData 依赖此服务
+func WithDailyNoteService(dailyNoteService daily_note.DailyNoteService) Option {
+	return func(s *UserApplicationServiceImpl) {
+		s.dailyNoteService = dailyNoteService
+	}
+}
+
+// WithAuditLogger 设置审计日志记录器，修改密码、邮箱、用户名、状态以及注销和恢复账户时写入审计日志
+func WithAuditLogger(auditLogger audit.AuditLogger) Option {
+	return func(s *UserApplicationServiceImpl) {
+		s.auditLogger = auditLogger
+	}
+}
+
+// WithAuditLogReader 设置审计日志查询，ListAuditLogs 依赖此接口
+func WithAuditLogReader(auditLogReader audit.AuditLogReader) Option {
+	return func(s *UserApplicationServiceImpl) {
+		s.auditLogReader = auditLogReader
+	}
+}
+
+// WithDefaultLocation 设置用户未设置时区时使用的默认时区，GetUserSummary 按此确定用户的"今天"
+func WithDefaultLocation(loc *time.Location) Option {
+	return func(s *UserApplicationServiceImpl) {
+		s.defaultLocation = loc
+	}
+}
+
+// WithEventBus 设置领域事件总线，注册和确认邮箱变更成功后发布事件
+func WithEventBus(bus event.EventBus) Option {
+	return func(s *UserApplicationServiceImpl) {
+		if bus != nil {
+			s.eventBus = bus
+		}
+	}
+}
+
+// WithRegistrationGuard 设置注册防护，注册前校验请求携带的人机验证凭证
+func WithRegistrationGuard(guard user.RegistrationGuard) Option {
+	return func(s *UserApplicationServiceImpl) {
+		if guard != nil {
+			s.registrationGuard = guard
+		}
+	}
 }
 
 // NewUserApplicationService 创建用户应用服务。
@@ -48,14 +178,21 @@ type UserApplicationServiceImpl struct {
 // 参数：
 //
 //	userService - 用户领域服务（通过依赖注入传入）
+//	opts - 可选配置
 //
 // 返回：
 //
 //	UserApplicationService - 应用服务接口
-func NewUserApplicationService(userService user.UserService) UserApplicationService {
-	return &UserApplicationServiceImpl{
-		userService: userService,
+func NewUserApplicationService(userService user.UserService, opts ...Option) UserApplicationService {
+	s := &UserApplicationServiceImpl{
+		userService:       userService,
+		eventBus:          event.NopBus{},
+		registrationGuard: user.NopRegistrationGuard{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *UserApplicationServiceImpl) Login(
@@ -65,7 +202,7 @@ func (s *UserApplicationServiceImpl) Login(
 	if err != nil {
 		return nil, err
 	}
-	pwdVO, err := user.NewPassword(pwd)
+	pwdVO, err := user.ParsePassword(pwd)
 	if err != nil {
 		return nil, err
 	}
@@ -73,21 +210,78 @@ func (s *UserApplicationServiceImpl) Login(
 	// 调用领域服务进行登录验证
 	userEntity, err := s.userService.AuthenticateUser(ctx, emailVo, pwdVO)
 	if err != nil {
+		metrics.LoginAttemptsTotal.WithLabelValues(metrics.LoginResultFailure).Inc()
 		return nil, err
 	}
+	metrics.LoginAttemptsTotal.WithLabelValues(metrics.LoginResultSuccess).Inc()
+	s.upgradePasswordHash(ctx, userEntity, pwdVO)
 
 	// 将领域实体转换为DTO
 	userDTO := dto.ToUserDTO(userEntity)
 	return &userDTO, nil
 }
 
+// ReactivateAccount 停用账户自助恢复用例。
+//
+// 校验邮箱和密码后将停用（inactive）的账户恢复为 active，调用方随后按登录流程签发 Token。
+// 封禁的账户不能自助恢复。恢复成功后记录审计日志，操作者为用户本人。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	email - 邮箱（原始字符串）
+//	password - 密码（原始字符串）
+//
+// 返回：
+//
+//	*dto.UserDTO - 恢复后的用户 DTO
+//	error - 凭证错误、账户被封禁、未停用或被锁定时的错误
+func (s *UserApplicationServiceImpl) ReactivateAccount(ctx context.Context, email string, password string) (*dto.UserDTO, error) {
+	emailVO, err := user.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	pwdVO, err := user.ParsePassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	applogger.InfoContext(ctx, "开始处理账户恢复请求",
+		applogger.String("email", email))
+
+	entity, err := s.userService.ReactivateUser(ctx, emailVO, pwdVO)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) || errors.Is(err, user.ErrAccountBanned) ||
+			errors.Is(err, user.ErrAccountNotInactive) || errors.Is(err, user.ErrAccountLocked) ||
+			errors.Is(err, user.ErrEmailNotVerified) {
+			applogger.WarnContext(ctx, "账户恢复失败",
+				applogger.String("email", email),
+				applogger.Err(err))
+		} else {
+			applogger.ErrorContext(ctx, "账户恢复失败",
+				applogger.String("email", email),
+				applogger.Err(err))
+		}
+		return nil, err
+	}
+
+	applogger.InfoContext(ctx, "账户恢复成功",
+		applogger.Int64("user_id", entity.GetID()))
+
+	s.recordAuditAs(ctx, entity.GetID(), entity.GetID(), audit.ActionAccountReactivated, nil)
+
+	userDTO := dto.ToUserDTO(entity)
+	return &userDTO, nil
+}
+
 // RegisterUser 用户注册用例。
 //
 // 此用例包括以下步骤：
-// 1. 参数验证与值对象创建
-// 2. 调用领域服务执行业务逻辑
-// 3. 转换为 DTO
-// 4. 记录业务日志
+// 1. 人机验证（未启用注册防护时直接通过）
+// 2. 参数验证与值对象创建
+// 3. 调用领域服务执行业务逻辑
+// 4. 转换为 DTO
+// 5. 记录业务日志
 //
 // 职责说明：
 //   - 接收原始的 HTTP 请求数据（string）
@@ -101,16 +295,18 @@ func (s *UserApplicationServiceImpl) Login(
 //	username - 用户名（原始字符串）
 //	email - 邮箱（原始字符串）
 //	password - 密码（原始字符串）
+//	captchaToken - 人机验证凭证，未启用注册防护时忽略
 //
 // 返回：
 //
 //	*dto.UserDTO - 注册成功的用户 DTO
-//	error - 注册失败时的错误（包含验证失败和业务逻辑失败）
+//	error - 注册失败时的错误（包含人机验证失败、验证失败和业务逻辑失败）
 func (s *UserApplicationServiceImpl) RegisterUser(
 	ctx context.Context,
 	username string,
 	email string,
 	password string,
+	captchaToken string,
 ) (*dto.UserDTO, error) {
 	startTime := time.Now()
 
@@ -120,7 +316,23 @@ func (s *UserApplicationServiceImpl) RegisterUser(
 		applogger.String("email", email),
 	)
 
-	// 1. 参数验证与值对象创建
+	// 1. 人机验证，先于其他检查执行，避免自动注册探测用户名和邮箱是否已被占用
+	if err := s.registrationGuard.Verify(ctx, captchaToken); err != nil {
+		if errors.Is(err, user.ErrCaptchaFailed) {
+			applogger.WarnContext(ctx, "注册人机验证未通过",
+				applogger.String("username", username),
+				applogger.Err(err),
+			)
+		} else {
+			applogger.ErrorContext(ctx, "注册人机验证失败",
+				applogger.String("username", username),
+				applogger.Err(err),
+			)
+		}
+		return nil, err
+	}
+
+	// 2. 参数验证与值对象创建
 	usernameVO, err := user.NewUsername(username)
 	if err != nil {
 		applogger.WarnContext(ctx, "用户名验证失败",
@@ -147,7 +359,7 @@ func (s *UserApplicationServiceImpl) RegisterUser(
 		return nil, err
 	}
 
-	// 2. 调用领域服务执行业务逻辑
+	// 3. 调用领域服务执行业务逻辑
 	userEntity, err := s.userService.RegisterUser(ctx, usernameVO, emailVO, passwordVO)
 	if err != nil {
 		applogger.ErrorContext(ctx, "用户注册失败",
@@ -158,10 +370,10 @@ func (s *UserApplicationServiceImpl) RegisterUser(
 		return nil, err
 	}
 
-	// 3. 转换为 DTO
+	// 4. 转换为 DTO
 	userDTO := dto.ToUserDTO(userEntity)
 
-	// 4. 记录成功日志
+	// 5. 记录成功日志
 	duration := time.Since(startTime)
 	applogger.InfoContext(ctx, "用户注册成功",
 		applogger.Int64("user_id", userDTO.ID),
@@ -169,6 +381,8 @@ func (s *UserApplicationServiceImpl) RegisterUser(
 		applogger.Duration("duration_ms", duration),
 	)
 
+	s.eventBus.Publish(ctx, event.New(event.TypeUserRegistered, userDTO.ID, 0))
+
 	return &userDTO, nil
 }
 
@@ -208,7 +422,7 @@ func (s *UserApplicationServiceImpl) AuthenticateUser(
 		return nil, err
 	}
 
-	passwordVO, err := user.NewPassword(password)
+	passwordVO, err := user.ParsePassword(password)
 	if err != nil {
 		applogger.WarnContext(ctx, "密码验证失败",
 			applogger.Err(err),
@@ -224,6 +438,7 @@ func (s *UserApplicationServiceImpl) AuthenticateUser(
 			applogger.String("email", email))
 		return nil, err
 	}
+	s.upgradePasswordHash(ctx, userEntity, passwordVO)
 
 	// 3. 转换为 DTO
 	userDTO := dto.ToUserDTO(userEntity)
@@ -262,7 +477,7 @@ func (s *UserApplicationServiceImpl) ChangePassword(
 		applogger.Int64("user_id", userID))
 
 	// 1. 参数验证与值对象创建
-	oldPasswordVO, err := user.NewPassword(oldPassword)
+	oldPasswordVO, err := user.ParsePassword(oldPassword)
 	if err != nil {
 		applogger.WarnContext(ctx, "旧密码验证失败",
 			applogger.Err(err),
@@ -290,15 +505,17 @@ func (s *UserApplicationServiceImpl) ChangePassword(
 	applogger.InfoContext(ctx, "密码修改成功",
 		applogger.Int64("user_id", userID))
 
+	s.recordAudit(ctx, userID, audit.ActionPasswordChanged, nil)
+
 	return nil
 }
 
-// UpdateEmail 更新邮箱用例。
+// UpdateEmail 发起邮箱变更用例。
 //
 // 职责说明：
 //   - 接收原始的邮箱数据（string）
 //   - 负责值对象的创建和验证
-//   - 调用领域服务更新邮箱
+//   - 调用领域服务记录待确认邮箱，确认前登录邮箱保持不变
 //
 // 参数：
 //
@@ -337,9 +554,120 @@ func (s *UserApplicationServiceImpl) UpdateEmail(
 		return err
 	}
 
-	applogger.InfoContext(ctx, "邮箱更新成功",
+	applogger.InfoContext(ctx, "邮箱变更已发起，等待确认",
+		applogger.Int64("user_id", userID))
+
+	s.recordAudit(ctx, userID, audit.ActionEmailChangeRequested, map[string]string{
+		"new_email": newEmailVO.String(),
+	})
+
+	return nil
+}
+
+// ConfirmEmailChange 确认邮箱变更用例。
+//
+// 职责说明：
+//   - Token 的解析由接口层完成，此处接收已解析的用户 ID 和 Token 中的新邮箱
+//   - 调用领域服务将待确认邮箱设为登录邮箱
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//	newEmail - 确认 Token 中携带的新邮箱
+//
+// 返回：
+//
+//	error - 确认失败时的错误
+func (s *UserApplicationServiceImpl) ConfirmEmailChange(ctx context.Context, userID int64, newEmail string) error {
+	applogger.InfoContext(ctx, "开始确认邮箱变更",
+		applogger.Int64("user_id", userID))
+
+	// Token 中的邮箱在发起变更时已校验，无法解析说明 Token 内容异常
+	newEmailVO, err := user.NewEmail(newEmail)
+	if err != nil {
+		applogger.WarnContext(ctx, "确认 Token 中的邮箱无效",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return user.ErrEmailChangeTokenInvalid
+	}
+
+	err = s.userService.ConfirmEmailChange(ctx, userID, newEmailVO)
+	if err != nil {
+		applogger.ErrorContext(ctx, "确认邮箱变更失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "邮箱变更确认成功",
+		applogger.Int64("user_id", userID))
+
+	s.recordAudit(ctx, userID, audit.ActionEmailChanged, map[string]string{
+		"new_email": newEmailVO.String(),
+	})
+	s.eventBus.Publish(ctx, event.New(event.TypeUserEmailChanged, userID, 0))
+
+	return nil
+}
+
+// ChangeUsername 修改用户名用例。
+//
+// 职责说明：
+//   - 接收原始的用户名数据（string）
+//   - 负责值对象的创建和验证
+//   - 调用领域服务修改用户名（检查占用和冷却期）
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//	newUsername - 新用户名（原始字符串）
+//
+// 返回：
+//
+//	error - 修改失败时的错误
+func (s *UserApplicationServiceImpl) ChangeUsername(
+	ctx context.Context,
+	userID int64,
+	newUsername string,
+) error {
+	applogger.InfoContext(ctx, "开始修改用户名",
+		applogger.Int64("user_id", userID),
+		applogger.String("new_username", newUsername))
+
+	// 1. 参数验证与值对象创建
+	newUsernameVO, err := user.NewUsername(newUsername)
+	if err != nil {
+		applogger.WarnContext(ctx, "用户名格式验证失败",
+			applogger.String("username", newUsername),
+			applogger.Err(err),
+		)
+		return user.ErrUsernameInvalid
+	}
+
+	// 2. 调用领域服务修改用户名
+	err = s.userService.ChangeUsername(ctx, userID, newUsernameVO)
+	if err != nil {
+		if errors.Is(err, user.ErrUsernameTaken) || errors.Is(err, user.ErrUsernameReserved) || errors.Is(err, user.ErrUsernameChangeTooSoon) {
+			applogger.WarnContext(ctx, "修改用户名被拒绝",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err))
+			return err
+		}
+		applogger.ErrorContext(ctx, "修改用户名失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "用户名修改成功",
 		applogger.Int64("user_id", userID))
 
+	s.recordAudit(ctx, userID, audit.ActionUsernameChanged, map[string]string{
+		"new_username": newUsernameVO.String(),
+	})
+
 	return nil
 }
 
@@ -380,3 +708,883 @@ func (s *UserApplicationServiceImpl) UpdateAvatar(
 
 	return nil
 }
+
+// UpdateTimezone 更新时区用例。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//	timezone - IANA 时区名称，空字符串表示清除设置
+//
+// 返回：
+//
+//	error - 时区无效时返回 ErrTimezoneInvalid
+func (s *UserApplicationServiceImpl) UpdateTimezone(
+	ctx context.Context,
+	userID int64,
+	timezone string,
+) error {
+	applogger.InfoContext(ctx, "开始更新时区",
+		applogger.Int64("user_id", userID),
+		applogger.String("timezone", timezone))
+
+	err := s.userService.UpdateTimezone(ctx, userID, timezone)
+	if err != nil {
+		applogger.WarnContext(ctx, "更新时区失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "时区更新成功",
+		applogger.Int64("user_id", userID))
+
+	return nil
+}
+
+// UploadAvatar 上传头像用例。
+//
+// 职责说明：
+//   - 根据文件内容探测实际类型，不信任客户端声明的 Content-Type
+//   - 调用领域服务校验类型、存储文件并更新头像
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//	data - 头像文件内容
+//
+// 返回：
+//
+//	string - 头像 URL
+//	error - 上传失败时的错误
+func (s *UserApplicationServiceImpl) UploadAvatar(
+	ctx context.Context,
+	userID int64,
+	data []byte,
+) (string, error) {
+	contentType := http.DetectContentType(data)
+
+	applogger.InfoContext(ctx, "开始上传头像",
+		applogger.Int64("user_id", userID),
+		applogger.String("content_type", contentType),
+		applogger.Int("size", len(data)))
+
+	avatarURL, err := s.userService.UploadAvatar(ctx, userID, contentType, data)
+	if err != nil {
+		if errors.Is(err, user.ErrAvatarTypeUnsupported) {
+			applogger.WarnContext(ctx, "头像文件类型不支持",
+				applogger.Int64("user_id", userID),
+				applogger.String("content_type", contentType))
+			return "", err
+		}
+		applogger.ErrorContext(ctx, "上传头像失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return "", err
+	}
+
+	applogger.InfoContext(ctx, "头像上传成功",
+		applogger.Int64("user_id", userID),
+		applogger.String("avatar_url", avatarURL))
+
+	return avatarURL, nil
+}
+
+// GetCurrentUser 获取当前用户信息用例。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//
+// 返回：
+//
+//	*dto.UserDTO - 用户信息
+//	error - 用户不存在时返回 ErrUserNotFound
+func (s *UserApplicationServiceImpl) GetCurrentUser(ctx context.Context, userID int64) (*dto.UserDTO, error) {
+	entity, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		applogger.WarnContext(ctx, "获取当前用户失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	userDTO := dto.ToUserDTO(entity)
+	return &userDTO, nil
+}
+
+// RefreshSession 刷新 Token 用例。
+//
+// 刷新 Token 只证明用户曾经登录，签发新的访问 Token 前需重新读取用户：
+// 已注销的用户返回 ErrRefreshTokenInvalid，停用或封禁的用户返回 ErrAccountInactive、ErrAccountBanned，
+// 成功时返回的用户信息（含角色）反映当前状态。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 刷新 Token 中的用户 ID
+//
+// 返回：
+//
+//	*dto.UserDTO - 当前用户信息
+//	error - 用户不存在或账户不可用时的错误
+func (s *UserApplicationServiceImpl) RefreshSession(ctx context.Context, userID int64) (*dto.UserDTO, error) {
+	entity, err := s.userService.GetActiveUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			applogger.WarnContext(ctx, "刷新 Token 的用户不存在",
+				applogger.Int64("user_id", userID))
+			return nil, user.ErrRefreshTokenInvalid
+		}
+		if errors.Is(err, user.ErrAccountInactive) || errors.Is(err, user.ErrAccountBanned) {
+			applogger.WarnContext(ctx, "刷新 Token 的账户不可用",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err))
+		} else {
+			applogger.ErrorContext(ctx, "刷新 Token 时获取用户失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err))
+		}
+		return nil, err
+	}
+
+	userDTO := dto.ToUserDTO(entity)
+	return &userDTO, nil
+}
+
+// GetPublicProfile 获取用户公开资料用例。
+//
+// 只公开活跃用户，已注销、未激活和已封禁的用户均返回 ErrUserNotFound，
+// 不对外暴露这些账户是否存在。用户名格式无效时同样视为不存在。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	username - 用户名（原始字符串）
+//
+// 返回：
+//
+//	*dto.PublicProfileDTO - 用户公开资料
+//	error - 用户不存在或不公开时返回 ErrUserNotFound
+func (s *UserApplicationServiceImpl) GetPublicProfile(ctx context.Context, username string) (*dto.PublicProfileDTO, error) {
+	usernameVO, err := user.NewUsername(username)
+	if err != nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	entity, err := s.userService.GetUserByUsername(ctx, usernameVO)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, user.ErrUserNotFound
+		}
+		applogger.ErrorContext(ctx, "获取用户公开资料失败",
+			applogger.String("username", username),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	if entity.GetStatus() != user.UserStatusActive {
+		return nil, user.ErrUserNotFound
+	}
+
+	profileDTO := dto.ToPublicProfileDTO(entity)
+	return &profileDTO, nil
+}
+
+// GetUserSummary 获取用户首页概览用例。
+//
+// 合并用户资料、笔记总数、今日是否已写和当前连续天数，
+// 供客户端首页一次请求获取。"今天"按用户时区确定，未设置时使用默认时区。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//
+// 返回：
+//
+//	*dto.UserSummaryDTO - 用户概览
+//	error - 用户不存在时返回 ErrUserNotFound
+func (s *UserApplicationServiceImpl) GetUserSummary(ctx context.Context, userID int64) (*dto.UserSummaryDTO, error) {
+	if s.dailyNoteService == nil {
+		return nil, errors.New("daily note service not configured")
+	}
+
+	entity, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		applogger.WarnContext(ctx, "获取用户概览失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	loc := user.ResolveLocation(entity.GetTimezone(), s.defaultLocation)
+	summary, err := s.dailyNoteService.GetSummary(ctx, userID, loc)
+	if err != nil {
+		applogger.ErrorContext(ctx, "获取用户笔记概览失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	summaryDTO := dto.ToUserSummaryDTO(entity, summary)
+	return &summaryDTO, nil
+}
+
+// ExportUserData 导出用户数据用例。
+//
+// 返回用户资料和全部笔记。笔记在遍历时按笔记日期游标分批读取，
+// 不会一次性加载到内存；系统目前只有笔记，新增的用户数据需一并加入导出。
+//
+// 参数：
+//
+//	ctx - 请求上下文，遍历笔记时仍会使用
+//	userID - 用户 ID
+//
+// 返回：
+//
+//	*dto.UserExportDTO - 用户数据导出
+//	error - 用户不存在时返回 ErrUserNotFound
+func (s *UserApplicationServiceImpl) ExportUserData(ctx context.Context, userID int64) (*dto.UserExportDTO, error) {
+	if s.dailyNoteService == nil {
+		return nil, errors.New("daily note service not configured")
+	}
+
+	entity, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		applogger.WarnContext(ctx, "导出用户数据失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	applogger.InfoContext(ctx, "导出用户数据",
+		applogger.Int64("user_id", userID))
+
+	return &dto.UserExportDTO{
+		ExportedAt: time.Now(),
+		User:       dto.ToUserDTO(entity),
+		DailyNotes: s.exportDailyNotes(ctx, userID),
+	}, nil
+}
+
+// exportDailyNotes 返回按笔记日期降序分批读取用户全部笔记的迭代器
+func (s *UserApplicationServiceImpl) exportDailyNotes(ctx context.Context, userID int64) iter.Seq2[dto.DailyNoteDTO, error] {
+	return func(yield func(dto.DailyNoteDTO, error) bool) {
+		var cursor time.Time
+		for {
+			entities, next, err := s.dailyNoteService.GetDailyNoteListAfter(ctx, userID, cursor, daily_note.MaxPageSize)
+			if err != nil {
+				yield(dto.DailyNoteDTO{}, err)
+				return
+			}
+
+			for _, entity := range entities {
+				if !yield(dto.ToDailyNoteDTO(entity), nil) {
+					return
+				}
+			}
+
+			if next.IsZero() {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// CheckAvailability 检查用户名或邮箱是否可用于注册用例。
+//
+// username 与 email 必须且只能提供一个，
+// 并按注册时相同的值对象规则校验和规范化（邮箱去空格并转小写）。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	username - 待检查的用户名（原始字符串）
+//	email - 待检查的邮箱（原始字符串）
+//
+// 返回：
+//
+//	bool - 是否可用
+//	error - 参数无效或查询失败时的错误
+func (s *UserApplicationServiceImpl) CheckAvailability(ctx context.Context, username string, email string) (bool, error) {
+	if (username == "") == (email == "") {
+		return false, user.ErrAvailabilityQueryInvalid
+	}
+
+	if username != "" {
+		usernameVO, err := user.NewUsername(username)
+		if err != nil {
+			return false, user.ErrUsernameInvalid
+		}
+		available, err := s.userService.IsUsernameAvailable(ctx, usernameVO)
+		if err != nil {
+			applogger.ErrorContext(ctx, "检查用户名可用性失败",
+				applogger.Err(err))
+			return false, err
+		}
+		return available, nil
+	}
+
+	emailVO, err := user.NewEmail(email)
+	if err != nil {
+		return false, err
+	}
+	available, err := s.userService.IsEmailAvailable(ctx, emailVO)
+	if err != nil {
+		applogger.ErrorContext(ctx, "检查邮箱可用性失败",
+			applogger.Err(err))
+		return false, err
+	}
+	return available, nil
+}
+
+// VerifyEmail 邮箱验证用例。
+//
+// 职责说明：
+//   - 调用领域服务将用户邮箱标记为已验证
+//   - Token 的解析由接口层完成，此处只接收已解析的用户 ID
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//
+// 返回：
+//
+//	error - 验证失败时的错误
+func (s *UserApplicationServiceImpl) VerifyEmail(ctx context.Context, userID int64) error {
+	applogger.InfoContext(ctx, "开始验证邮箱",
+		applogger.Int64("user_id", userID))
+
+	err := s.userService.VerifyEmail(ctx, userID)
+	if err != nil {
+		applogger.ErrorContext(ctx, "邮箱验证失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "邮箱验证成功",
+		applogger.Int64("user_id", userID))
+
+	return nil
+}
+
+// DeleteAccount 注销账户用例。
+//
+// 职责说明：
+//   - 通过领域服务重新校验密码，防止被盗用的 Token 直接注销账户
+//   - 校验通过后软删除用户
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//	password - 当前密码（原始字符串）
+//
+// 返回：
+//
+//	error - 注销失败时的错误
+func (s *UserApplicationServiceImpl) DeleteAccount(ctx context.Context, userID int64, password string) error {
+	applogger.InfoContext(ctx, "开始注销账户",
+		applogger.Int64("user_id", userID))
+
+	// 1. 参数验证与值对象创建
+	passwordVO, err := user.ParsePassword(password)
+	if err != nil {
+		applogger.WarnContext(ctx, "密码格式验证失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return user.ErrPasswordIncorrect
+	}
+
+	// 2. 重新校验密码
+	if err := s.userService.VerifyUserPassword(ctx, userID, passwordVO); err != nil {
+		applogger.WarnContext(ctx, "注销账户密码校验失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	// 3. 软删除用户
+	if err := s.userService.SoftDeleteUser(ctx, userID); err != nil {
+		applogger.ErrorContext(ctx, "注销账户失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "账户注销成功",
+		applogger.Int64("user_id", userID))
+
+	s.recordAudit(ctx, userID, audit.ActionAccountDeleted, nil)
+
+	return nil
+}
+
+// PurgeDeletedUsers 清理已注销账户用例（后台定时任务）。
+//
+// 注销（软删除）超过保留期的账户连同每日笔记一起永久删除，保留期内的账户仍可由管理员恢复。
+//
+// 参数：
+//
+//	ctx - 任务上下文
+//	retention - 注销后的保留期，必须为正数
+//
+// 返回：
+//
+//	int64 - 删除的用户数
+//	error - 删除失败时的错误
+func (s *UserApplicationServiceImpl) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	startTime := time.Now()
+
+	purged, err := s.userService.PurgeDeletedUsers(ctx, retention)
+	if err != nil {
+		applogger.ErrorContext(ctx, "清理已注销账户失败",
+			applogger.Duration("retention", retention),
+			applogger.Err(err))
+		return 0, err
+	}
+
+	applogger.InfoContext(ctx, "清理已注销账户完成",
+		applogger.Int64("purged", purged),
+		applogger.Duration("retention", retention),
+		applogger.Duration("duration_ms", time.Since(startTime)))
+
+	return purged, nil
+}
+
+// ListUsers 分页列出用户用例（管理端）。
+//
+// createdFrom、createdTo 为 YYYY-MM-DD 格式的创建日期闭区间，
+// 均为空时列出全部用户，仅提供其一时返回 ErrUserDateRangeInvalid。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	createdFrom - 创建日期下界（原始字符串）
+//	createdTo - 创建日期上界（原始字符串）
+//	page - 页码（从 1 开始）
+//	pageSize - 每页大小
+//
+// 返回：
+//
+//	*dto.UserPageDTO - 用户分页结果
+//	error - 日期无效或查询失败时的错误
+func (s *UserApplicationServiceImpl) ListUsers(
+	ctx context.Context,
+	createdFrom string,
+	createdTo string,
+	page int,
+	pageSize int,
+) (*dto.UserPageDTO, error) {
+	applogger.InfoContext(ctx, "开始列出用户",
+		applogger.String("created_from", createdFrom),
+		applogger.String("created_to", createdTo),
+		applogger.Int("page", page),
+		applogger.Int("page_size", pageSize))
+
+	// 1. 校验分页参数
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > user.MaxPageSize {
+		pageSize = user.DefaultPageSize
+	}
+	offset := (page - 1) * pageSize
+
+	var (
+		entities []user.UserEntity
+		total    int64
+		err      error
+	)
+	if createdFrom == "" && createdTo == "" {
+		// 2a. 未指定日期区间，列出全部用户
+		entities, err = s.userService.ListUsers(ctx, pageSize, offset)
+		if err == nil {
+			total, err = s.userService.CountUsers(ctx)
+		}
+	} else {
+		// 2b. 解析日期区间，结束日期包含当天全部时间
+		from, to, parseErr := parseCreatedDateRange(createdFrom, createdTo)
+		if parseErr != nil {
+			applogger.WarnContext(ctx, "创建日期区间无效",
+				applogger.String("created_from", createdFrom),
+				applogger.String("created_to", createdTo),
+				applogger.Err(parseErr))
+			return nil, parseErr
+		}
+		entities, total, err = s.userService.ListUsersByDateRange(ctx, from, to, pageSize, offset)
+		if errors.Is(err, user.ErrUserDateRangeInvalid) {
+			applogger.WarnContext(ctx, "创建日期区间无效",
+				applogger.String("created_from", createdFrom),
+				applogger.String("created_to", createdTo))
+			return nil, err
+		}
+	}
+	if err != nil {
+		applogger.ErrorContext(ctx, "列出用户失败",
+			applogger.Err(err))
+		return nil, err
+	}
+
+	pageDTO := dto.ToUserPageDTO(entities, total, page, pageSize)
+
+	applogger.InfoContext(ctx, "列出用户成功",
+		applogger.Int64("total", total))
+
+	return &pageDTO, nil
+}
+
+// ListDeletedUsers 分页列出可恢复的已注销用户用例（管理端）。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	page - 页码（从 1 开始）
+//	pageSize - 每页大小
+//
+// 返回：
+//
+//	*dto.UserPageDTO - 用户分页结果
+//	error - 查询失败时的错误
+func (s *UserApplicationServiceImpl) ListDeletedUsers(ctx context.Context, page, pageSize int) (*dto.UserPageDTO, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > user.MaxPageSize {
+		pageSize = user.DefaultPageSize
+	}
+
+	entities, total, err := s.userService.ListDeletedUsers(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		applogger.ErrorContext(ctx, "列出已注销用户失败",
+			applogger.Err(err))
+		return nil, err
+	}
+
+	pageDTO := dto.ToUserPageDTO(entities, total, page, pageSize)
+	return &pageDTO, nil
+}
+
+// SearchUsers 按用户名前缀搜索用户用例（管理端）。
+//
+// 搜索词去除首尾空白后为空时返回空结果，不会列出全部用户。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	query - 用户名前缀
+//	page - 页码（从 1 开始）
+//	pageSize - 每页大小
+//
+// 返回：
+//
+//	*dto.UserPageDTO - 用户分页结果
+//	error - 查询失败时的错误
+func (s *UserApplicationServiceImpl) SearchUsers(ctx context.Context, query string, page, pageSize int) (*dto.UserPageDTO, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > user.MaxPageSize {
+		pageSize = user.DefaultPageSize
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		pageDTO := dto.ToUserPageDTO(nil, 0, page, pageSize)
+		return &pageDTO, nil
+	}
+
+	entities, total, err := s.userService.SearchUsersByUsername(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		applogger.ErrorContext(ctx, "搜索用户失败",
+			applogger.String("query", query),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	pageDTO := dto.ToUserPageDTO(entities, total, page, pageSize)
+	return &pageDTO, nil
+}
+
+// GetUserStats 统计各状态用户数用例（管理端）。
+//
+// 通过一次分组查询获取各状态用户数，总数为各状态之和，不含已注销用户。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//
+// 返回：
+//
+//	*dto.UserStatsDTO - 用户数量统计
+//	error - 查询失败时的错误
+func (s *UserApplicationServiceImpl) GetUserStats(ctx context.Context) (*dto.UserStatsDTO, error) {
+	counts, err := s.userService.CountUsersByStatus(ctx)
+	if err != nil {
+		applogger.ErrorContext(ctx, "统计用户数量失败",
+			applogger.Err(err))
+		return nil, err
+	}
+
+	statsDTO := dto.ToUserStatsDTO(counts)
+	return &statsDTO, nil
+}
+
+// RestoreUser 恢复已注销用户用例（管理端）。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 用户 ID
+//
+// 返回：
+//
+//	*dto.UserDTO - 恢复后的用户信息
+//	error - 用户不存在、用户名或邮箱已被占用时的错误
+func (s *UserApplicationServiceImpl) RestoreUser(ctx context.Context, userID int64) (*dto.UserDTO, error) {
+	applogger.InfoContext(ctx, "开始恢复用户",
+		applogger.Int64("user_id", userID))
+
+	if err := s.userService.RestoreUser(ctx, userID); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, user.ErrUserRestoreConflict) {
+			applogger.WarnContext(ctx, "恢复用户失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err))
+		} else {
+			applogger.ErrorContext(ctx, "恢复用户失败",
+				applogger.Int64("user_id", userID),
+				applogger.Err(err))
+		}
+		return nil, err
+	}
+
+	entity, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		applogger.ErrorContext(ctx, "获取恢复后的用户失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return nil, err
+	}
+
+	applogger.InfoContext(ctx, "用户恢复成功",
+		applogger.Int64("user_id", userID))
+
+	s.recordAudit(ctx, userID, audit.ActionAccountRestored, nil)
+
+	userDTO := dto.ToUserDTO(entity)
+	return &userDTO, nil
+}
+
+// ChangeUsersStatus 管理端批量修改用户状态用例。
+//
+// 全部用户在同一事务中更新，任一用户失败时整体回滚。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	ids - 用户 ID 列表
+//	status - 目标状态（active/inactive/banned）
+//
+// 返回：
+//
+//	error - 状态无效、ID 数量超限或用户不存在时的错误
+func (s *UserApplicationServiceImpl) ChangeUsersStatus(ctx context.Context, ids []int64, status string) error {
+	statusVO, err := user.ParseUserStatus(status)
+	if err != nil {
+		return err
+	}
+
+	applogger.InfoContext(ctx, "开始批量修改用户状态",
+		applogger.Int("count", len(ids)),
+		applogger.String("status", string(statusVO)))
+
+	if err := s.userService.ChangeUsersStatus(ctx, ids, statusVO); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, user.ErrUserStatusBatchInvalid) {
+			applogger.WarnContext(ctx, "批量修改用户状态失败",
+				applogger.Int("count", len(ids)),
+				applogger.Err(err))
+		} else {
+			applogger.ErrorContext(ctx, "批量修改用户状态失败",
+				applogger.Int("count", len(ids)),
+				applogger.Err(err))
+		}
+		return err
+	}
+
+	applogger.InfoContext(ctx, "批量修改用户状态成功",
+		applogger.Int("count", len(ids)),
+		applogger.String("status", string(statusVO)))
+
+	for _, id := range ids {
+		s.recordAudit(ctx, id, audit.ActionStatusChanged, map[string]string{
+			"status": string(statusVO),
+		})
+	}
+	return nil
+}
+
+// BulkRegisterUsers 管理端批量导入用户用例。
+//
+// 每行独立校验，单行失败不影响其他行；strict 为 true 时任一行失败则不导入任何用户。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	users - 待导入的用户（原始字符串）
+//	strict - 是否全部成功才写入
+//
+// 返回：
+//
+//	*dto.UserImportResultDTO - 逐行结果及统计
+//	error - 条数超限或仓储失败时的错误
+func (s *UserApplicationServiceImpl) BulkRegisterUsers(ctx context.Context, users []ImportUserInput, strict bool) (*dto.UserImportResultDTO, error) {
+	startTime := time.Now()
+
+	applogger.InfoContext(ctx, "开始批量导入用户",
+		applogger.Int("count", len(users)),
+		applogger.Bool("strict", strict))
+
+	batch := make([]user.ImportUser, len(users))
+	for i, input := range users {
+		batch[i] = user.ImportUser{Username: input.Username, Email: input.Email, Password: input.Password}
+	}
+
+	results, err := s.userService.BulkRegisterUsers(ctx, batch, strict)
+	if err != nil {
+		if errors.Is(err, user.ErrUserImportBatchInvalid) || errors.Is(err, user.ErrUserAlreadyExists) {
+			applogger.WarnContext(ctx, "批量导入用户失败",
+				applogger.Int("count", len(users)),
+				applogger.Err(err))
+		} else {
+			applogger.ErrorContext(ctx, "批量导入用户失败",
+				applogger.Int("count", len(users)),
+				applogger.Err(err))
+		}
+		return nil, err
+	}
+
+	resultDTO := dto.ToUserImportResultDTO(results)
+
+	duration := time.Since(startTime)
+	applogger.InfoContext(ctx, "批量导入用户完成",
+		applogger.Int("created", resultDTO.Created),
+		applogger.Int("skipped", resultDTO.Skipped),
+		applogger.Int("failed", resultDTO.Failed),
+		applogger.Duration("duration_ms", duration))
+
+	return &resultDTO, nil
+}
+
+// ListAuditLogs 分页列出用户审计日志用例（管理端）。
+//
+// 已注销用户的审计日志同样可以查询。
+//
+// 参数：
+//
+//	ctx - 请求上下文
+//	userID - 目标用户 ID
+//	page - 页码（从 1 开始）
+//	pageSize - 每页大小
+//
+// 返回：
+//
+//	*dto.AuditLogPageDTO - 审计日志分页结果
+//	error - 查询失败时的错误
+func (s *UserApplicationServiceImpl) ListAuditLogs(ctx context.Context, userID int64, page, pageSize int) (*dto.AuditLogPageDTO, error) {
+	if s.auditLogReader == nil {
+		return nil, errors.New("audit log reader not configured")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > audit.MaxPageSize {
+		pageSize = audit.DefaultPageSize
+	}
+
+	entries, err := s.auditLogReader.ListByUserID(ctx, userID, pageSize, (page-1)*pageSize)
+	if err == nil {
+		var total int64
+		total, err = s.auditLogReader.CountByUserID(ctx, userID)
+		if err == nil {
+			pageDTO := dto.ToAuditLogPageDTO(entries, total, page, pageSize)
+			return &pageDTO, nil
+		}
+	}
+
+	applogger.ErrorContext(ctx, "列出审计日志失败",
+		applogger.Int64("user_id", userID),
+		applogger.Err(err))
+	return nil, err
+}
+
+// recordAudit 记录敏感操作审计日志。
+//
+// 操作者取自上下文中的认证用户，无法获取时记为 0。
+// 审计日志写入失败只记录错误日志，不影响已完成的操作。
+func (s *UserApplicationServiceImpl) recordAudit(ctx context.Context, userID int64, action audit.Action, metadata map[string]string) {
+	var actorID int64
+	if actor, ok := contextx.GetDataFromContext(ctx); ok {
+		actorID = actor.UserID
+	}
+	s.recordAuditAs(ctx, userID, actorID, action, metadata)
+}
+
+// recordAuditAs 以指定操作者记录审计日志，用于请求未经 Token 认证、但已通过密码确认身份的操作。
+func (s *UserApplicationServiceImpl) recordAuditAs(ctx context.Context, userID, actorID int64, action audit.Action, metadata map[string]string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	if err := s.auditLogger.Record(ctx, audit.NewEntry(userID, actorID, action, metadata)); err != nil {
+		applogger.ErrorContext(ctx, "写入审计日志失败",
+			applogger.Int64("user_id", userID),
+			applogger.Int64("actor_id", actorID),
+			applogger.String("action", string(action)),
+			applogger.Err(err))
+	}
+}
+
+// upgradePasswordHash 登录成功后升级计算成本过低的密码哈希。
+//
+// 升级失败只记录警告日志，不影响本次登录，下次登录时会再次尝试。
+func (s *UserApplicationServiceImpl) upgradePasswordHash(ctx context.Context, entity user.UserEntity, password user.Password) {
+	upgraded, err := s.userService.UpgradePasswordHash(ctx, entity, password)
+	if err != nil {
+		applogger.WarnContext(ctx, "升级密码哈希失败",
+			applogger.Int64("user_id", entity.GetID()),
+			applogger.Err(err))
+		return
+	}
+	if upgraded {
+		applogger.InfoContext(ctx, "密码哈希已升级到当前计算成本",
+			applogger.Int64("user_id", entity.GetID()))
+	}
+}
+
+// parseCreatedDateRange 解析创建日期区间，两端必须同时提供
+//
+// 返回的 to 为结束日期当天的最后一毫秒，与 created_at 的 DATETIME(3) 精度一致。
+func parseCreatedDateRange(createdFrom, createdTo string) (time.Time, time.Time, error) {
+	if createdFrom == "" || createdTo == "" {
+		return time.Time{}, time.Time{}, user.ErrUserDateRangeInvalid
+	}
+
+	from, err := user.ParseDate(createdFrom)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := user.ParseDate(createdTo)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return from, to.AddDate(0, 0, 1).Add(-time.Millisecond), nil
+}
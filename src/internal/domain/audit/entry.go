@@ -0,0 +1,75 @@
+// Package audit 定义敏感操作审计日志的领域模型和仓储接口。
+package audit
+
+import "time"
+
+// Action 审计操作类型
+type Action string
+
+const (
+	// ActionPasswordChanged 修改密码
+	ActionPasswordChanged Action = "password_changed"
+
+	// ActionEmailChangeRequested 发起邮箱变更（新邮箱待确认）
+	ActionEmailChangeRequested Action = "email_change_requested"
+
+	// ActionEmailChanged 确认邮箱变更
+	ActionEmailChanged Action = "email_changed"
+
+	// ActionUsernameChanged 修改用户名
+	ActionUsernameChanged Action = "username_changed"
+
+	// ActionStatusChanged 修改用户状态
+	ActionStatusChanged Action = "status_changed"
+
+	// ActionAccountDeleted 注销账户
+	ActionAccountDeleted Action = "account_deleted"
+
+	// ActionAccountRestored 恢复已注销账户
+	ActionAccountRestored Action = "account_restored"
+
+	// ActionAccountReactivated 停用的用户自助恢复账户
+	ActionAccountReactivated Action = "account_reactivated"
+)
+
+const (
+	// DefaultPageSize 审计日志列表默认每页条数
+	DefaultPageSize = 20
+
+	// MaxPageSize 审计日志列表每页最大条数
+	MaxPageSize = 100
+)
+
+// Entry 审计日志条目
+//
+// 审计日志只追加不修改，因此使用值类型而非实体。
+type Entry struct {
+	// ID 日志唯一标识，写入前为 0
+	ID int64
+
+	// UserID 被操作的目标用户 ID
+	UserID int64
+
+	// ActorID 执行操作的用户 ID，为 0 表示无法确定操作者（如系统任务）
+	ActorID int64
+
+	// Action 操作类型
+	Action Action
+
+	// Metadata 操作相关的附加信息，如新邮箱、新状态
+	Metadata map[string]string
+
+	// CreatedAt 操作时间
+	CreatedAt time.Time
+}
+
+// NewEntry 创建审计日志条目，操作时间为当前时间
+func NewEntry(userID, actorID int64, action Action, metadata map[string]string) Entry {
+	return Entry{
+		UserID:    userID,
+		ActorID:   actorID,
+		Action:    action,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+}
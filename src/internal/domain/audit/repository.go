@@ -0,0 +1,27 @@
+package audit
+
+import "context"
+
+// ==================== 仓储接口 ====================
+// 记录与查询分离，应用服务只依赖 AuditLogger，未注入时不记录审计日志
+
+// AuditLogger 审计日志记录接口
+type AuditLogger interface {
+	// Record 写入一条审计日志
+	Record(ctx context.Context, entry Entry) error
+}
+
+// AuditLogReader 审计日志查询接口
+type AuditLogReader interface {
+	// ListByUserID 按时间倒序列出目标用户的审计日志
+	ListByUserID(ctx context.Context, userID int64, limit, offset int) ([]Entry, error)
+
+	// CountByUserID 统计目标用户的审计日志条数
+	CountByUserID(ctx context.Context, userID int64) (int64, error)
+}
+
+// Repository 审计日志仓储组合接口
+type Repository interface {
+	AuditLogger
+	AuditLogReader
+}
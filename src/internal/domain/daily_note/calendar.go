@@ -0,0 +1,29 @@
+package daily_note
+
+import "time"
+
+const (
+	// MinCalendarYear 月历可查询的最早年份
+	MinCalendarYear = 1900
+
+	// MaxCalendarYear 月历可查询的最晚年份
+	MaxCalendarYear = 9999
+)
+
+// CalendarDay 月历中有笔记的一天
+type CalendarDay struct {
+	// Date 笔记日期
+	Date time.Time
+
+	// HasContent 笔记内容去除首尾空格后是否非空
+	HasContent bool
+
+	// CharCount 笔记内容的字符数，按 Unicode 字符计算
+	CharCount int
+}
+
+// MonthRange 返回指定年月的第一天和最后一天，按笔记日期比较时作为闭区间使用
+func MonthRange(year int, month time.Month) (time.Time, time.Time) {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return first, first.AddDate(0, 1, -1)
+}
@@ -1,7 +1,9 @@
 package daily_note
 
 import (
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // DailyNoteEntity 每日笔记领域实体接口
@@ -26,26 +28,68 @@ type DailyNoteEntity interface {
 	// GetUpdatedAt 获取每日笔记的更新时间。
 	GetUpdatedAt() time.Time
 
+	// GetVersion 获取每日笔记的版本号，用于乐观锁。
+	GetVersion() int
+
+	// WordCount 统计内容的词数，按空白字符分隔。
+	//
+	// 中日韩等不以空格分词的文本，连续的字符计为一个词，此时应参考 CharCount。
+	WordCount() int
+
+	// CharCount 统计内容的字符数，按 Unicode 字符（rune）而非字节计算。
+	CharCount() int
+
+	// CheckVersion 检查客户端期望的版本号是否与当前版本一致。
+	//
+	// expected 为 0 表示客户端未指定版本，不做检查；
+	// 版本不一致时返回ErrDailyNoteConflict错误。
+	CheckVersion(expected int) error
+
+	// IsPinned 判断每日笔记是否已置顶。
+	IsPinned() bool
+
+	// Pin 置顶每日笔记。
+	//
+	// 置顶状态属于展示属性，不修改版本号和更新时间，避免影响内容编辑的乐观锁。
+	Pin()
+
+	// Unpin 取消置顶每日笔记。
+	Unpin()
+
+	// GetTags 获取每日笔记的标签，按字典序排列。
+	GetTags() []string
+
+	// SetTags 替换每日笔记的标签。
+	//
+	// 标签经 NormalizeTags 规范化，超出数量或长度限制时返回对应错误且不修改已有标签。
+	// 标签属于分类属性，与置顶一样不修改版本号和更新时间。
+	SetTags(tags []string) error
+
 	// UpdateContent 更新每日笔记内容。
 	//
-	// 如果内容为空，返回ErrDailyNoteContentEmpty错误。
+	// 如果内容为空，返回ErrDailyNoteContentEmpty错误；
+	// 如果内容超过MaxContentLength个字符，返回ErrDailyNoteContentTooLong错误。
+	// 更新成功后版本号加一；新内容与原内容相同时不做修改。
 	UpdateContent(content string) error
 }
 
 // dailyNote 每日笔记领域实体实现
 type dailyNote struct {
-	id        int64     `json:"id"`
-	userID    int64     `json:"user_id"`
-	noteDate  time.Time `json:"note_date"`
-	content   string    `json:"content"`
-	createdAt time.Time `json:"created_at"`
-	updatedAt time.Time `json:"updated_at"`
+	id        int64
+	userID    int64
+	noteDate  time.Time
+	content   string
+	createdAt time.Time
+	updatedAt time.Time
+	version   int
+	isPinned  bool
+	tags      []string
 }
 
 // NewDailyNote 创建新的每日笔记实体
 func NewDailyNote(userID int64, noteDate time.Time, content string) (DailyNoteEntity, error) {
-	if content == "" {
-		return nil, ErrDailyNoteContentEmpty
+	if err := validateContent(content); err != nil {
+		return nil, err
 	}
 
 	return &dailyNote{
@@ -54,11 +98,12 @@ func NewDailyNote(userID int64, noteDate time.Time, content string) (DailyNoteEn
 		content:   content,
 		createdAt: time.Now(),
 		updatedAt: time.Now(),
+		version:   InitialVersion,
 	}, nil
 }
 
 // ReconstructDailyNote 从持久化数据重建每日笔记实体
-func ReconstructDailyNote(id int64, userID int64, noteDate time.Time, content string, createdAt time.Time, updatedAt time.Time) DailyNoteEntity {
+func ReconstructDailyNote(id int64, userID int64, noteDate time.Time, content string, createdAt time.Time, updatedAt time.Time, version int, isPinned bool, tags []string) DailyNoteEntity {
 	return &dailyNote{
 		id:        id,
 		userID:    userID,
@@ -66,6 +111,9 @@ func ReconstructDailyNote(id int64, userID int64, noteDate time.Time, content st
 		content:   content,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
+		version:   version,
+		isPinned:  isPinned,
+		tags:      tags,
 	}
 }
 
@@ -101,18 +149,77 @@ func (d *dailyNote) GetUpdatedAt() time.Time {
 	return d.updatedAt
 }
 
+// GetVersion 获取每日笔记的版本号。
+func (d *dailyNote) GetVersion() int {
+	return d.version
+}
+
+// IsPinned 判断每日笔记是否已置顶。
+func (d *dailyNote) IsPinned() bool {
+	return d.isPinned
+}
+
+// GetTags 获取每日笔记的标签。
+func (d *dailyNote) GetTags() []string {
+	return d.tags
+}
+
+// WordCount 统计内容的词数，按空白字符分隔。
+func (d *dailyNote) WordCount() int {
+	return len(strings.Fields(d.content))
+}
+
+// CharCount 统计内容的字符数，按 rune 计算。
+func (d *dailyNote) CharCount() int {
+	return utf8.RuneCountInString(d.content)
+}
+
 // Business Methods 业务方法实现
 
+// Pin 置顶每日笔记
+func (d *dailyNote) Pin() {
+	d.isPinned = true
+}
+
+// Unpin 取消置顶每日笔记
+func (d *dailyNote) Unpin() {
+	d.isPinned = false
+}
+
+// SetTags 替换每日笔记的标签
+func (d *dailyNote) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	d.tags = normalized
+	return nil
+}
+
+// CheckVersion 检查客户端期望的版本号是否与当前版本一致
+func (d *dailyNote) CheckVersion(expected int) error {
+	if expected != 0 && expected != d.version {
+		return ErrDailyNoteConflict
+	}
+	return nil
+}
+
 // UpdateContent 更新每日笔记内容
 //
-// 如果内容为空，返回ErrDailyNoteContentEmpty错误。
-// 更新成功后会自动设置updated_at为当前时间。
+// 如果内容为空，返回ErrDailyNoteContentEmpty错误；
+// 如果内容超过MaxContentLength个字符，返回ErrDailyNoteContentTooLong错误。
+// 更新成功后会自动设置updated_at为当前时间，并将版本号加一。
+// 新内容与原内容相同时不修改更新时间和版本号。
 func (d *dailyNote) UpdateContent(content string) error {
-	if content == "" {
-		return ErrDailyNoteContentEmpty
+	if err := validateContent(content); err != nil {
+		return err
+	}
+	if content == d.content {
+		return nil
 	}
 
 	d.content = content
 	d.updatedAt = time.Now()
+	d.version++
 	return nil
 }
@@ -0,0 +1,31 @@
+package daily_note
+
+import domainerr "todolist/internal/pkg/domainerr"
+
+// init 注册每日笔记领域的全部业务错误
+func init() {
+	domainerr.Register(
+		// 仓储相关错误
+		ErrDailyNoteNotFound,
+		ErrDailyNoteAlreadyExists,
+		ErrDailyNotePreviousNotFound,
+		ErrDailyNoteConflict,
+
+		// 业务逻辑错误
+		ErrDailyNoteContentEmpty,
+		ErrDailyNoteContentTooLong,
+		ErrDailyNoteDateInvalid,
+		ErrDailyNoteDateRangeInvalid,
+		ErrDailyNoteMonthInvalid,
+		ErrDailyNoteSortInvalid,
+		ErrDailyNoteCursorInvalid,
+		ErrDailyNoteBatchInvalid,
+		ErrDailyNoteTooManyTags,
+		ErrDailyNoteTagTooLong,
+		ErrDailyNoteDeleteAllNotConfirmed,
+
+		// 操作相关错误
+		ErrDailyNoteUpdateFailed,
+		ErrDailyNoteDeleteFailed,
+	)
+}
@@ -18,6 +18,13 @@ var (
 		Message: "每日笔记内容不能为空",
 	}
 
+	// ErrDailyNoteContentTooLong 表示每日笔记内容超出长度限制
+	ErrDailyNoteContentTooLong = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_CONTENT_TOO_LONG",
+		Type:    domainerr.ValidationError,
+		Message: "每日笔记内容不能超过10000个字符",
+	}
+
 	// ErrDailyNoteAlreadyExists 表示当日已存在每日笔记
 	ErrDailyNoteAlreadyExists = domainerr.BusinessError{
 		Code:    "DAILY_NOTE_ALREADY_EXISTS",
@@ -25,6 +32,83 @@ var (
 		Message: "当日已存在每日笔记",
 	}
 
+	// ErrDailyNotePreviousNotFound 表示没有可复制的历史笔记
+	ErrDailyNotePreviousNotFound = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_PREVIOUS_NOT_FOUND",
+		Type:    domainerr.NotFoundError,
+		Message: "没有可复制的历史笔记",
+	}
+
+	// ErrDailyNoteConflict 表示每日笔记已被其他请求修改
+	ErrDailyNoteConflict = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_CONFLICT",
+		Type:    domainerr.ConflictError,
+		Message: "每日笔记已被修改，请刷新后重试",
+	}
+
+	// ErrDailyNoteDateInvalid 表示日期格式无效
+	ErrDailyNoteDateInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_DATE_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "日期格式无效，应为 YYYY-MM-DD",
+	}
+
+	// ErrDailyNoteDateRangeInvalid 表示日期区间无效
+	ErrDailyNoteDateRangeInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_DATE_RANGE_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "开始日期不能晚于结束日期",
+	}
+
+	// ErrDailyNoteMonthInvalid 表示月历查询的年月无效
+	ErrDailyNoteMonthInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_MONTH_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "年份必须在1900到9999之间，月份必须在1到12之间",
+	}
+
+	// ErrDailyNoteCursorInvalid 表示分页游标无效
+	ErrDailyNoteCursorInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_CURSOR_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "分页游标无效",
+	}
+
+	// ErrDailyNoteSortInvalid 表示排序方式无效
+	ErrDailyNoteSortInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_SORT_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "排序方式无效，可选值为 date_asc、date_desc、updated_desc、pinned_first",
+	}
+
+	// ErrDailyNoteBatchInvalid 表示批量导入条数无效
+	ErrDailyNoteBatchInvalid = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_BATCH_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "批量导入条数必须在1到365之间",
+	}
+
+	// ErrDailyNoteTooManyTags 表示笔记标签数量超出限制
+	ErrDailyNoteTooManyTags = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_TOO_MANY_TAGS",
+		Type:    domainerr.ValidationError,
+		Message: "每篇笔记最多10个标签",
+	}
+
+	// ErrDailyNoteTagTooLong 表示笔记标签超出长度限制
+	ErrDailyNoteTagTooLong = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_TAG_TOO_LONG",
+		Type:    domainerr.ValidationError,
+		Message: "标签不能超过32个字符",
+	}
+
+	// ErrDailyNoteDeleteAllNotConfirmed 表示删除全部笔记时未确认
+	ErrDailyNoteDeleteAllNotConfirmed = domainerr.BusinessError{
+		Code:    "DAILY_NOTE_DELETE_ALL_NOT_CONFIRMED",
+		Type:    domainerr.ValidationError,
+		Message: "删除全部笔记需要确认，请将 confirm 设为 true",
+	}
+
 	// ErrDailyNoteUpdateFailed 表示每日笔记更新失败
 	ErrDailyNoteUpdateFailed = domainerr.BusinessError{
 		Code:    "DAILY_NOTE_UPDATE_FAILED",
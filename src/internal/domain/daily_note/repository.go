@@ -10,19 +10,67 @@ type DailyNoteRepository interface {
 	// Save 保存每日笔记
 	Save(ctx context.Context, entity DailyNoteEntity) error
 
+	// Create 新增每日笔记，返回带有生成 ID 的实体
+	// 存在性检查与写入在同一事务中完成，用户当日已存在笔记时返回 ErrDailyNoteAlreadyExists
+	Create(ctx context.Context, entity DailyNoteEntity) (DailyNoteEntity, error)
+
+	// SaveBatch 在同一事务中批量新增每日笔记
+	// 用户当日已存在笔记的条目会被跳过，返回值：被跳过的笔记实体、错误
+	SaveBatch(ctx context.Context, entities []DailyNoteEntity) ([]DailyNoteEntity, error)
+
 	// FindByID 根据ID查询每日笔记
 	FindByID(ctx context.Context, id int64) (DailyNoteEntity, error)
 
 	// FindByUserIDAndDate 根据用户ID和日期查询每日笔记
 	FindByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (DailyNoteEntity, error)
 
-	// FindByUserID 根据用户ID分页查询每日笔记列表
+	// FindByUserID 根据用户ID按指定排序方式分页查询每日笔记列表
 	// 返回值：每日笔记列表、总记录数、错误
-	FindByUserID(ctx context.Context, userID int64, page, pageSize int) ([]DailyNoteEntity, int64, error)
+	FindByUserID(ctx context.Context, userID int64, sort SortOrder, page, pageSize int) ([]DailyNoteEntity, int64, error)
+
+	// FindByUserIDAfter 根据用户ID按笔记日期降序游标分页查询每日笔记列表
+	// 仅返回日期早于 afterNoteDate 的笔记，afterNoteDate 为零值时从最新的笔记开始
+	// 返回值：每日笔记列表、下一页游标（最后一条笔记的日期，无更多数据时为零值）、错误
+	FindByUserIDAfter(ctx context.Context, userID int64, afterNoteDate time.Time, limit int) ([]DailyNoteEntity, time.Time, error)
+
+	// FindByUserIDAndTagAfter 根据用户ID和标签按笔记日期降序游标分页查询每日笔记列表
+	// 游标语义与 FindByUserIDAfter 相同，tag 应为规范化后的标签
+	FindByUserIDAndTagAfter(ctx context.Context, userID int64, tag string, afterNoteDate time.Time, limit int) ([]DailyNoteEntity, time.Time, error)
+
+	// FindByUserIDAndDateRange 根据用户ID和日期区间（闭区间）分页查询每日笔记列表
+	// 返回值：每日笔记列表、总记录数、错误
+	FindByUserIDAndDateRange(ctx context.Context, userID int64, from, to time.Time, page, pageSize int) ([]DailyNoteEntity, int64, error)
+
+	// FindCalendarDaysByUserIDAndDateRange 查询用户日期区间（闭区间）内每篇笔记的日期和字数，按日期升序
+	// 只读取统计结果，不加载笔记内容
+	FindCalendarDaysByUserIDAndDateRange(ctx context.Context, userID int64, from, to time.Time) ([]CalendarDay, error)
+
+	// FindNoteDatesByUserID 查询用户所有写过笔记的日期（去重，按日期升序）
+	FindNoteDatesByUserID(ctx context.Context, userID int64) ([]time.Time, error)
+
+	// CountByUserID 统计用户的笔记总数
+	CountByUserID(ctx context.Context, userID int64) (int64, error)
+
+	// ExistsByUserIDAndDate 检查用户指定日期是否已有笔记
+	ExistsByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (bool, error)
+
+	// UpdatePinned 更新每日笔记的置顶状态
+	// 不修改内容、版本号和更新时间，记录不存在时返回 ErrDailyNoteNotFound
+	UpdatePinned(ctx context.Context, entity DailyNoteEntity) error
+
+	// UpdateTags 以实体当前的标签替换每日笔记已保存的标签
+	// 不修改内容、版本号和更新时间，记录不存在时返回 ErrDailyNoteNotFound
+	UpdateTags(ctx context.Context, entity DailyNoteEntity) error
 
 	// Delete 删除每日笔记
 	Delete(ctx context.Context, id int64) error
 
-	// Update 更新每日笔记
+	// DeleteAllByUserID 永久删除用户的全部每日笔记（标签随之删除）
+	// 返回值：删除的笔记数、错误
+	DeleteAllByUserID(ctx context.Context, userID int64) (int64, error)
+
+	// Update 更新每日笔记（乐观锁）
+	// 实体版本号应已通过 UpdateContent 加一，仅当数据库中版本号为更新前版本时才会写入，
+	// 否则返回 ErrDailyNoteConflict
 	Update(ctx context.Context, entity DailyNoteEntity) error
 }
@@ -2,9 +2,10 @@ package daily_note
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
+
+	"todolist/internal/pkg/clock"
 )
 
 const (
@@ -17,31 +18,90 @@ const (
 // DailyNoteService 每日笔记领域服务接口
 type DailyNoteService interface {
 	// CreateDailyNote 创建每日笔记
-	CreateDailyNote(ctx context.Context, userID int64, content string) (DailyNoteEntity, error)
+	CreateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string) (DailyNoteEntity, error)
+
+	// CopyPreviousDayNote 以最近一篇历史笔记的内容创建今日笔记
+	CopyPreviousDayNote(ctx context.Context, userID int64, loc *time.Location) (DailyNoteEntity, error)
+
+	// BatchCreateDailyNotes 批量导入历史每日笔记
+	BatchCreateDailyNotes(ctx context.Context, userID int64, notes []BatchNote) (BatchCreateResult, error)
 
 	// GetTodayDailyNote 获取今日的每日笔记
-	GetTodayDailyNote(ctx context.Context, userID int64) (DailyNoteEntity, error)
+	GetTodayDailyNote(ctx context.Context, userID int64, loc *time.Location) (DailyNoteEntity, error)
 
 	// GetDailyNoteList 根据用户ID分页获取每日笔记列表
-	GetDailyNoteList(ctx context.Context, userID int64, page, pageSize int) ([]DailyNoteEntity, int64, error)
+	GetDailyNoteList(ctx context.Context, userID int64, sort SortOrder, page, pageSize int) ([]DailyNoteEntity, int64, error)
+
+	// GetDailyNoteListAfter 根据用户ID按游标分页获取每日笔记列表
+	GetDailyNoteListAfter(ctx context.Context, userID int64, cursor time.Time, limit int) ([]DailyNoteEntity, time.Time, error)
+
+	// GetDailyNoteListByTagAfter 根据用户ID和标签按游标分页获取每日笔记列表
+	GetDailyNoteListByTagAfter(ctx context.Context, userID int64, tag string, cursor time.Time, limit int) ([]DailyNoteEntity, time.Time, error)
+
+	// GetDailyNoteListByRange 根据用户ID和日期区间分页获取每日笔记列表
+	GetDailyNoteListByRange(ctx context.Context, userID int64, from, to time.Time, page, pageSize int) ([]DailyNoteEntity, int64, error)
+
+	// GetNotesForMonth 获取用户指定月份每天的笔记概况，用于月历展示
+	GetNotesForMonth(ctx context.Context, userID int64, loc *time.Location, year, month int) ([]CalendarDay, error)
+
+	// GetStreak 获取用户连续写笔记天数统计
+	GetStreak(ctx context.Context, userID int64, loc *time.Location) (Streak, error)
+
+	// GetSummary 获取用户笔记总数、今日是否已写及连续天数
+	GetSummary(ctx context.Context, userID int64, loc *time.Location) (Summary, error)
 
 	// UpdateDailyNote 更新今日的每日笔记
-	UpdateDailyNote(ctx context.Context, userID int64, content string) (DailyNoteEntity, error)
+	UpdateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string, expectedVersion int) (DailyNoteEntity, error)
 
 	// DeleteDailyNote 删除今日的每日笔记
-	DeleteDailyNote(ctx context.Context, userID int64) error
+	DeleteDailyNote(ctx context.Context, userID int64, loc *time.Location) error
+
+	// GetDailyNoteByID 获取用户指定 ID 的每日笔记
+	GetDailyNoteByID(ctx context.Context, userID, noteID int64) (DailyNoteEntity, error)
+
+	// DeleteDailyNoteByID 删除用户指定 ID 的每日笔记
+	DeleteDailyNoteByID(ctx context.Context, userID, noteID int64) error
+
+	// DeleteAllDailyNotes 永久删除用户的全部每日笔记，返回删除的笔记数
+	DeleteAllDailyNotes(ctx context.Context, userID int64, confirmed bool) (int64, error)
+
+	// SetDailyNotePinned 置顶或取消置顶用户指定 ID 的每日笔记
+	SetDailyNotePinned(ctx context.Context, userID, noteID int64, pinned bool) (DailyNoteEntity, error)
+
+	// SetDailyNoteTags 替换用户指定 ID 的每日笔记的标签
+	SetDailyNoteTags(ctx context.Context, userID, noteID int64, tags []string) (DailyNoteEntity, error)
 }
 
 // Service 每日笔记领域服务实现
 type Service struct {
 	repo DailyNoteRepository
+
+	// clock 确定"今天"使用的时间来源，默认为系统时间
+	clock clock.Clock
+}
+
+// ServiceOption 每日笔记领域服务可选配置
+type ServiceOption func(*Service)
+
+// WithClock 设置时间来源，测试中传入 clock.FakeClock 固定"今天"
+func WithClock(c clock.Clock) ServiceOption {
+	return func(s *Service) {
+		if c != nil {
+			s.clock = c
+		}
+	}
 }
 
 // NewService 创建每日笔记领域服务实例
-func NewService(repo DailyNoteRepository) DailyNoteService {
-	return &Service{
-		repo: repo,
+func NewService(repo DailyNoteRepository, opts ...ServiceOption) DailyNoteService {
+	s := &Service{
+		repo:  repo,
+		clock: clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // CreateDailyNote 创建每日笔记
@@ -52,25 +112,15 @@ func NewService(repo DailyNoteRepository) DailyNoteService {
 // 参数：
 //   ctx - 请求上下文
 //   userID - 用户ID
+//   loc - 用户时区，"今天"按该时区的日历日确定，nil 时使用服务器本地时区
 //   content - 笔记内容
 //
 // 返回：
 //   DailyNoteEntity - 创建成功的每日笔记实体
 //   error - 错误信息
-func (s *Service) CreateDailyNote(ctx context.Context, userID int64, content string) (DailyNoteEntity, error) {
-	// 获取今天的日期（仅日期部分，时间设置为00:00:00）
-	today := time.Now().Truncate(24 * time.Hour)
-
-	// 检查今日是否已存在笔记
-	_, err := s.repo.FindByUserIDAndDate(ctx, userID, today)
-	if err == nil {
-		// 已存在笔记
-		return nil, ErrDailyNoteAlreadyExists
-	}
-	// 如果错误不是"未找到"，说明是其他错误（如数据库连接错误）
-	if !errors.Is(err, ErrDailyNoteNotFound) {
-		return nil, fmt.Errorf("failed to check existing daily note: %w", err)
-	}
+func (s *Service) CreateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string) (DailyNoteEntity, error) {
+	// 获取用户时区的今天（仅日期部分，时间设置为00:00:00）
+	today := TodayIn(s.clock.Now(), loc)
 
 	// 创建新笔记
 	dailyNoteEntity, err := NewDailyNote(userID, today, content)
@@ -78,13 +128,96 @@ func (s *Service) CreateDailyNote(ctx context.Context, userID int64, content str
 		return nil, err
 	}
 
-	// 保存到仓储
-	err = s.repo.Save(ctx, dailyNoteEntity)
+	// 在同一事务中检查今日是否已存在笔记并保存
+	return s.repo.Create(ctx, dailyNoteEntity)
+}
+
+// CopyPreviousDayNote 以最近一篇历史笔记的内容创建今日笔记
+//
+// 历史笔记取今日之前日期最近的一篇，不要求恰好是昨天；
+// 今日已有笔记时返回 ErrDailyNoteAlreadyExists，没有历史笔记时返回 ErrDailyNotePreviousNotFound。
+// 创建复用 CreateDailyNote，内容校验和唯一性检查与普通创建一致。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
+//
+// 返回：
+//   DailyNoteEntity - 创建成功的今日笔记实体
+//   error - 错误信息
+func (s *Service) CopyPreviousDayNote(ctx context.Context, userID int64, loc *time.Location) (DailyNoteEntity, error) {
+	today := TodayIn(s.clock.Now(), loc)
+
+	// 先检查今日笔记，保证今日已存在时无论有无历史笔记都返回冲突
+	exists, err := s.repo.ExistsByUserIDAndDate(ctx, userID, today)
 	if err != nil {
 		return nil, err
 	}
+	if exists {
+		return nil, ErrDailyNoteAlreadyExists
+	}
 
-	return dailyNoteEntity, nil
+	// 游标查询返回早于今日的笔记（按日期降序），取第一条即最近一篇
+	previous, _, err := s.repo.FindByUserIDAfter(ctx, userID, today, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(previous) == 0 {
+		return nil, ErrDailyNotePreviousNotFound
+	}
+
+	return s.CreateDailyNote(ctx, userID, loc, previous[0].GetContent())
+}
+
+// BatchCreateDailyNotes 批量导入历史每日笔记
+//
+// 所有笔记在同一事务中写入，任一条内容校验失败则整体失败；
+// 当日已存在笔记的条目会被跳过并在结果中返回。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   notes - 待导入的笔记列表，条数不能超过 MaxBatchSize
+//
+// 返回：
+//   BatchCreateResult - 导入结果
+//   error - 错误信息
+func (s *Service) BatchCreateDailyNotes(ctx context.Context, userID int64, notes []BatchNote) (BatchCreateResult, error) {
+	if len(notes) == 0 || len(notes) > MaxBatchSize {
+		return BatchCreateResult{}, ErrDailyNoteBatchInvalid
+	}
+
+	// 创建笔记实体（完成内容校验）
+	entities := make([]DailyNoteEntity, 0, len(notes))
+	for _, note := range notes {
+		entity, err := NewDailyNote(userID, note.NoteDate, note.Content)
+		if err != nil {
+			return BatchCreateResult{}, err
+		}
+		entities = append(entities, entity)
+	}
+
+	// 批量保存到仓储
+	skipped, err := s.repo.SaveBatch(ctx, entities)
+	if err != nil {
+		return BatchCreateResult{}, fmt.Errorf("failed to batch create daily notes: %w", err)
+	}
+
+	// 汇总结果
+	result := BatchCreateResult{}
+	skippedSet := make(map[DailyNoteEntity]bool, len(skipped))
+	for _, entity := range skipped {
+		skippedSet[entity] = true
+		result.Skipped = append(result.Skipped, entity.GetNoteDate())
+	}
+	for _, entity := range entities {
+		if !skippedSet[entity] {
+			result.Created = append(result.Created, entity)
+		}
+	}
+
+	return result, nil
 }
 
 // GetTodayDailyNote 获取今日的每日笔记
@@ -92,13 +225,14 @@ func (s *Service) CreateDailyNote(ctx context.Context, userID int64, content str
 // 参数：
 //   ctx - 请求上下文
 //   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
 //
 // 返回：
 //   DailyNoteEntity - 今日的每日笔记实体
 //   error - 错误信息
-func (s *Service) GetTodayDailyNote(ctx context.Context, userID int64) (DailyNoteEntity, error) {
-	// 获取今天的日期（仅日期部分，时间设置为00:00:00）
-	today := time.Now().Truncate(24 * time.Hour)
+func (s *Service) GetTodayDailyNote(ctx context.Context, userID int64, loc *time.Location) (DailyNoteEntity, error) {
+	// 获取用户时区的今天（仅日期部分，时间设置为00:00:00）
+	today := TodayIn(s.clock.Now(), loc)
 
 	// 查询今日笔记
 	dailyNoteEntity, err := s.repo.FindByUserIDAndDate(ctx, userID, today)
@@ -114,6 +248,7 @@ func (s *Service) GetTodayDailyNote(ctx context.Context, userID int64) (DailyNot
 // 参数：
 //   ctx - 请求上下文
 //   userID - 用户ID
+//   sort - 排序方式，为空时使用默认排序
 //   page - 页码（从1开始）
 //   pageSize - 每页大小
 //
@@ -121,7 +256,15 @@ func (s *Service) GetTodayDailyNote(ctx context.Context, userID int64) (DailyNot
 //   []DailyNoteEntity - 每日笔记实体列表
 //   int64 - 总记录数
 //   error - 错误信息
-func (s *Service) GetDailyNoteList(ctx context.Context, userID int64, page, pageSize int) ([]DailyNoteEntity, int64, error) {
+func (s *Service) GetDailyNoteList(ctx context.Context, userID int64, sort SortOrder, page, pageSize int) ([]DailyNoteEntity, int64, error) {
+	// 校验排序方式
+	if sort == "" {
+		sort = DefaultSortOrder
+	}
+	if !sort.IsValid() {
+		return nil, 0, ErrDailyNoteSortInvalid
+	}
+
 	// 校验分页参数
 	if page < 1 {
 		page = 1
@@ -131,22 +274,200 @@ func (s *Service) GetDailyNoteList(ctx context.Context, userID int64, page, page
 	}
 
 	// 查询笔记列表
-	return s.repo.FindByUserID(ctx, userID, page, pageSize)
+	return s.repo.FindByUserID(ctx, userID, sort, page, pageSize)
+}
+
+// GetDailyNoteListAfter 根据用户ID按游标分页获取每日笔记列表
+//
+// 按笔记日期降序返回早于 cursor 的笔记，基于 note_date 键集分页，
+// 翻页深度不影响查询性能，翻页期间新增笔记也不会导致重复或遗漏。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   cursor - 上一页返回的游标，零值表示第一页
+//   limit - 每页大小
+//
+// 返回：
+//   []DailyNoteEntity - 每日笔记实体列表
+//   time.Time - 下一页游标，零值表示没有更多数据
+//   error - 错误信息
+func (s *Service) GetDailyNoteListAfter(ctx context.Context, userID int64, cursor time.Time, limit int) ([]DailyNoteEntity, time.Time, error) {
+	// 校验分页参数
+	if limit < 1 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+
+	// 查询笔记列表
+	return s.repo.FindByUserIDAfter(ctx, userID, cursor, limit)
+}
+
+// GetDailyNoteListByTagAfter 根据用户ID和标签按游标分页获取每日笔记列表
+//
+// 标签按 NormalizeTag 规范化后比较，因此筛选不区分大小写；
+// 排序和游标语义与 GetDailyNoteListAfter 一致。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   tag - 标签
+//   cursor - 上一页返回的游标，零值表示第一页
+//   limit - 每页大小
+//
+// 返回：
+//   []DailyNoteEntity - 带有该标签的每日笔记实体列表
+//   time.Time - 下一页游标，零值表示没有更多数据
+//   error - 错误信息
+func (s *Service) GetDailyNoteListByTagAfter(ctx context.Context, userID int64, tag string, cursor time.Time, limit int) ([]DailyNoteEntity, time.Time, error) {
+	// 校验分页参数
+	if limit < 1 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+
+	// 查询笔记列表
+	return s.repo.FindByUserIDAndTagAfter(ctx, userID, NormalizeTag(tag), cursor, limit)
+}
+
+// GetDailyNoteListByRange 根据用户ID和日期区间分页获取每日笔记列表
+//
+// 日期区间为闭区间，开始日期晚于结束日期时返回 ErrDailyNoteDateRangeInvalid。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   from - 开始日期
+//   to - 结束日期
+//   page - 页码（从1开始）
+//   pageSize - 每页大小
+//
+// 返回：
+//   []DailyNoteEntity - 每日笔记实体列表
+//   int64 - 总记录数
+//   error - 错误信息
+func (s *Service) GetDailyNoteListByRange(ctx context.Context, userID int64, from, to time.Time, page, pageSize int) ([]DailyNoteEntity, int64, error) {
+	// 校验日期区间
+	if from.After(to) {
+		return nil, 0, ErrDailyNoteDateRangeInvalid
+	}
+
+	// 校验分页参数
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > MaxPageSize {
+		pageSize = DefaultPageSize
+	}
+
+	// 查询笔记列表
+	return s.repo.FindByUserIDAndDateRange(ctx, userID, from, to, page, pageSize)
+}
+
+// GetNotesForMonth 获取用户指定月份每天的笔记概况
+//
+// 只返回有笔记的日期，按日期升序；月份边界按笔记日期（用户本地日历日）确定。
+// year、month 均为 0 时查询用户时区的当前月份。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   loc - 用户时区，用于确定当前月份，nil 时使用服务器本地时区
+//   year - 年份，范围为 MinCalendarYear 到 MaxCalendarYear
+//   month - 月份，范围为 1 到 12
+//
+// 返回：
+//   []CalendarDay - 有笔记的日期及其字数
+//   error - 错误信息，年月无效时返回 ErrDailyNoteMonthInvalid
+func (s *Service) GetNotesForMonth(ctx context.Context, userID int64, loc *time.Location, year, month int) ([]CalendarDay, error) {
+	if year == 0 && month == 0 {
+		today := TodayIn(s.clock.Now(), loc)
+		year, month = today.Year(), int(today.Month())
+	}
+
+	// 校验年月
+	if year < MinCalendarYear || year > MaxCalendarYear || month < 1 || month > 12 {
+		return nil, ErrDailyNoteMonthInvalid
+	}
+
+	from, to := MonthRange(year, time.Month(month))
+	return s.repo.FindCalendarDaysByUserIDAndDateRange(ctx, userID, from, to)
+}
+
+// GetStreak 获取用户连续写笔记天数统计
+//
+// 在 Go 中基于排序后的日期计算连续天数，不依赖特定数据库的窗口函数。
+// 当前连续天数按用户时区的今天确定。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
+//
+// 返回：
+//   Streak - 当前连续天数、历史最长连续天数和总天数
+//   error - 错误信息
+func (s *Service) GetStreak(ctx context.Context, userID int64, loc *time.Location) (Streak, error) {
+	dates, err := s.repo.FindNoteDatesByUserID(ctx, userID)
+	if err != nil {
+		return Streak{}, err
+	}
+
+	return CalculateStreak(dates, TodayIn(s.clock.Now(), loc)), nil
+}
+
+// GetSummary 获取用户每日笔记概览
+//
+// 笔记总数与今日是否已写分别使用计数查询和存在性查询，连续天数复用 GetStreak。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
+//
+// 返回：
+//   Summary - 笔记总数、今日是否已写和连续天数统计
+//   error - 错误信息
+func (s *Service) GetSummary(ctx context.Context, userID int64, loc *time.Location) (Summary, error) {
+	total, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	// 获取用户时区的今天（仅日期部分，时间设置为00:00:00）
+	today := TodayIn(s.clock.Now(), loc)
+	hasToday, err := s.repo.ExistsByUserIDAndDate(ctx, userID, today)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	streak, err := s.GetStreak(ctx, userID, loc)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	return Summary{
+		TotalNotes:   total,
+		HasTodayNote: hasToday,
+		Streak:       streak,
+	}, nil
 }
 
 // UpdateDailyNote 更新今日的每日笔记
 //
+// 新内容与当前内容相同时直接返回原笔记，不写数据库，更新时间和版本号保持不变。
+//
 // 参数：
 //   ctx - 请求上下文
 //   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
 //   content - 新的笔记内容
+//   expectedVersion - 客户端期望的版本号，0 表示不检查
 //
 // 返回：
 //   DailyNoteEntity - 更新后的每日笔记实体
-//   error - 错误信息
-func (s *Service) UpdateDailyNote(ctx context.Context, userID int64, content string) (DailyNoteEntity, error) {
-	// 获取今天的日期（仅日期部分，时间设置为00:00:00）
-	today := time.Now().Truncate(24 * time.Hour)
+//   error - 错误信息，版本冲突时返回 ErrDailyNoteConflict
+func (s *Service) UpdateDailyNote(ctx context.Context, userID int64, loc *time.Location, content string, expectedVersion int) (DailyNoteEntity, error) {
+	// 获取用户时区的今天（仅日期部分，时间设置为00:00:00）
+	today := TodayIn(s.clock.Now(), loc)
 
 	// 查询今日笔记
 	dailyNoteEntity, err := s.repo.FindByUserIDAndDate(ctx, userID, today)
@@ -154,6 +475,16 @@ func (s *Service) UpdateDailyNote(ctx context.Context, userID int64, content str
 		return nil, err
 	}
 
+	// 检查客户端期望的版本号
+	if err := dailyNoteEntity.CheckVersion(expectedVersion); err != nil {
+		return nil, err
+	}
+
+	// 内容未变化时无需写入
+	if dailyNoteEntity.GetContent() == content {
+		return dailyNoteEntity, nil
+	}
+
 	// 更新内容
 	err = dailyNoteEntity.UpdateContent(content)
 	if err != nil {
@@ -174,12 +505,13 @@ func (s *Service) UpdateDailyNote(ctx context.Context, userID int64, content str
 // 参数：
 //   ctx - 请求上下文
 //   userID - 用户ID
+//   loc - 用户时区，nil 时使用服务器本地时区
 //
 // 返回：
 //   error - 错误信息
-func (s *Service) DeleteDailyNote(ctx context.Context, userID int64) error {
-	// 获取今天的日期（仅日期部分，时间设置为00:00:00）
-	today := time.Now().Truncate(24 * time.Hour)
+func (s *Service) DeleteDailyNote(ctx context.Context, userID int64, loc *time.Location) error {
+	// 获取用户时区的今天（仅日期部分，时间设置为00:00:00）
+	today := TodayIn(s.clock.Now(), loc)
 
 	// 查询今日笔记
 	dailyNoteEntity, err := s.repo.FindByUserIDAndDate(ctx, userID, today)
@@ -195,3 +527,153 @@ func (s *Service) DeleteDailyNote(ctx context.Context, userID int64) error {
 
 	return nil
 }
+
+// GetDailyNoteByID 获取用户指定 ID 的每日笔记
+//
+// 笔记不属于该用户时同样返回 ErrDailyNoteNotFound，避免泄露其他用户笔记是否存在。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   noteID - 笔记ID
+//
+// 返回：
+//   DailyNoteEntity - 每日笔记实体
+//   error - 错误信息
+func (s *Service) GetDailyNoteByID(ctx context.Context, userID, noteID int64) (DailyNoteEntity, error) {
+	dailyNoteEntity, err := s.repo.FindByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if dailyNoteEntity.GetUserID() != userID {
+		return nil, ErrDailyNoteNotFound
+	}
+
+	return dailyNoteEntity, nil
+}
+
+// DeleteDailyNoteByID 删除用户指定 ID 的每日笔记
+//
+// 笔记不属于该用户时同样返回 ErrDailyNoteNotFound，避免泄露其他用户笔记是否存在。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   noteID - 笔记ID
+//
+// 返回：
+//   error - 错误信息
+func (s *Service) DeleteDailyNoteByID(ctx context.Context, userID, noteID int64) error {
+	dailyNoteEntity, err := s.repo.FindByID(ctx, noteID)
+	if err != nil {
+		return err
+	}
+	if dailyNoteEntity.GetUserID() != userID {
+		return ErrDailyNoteNotFound
+	}
+
+	err = s.repo.Delete(ctx, noteID)
+	if err != nil {
+		return fmt.Errorf("failed to delete daily note: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAllDailyNotes 永久删除用户的全部每日笔记
+//
+// 操作不可恢复，调用方必须显式确认，否则返回 ErrDailyNoteDeleteAllNotConfirmed。
+// 用户没有笔记时返回 0。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   confirmed - 是否已确认删除
+//
+// 返回：
+//   int64 - 删除的笔记数
+//   error - 错误信息
+func (s *Service) DeleteAllDailyNotes(ctx context.Context, userID int64, confirmed bool) (int64, error) {
+	if !confirmed {
+		return 0, ErrDailyNoteDeleteAllNotConfirmed
+	}
+
+	deleted, err := s.repo.DeleteAllByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete all daily notes: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// SetDailyNotePinned 置顶或取消置顶用户指定 ID 的每日笔记
+//
+// 笔记不属于该用户时同样返回 ErrDailyNoteNotFound，避免泄露其他用户笔记是否存在。
+// 置顶状态不影响版本号，客户端持有的版本号在置顶后仍可用于更新内容。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   noteID - 笔记ID
+//   pinned - true 为置顶，false 为取消置顶
+//
+// 返回：
+//   DailyNoteEntity - 更新后的每日笔记实体
+//   error - 错误信息
+func (s *Service) SetDailyNotePinned(ctx context.Context, userID, noteID int64, pinned bool) (DailyNoteEntity, error) {
+	dailyNoteEntity, err := s.repo.FindByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if dailyNoteEntity.GetUserID() != userID {
+		return nil, ErrDailyNoteNotFound
+	}
+
+	if pinned {
+		dailyNoteEntity.Pin()
+	} else {
+		dailyNoteEntity.Unpin()
+	}
+
+	err = s.repo.UpdatePinned(ctx, dailyNoteEntity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update daily note pinned: %w", err)
+	}
+
+	return dailyNoteEntity, nil
+}
+
+// SetDailyNoteTags 替换用户指定 ID 的每日笔记的标签
+//
+// 笔记不属于该用户时同样返回 ErrDailyNoteNotFound，避免泄露其他用户笔记是否存在。
+// 传入空列表表示清空标签；标签数量或长度超出限制时不修改已保存的标签。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户ID
+//   noteID - 笔记ID
+//   tags - 新的标签列表
+//
+// 返回：
+//   DailyNoteEntity - 更新后的每日笔记实体
+//   error - 错误信息
+func (s *Service) SetDailyNoteTags(ctx context.Context, userID, noteID int64, tags []string) (DailyNoteEntity, error) {
+	dailyNoteEntity, err := s.repo.FindByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if dailyNoteEntity.GetUserID() != userID {
+		return nil, ErrDailyNoteNotFound
+	}
+
+	if err := dailyNoteEntity.SetTags(tags); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateTags(ctx, dailyNoteEntity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update daily note tags: %w", err)
+	}
+
+	return dailyNoteEntity, nil
+}
@@ -0,0 +1,68 @@
+package daily_note
+
+import "time"
+
+// Streak 连续写笔记天数统计
+type Streak struct {
+	// Current 当前连续天数
+	Current int
+
+	// Longest 历史最长连续天数
+	Longest int
+
+	// TotalDays 有笔记的总天数
+	TotalDays int
+}
+
+// CalculateStreak 根据笔记日期计算连续天数
+//
+// dates 需按日期升序排列且不重复。当前连续天数以 today 为终点，
+// 今天尚未写笔记时以昨天为终点，避免当天未写前连续记录被清零。
+// 笔记日期按各自的年月日比较，today 按其所在时区的日历日比较，
+// 调用方传入用户时区的当前时间即可按用户的"今天"计算。
+func CalculateStreak(dates []time.Time, today time.Time) Streak {
+	streak := Streak{TotalDays: len(dates)}
+	if len(dates) == 0 {
+		return streak
+	}
+
+	// 计算历史最长连续天数
+	run := 1
+	streak.Longest = 1
+	for i := 1; i < len(dates); i++ {
+		if isNextDay(dates[i-1], dates[i]) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	// 最后一条笔记不是今天或昨天时，当前连续已中断
+	last := dates[len(dates)-1]
+	todayDate := calendarDate(today)
+	if !calendarDate(last).Equal(todayDate) && !isNextDay(last, todayDate) {
+		return streak
+	}
+
+	// 从最后一条笔记向前计算当前连续天数
+	streak.Current = 1
+	for i := len(dates) - 1; i > 0 && isNextDay(dates[i-1], dates[i]); i-- {
+		streak.Current++
+	}
+
+	return streak
+}
+
+// calendarDate 返回 t 在其自身时区的日历日，统一表示为 UTC 零点以便比较
+func calendarDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// isNextDay 判断 next 是否为 prev 的后一天
+func isNextDay(prev, next time.Time) bool {
+	return calendarDate(prev).AddDate(0, 0, 1).Equal(calendarDate(next))
+}
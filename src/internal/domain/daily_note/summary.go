@@ -0,0 +1,13 @@
+package daily_note
+
+// Summary 用户每日笔记概览
+type Summary struct {
+	// TotalNotes 笔记总数
+	TotalNotes int64
+
+	// HasTodayNote 今天是否已写笔记
+	HasTodayNote bool
+
+	// Streak 连续天数统计
+	Streak Streak
+}
@@ -0,0 +1,15 @@
+package daily_note
+
+import "time"
+
+// TodayIn 返回 now 在 loc 时区对应日历日的零点，作为用户的"今天"
+//
+// loc 为 nil 时使用 time.Local。返回值位于 loc 时区，
+// 按 NoteDateLayout 格式化即为用户本地的日期。
+func TodayIn(now time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	y, m, d := now.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
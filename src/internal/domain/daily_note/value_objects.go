@@ -0,0 +1,171 @@
+package daily_note
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// NoteDateLayout 笔记日期格式
+	NoteDateLayout = "2006-01-02"
+
+	// MaxContentLength 笔记内容最大长度（按字符计）
+	MaxContentLength = 10000
+
+	// InitialVersion 新建笔记的初始版本号
+	InitialVersion = 1
+
+	// MaxBatchSize 批量导入笔记的最大条数
+	MaxBatchSize = 365
+
+	// MaxTagsPerNote 每篇笔记的最大标签数
+	MaxTagsPerNote = 10
+
+	// MaxTagLength 单个标签最大长度（按字符计）
+	MaxTagLength = 32
+)
+
+// SortOrder 笔记列表排序方式
+type SortOrder string
+
+const (
+	// SortDateAsc 按笔记日期升序
+	SortDateAsc SortOrder = "date_asc"
+
+	// SortDateDesc 按笔记日期降序
+	SortDateDesc SortOrder = "date_desc"
+
+	// SortUpdatedDesc 按最后更新时间降序
+	SortUpdatedDesc SortOrder = "updated_desc"
+
+	// SortPinnedFirst 置顶笔记在前，组内按笔记日期降序
+	SortPinnedFirst SortOrder = "pinned_first"
+
+	// DefaultSortOrder 默认排序方式
+	DefaultSortOrder = SortDateDesc
+)
+
+// ParseSortOrder 解析排序方式
+//
+// 为空时返回默认排序方式，不在允许列表中时返回 ErrDailyNoteSortInvalid。
+func ParseSortOrder(value string) (SortOrder, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultSortOrder, nil
+	}
+
+	order := SortOrder(value)
+	if !order.IsValid() {
+		return "", ErrDailyNoteSortInvalid
+	}
+	return order, nil
+}
+
+// IsValid 判断排序方式是否在允许列表中
+func (o SortOrder) IsValid() bool {
+	switch o {
+	case SortDateAsc, SortDateDesc, SortUpdatedDesc, SortPinnedFirst:
+		return true
+	}
+	return false
+}
+
+// BatchNote 批量导入的单条笔记
+type BatchNote struct {
+	// NoteDate 笔记日期
+	NoteDate time.Time
+
+	// Content 笔记内容
+	Content string
+}
+
+// BatchCreateResult 批量导入结果
+type BatchCreateResult struct {
+	// Created 成功创建的笔记
+	Created []DailyNoteEntity
+
+	// Skipped 因当日已存在笔记而跳过的日期
+	Skipped []time.Time
+}
+
+// validateContent 验证笔记内容
+//
+// 长度按 rune 计算，保证中文等多字节字符按单个字符计数。
+func validateContent(content string) error {
+	if content == "" {
+		return ErrDailyNoteContentEmpty
+	}
+	if utf8.RuneCountInString(content) > MaxContentLength {
+		return ErrDailyNoteContentTooLong
+	}
+	return nil
+}
+
+// NormalizeTags 规范化笔记标签
+//
+// 去除首尾空白并转为小写，忽略空标签和重复标签，结果按字典序排列。
+// 规范化后超过 MaxTagsPerNote 个时返回 ErrDailyNoteTooManyTags，
+// 单个标签超过 MaxTagLength 个字符时返回 ErrDailyNoteTagTooLong。
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, ErrDailyNoteTagTooLong
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTagsPerNote {
+		return nil, ErrDailyNoteTooManyTags
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// NormalizeTag 规范化单个标签，用于按标签筛选时与已保存的标签比较
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ParseCursor 解析笔记列表分页游标
+//
+// 游标为上一页最后一条笔记的日期（YYYY-MM-DD），为空时返回零值表示从第一页开始。
+// 格式无效时返回 ErrDailyNoteCursorInvalid。
+func ParseCursor(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, nil
+	}
+	cursor, err := ParseNoteDate(value)
+	if err != nil {
+		return time.Time{}, ErrDailyNoteCursorInvalid
+	}
+	return cursor, nil
+}
+
+// FormatCursor 将笔记日期格式化为分页游标，零值返回空字符串表示没有下一页
+func FormatCursor(noteDate time.Time) string {
+	if noteDate.IsZero() {
+		return ""
+	}
+	return noteDate.Format(NoteDateLayout)
+}
+
+// ParseNoteDate 解析 YYYY-MM-DD 格式的笔记日期
+//
+// 日期按本地时区解析，与数据库连接的 loc=Local 保持一致。
+// 格式无效时返回 ErrDailyNoteDateInvalid。
+func ParseNoteDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(NoteDateLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, ErrDailyNoteDateInvalid
+	}
+	return date, nil
+}
@@ -0,0 +1,67 @@
+// Package event 定义用户和每日笔记生命周期的领域事件及发布接口。
+//
+// 应用服务在操作成功（事务提交）后发布事件，通知、统计、Webhook 等外部集成通过订阅事件实现，
+// 不直接侵入业务流程。
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Type 事件类型，格式为 <资源>.<动作>
+type Type string
+
+const (
+	// TypeUserRegistered 用户注册成功
+	TypeUserRegistered Type = "user.registered"
+
+	// TypeUserEmailChanged 用户确认邮箱变更
+	TypeUserEmailChanged Type = "user.email_changed"
+
+	// TypeDailyNoteCreated 创建今日笔记（含复制历史笔记）
+	//
+	// 批量导入写入时不回填笔记 ID，不逐条发布此事件。
+	TypeDailyNoteCreated Type = "daily_note.created"
+)
+
+// Event 领域事件
+//
+// 只携带 ID 和时间，订阅者需要更多数据时自行查询，避免邮箱等个人信息随事件扩散。
+type Event struct {
+	// Type 事件类型
+	Type Type
+
+	// UserID 事件所属用户 ID
+	UserID int64
+
+	// ResourceID 事件关联资源的 ID（如笔记 ID），用户事件为 0
+	ResourceID int64
+
+	// OccurredAt 事件发生时间
+	OccurredAt time.Time
+}
+
+// New 创建事件，发生时间为当前时间
+func New(typ Type, userID, resourceID int64) Event {
+	return Event{
+		Type:       typ,
+		UserID:     userID,
+		ResourceID: resourceID,
+		OccurredAt: time.Now(),
+	}
+}
+
+// EventBus 事件发布接口
+//
+// Publish 不返回错误，实现不得阻塞调用方，订阅者失败也不能影响发布事件的请求。
+type EventBus interface {
+	// Publish 发布一个事件
+	Publish(ctx context.Context, e Event)
+}
+
+// NopBus 丢弃所有事件的 EventBus，未配置事件总线时使用
+type NopBus struct{}
+
+// Publish 丢弃事件
+func (NopBus) Publish(context.Context, Event) {}
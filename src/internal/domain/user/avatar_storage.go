@@ -0,0 +1,24 @@
+package user
+
+import "context"
+
+// avatarExtensions 允许上传的头像内容类型及对应的文件扩展名
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
+// AvatarStorage 头像存储接口
+// 定义头像文件的存储行为，由基础设施层提供实现（本地文件系统、对象存储等）
+type AvatarStorage interface {
+	// Save 保存头像文件，返回可公开访问的头像 URL
+	Save(ctx context.Context, userID int64, contentType string, data []byte) (string, error)
+}
+
+// AvatarExtension 返回头像内容类型对应的文件扩展名
+// 仅支持 png、jpeg、webp，其他类型返回 false
+func AvatarExtension(contentType string) (string, bool) {
+	ext, ok := avatarExtensions[contentType]
+	return ext, ok
+}
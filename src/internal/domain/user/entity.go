@@ -13,6 +13,14 @@ const (
 	UserStatusBanned   UserStatus = "banned"
 )
 
+// UserRole 用户角色
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
 // UserEntity 用户领域实体接口
 type UserEntity interface {
 	// Getters 获取属性
@@ -21,7 +29,15 @@ type UserEntity interface {
 	GetEmail() string
 	GetPasswordHash() string
 	GetAvatarURL() string
+	GetTimezone() string
 	GetStatus() UserStatus
+	GetRole() UserRole
+	IsEmailVerified() bool
+	GetPendingEmail() string
+	GetFailedLoginAttempts() int
+	GetLastFailedLoginAt() time.Time
+	GetLockedUntil() time.Time
+	GetUsernameChangedAt() time.Time
 	GetCreatedAt() time.Time
 	GetUpdatedAt() time.Time
 
@@ -31,22 +47,48 @@ type UserEntity interface {
 	VerifyPassword(password string) error
 	UpdatePassword(hash string) error
 	ChangeEmail(email string) error
+	RequestEmailChange(email string) error
+	ConfirmEmailChange(email string) error
+	ChangeUsername(username string, now time.Time, cooldown time.Duration) error
 	UpdateAvatar(url string) error
+	UpdateTimezone(timezone string) error
 	Activate() error
 	Deactivate() error
 	Ban() error
+	MarkEmailVerified() error
+	IsLocked(now time.Time) bool
+	RecordFailedLogin(now time.Time, policy LockoutPolicy) bool
+	ResetFailedLogins()
 }
 
 // user 用户领域实体实现
 type user struct {
-	id           int64
-	username     string
-	email        string
-	passwordHash string
-	avatarURL    string
-	status       UserStatus
-	createdAt    time.Time
-	updatedAt    time.Time
+	id            int64
+	username      string
+	email         string
+	passwordHash  string
+	avatarURL     string
+	status        UserStatus
+	emailVerified bool
+	createdAt     time.Time
+	updatedAt     time.Time
+
+	// 登录失败锁定状态
+	failedLoginAttempts int
+	lastFailedLoginAt   time.Time
+	lockedUntil         time.Time
+
+	// usernameChangedAt 最近一次修改用户名的时间，零值表示从未修改
+	usernameChangedAt time.Time
+
+	// pendingEmail 待确认的新邮箱，为空表示没有进行中的邮箱变更
+	pendingEmail string
+
+	// timezone 用户时区（IANA 名称），为空表示使用服务器默认时区
+	timezone string
+
+	// role 用户角色，决定令牌中的角色声明
+	role UserRole
 }
 
 // NewUser 创建新用户（用于注册）
@@ -57,22 +99,36 @@ func NewUser(username string, email string, passwordHash string) (UserEntity, er
 		email:        email,
 		passwordHash: passwordHash,
 		status:       UserStatusActive,
+		role:         UserRoleUser,
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
 	}, nil
 }
 
 // ReconstructUser 从持久化数据重建用户实体
-func ReconstructUser(id int64, username, email, passwordHash, avatarURL string, status UserStatus, createdAt, updatedAt time.Time) UserEntity {
+// lastFailedLoginAt、lockedUntil、usernameChangedAt 为零值表示无记录，timezone 为空表示未设置时区，
+// role 为空时按普通用户处理
+func ReconstructUser(id int64, username, email, passwordHash, avatarURL, timezone string, status UserStatus, emailVerified bool, pendingEmail string, failedLoginAttempts int, lastFailedLoginAt, lockedUntil, usernameChangedAt, createdAt, updatedAt time.Time, role UserRole) UserEntity {
+	if role == "" {
+		role = UserRoleUser
+	}
 	return &user{
-		id:           id,
-		username:     username,
-		email:        email,
-		passwordHash: passwordHash,
-		avatarURL:    avatarURL,
-		status:       status,
-		createdAt:    createdAt,
-		updatedAt:    updatedAt,
+		id:                  id,
+		username:            username,
+		email:               email,
+		passwordHash:        passwordHash,
+		avatarURL:           avatarURL,
+		timezone:            timezone,
+		status:              status,
+		emailVerified:       emailVerified,
+		pendingEmail:        pendingEmail,
+		failedLoginAttempts: failedLoginAttempts,
+		lastFailedLoginAt:   lastFailedLoginAt,
+		lockedUntil:         lockedUntil,
+		usernameChangedAt:   usernameChangedAt,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+		role:                role,
 	}
 }
 
@@ -97,10 +153,42 @@ func (u *user) GetAvatarURL() string {
 	return u.avatarURL
 }
 
+func (u *user) GetTimezone() string {
+	return u.timezone
+}
+
 func (u *user) GetStatus() UserStatus {
 	return u.status
 }
 
+func (u *user) GetRole() UserRole {
+	return u.role
+}
+
+func (u *user) IsEmailVerified() bool {
+	return u.emailVerified
+}
+
+func (u *user) GetPendingEmail() string {
+	return u.pendingEmail
+}
+
+func (u *user) GetFailedLoginAttempts() int {
+	return u.failedLoginAttempts
+}
+
+func (u *user) GetLastFailedLoginAt() time.Time {
+	return u.lastFailedLoginAt
+}
+
+func (u *user) GetLockedUntil() time.Time {
+	return u.lockedUntil
+}
+
+func (u *user) GetUsernameChangedAt() time.Time {
+	return u.usernameChangedAt
+}
+
 func (u *user) GetCreatedAt() time.Time {
 	return u.createdAt
 }
@@ -143,6 +231,59 @@ func (u *user) ChangeEmail(email string) error {
 	return nil
 }
 
+// RequestEmailChange 发起邮箱变更，新邮箱确认前登录邮箱保持不变
+// 预期：调用方应使用 Email 值对象保证邮箱有效性，并已检查邮箱未被占用
+// 再次发起会覆盖之前未确认的邮箱，旧的确认链接随之失效
+func (u *user) RequestEmailChange(email string) error {
+	if email == "" {
+		return ErrEmailInvalid
+	}
+	u.pendingEmail = email
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// ConfirmEmailChange 确认邮箱变更，将待确认邮箱设为登录邮箱
+// email 为确认链接中携带的邮箱，与当前待确认邮箱不一致时返回 ErrEmailChangeTokenInvalid
+// 新邮箱已通过确认链接验证，因此同时标记邮箱已验证
+func (u *user) ConfirmEmailChange(email string) error {
+	if u.pendingEmail == "" || u.pendingEmail != email {
+		return ErrEmailChangeTokenInvalid
+	}
+	u.email = u.pendingEmail
+	u.pendingEmail = ""
+	u.emailVerified = true
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeUsername 修改用户名
+// 预期：调用方应使用 Username 值对象保证用户名有效性，并已检查用户名未被占用
+// cooldown 为两次修改的最小间隔，非正数时不限制；距上次修改不足 cooldown 时返回 ErrUsernameChangeTooSoon
+func (u *user) ChangeUsername(username string, now time.Time, cooldown time.Duration) error {
+	if username == "" {
+		return ErrUsernameInvalid
+	}
+	if cooldown > 0 && !u.usernameChangedAt.IsZero() && now.Before(u.usernameChangedAt.Add(cooldown)) {
+		return ErrUsernameChangeTooSoon
+	}
+	u.username = username
+	u.usernameChangedAt = now
+	u.updatedAt = now
+	return nil
+}
+
+// MarkEmailVerified 标记邮箱已验证
+// 重复验证不会报错，保证验证链接可以被多次点击
+func (u *user) MarkEmailVerified() error {
+	if u.emailVerified {
+		return nil
+	}
+	u.emailVerified = true
+	u.updatedAt = time.Now()
+	return nil
+}
+
 // UpdateAvatar 更新头像
 func (u *user) UpdateAvatar(url string) error {
 	u.avatarURL = url
@@ -150,6 +291,14 @@ func (u *user) UpdateAvatar(url string) error {
 	return nil
 }
 
+// UpdateTimezone 更新时区
+// 预期：调用方应使用 IsValidTimezone 校验时区名称，空字符串表示清除设置
+func (u *user) UpdateTimezone(timezone string) error {
+	u.timezone = timezone
+	u.updatedAt = time.Now()
+	return nil
+}
+
 // Activate 激活用户
 func (u *user) Activate() error {
 	u.status = UserStatusActive
@@ -170,3 +319,41 @@ func (u *user) Ban() error {
 	u.updatedAt = time.Now()
 	return nil
 }
+
+// IsLocked 判断账户在 now 时刻是否处于登录锁定期
+func (u *user) IsLocked(now time.Time) bool {
+	return now.Before(u.lockedUntil)
+}
+
+// RecordFailedLogin 记录一次登录失败
+// 距上次失败超过统计窗口时重新计数；窗口内失败次数达到阈值时锁定账户，
+// 返回本次失败是否触发了锁定
+func (u *user) RecordFailedLogin(now time.Time, policy LockoutPolicy) bool {
+	if !policy.Enabled() {
+		return false
+	}
+
+	if u.lastFailedLoginAt.IsZero() || now.Sub(u.lastFailedLoginAt) > policy.Window {
+		u.failedLoginAttempts = 0
+	}
+	u.failedLoginAttempts++
+	u.lastFailedLoginAt = now
+	u.updatedAt = now
+
+	if u.failedLoginAttempts < policy.MaxAttempts {
+		return false
+	}
+
+	// 达到阈值后锁定并重新计数，锁定期结束后重新获得完整的尝试次数
+	u.lockedUntil = now.Add(policy.LockDuration)
+	u.failedLoginAttempts = 0
+	return true
+}
+
+// ResetFailedLogins 登录成功后清除失败记录和锁定状态
+func (u *user) ResetFailedLogins() {
+	u.failedLoginAttempts = 0
+	u.lastFailedLoginAt = time.Time{}
+	u.lockedUntil = time.Time{}
+	u.updatedAt = time.Now()
+}
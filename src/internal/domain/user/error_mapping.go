@@ -0,0 +1,53 @@
+package user
+
+import domainerr "todolist/internal/pkg/domainerr"
+
+// init 注册用户领域的全部业务错误
+func init() {
+	domainerr.Register(
+		// 仓储相关错误
+		ErrUserNotFound,
+		ErrUserAlreadyExists,
+		ErrEmailAlreadyExists,
+		ErrUsernameTaken,
+		ErrUsernameReserved,
+		ErrUserRestoreConflict,
+		ErrUserImportAborted,
+		ErrUsernameChangeTooSoon,
+
+		// 业务逻辑错误
+		ErrInvalidCredentials,
+		ErrAccountInactive,
+		ErrAccountBanned,
+		ErrAccountNotInactive,
+		ErrAccountLocked,
+		ErrEmailNotVerified,
+		ErrVerificationTokenInvalid,
+		ErrCaptchaFailed,
+		ErrEmailChangeTokenInvalid,
+		ErrRefreshTokenInvalid,
+		ErrSessionNotFound,
+		ErrPasswordTooWeak,
+		ErrPasswordMismatch,
+		ErrPasswordInvalid,
+		ErrOldPasswordIncorrect,
+		ErrPasswordIncorrect,
+		ErrEmailInvalid,
+		ErrUsernameInvalid,
+		ErrAvatarURLInvalid,
+		ErrTimezoneInvalid,
+		ErrAvatarTypeUnsupported,
+		ErrAvatarTooLarge,
+		ErrAvailabilityQueryInvalid,
+		ErrUserDateInvalid,
+		ErrUserDateRangeInvalid,
+		ErrUserStatusInvalid,
+		ErrUserStatusBatchInvalid,
+		ErrUserImportBatchInvalid,
+
+		// 操作相关错误
+		ErrUserUpdateFailed,
+		ErrUserDeleteFailed,
+		ErrUserCreateFailed,
+	)
+}
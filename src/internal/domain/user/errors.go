@@ -27,6 +27,30 @@ var (
 		Type:    domainerr.ConflictError,
 		Message: "username already taken",
 	}
+
+	ErrUsernameReserved = domainerr.BusinessError{
+		Code:    "USERNAME_RESERVED",
+		Type:    domainerr.ConflictError,
+		Message: "username is reserved",
+	}
+
+	ErrUserRestoreConflict = domainerr.BusinessError{
+		Code:    "USER_RESTORE_CONFLICT",
+		Type:    domainerr.ConflictError,
+		Message: "username or email is already used by another account",
+	}
+
+	ErrUserImportAborted = domainerr.BusinessError{
+		Code:    "USER_IMPORT_ABORTED",
+		Type:    domainerr.ConflictError,
+		Message: "not imported because another entry in the strict batch failed",
+	}
+
+	ErrUsernameChangeTooSoon = domainerr.BusinessError{
+		Code:    "USERNAME_CHANGE_TOO_SOON",
+		Type:    domainerr.ConflictError,
+		Message: "username was changed recently, please try again later",
+	}
 )
 
 // 业务逻辑错误
@@ -49,6 +73,54 @@ var (
 		Message: "account has been banned",
 	}
 
+	ErrAccountNotInactive = domainerr.BusinessError{
+		Code:    "ACCOUNT_NOT_INACTIVE",
+		Type:    domainerr.ConflictError,
+		Message: "account is not inactive",
+	}
+
+	ErrAccountLocked = domainerr.BusinessError{
+		Code:    "ACCOUNT_LOCKED",
+		Type:    domainerr.PermissionError,
+		Message: "account is temporarily locked due to too many failed login attempts",
+	}
+
+	ErrEmailNotVerified = domainerr.BusinessError{
+		Code:    "EMAIL_NOT_VERIFIED",
+		Type:    domainerr.PermissionError,
+		Message: "email has not been verified",
+	}
+
+	ErrVerificationTokenInvalid = domainerr.BusinessError{
+		Code:    "VERIFICATION_TOKEN_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "verification token is invalid or expired",
+	}
+
+	ErrCaptchaFailed = domainerr.BusinessError{
+		Code:    "CAPTCHA_FAILED",
+		Type:    domainerr.ValidationError,
+		Message: "captcha verification failed",
+	}
+
+	ErrEmailChangeTokenInvalid = domainerr.BusinessError{
+		Code:    "EMAIL_CHANGE_TOKEN_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "email change confirmation token is invalid or expired",
+	}
+
+	ErrRefreshTokenInvalid = domainerr.BusinessError{
+		Code:    "REFRESH_TOKEN_INVALID",
+		Type:    domainerr.AuthenticationError,
+		Message: "refresh token is invalid or expired",
+	}
+
+	ErrSessionNotFound = domainerr.BusinessError{
+		Code:    "SESSION_NOT_FOUND",
+		Type:    domainerr.NotFoundError,
+		Message: "session not found",
+	}
+
 	ErrPasswordTooWeak = domainerr.BusinessError{
 		Code:    "PASSWORD_TOO_WEAK",
 		Type:    domainerr.ValidationError,
@@ -73,6 +145,12 @@ var (
 		Message: "old password is incorrect",
 	}
 
+	ErrPasswordIncorrect = domainerr.BusinessError{
+		Code:    "PASSWORD_INCORRECT",
+		Type:    domainerr.PermissionError,
+		Message: "password is incorrect",
+	}
+
 	ErrEmailInvalid = domainerr.BusinessError{
 		Code:    "EMAIL_INVALID",
 		Type:    domainerr.ValidationError,
@@ -90,6 +168,60 @@ var (
 		Type:    domainerr.ValidationError,
 		Message: "avatar URL is invalid",
 	}
+
+	ErrTimezoneInvalid = domainerr.BusinessError{
+		Code:    "TIMEZONE_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "timezone must be an IANA time zone name such as Asia/Shanghai",
+	}
+
+	ErrAvatarTypeUnsupported = domainerr.BusinessError{
+		Code:    "AVATAR_TYPE_UNSUPPORTED",
+		Type:    domainerr.ValidationError,
+		Message: "avatar must be a png, jpeg or webp image",
+	}
+
+	ErrAvatarTooLarge = domainerr.BusinessError{
+		Code:    "AVATAR_TOO_LARGE",
+		Type:    domainerr.ValidationError,
+		Message: "avatar file is too large",
+	}
+
+	ErrAvailabilityQueryInvalid = domainerr.BusinessError{
+		Code:    "AVAILABILITY_QUERY_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "exactly one of username or email is required",
+	}
+
+	ErrUserDateInvalid = domainerr.BusinessError{
+		Code:    "USER_DATE_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "date must be in YYYY-MM-DD format",
+	}
+
+	ErrUserDateRangeInvalid = domainerr.BusinessError{
+		Code:    "USER_DATE_RANGE_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "created_from and created_to are both required and created_from must not be after created_to",
+	}
+
+	ErrUserStatusInvalid = domainerr.BusinessError{
+		Code:    "USER_STATUS_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "status must be one of active, inactive, banned",
+	}
+
+	ErrUserStatusBatchInvalid = domainerr.BusinessError{
+		Code:    "USER_STATUS_BATCH_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "ids must contain between 1 and 100 user ids",
+	}
+
+	ErrUserImportBatchInvalid = domainerr.BusinessError{
+		Code:    "USER_IMPORT_BATCH_INVALID",
+		Type:    domainerr.ValidationError,
+		Message: "users must contain between 1 and 100 entries",
+	}
 )
 
 // 操作相关错误
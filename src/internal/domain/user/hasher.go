@@ -9,3 +9,11 @@ type Hasher interface {
 	// Verify 验证哈希值是否匹配
 	Verify(hash, value string) bool
 }
+
+// RehashChecker 可判断已有哈希值是否弱于当前参数的 Hasher（可选实现）
+//
+// 提高哈希计算成本后，用户登录成功时据此用明文重新生成哈希，逐步迁移到新参数。
+type RehashChecker interface {
+	// NeedsRehash 哈希值的计算成本低于当前配置时返回 true，无法解析的哈希值返回 false
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,50 @@
+package user
+
+// ImportStatus 批量导入用户时单行的处理结果
+type ImportStatus string
+
+const (
+	// ImportStatusCreated 已创建
+	ImportStatusCreated ImportStatus = "created"
+
+	// ImportStatusSkipped 用户名或邮箱已被占用，或严格模式下因其他行失败未导入
+	ImportStatusSkipped ImportStatus = "skipped"
+
+	// ImportStatusError 用户名、邮箱或密码校验失败
+	ImportStatusError ImportStatus = "error"
+)
+
+// ImportUser 批量导入的单个用户（原始输入，逐行创建值对象校验）
+type ImportUser struct {
+	// Username 用户名
+	Username string
+
+	// Email 邮箱
+	Email string
+
+	// Password 明文密码
+	Password string
+}
+
+// ImportResult 批量导入单行结果
+type ImportResult struct {
+	// Username 输入的用户名
+	Username string
+
+	// Email 输入的邮箱
+	Email string
+
+	// Status 处理结果
+	Status ImportStatus
+
+	// Err 跳过或失败的原因，创建成功时为 nil
+	Err error
+}
+
+// importCandidate 通过校验、等待写入的导入行
+type importCandidate struct {
+	index    int
+	username Username
+	email    Email
+	password Password
+}
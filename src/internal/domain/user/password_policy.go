@@ -0,0 +1,66 @@
+package user
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// commonPasswordsList 常见弱密码列表（每行一个，按小写比较）
+//
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+var (
+	commonPasswords     map[string]struct{}
+	commonPasswordsOnce sync.Once
+
+	// passwordPolicy 当前生效的密码策略，启动时通过 SetPasswordPolicy 设置
+	passwordPolicy = DefaultPasswordPolicy()
+)
+
+// PasswordPolicy 密码强度策略
+type PasswordPolicy struct {
+	// MinLength 最小长度（字节），不超过 MaxPasswordLength
+	MinLength int
+
+	// RequiredClasses 至少需要包含的字符类别数（大写、小写、数字、特殊字符）
+	RequiredClasses int
+
+	// DisallowCommon 是否拒绝常见弱密码
+	DisallowCommon bool
+}
+
+// DefaultPasswordPolicy 默认密码策略：至少 8 位，包含两类字符，不检查常见密码
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:       MinPasswordLength,
+		RequiredClasses: 2,
+	}
+}
+
+// SetPasswordPolicy 设置 NewPassword 使用的密码策略
+//
+// 应在启动时调用一次，运行期间修改不保证并发安全。
+func SetPasswordPolicy(policy PasswordPolicy) {
+	passwordPolicy = policy
+}
+
+// CurrentPasswordPolicy 返回当前生效的密码策略
+func CurrentPasswordPolicy() PasswordPolicy {
+	return passwordPolicy
+}
+
+// IsCommonPassword 判断密码是否在常见弱密码列表中（不区分大小写）
+func IsCommonPassword(value string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = make(map[string]struct{})
+		for _, line := range strings.Split(commonPasswordsList, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				commonPasswords[strings.ToLower(line)] = struct{}{}
+			}
+		}
+	})
+	_, ok := commonPasswords[strings.ToLower(value)]
+	return ok
+}
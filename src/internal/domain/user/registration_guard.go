@@ -0,0 +1,18 @@
+package user
+
+import "context"
+
+// RegistrationGuard 注册防护接口
+// 在创建账户前校验客户端提交的人机验证凭证（CAPTCHA、工作量证明等），由基础设施层提供实现
+type RegistrationGuard interface {
+	// Verify 校验凭证，未通过时返回 ErrCaptchaFailed
+	Verify(ctx context.Context, token string) error
+}
+
+// NopRegistrationGuard 不做任何校验的 RegistrationGuard，未启用注册防护时使用
+type NopRegistrationGuard struct{}
+
+// Verify 始终通过
+func (NopRegistrationGuard) Verify(context.Context, string) error {
+	return nil
+}
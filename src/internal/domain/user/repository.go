@@ -1,6 +1,9 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ==================== 仓储接口 ====================
 // 遵循接口隔离原则，将查询和存储操作分离
@@ -13,7 +16,7 @@ type UserRepository interface {
 	// FindByEmail 根据邮箱查找用户
 	FindByEmail(ctx context.Context, email string) (UserEntity, error)
 
-	// FindByUsername 根据用户名查找用户
+	// FindByUsername 根据用户名查找用户，按 CanonicalUsername 规范形式比较（不区分大小写）
 	FindByUsername(ctx context.Context, username string) (UserEntity, error)
 
 	// List 列出用户
@@ -22,10 +25,16 @@ type UserRepository interface {
 	// ListByStatus 根据状态列出用户
 	ListByStatus(ctx context.Context, status UserStatus, limit, offset int) ([]UserEntity, error)
 
+	// ListByDateRange 列出创建时间在 [from, to] 区间内的用户
+	ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]UserEntity, error)
+
+	// SearchByUsername 列出用户名以 prefix 开头的用户（不区分大小写），prefix 中的 % 和 _ 按字面匹配
+	SearchByUsername(ctx context.Context, prefix string, limit, offset int) ([]UserEntity, error)
+
 	// ExistsByEmail 检查邮箱是否存在
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 
-	// ExistsByUsername 检查用户名是否存在
+	// ExistsByUsername 检查用户名是否存在，按 CanonicalUsername 规范形式比较（不区分大小写）
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
 
 	// Count 统计用户总数
@@ -33,11 +42,29 @@ type UserRepository interface {
 
 	// CountByStatus 根据状态统计用户数
 	CountByStatus(ctx context.Context, status UserStatus) (int64, error)
+
+	// CountGroupByStatus 一次查询统计各状态的用户数，没有用户的状态不出现在结果中
+	CountGroupByStatus(ctx context.Context) (map[UserStatus]int64, error)
+
+	// CountByDateRange 统计创建时间在 [from, to] 区间内的用户数
+	CountByDateRange(ctx context.Context, from, to time.Time) (int64, error)
+
+	// CountByUsernamePrefix 统计用户名以 prefix 开头的用户数（不区分大小写）
+	CountByUsernamePrefix(ctx context.Context, prefix string) (int64, error)
+
+	// FindDeletedByID 根据ID查找已软删除的用户
+	FindDeletedByID(ctx context.Context, id int64) (UserEntity, error)
+
+	// ListDeleted 列出已软删除的用户
+	ListDeleted(ctx context.Context, limit, offset int) ([]UserEntity, error)
+
+	// CountDeleted 统计已软删除的用户数
+	CountDeleted(ctx context.Context) (int64, error)
 }
 
 // UserStore 用户存储接口（写操作）
 type UserStore interface {
-	// Save 保存用户（新增或更新）
+	// Save 保存用户（新增或更新），同时写入用户名的规范形式
 	Save(ctx context.Context, user UserEntity) error
 
 	// Delete 删除用户
@@ -45,6 +72,23 @@ type UserStore interface {
 
 	// SoftDelete 软删除用户
 	SoftDelete(ctx context.Context, id int64) error
+
+	// Restore 恢复软删除的用户
+	Restore(ctx context.Context, id int64) error
+
+	// PurgeSoftDeletedUsers 永久删除软删除时间早于 olderThan 之前的用户及其每日笔记，返回删除的用户数
+	PurgeSoftDeletedUsers(ctx context.Context, olderThan time.Duration) (int64, error)
+
+	// RecordFailedLogin 锁定用户行后按最新的失败记录累加一次登录失败（见 UserEntity.RecordFailedLogin），
+	// 只保存失败计数和锁定状态，返回本次失败是否触发了锁定；并发的失败登录不会互相覆盖计数
+	RecordFailedLogin(ctx context.Context, id int64, now time.Time, policy LockoutPolicy) (bool, error)
+
+	// UpdateStatusBatch 在同一事务中只更新多个已存在用户的状态和更新时间，任一用户更新失败时整体回滚
+	UpdateStatusBatch(ctx context.Context, users []UserEntity) error
+
+	// InsertBatch 在同一事务中新增多个用户，返回因用户名或邮箱已被占用而跳过的用户；
+	// strict 为 true 时任一用户冲突即整体回滚并返回 ErrUserAlreadyExists
+	InsertBatch(ctx context.Context, users []UserEntity, strict bool) ([]UserEntity, error)
 }
 
 // Repository 用户仓储组合接口
@@ -0,0 +1,42 @@
+package user
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// defaultReservedUsernamesList 默认保留用户名列表（每行一个）
+//
+//go:embed reserved_usernames.txt
+var defaultReservedUsernamesList string
+
+// reservedUsernames 当前生效的保留用户名（规范形式），启动时可通过 SetReservedUsernames 替换
+var reservedUsernames = newReservedUsernameSet(strings.Split(defaultReservedUsernamesList, "\n"))
+
+// SetReservedUsernames 设置普通用户不能注册或改用的用户名
+//
+// names 为空时恢复默认列表。
+// 应在启动时调用一次，运行期间修改不保证并发安全。
+func SetReservedUsernames(names []string) {
+	if len(names) == 0 {
+		names = strings.Split(defaultReservedUsernamesList, "\n")
+	}
+	reservedUsernames = newReservedUsernameSet(names)
+}
+
+// IsReservedUsername 判断用户名是否为保留用户名，按规范形式比较（不区分大小写）
+func IsReservedUsername(value string) bool {
+	_, ok := reservedUsernames[CanonicalUsername(value)]
+	return ok
+}
+
+// newReservedUsernameSet 将用户名列表转换为规范形式的集合，忽略空行
+func newReservedUsernameSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if name = CanonicalUsername(name); name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	return set
+}
@@ -5,7 +5,16 @@ import (
 	"crypto/subtle"
 	"errors"
 	"fmt"
-	"regexp"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPageSize 默认分页大小
+	DefaultPageSize = 20
+	// MaxPageSize 最大分页大小
+	MaxPageSize = 100
 )
 
 type UserService interface {
@@ -13,25 +22,67 @@ type UserService interface {
 
 	AuthenticateUser(ctx context.Context, email Email, password Password) (UserEntity, error)
 
+	ReactivateUser(ctx context.Context, email Email, password Password) (UserEntity, error)
+
+	GetActiveUser(ctx context.Context, userID int64) (UserEntity, error)
+
+	UpgradePasswordHash(ctx context.Context, user UserEntity, password Password) (bool, error)
+
 	ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword Password) error
 
+	VerifyUserPassword(ctx context.Context, userID int64, password Password) error
+
 	ResetPassword(ctx context.Context, userID int64, newPassword Password) error
 
 	UpdateEmail(ctx context.Context, userID int64, newEmail Email) error
 
+	ConfirmEmailChange(ctx context.Context, userID int64, newEmail Email) error
+
+	ChangeUsername(ctx context.Context, userID int64, newUsername Username) error
+
 	UpdateAvatar(ctx context.Context, userID int64, avatarURL string) error
 
+	UpdateTimezone(ctx context.Context, userID int64, timezone string) error
+
+	UploadAvatar(ctx context.Context, userID int64, contentType string, data []byte) (string, error)
+
 	ChangeUserStatus(ctx context.Context, userID int64, status UserStatus) error
 
 	DeleteUser(ctx context.Context, userID int64) error
 
 	SoftDeleteUser(ctx context.Context, userID int64) error
 
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+
+	RestoreUser(ctx context.Context, userID int64) error
+
+	ListDeletedUsers(ctx context.Context, limit, offset int) ([]UserEntity, int64, error)
+
+	ChangeUsersStatus(ctx context.Context, ids []int64, status UserStatus) error
+
+	BulkRegisterUsers(ctx context.Context, users []ImportUser, strict bool) ([]ImportResult, error)
+
 	ListUsers(ctx context.Context, limit, offset int) ([]UserEntity, error)
 
+	ListUsersByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]UserEntity, int64, error)
+
+	SearchUsersByUsername(ctx context.Context, prefix string, limit, offset int) ([]UserEntity, int64, error)
+
+	CountUsers(ctx context.Context) (int64, error)
+
+	CountUsersByStatus(ctx context.Context) (map[UserStatus]int64, error)
+
 	GetUserByID(ctx context.Context, userID int64) (UserEntity, error)
 
+	IsUsernameAvailable(ctx context.Context, username Username) (bool, error)
+
+	IsEmailAvailable(ctx context.Context, email Email) (bool, error)
+
 	GetUserByEmail(ctx context.Context, email Email) (UserEntity, error)
+
+	GetUserByUsername(ctx context.Context, username Username) (UserEntity, error)
+
+	VerifyEmail(ctx context.Context, userID int64) error
 }
 
 // Service 用户领域服务
@@ -39,21 +90,78 @@ type UserService interface {
 type Service struct {
 	repo Repository
 	hash Hasher
+
+	// dummyHash 用户不存在时用于校验的占位哈希，由 hash 按当前参数生成
+	dummyHash string
+
+	// requireEmailVerification 登录时是否要求邮箱已验证
+	requireEmailVerification bool
+
+	// lockoutPolicy 登录失败锁定策略，默认不启用
+	lockoutPolicy LockoutPolicy
+
+	// avatarStorage 头像文件存储，未设置时不支持上传头像
+	avatarStorage AvatarStorage
+
+	// usernameChangeCooldown 两次修改用户名的最小间隔，默认不限制
+	usernameChangeCooldown time.Duration
+}
+
+// ServiceOption 用户领域服务可选配置
+type ServiceOption func(*Service)
+
+// WithRequireEmailVerification 设置登录时是否要求邮箱已验证
+func WithRequireEmailVerification(required bool) ServiceOption {
+	return func(s *Service) {
+		s.requireEmailVerification = required
+	}
+}
+
+// WithLockoutPolicy 设置登录失败锁定策略
+func WithLockoutPolicy(policy LockoutPolicy) ServiceOption {
+	return func(s *Service) {
+		s.lockoutPolicy = policy
+	}
+}
+
+// WithAvatarStorage 设置头像文件存储
+func WithAvatarStorage(storage AvatarStorage) ServiceOption {
+	return func(s *Service) {
+		s.avatarStorage = storage
+	}
+}
+
+// WithUsernameChangeCooldown 设置两次修改用户名的最小间隔，非正数时不限制
+func WithUsernameChangeCooldown(cooldown time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.usernameChangeCooldown = cooldown
+	}
 }
 
 // NewService 创建用户领域服务
-func NewService(repo Repository, hash Hasher) *Service {
-	return &Service{
+func NewService(repo Repository, hash Hasher, opts ...ServiceOption) *Service {
+	s := &Service{
 		repo: repo,
 		hash: hash,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.dummyHash = dummyHashFor(hash)
+	return s
 }
 
 // RegisterUser 用户注册
 // 接口依赖值对象，调用方需先创建值对象（完成验证）
+// 保留用户名（见 SetReservedUsernames）返回 ErrUsernameReserved
 func (s *Service) RegisterUser(
 	ctx context.Context, username Username, email Email, password Password,
 ) (UserEntity, error) {
+	// 保留用户名不允许普通用户注册
+	if IsReservedUsername(username.String()) {
+		return nil, ErrUsernameReserved
+	}
+
 	// 检查用户名是否已存在
 	exists, err := s.repo.ExistsByUsername(ctx, username.String())
 	if err != nil {
@@ -89,35 +197,185 @@ func (s *Service) RegisterUser(
 		return nil, fmt.Errorf("failed to save user: %w", err)
 	}
 
-	return user, nil
+	// 仓储保存不回填自增 ID，重新查询以返回带 ID 的实体
+	saved, err := s.repo.FindByUsername(ctx, username.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registered user: %w", err)
+	}
+
+	return saved, nil
 }
 
 // AuthenticateUser 用户认证
 // 接口依赖值对象，调用方需先创建值对象（完成验证）
 func (s *Service) AuthenticateUser(ctx context.Context, email Email, password Password) (UserEntity, error) {
-	// 查找用户
+	// 查找用户，用户不存在时仍执行一次密码校验，
+	// 使“邮箱未注册”与“密码错误”的响应耗时一致，避免通过耗时枚举已注册邮箱
 	user, err := s.repo.FindByEmail(ctx, email.String())
-	if err != nil {
-		return nil, ErrInvalidCredentials
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
 	}
-	if user == nil {
+	if err != nil || user == nil {
+		s.hash.Verify(s.dummyHash, password.String())
 		return nil, ErrInvalidCredentials
 	}
 
 	// 检查账户状态
-	switch user.GetStatus() {
-	case UserStatusInactive:
-		return nil, ErrAccountInactive
-	case UserStatusBanned:
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkLoginPassword(ctx, user, password); err != nil {
+		return nil, err
+	}
+
+	// 密码正确后再检查邮箱验证状态，避免向未认证方泄露账户信息
+	if s.requireEmailVerification && !user.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	// 登录成功，清除之前的失败记录
+	if hasFailedLogins(user) {
+		user.ResetFailedLogins()
+		if err := s.repo.Save(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to reset failed logins: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+// ReactivateUser 停用的用户通过邮箱和密码自助恢复账户
+//
+// 只有停用（inactive）的账户可以自助恢复；封禁（banned）由管理员执行，返回 ErrAccountBanned，
+// 只能由管理员解除；账户未停用时返回 ErrAccountNotInactive。
+// 密码校验与登录共用锁定策略，恢复成功即视为一次成功登录，清除之前的失败记录。
+// 接口依赖值对象，调用方需先创建值对象（完成验证）
+func (s *Service) ReactivateUser(ctx context.Context, email Email, password Password) (UserEntity, error) {
+	user, err := s.repo.FindByEmail(ctx, email.String())
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+	if err != nil || user == nil {
+		s.hash.Verify(s.dummyHash, password.String())
+		return nil, ErrInvalidCredentials
+	}
+
+	if user.GetStatus() == UserStatusBanned {
 		return nil, ErrAccountBanned
 	}
 
-	// 验证密码
+	if err := s.checkLoginPassword(ctx, user, password); err != nil {
+		return nil, err
+	}
+
+	// 密码正确后再检查状态，避免向未认证方泄露账户是否已停用
+	if user.GetStatus() != UserStatusInactive {
+		return nil, ErrAccountNotInactive
+	}
+	if s.requireEmailVerification && !user.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	if err := s.ChangeUserStatus(ctx, user.GetID(), UserStatusActive); err != nil {
+		return nil, fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	reactivated, err := s.repo.FindByID(ctx, user.GetID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reactivated user: %w", err)
+	}
+	if hasFailedLogins(reactivated) {
+		reactivated.ResetFailedLogins()
+		if err := s.repo.Save(ctx, reactivated); err != nil {
+			return nil, fmt.Errorf("failed to reset failed logins: %w", err)
+		}
+	}
+
+	return reactivated, nil
+}
+
+// checkLoginPassword 校验登录密码
+//
+// 锁定期内直接拒绝，不再校验密码；密码错误时累计失败次数，达到阈值后锁定账户。
+func (s *Service) checkLoginPassword(ctx context.Context, user UserEntity, password Password) error {
+	now := time.Now()
+	if user.IsLocked(now) {
+		return ErrAccountLocked
+	}
+
 	if !s.hash.Verify(user.GetPasswordHash(), password.String()) {
-		return nil, ErrInvalidCredentials
+		if !s.lockoutPolicy.Enabled() {
+			return ErrInvalidCredentials
+		}
+		// 由仓储在锁定用户行后累加，避免并发的失败登录基于同一旧计数写回而少计
+		locked, err := s.repo.RecordFailedLogin(ctx, user.GetID(), now, s.lockoutPolicy)
+		if err != nil {
+			return fmt.Errorf("failed to record failed login: %w", err)
+		}
+		if locked {
+			return ErrAccountLocked
+		}
+		return ErrInvalidCredentials
 	}
+	return nil
+}
 
-	return user, nil
+// UpgradePasswordHash 升级计算成本过低的密码哈希
+//
+// 需在密码校验通过后调用：Hasher 实现了 RehashChecker 且已存储的哈希弱于当前配置时，
+// 用明文重新生成哈希并保存。返回是否进行了升级。
+func (s *Service) UpgradePasswordHash(ctx context.Context, user UserEntity, password Password) (bool, error) {
+	checker, ok := s.hash.(RehashChecker)
+	if !ok || !checker.NeedsRehash(user.GetPasswordHash()) {
+		return false, nil
+	}
+
+	hash, err := s.hash.Hash(password.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to rehash password: %w", err)
+	}
+	if err := user.UpdatePassword(hash); err != nil {
+		return false, err
+	}
+	if err := s.repo.Save(ctx, user); err != nil {
+		return false, fmt.Errorf("failed to save upgraded password hash: %w", err)
+	}
+	return true, nil
+}
+
+// dummyPassword 生成占位哈希使用的明文，校验结果不影响返回值
+const dummyPassword = "dummy-password-for-unknown-users"
+
+// dummyHashes 按 Hasher 实例缓存的占位哈希，服务按请求构造时不必重复计算
+var dummyHashes sync.Map
+
+// dummyHashFor 使用 h 的当前参数生成占位哈希，使“用户不存在”与“密码错误”的校验耗时一致
+//
+// 可比较的 Hasher 按实例缓存结果；h 为 nil 或生成失败时返回空字符串。
+func dummyHashFor(h Hasher) string {
+	if h == nil {
+		return ""
+	}
+	cacheable := reflect.TypeOf(h).Comparable()
+	if cacheable {
+		if hash, ok := dummyHashes.Load(h); ok {
+			return hash.(string)
+		}
+	}
+	hash, err := h.Hash(dummyPassword)
+	if err != nil {
+		return ""
+	}
+	if cacheable {
+		dummyHashes.Store(h, hash)
+	}
+	return hash
+}
+
+// hasFailedLogins 判断用户是否存在需要清除的登录失败记录
+func hasFailedLogins(user UserEntity) bool {
+	return user.GetFailedLoginAttempts() > 0 || !user.GetLastFailedLoginAt().IsZero() || !user.GetLockedUntil().IsZero()
 }
 
 // ChangePassword 修改密码
@@ -148,6 +406,21 @@ func (s *Service) ChangePassword(ctx context.Context, userID int64, oldPassword,
 	return s.repo.Save(ctx, user)
 }
 
+// VerifyUserPassword 校验用户当前密码
+// 用于敏感操作前的二次确认，密码不匹配时返回 ErrPasswordIncorrect
+func (s *Service) VerifyUserPassword(ctx context.Context, userID int64, password Password) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !s.hash.Verify(user.GetPasswordHash(), password.String()) {
+		return ErrPasswordIncorrect
+	}
+
+	return nil
+}
+
 // ResetPassword 重置密码（管理员操作或找回密码）
 // 接口依赖值对象，调用方需先创建值对象（完成验证）
 func (s *Service) ResetPassword(ctx context.Context, userID int64, newPassword Password) error {
@@ -171,8 +444,9 @@ func (s *Service) ResetPassword(ctx context.Context, userID int64, newPassword P
 	return s.repo.Save(ctx, user)
 }
 
-// UpdateEmail 更新邮箱
+// UpdateEmail 发起邮箱变更
 // 接口依赖值对象，调用方需先创建值对象（完成验证）
+// 新邮箱仅记录为待确认邮箱，通过 ConfirmEmailChange 确认后才替换登录邮箱
 func (s *Service) UpdateEmail(ctx context.Context, userID int64, newEmail Email) error {
 	// 检查新邮箱是否已被使用
 	exists, err := s.repo.ExistsByEmail(ctx, newEmail.String())
@@ -188,8 +462,71 @@ func (s *Service) UpdateEmail(ctx context.Context, userID int64, newEmail Email)
 		return ErrUserNotFound
 	}
 
-	// 更换邮箱
-	if err := user.ChangeEmail(newEmail.String()); err != nil {
+	// 记录待确认邮箱
+	if err := user.RequestEmailChange(newEmail.String()); err != nil {
+		return err
+	}
+
+	// 保存变更
+	return s.repo.Save(ctx, user)
+}
+
+// ConfirmEmailChange 确认邮箱变更
+// newEmail 为确认链接中携带的邮箱，必须与用户当前的待确认邮箱一致
+// 发起变更后邮箱可能已被其他用户占用，因此确认时重新检查
+func (s *Service) ConfirmEmailChange(ctx context.Context, userID int64, newEmail Email) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	// 校验并替换登录邮箱，未保存前不影响持久化状态
+	if err := user.ConfirmEmailChange(newEmail.String()); err != nil {
+		return err
+	}
+
+	exists, err := s.repo.ExistsByEmail(ctx, newEmail.String())
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return ErrEmailAlreadyExists
+	}
+
+	// 保存变更
+	return s.repo.Save(ctx, user)
+}
+
+// ChangeUsername 修改用户名
+// 接口依赖值对象，调用方需先创建值对象（完成验证）
+// 新用户名与当前用户名相同时不做修改，也不计入冷却期；
+// 仅大小写不同时视为修改，但不做保留和占用检查（规范形式相同，占用者就是当前用户）
+func (s *Service) ChangeUsername(ctx context.Context, userID int64, newUsername Username) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if user.GetUsername() == newUsername.String() {
+		return nil
+	}
+
+	// 检查新用户名是否为保留用户名或已被其他用户使用，仅修改大小写时不检查
+	if CanonicalUsername(user.GetUsername()) != newUsername.Canonical() {
+		if IsReservedUsername(newUsername.String()) {
+			return ErrUsernameReserved
+		}
+		exists, err := s.repo.ExistsByUsername(ctx, newUsername.String())
+		if err != nil {
+			return fmt.Errorf("failed to check username: %w", err)
+		}
+		if exists {
+			return ErrUsernameTaken
+		}
+	}
+
+	// 修改用户名（检查冷却期）
+	if err := user.ChangeUsername(newUsername.String(), time.Now(), s.usernameChangeCooldown); err != nil {
 		return err
 	}
 
@@ -200,7 +537,7 @@ func (s *Service) UpdateEmail(ctx context.Context, userID int64, newEmail Email)
 // UpdateAvatar 更新头像
 func (s *Service) UpdateAvatar(ctx context.Context, userID int64, avatarURL string) error {
 	// 验证 URL 格式
-	if avatarURL != "" && !s.isValidURL(avatarURL) {
+	if !IsValidAvatarURL(avatarURL) {
 		return ErrAvatarURLInvalid
 	}
 
@@ -218,6 +555,52 @@ func (s *Service) UpdateAvatar(ctx context.Context, userID int64, avatarURL stri
 	return s.repo.Save(ctx, user)
 }
 
+// UpdateTimezone 更新时区
+// 空字符串表示清除设置，之后按服务器默认时区确定用户的"今天"
+func (s *Service) UpdateTimezone(ctx context.Context, userID int64, timezone string) error {
+	if !IsValidTimezone(timezone) {
+		return ErrTimezoneInvalid
+	}
+
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := user.UpdateTimezone(timezone); err != nil {
+		return err
+	}
+
+	return s.repo.Save(ctx, user)
+}
+
+// UploadAvatar 上传头像文件并更新用户头像
+// contentType 由调用方根据文件内容探测得到，仅允许 png、jpeg、webp
+// 返回存储后的头像 URL
+func (s *Service) UploadAvatar(ctx context.Context, userID int64, contentType string, data []byte) (string, error) {
+	if _, ok := AvatarExtension(contentType); !ok {
+		return "", ErrAvatarTypeUnsupported
+	}
+	if s.avatarStorage == nil {
+		return "", errors.New("avatar storage is not configured")
+	}
+
+	// 先确认用户存在，避免为不存在的用户写入文件
+	if _, err := s.repo.FindByID(ctx, userID); err != nil {
+		return "", ErrUserNotFound
+	}
+
+	avatarURL, err := s.avatarStorage.Save(ctx, userID, contentType, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to save avatar: %w", err)
+	}
+
+	if err := s.UpdateAvatar(ctx, userID, avatarURL); err != nil {
+		return "", err
+	}
+	return avatarURL, nil
+}
+
 // ChangeUserStatus 修改用户状态
 func (s *Service) ChangeUserStatus(ctx context.Context, userID int64, status UserStatus) error {
 	user, err := s.repo.FindByID(ctx, userID)
@@ -246,6 +629,21 @@ func (s *Service) ChangeUserStatus(ctx context.Context, userID int64, status Use
 	return s.repo.Save(ctx, user)
 }
 
+// VerifyEmail 标记用户邮箱已验证
+func (s *Service) VerifyEmail(ctx context.Context, userID int64) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := user.MarkEmailVerified(); err != nil {
+		return err
+	}
+
+	// 保存变更
+	return s.repo.Save(ctx, user)
+}
+
 // DeleteUser 删除用户（硬删除）。
 //
 // 此操作会永久删除用户数据，不可恢复。
@@ -262,10 +660,265 @@ func (s *Service) SoftDeleteUser(ctx context.Context, userID int64) error {
 	return s.repo.SoftDelete(ctx, userID)
 }
 
-// isValidURL 简单的 URL 验证
-func (s *Service) isValidURL(url string) bool {
-	matched, _ := regexp.MatchString(`^https?://`, url)
-	return matched
+// PurgeDeletedUsers 永久删除注销超过保留期的用户。
+//
+// 用户的每日笔记一并删除，删除后无法恢复。返回删除的用户数。
+func (s *Service) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.PurgeSoftDeletedUsers(ctx, retention)
+}
+
+// RestoreUser 恢复软删除的用户。
+//
+// 用户名或邮箱已被其他有效账户使用时返回 ErrUserRestoreConflict，
+// 用户不存在或未被删除时返回 ErrUserNotFound。
+//
+// 参数：
+//   ctx - 请求上下文
+//   userID - 用户 ID
+//
+// 返回：
+//   error - 恢复失败时的错误
+func (s *Service) RestoreUser(ctx context.Context, userID int64) error {
+	deleted, err := s.repo.FindDeletedByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	usernameTaken, err := s.repo.ExistsByUsername(ctx, deleted.GetUsername())
+	if err != nil {
+		return fmt.Errorf("failed to check username: %w", err)
+	}
+	emailTaken, err := s.repo.ExistsByEmail(ctx, deleted.GetEmail())
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if usernameTaken || emailTaken {
+		return ErrUserRestoreConflict
+	}
+
+	return s.repo.Restore(ctx, userID)
+}
+
+// ListDeletedUsers 列出可恢复的已软删除用户
+//
+// 参数：
+//   ctx - 请求上下文
+//   limit - 限制数量
+//   offset - 偏移量
+//
+// 返回：
+//   []UserEntity - 用户列表
+//   int64 - 已软删除用户总数
+//   error - 查询失败时的错误
+func (s *Service) ListDeletedUsers(ctx context.Context, limit, offset int) ([]UserEntity, int64, error) {
+	users, err := s.repo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountDeleted(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// ChangeUsersStatus 批量修改用户状态。
+//
+// 对每个用户执行与单个修改相同的状态转换（Activate/Deactivate/Ban），
+// 并在同一事务中只保存状态列，不覆盖读取后其他请求对用户的修改；任一用户不存在或保存失败时整体回滚。
+// 重复的 ID 只处理一次。
+//
+// 参数：
+//   ctx - 请求上下文
+//   ids - 用户 ID 列表，1 到 MaxStatusBatchSize 个
+//   status - 目标状态
+//
+// 返回：
+//   error - 参数无效时返回 ErrUserStatusBatchInvalid/ErrUserStatusInvalid，
+//           用户不存在时返回 ErrUserNotFound
+func (s *Service) ChangeUsersStatus(ctx context.Context, ids []int64, status UserStatus) error {
+	if len(ids) == 0 || len(ids) > MaxStatusBatchSize {
+		return ErrUserStatusBatchInvalid
+	}
+	if _, err := ParseUserStatus(string(status)); err != nil {
+		return err
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	users := make([]UserEntity, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		user, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := applyStatus(user, status); err != nil {
+			return err
+		}
+		users = append(users, user)
+	}
+
+	return s.repo.UpdateStatusBatch(ctx, users)
+}
+
+// applyStatus 对用户执行目标状态对应的状态转换
+func applyStatus(user UserEntity, status UserStatus) error {
+	switch status {
+	case UserStatusActive:
+		return user.Activate()
+	case UserStatusInactive:
+		return user.Deactivate()
+	case UserStatusBanned:
+		return user.Ban()
+	default:
+		return ErrUserStatusInvalid
+	}
+}
+
+// BulkRegisterUsers 管理端批量导入用户
+//
+// 逐行创建值对象完成校验：校验失败或使用保留用户名的行标记为 error，
+// 用户名或邮箱已被占用（含与批次内前面的行重复）的行标记为 skipped，
+// 其余行计算密码哈希后在同一事务中写入，一行失败不影响其他行。
+// strict 为 true 时要求全部成功：任一行未通过检查则不写入任何用户，
+// 通过检查的行标记为 skipped（ErrUserImportAborted）。
+//
+// 参数：
+//   ctx - 请求上下文
+//   users - 待导入的用户，1 到 MaxImportBatchSize 个
+//   strict - 是否全部成功才写入
+//
+// 返回：
+//   []ImportResult - 与 users 顺序一致的逐行结果
+//   error - 条数无效时返回 ErrUserImportBatchInvalid；严格模式下写入时发生冲突返回 ErrUserAlreadyExists
+func (s *Service) BulkRegisterUsers(ctx context.Context, users []ImportUser, strict bool) ([]ImportResult, error) {
+	if len(users) == 0 || len(users) > MaxImportBatchSize {
+		return nil, ErrUserImportBatchInvalid
+	}
+
+	// 逐行校验，记录批次内已出现的用户名和邮箱
+	results := make([]ImportResult, len(users))
+	candidates := make([]importCandidate, 0, len(users))
+	seenUsernames := make(map[string]bool, len(users))
+	seenEmails := make(map[string]bool, len(users))
+	failed := false
+	for i, input := range users {
+		results[i] = ImportResult{Username: input.Username, Email: input.Email}
+
+		candidate, status, err := s.checkImportUser(ctx, input, seenUsernames, seenEmails)
+		if err != nil {
+			if status == "" {
+				return nil, err
+			}
+			results[i].Status = status
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		candidate.index = i
+		candidates = append(candidates, candidate)
+	}
+
+	if strict && failed {
+		for _, candidate := range candidates {
+			results[candidate.index].Status = ImportStatusSkipped
+			results[candidate.index].Err = ErrUserImportAborted
+		}
+		return results, nil
+	}
+	if len(candidates) == 0 {
+		return results, nil
+	}
+
+	// 哈希密码并创建用户实体
+	entities := make([]UserEntity, len(candidates))
+	for i, candidate := range candidates {
+		passwordHash, err := candidate.password.Hash(s.hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		entity, err := NewUser(candidate.username.String(), candidate.email.String(), passwordHash.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		entities[i] = entity
+	}
+
+	// 批量保存到仓储，检查之后被并发注册占用的用户由仓储跳过
+	skipped, err := s.repo.InsertBatch(ctx, entities, strict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import users: %w", err)
+	}
+	skippedSet := make(map[UserEntity]bool, len(skipped))
+	for _, entity := range skipped {
+		skippedSet[entity] = true
+	}
+	for i, candidate := range candidates {
+		if skippedSet[entities[i]] {
+			results[candidate.index].Status = ImportStatusSkipped
+			results[candidate.index].Err = ErrUserAlreadyExists
+			continue
+		}
+		results[candidate.index].Status = ImportStatusCreated
+	}
+
+	return results, nil
+}
+
+// checkImportUser 校验单个导入用户并检查用户名、邮箱是否已被占用
+//
+// 校验失败返回 ImportStatusError，已被占用返回 ImportStatusSkipped；
+// 查询仓储失败时状态为空，调用方应终止整个批次。
+func (s *Service) checkImportUser(
+	ctx context.Context, input ImportUser, seenUsernames, seenEmails map[string]bool,
+) (importCandidate, ImportStatus, error) {
+	username, err := NewUsername(input.Username)
+	if err != nil {
+		return importCandidate{}, ImportStatusError, err
+	}
+	email, err := NewEmail(input.Email)
+	if err != nil {
+		return importCandidate{}, ImportStatusError, err
+	}
+	password, err := NewPassword(input.Password)
+	if err != nil {
+		return importCandidate{}, ImportStatusError, err
+	}
+	if IsReservedUsername(username.String()) {
+		return importCandidate{}, ImportStatusError, ErrUsernameReserved
+	}
+
+	if seenUsernames[username.Canonical()] {
+		return importCandidate{}, ImportStatusSkipped, ErrUsernameTaken
+	}
+	if seenEmails[email.String()] {
+		return importCandidate{}, ImportStatusSkipped, ErrEmailAlreadyExists
+	}
+	seenUsernames[username.Canonical()] = true
+	seenEmails[email.String()] = true
+
+	exists, err := s.repo.ExistsByUsername(ctx, username.String())
+	if err != nil {
+		return importCandidate{}, "", fmt.Errorf("failed to check username: %w", err)
+	}
+	if exists {
+		return importCandidate{}, ImportStatusSkipped, ErrUsernameTaken
+	}
+	exists, err = s.repo.ExistsByEmail(ctx, email.String())
+	if err != nil {
+		return importCandidate{}, "", fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return importCandidate{}, ImportStatusSkipped, ErrEmailAlreadyExists
+	}
+
+	return importCandidate{username: username, email: email, password: password}, "", nil
 }
 
 // ListUsers 列出用户
@@ -282,6 +935,121 @@ func (s *Service) ListUsers(ctx context.Context, limit, offset int) ([]UserEntit
 	return s.repo.List(ctx, limit, offset)
 }
 
+// ListUsersByDateRange 按创建时间区间列出用户
+//
+// 区间为闭区间，开始时间晚于结束时间时返回 ErrUserDateRangeInvalid。
+//
+// 参数：
+//   ctx - 请求上下文
+//   from - 创建时间下界
+//   to - 创建时间上界
+//   limit - 限制数量
+//   offset - 偏移量
+//
+// 返回：
+//   []UserEntity - 用户列表
+//   int64 - 区间内用户总数
+//   error - 查询失败时的错误
+func (s *Service) ListUsersByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]UserEntity, int64, error) {
+	if from.After(to) {
+		return nil, 0, ErrUserDateRangeInvalid
+	}
+
+	users, err := s.repo.ListByDateRange(ctx, from, to, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByDateRange(ctx, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// SearchUsersByUsername 按用户名前缀搜索用户
+//
+// 参数：
+//   ctx - 请求上下文
+//   prefix - 用户名前缀，其中的 % 和 _ 按字面匹配
+//   limit - 限制数量
+//   offset - 偏移量
+//
+// 返回：
+//   []UserEntity - 按用户名升序排列的用户列表
+//   int64 - 匹配的用户总数
+//   error - 查询失败时的错误
+func (s *Service) SearchUsersByUsername(ctx context.Context, prefix string, limit, offset int) ([]UserEntity, int64, error) {
+	users, err := s.repo.SearchByUsername(ctx, prefix, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByUsernamePrefix(ctx, prefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// CountUsers 统计用户总数
+func (s *Service) CountUsers(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// CountUsersByStatus 统计各状态的用户数（不含已软删除用户）
+func (s *Service) CountUsersByStatus(ctx context.Context) (map[UserStatus]int64, error) {
+	return s.repo.CountGroupByStatus(ctx)
+}
+
+// IsUsernameAvailable 检查用户名是否可用于注册，保留用户名视为不可用
+func (s *Service) IsUsernameAvailable(ctx context.Context, username Username) (bool, error) {
+	if IsReservedUsername(username.String()) {
+		return false, nil
+	}
+	exists, err := s.repo.ExistsByUsername(ctx, username.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to check username: %w", err)
+	}
+	return !exists, nil
+}
+
+// IsEmailAvailable 检查邮箱是否可用于注册
+func (s *Service) IsEmailAvailable(ctx context.Context, email Email) (bool, error) {
+	exists, err := s.repo.ExistsByEmail(ctx, email.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return !exists, nil
+}
+
+// GetActiveUser 获取状态为 active 的用户，用于刷新 Token 等无需密码的会话延续场景
+//
+// 用户不存在（含已注销）时返回 ErrUserNotFound，停用或封禁时返回 ErrAccountInactive、ErrAccountBanned。
+func (s *Service) GetActiveUser(ctx context.Context, userID int64) (UserEntity, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by ID: %w", err)
+	}
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkAccountStatus 停用或封禁的账户不能登录或延续会话
+func checkAccountStatus(user UserEntity) error {
+	switch user.GetStatus() {
+	case UserStatusInactive:
+		return ErrAccountInactive
+	case UserStatusBanned:
+		return ErrAccountBanned
+	}
+	return nil
+}
+
 // GetUserByID 根据 ID 获取用户
 //
 // 参数：
@@ -316,6 +1084,25 @@ func (s *Service) GetUserByEmail(ctx context.Context, email Email) (UserEntity,
 	return user, nil
 }
 
+// GetUserByUsername 根据用户名获取用户
+//
+// 已注销用户视为不存在，其余状态的用户均会返回，是否对外公开由调用方决定。
+//
+// 参数：
+//   ctx - 请求上下文
+//   username - 用户名值对象
+//
+// 返回：
+//   UserEntity - 用户实体
+//   error - 查询失败时的错误，用户不存在时包装 ErrUserNotFound
+func (s *Service) GetUserByUsername(ctx context.Context, username Username) (UserEntity, error) {
+	user, err := s.repo.FindByUsername(ctx, username.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by username: %w", err)
+	}
+	return user, nil
+}
+
 // ConstantTimeCompare 恒定时间比较，防止时序攻击
 // 用于密码、令牌等敏感数据的比较
 func ConstantTimeCompare(a, b string) bool {
@@ -2,8 +2,11 @@ package user
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -16,6 +19,16 @@ const (
 	MinUsernameLength = 3
 	// MaxUsernameLength 用户名最大长度
 	MaxUsernameLength = 32
+	// MaxAvatarURLLength 头像 URL 最大长度，与 users.avatar_url 列宽一致
+	MaxAvatarURLLength = 500
+	// MaxTimezoneLength 时区名称最大长度，与 users.timezone 列宽一致
+	MaxTimezoneLength = 64
+	// DateLayout 查询参数中的日期格式
+	DateLayout = "2006-01-02"
+	// MaxStatusBatchSize 批量修改用户状态的最大用户数
+	MaxStatusBatchSize = 100
+	// MaxImportBatchSize 批量导入用户的最大条数，每个用户都要计算一次密码哈希
+	MaxImportBatchSize = 100
 )
 
 // Username 用户名值对象
@@ -44,6 +57,92 @@ func (u Username) String() string {
 	return u.value
 }
 
+// Canonical 返回用户名的规范形式，用于唯一性检查和查找
+func (u Username) Canonical() string {
+	return CanonicalUsername(u.value)
+}
+
+// CanonicalUsername 返回用户名的规范形式（去除首尾空白并转为小写）
+//
+// 用户名只允许 ASCII 字母、数字和下划线，规范形式只折叠大小写：
+// Alice 与 alice 视为同一用户名，展示时仍保留注册或修改时的大小写。
+func CanonicalUsername(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// IsValidAvatarURL 校验头像 URL
+//
+// 要求为不超过 MaxAvatarURLLength 的绝对 http/https URL，主机名非空，且不含空白字符和用户信息。
+// 空字符串表示清除头像，视为有效。
+func IsValidAvatarURL(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	if len(raw) > MaxAvatarURLLength || strings.ContainsFunc(raw, unicode.IsSpace) {
+		return false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	return u.Hostname() != "" && u.User == nil
+}
+
+// IsValidTimezone 校验时区名称
+//
+// 要求为 IANA 时区数据库中的名称（如 Asia/Shanghai、UTC），不接受依赖服务器环境的 Local。
+// 空字符串表示清除时区设置，视为有效。
+func IsValidTimezone(name string) bool {
+	if name == "" {
+		return true
+	}
+	if len(name) > MaxTimezoneLength || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// ResolveLocation 返回时区名称对应的 *time.Location
+//
+// 名称为空或无法加载时返回 fallback，fallback 为 nil 时返回 time.Local。
+func ResolveLocation(name string, fallback *time.Location) *time.Location {
+	if fallback == nil {
+		fallback = time.Local
+	}
+	if name == "" || name == "Local" {
+		return fallback
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
+
+// ParseDate 按 DateLayout 解析日期（服务器本地时区的零点）
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(DateLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, ErrUserDateInvalid
+	}
+	return date, nil
+}
+
+// ParseUserStatus 解析用户状态，仅接受 active、inactive、banned
+func ParseUserStatus(value string) (UserStatus, error) {
+	switch status := UserStatus(strings.TrimSpace(value)); status {
+	case UserStatusActive, UserStatusInactive, UserStatusBanned:
+		return status, nil
+	default:
+		return "", ErrUserStatusInvalid
+	}
+}
+
 // Email 邮箱值对象
 type Email struct {
 	value string
@@ -101,12 +200,19 @@ type Password struct {
 }
 
 // NewPassword 创建密码值对象
+//
+// 按当前密码策略（见 SetPasswordPolicy）校验密码强度，用于设置新密码。
 func NewPassword(value string) (Password, error) {
-	if len(value) < MinPasswordLength {
-		return Password{}, errors.New("password must be at least 8 characters")
+	return NewPasswordWithPolicy(value, passwordPolicy)
+}
+
+// NewPasswordWithPolicy 按指定策略创建密码值对象
+func NewPasswordWithPolicy(value string, policy PasswordPolicy) (Password, error) {
+	if len(value) < policy.MinLength {
+		return Password{}, passwordTooWeak(fmt.Sprintf("password must be at least %d characters", policy.MinLength))
 	}
 	if len(value) > MaxPasswordLength {
-		return Password{}, errors.New("password must not exceed 72 characters")
+		return Password{}, passwordTooWeak(fmt.Sprintf("password must not exceed %d characters", MaxPasswordLength))
 	}
 
 	var (
@@ -129,7 +235,7 @@ func NewPassword(value string) (Password, error) {
 		}
 	}
 
-	// 至少包含大写字母、小写字母、数字中的两种
+	// 至少包含策略要求数量的字符类别
 	complexity := 0
 	if hasUpper {
 		complexity++
@@ -144,13 +250,34 @@ func NewPassword(value string) (Password, error) {
 		complexity++
 	}
 
-	if complexity < 2 {
-		return Password{}, errors.New("password must contain at least two of: uppercase, lowercase, number, special character")
+	if complexity < policy.RequiredClasses {
+		return Password{}, passwordTooWeak(fmt.Sprintf("password must contain at least %d of: uppercase, lowercase, number, special character", policy.RequiredClasses))
+	}
+
+	if policy.DisallowCommon && IsCommonPassword(value) {
+		return Password{}, passwordTooWeak("password is too common")
 	}
 
 	return Password{value: value}, nil
 }
 
+// ParsePassword 创建用于校验已有密码的值对象
+//
+// 只检查长度上限，不应用密码策略，避免策略收紧后已有用户无法登录。
+func ParsePassword(value string) (Password, error) {
+	if value == "" || len(value) > MaxPasswordLength {
+		return Password{}, ErrPasswordInvalid
+	}
+	return Password{value: value}, nil
+}
+
+// passwordTooWeak 返回带具体原因的 ErrPasswordTooWeak
+func passwordTooWeak(message string) error {
+	err := ErrPasswordTooWeak
+	err.Message = message
+	return err
+}
+
 // String 返回字符串值
 func (p Password) String() string {
 	return p.value
@@ -191,3 +318,19 @@ func NewPasswordHash(value string) (PasswordHash, error) {
 func (p PasswordHash) String() string {
 	return p.value
 }
+
+// LockoutPolicy 登录失败锁定策略值对象
+// 在 Window 时间窗口内连续失败 MaxAttempts 次后锁定账户 LockDuration
+type LockoutPolicy struct {
+	// MaxAttempts 触发锁定的失败次数，小于等于 0 时不启用锁定
+	MaxAttempts int
+	// Window 失败次数统计窗口
+	Window time.Duration
+	// LockDuration 锁定时长
+	LockDuration time.Duration
+}
+
+// Enabled 是否启用登录失败锁定
+func (p LockoutPolicy) Enabled() bool {
+	return p.MaxAttempts > 0
+}
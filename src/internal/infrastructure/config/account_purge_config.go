@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAccountPurgeRetention 默认已注销账户的保留期（30天）
+	DefaultAccountPurgeRetention = 30 * 24 * time.Hour
+
+	// DefaultAccountPurgeInterval 默认清理任务执行间隔（每天一次）
+	DefaultAccountPurgeInterval = 24 * time.Hour
+)
+
+// AccountPurgeConfig 已注销账户清理配置
+type AccountPurgeConfig struct {
+	// Retention 账户注销（软删除）后保留的时长，期满后连同笔记一起永久删除；为 0 时不清理
+	Retention time.Duration
+
+	// Interval 清理任务执行间隔
+	Interval time.Duration
+}
+
+var (
+	accountPurgeConfig     *AccountPurgeConfig
+	accountPurgeConfigErr  error
+	accountPurgeConfigOnce sync.Once
+)
+
+// LoadAccountPurgeConfig 加载已注销账户清理配置
+//
+// 从环境变量 ACCOUNT_PURGE_RETENTION、ACCOUNT_PURGE_INTERVAL 读取，
+// 未配置时注销 30 天后清理，每天执行一次。
+func LoadAccountPurgeConfig() (*AccountPurgeConfig, error) {
+	cfg := &AccountPurgeConfig{
+		Retention: getEnvDurationOrDefault("ACCOUNT_PURGE_RETENTION", DefaultAccountPurgeRetention),
+		Interval:  getEnvDurationOrDefault("ACCOUNT_PURGE_INTERVAL", DefaultAccountPurgeInterval),
+	}
+
+	if cfg.Retention < 0 {
+		return nil, fmt.Errorf("invalid account purge config: retention must not be negative (current: %s)", cfg.Retention)
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("invalid account purge config: interval must be positive (current: %s)", cfg.Interval)
+	}
+
+	return cfg, nil
+}
+
+// Enabled 是否启用已注销账户清理
+func (c *AccountPurgeConfig) Enabled() bool {
+	return c.Retention > 0
+}
+
+// GetAccountPurgeConfig 获取已注销账户清理配置（单例模式）
+func GetAccountPurgeConfig() (*AccountPurgeConfig, error) {
+	accountPurgeConfigOnce.Do(func() {
+		accountPurgeConfig, accountPurgeConfigErr = LoadAccountPurgeConfig()
+	})
+	return accountPurgeConfig, accountPurgeConfigErr
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"todolist/internal/pkg/logger"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// AppConfig 应用统一配置
+//
+// 由 LoadAppConfig 从配置文件和环境变量合并生成，
+// GetMySQLConfig、GetJWTConfig 等访问器均委托给它加载。
+type AppConfig struct {
+	MySQL  MySQLConfig
+	JWT    JWTSettings
+	Logger LoggerConfig
+}
+
+// JWTSettings JWT 配置项
+//
+// 过期时间为 0、签发者或受众为空时由 JWT 配置加载时填充默认值。
+type JWTSettings struct {
+	SecretKey             string
+	ExpireDuration        time.Duration
+	RefreshExpireDuration time.Duration
+	Issuer                string
+	Audience              string
+}
+
+// LoggerConfig 日志配置项
+type LoggerConfig struct {
+	// Level 日志级别：debug、info、warn、error
+	Level string
+
+	// Format 日志格式：json、text、ecs、stackdriver
+	Format string
+
+	// FilePath 日志文件路径，为空时输出到标准输出
+	FilePath string
+
+	// MaxSizeMB 单个日志文件最大大小（MB）
+	MaxSizeMB int
+
+	// MaxBackups 保留的旧日志文件个数
+	MaxBackups int
+
+	// MaxAgeDays 旧日志文件保留天数
+	MaxAgeDays int
+}
+
+// appConfigKey 配置项定义：配置文件中的键、对应的环境变量和默认值
+type appConfigKey struct {
+	key          string
+	env          string
+	defaultValue any
+}
+
+// appConfigKeys 所有统一管理的配置项
+//
+// 优先级：环境变量 > 配置文件 > 默认值。
+var appConfigKeys = []appConfigKey{
+	{"mysql.host", "MYSQL_HOST", "localhost"},
+	{"mysql.port", "MYSQL_PORT", 3307},
+	{"mysql.db", "MYSQL_DB", "test"},
+	{"mysql.user", "MYSQL_USER", "root"},
+	{"mysql.password", "MYSQL_PASSWORD", "123456"},
+	{"mysql.max_open_conns", "MYSQL_MAX_OPEN_CONNS", 100},
+	{"mysql.max_idle_conns", "MYSQL_MAX_IDLE_CONNS", 10},
+	{"mysql.query_timeout", "MYSQL_QUERY_TIMEOUT", DefaultMySQLQueryTimeout},
+	{"mysql.conn_max_lifetime", "MYSQL_CONN_MAX_LIFETIME", DefaultMySQLConnMaxLifetime},
+	{"mysql.conn_max_idle_time", "MYSQL_CONN_MAX_IDLE_TIME", DefaultMySQLConnMaxIdleTime},
+	{"mysql.slow_query_threshold", "MYSQL_SLOW_QUERY_THRESHOLD", time.Duration(0)},
+	{"mysql.replica.host", "MYSQL_REPLICA_HOST", ""},
+	{"mysql.replica.port", "MYSQL_REPLICA_PORT", 0},
+	{"mysql.replica.user", "MYSQL_REPLICA_USER", ""},
+	{"mysql.replica.password", "MYSQL_REPLICA_PASSWORD", ""},
+
+	{"jwt.secret_key", "JWT_SECRET_KEY", ""},
+	{"jwt.expire_duration", "JWT_EXPIRE_DURATION", time.Duration(0)},
+	{"jwt.refresh_expire_duration", "JWT_REFRESH_EXPIRE_DURATION", time.Duration(0)},
+	{"jwt.issuer", "JWT_ISSUER", ""},
+	{"jwt.audience", "JWT_AUDIENCE", ""},
+
+	{"logger.level", "LOG_LEVEL", "info"},
+	{"logger.format", "LOG_FORMAT", "json"},
+	{"logger.file", "LOG_FILE", ""},
+	{"logger.max_size_mb", "LOG_FILE_MAX_SIZE_MB", logger.DefaultMaxSizeMB},
+	{"logger.max_backups", "LOG_FILE_MAX_BACKUPS", logger.DefaultMaxBackups},
+	{"logger.max_age_days", "LOG_FILE_MAX_AGE_DAYS", logger.DefaultMaxAgeDays},
+}
+
+// LoadAppConfig 加载应用统一配置
+//
+// 设置 CONFIG_FILE 时从该 YAML/JSON 文件读取配置（按扩展名识别格式），
+// 同名环境变量优先于文件中的值。每次调用都会重新读取，不做缓存。
+func LoadAppConfig() (*AppConfig, error) {
+	v := viper.New()
+	for _, k := range appConfigKeys {
+		v.SetDefault(k.key, k.defaultValue)
+		if err := v.BindEnv(k.key, k.env); err != nil {
+			return nil, fmt.Errorf("failed to bind env %s: %w", k.env, err)
+		}
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	r := &appConfigReader{v: v}
+	cfg := &AppConfig{
+		MySQL: MySQLConfig{
+			Host:               v.GetString("mysql.host"),
+			Port:               r.int("mysql.port"),
+			DB:                 v.GetString("mysql.db"),
+			User:               v.GetString("mysql.user"),
+			Password:           v.GetString("mysql.password"),
+			MaxOpenConns:       r.int("mysql.max_open_conns"),
+			MaxIdleConns:       r.int("mysql.max_idle_conns"),
+			QueryTimeout:       r.duration("mysql.query_timeout"),
+			ConnMaxLifetime:    r.duration("mysql.conn_max_lifetime"),
+			ConnMaxIdleTime:    r.duration("mysql.conn_max_idle_time"),
+			SlowQueryThreshold: r.duration("mysql.slow_query_threshold"),
+			ReplicaHost:        v.GetString("mysql.replica.host"),
+			ReplicaPort:        r.int("mysql.replica.port"),
+			ReplicaUser:        v.GetString("mysql.replica.user"),
+			ReplicaPassword:    v.GetString("mysql.replica.password"),
+		},
+		JWT: JWTSettings{
+			SecretKey:             v.GetString("jwt.secret_key"),
+			ExpireDuration:        r.duration("jwt.expire_duration"),
+			RefreshExpireDuration: r.duration("jwt.refresh_expire_duration"),
+			Issuer:                v.GetString("jwt.issuer"),
+			Audience:              v.GetString("jwt.audience"),
+		},
+		Logger: LoggerConfig{
+			Level:      strings.ToLower(v.GetString("logger.level")),
+			Format:     strings.ToLower(v.GetString("logger.format")),
+			FilePath:   v.GetString("logger.file"),
+			MaxSizeMB:  r.int("logger.max_size_mb"),
+			MaxBackups: r.int("logger.max_backups"),
+			MaxAgeDays: r.int("logger.max_age_days"),
+		},
+	}
+
+	if err := errors.Join(r.errs...); err != nil {
+		return nil, fmt.Errorf("invalid config value: %w", err)
+	}
+	if _, err := cfg.Logger.level(); err != nil {
+		return nil, fmt.Errorf("invalid logger config: %w", err)
+	}
+	if _, err := logger.ParseFormat(cfg.Logger.Format); err != nil {
+		return nil, fmt.Errorf("invalid logger config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// appConfigReader 按类型读取配置项并收集解析错误
+//
+// viper 的 GetInt、GetDuration 遇到无法解析的值会静默返回 0，
+// 这里改用 cast 的 E 系列函数，使格式错误的环境变量或文件值能被报告出来。
+type appConfigReader struct {
+	v    *viper.Viper
+	errs []error
+}
+
+// int 读取整数配置项
+func (r *appConfigReader) int(key string) int {
+	n, err := cast.ToIntE(r.v.Get(key))
+	if err != nil {
+		r.fail(key, err)
+	}
+	return n
+}
+
+// duration 读取时长配置项
+func (r *appConfigReader) duration(key string) time.Duration {
+	d, err := cast.ToDurationE(r.v.Get(key))
+	if err != nil {
+		r.fail(key, err)
+	}
+	return d
+}
+
+// fail 记录配置项解析错误，错误信息中附带对应的环境变量名
+func (r *appConfigReader) fail(key string, err error) {
+	for _, k := range appConfigKeys {
+		if k.key == key {
+			err = fmt.Errorf("%s (%s): %w", key, k.env, err)
+			break
+		}
+	}
+	r.errs = append(r.errs, err)
+}
+
+// Apply 将日志配置项应用到 base 上，返回可直接传给 logger.Init 的配置
+func (c LoggerConfig) Apply(base logger.Config) logger.Config {
+	if level, err := c.level(); err == nil {
+		base.Level = level
+	}
+	if format, err := logger.ParseFormat(c.Format); err == nil {
+		base.Format = format
+	}
+	base.FilePath = c.FilePath
+	base.MaxSizeMB = c.MaxSizeMB
+	base.MaxBackups = c.MaxBackups
+	base.MaxAgeDays = c.MaxAgeDays
+	return base
+}
+
+// level 解析日志级别
+func (c LoggerConfig) level() (logger.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", c.Level)
+	}
+	return level, nil
+}
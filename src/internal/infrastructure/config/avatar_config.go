@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultAvatarStorageDir 默认头像存储目录
+	DefaultAvatarStorageDir = "uploads/avatars"
+
+	// DefaultAvatarBaseURL 默认头像访问 URL 前缀
+	DefaultAvatarBaseURL = "http://localhost:8080/uploads/avatars"
+
+	// DefaultAvatarMaxBytes 默认头像文件大小上限（512KB）
+	DefaultAvatarMaxBytes int64 = 512 << 10
+)
+
+// AvatarConfig 头像上传配置
+type AvatarConfig struct {
+	// StorageDir 本地存储目录
+	StorageDir string
+
+	// BaseURL 头像对外访问的 URL 前缀，需为 http/https 绝对地址
+	BaseURL string
+
+	// MaxBytes 头像文件大小上限（字节），需小于 MAX_BODY_BYTES
+	MaxBytes int64
+}
+
+var (
+	avatarConfig     *AvatarConfig
+	avatarConfigErr  error
+	avatarConfigOnce sync.Once
+)
+
+// LoadAvatarConfig 加载头像上传配置
+//
+// 从环境变量 AVATAR_STORAGE_DIR、AVATAR_BASE_URL、AVATAR_MAX_BYTES 读取。
+func LoadAvatarConfig() (*AvatarConfig, error) {
+	cfg := &AvatarConfig{
+		StorageDir: getEnvOrDefault("AVATAR_STORAGE_DIR", DefaultAvatarStorageDir),
+		BaseURL:    getEnvOrDefault("AVATAR_BASE_URL", DefaultAvatarBaseURL),
+		MaxBytes:   int64(getEnvIntOrDefault("AVATAR_MAX_BYTES", int(DefaultAvatarMaxBytes))),
+	}
+
+	if err := validateAvatarConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid avatar config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetAvatarConfig 获取头像上传配置（单例模式）
+func GetAvatarConfig() (*AvatarConfig, error) {
+	avatarConfigOnce.Do(func() {
+		avatarConfig, avatarConfigErr = LoadAvatarConfig()
+	})
+	return avatarConfig, avatarConfigErr
+}
+
+// validateAvatarConfig 验证配置有效性
+func validateAvatarConfig(cfg *AvatarConfig) error {
+	if cfg.StorageDir == "" {
+		return fmt.Errorf("storage dir cannot be empty")
+	}
+	if cfg.BaseURL == "" {
+		return fmt.Errorf("base url cannot be empty")
+	}
+	if cfg.MaxBytes <= 0 {
+		return fmt.Errorf("max bytes must be positive (current: %d)", cfg.MaxBytes)
+	}
+	return nil
+}
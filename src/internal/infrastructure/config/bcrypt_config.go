@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultBcryptCost bcrypt 默认计算成本
+	DefaultBcryptCost = 10
+
+	// MinBcryptCost bcrypt 允许的最小计算成本
+	MinBcryptCost = 4
+
+	// MaxBcryptCost bcrypt 允许的最大计算成本
+	MaxBcryptCost = 31
+)
+
+// BcryptConfig 密码哈希配置
+type BcryptConfig struct {
+	// Cost bcrypt 计算成本，数值越大哈希越慢越安全
+	Cost int
+}
+
+var (
+	bcryptConfig     *BcryptConfig
+	bcryptConfigErr  error
+	bcryptConfigOnce sync.Once
+)
+
+// LoadBcryptConfig 加载密码哈希配置
+//
+// 从环境变量 BCRYPT_COST 读取计算成本，未配置时使用默认值 10。
+func LoadBcryptConfig() (*BcryptConfig, error) {
+	cfg := &BcryptConfig{
+		Cost: getEnvIntOrDefault("BCRYPT_COST", DefaultBcryptCost),
+	}
+
+	if err := validateBcryptConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid bcrypt config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetBcryptConfig 获取密码哈希配置（单例模式）
+func GetBcryptConfig() (*BcryptConfig, error) {
+	bcryptConfigOnce.Do(func() {
+		bcryptConfig, bcryptConfigErr = LoadBcryptConfig()
+	})
+	return bcryptConfig, bcryptConfigErr
+}
+
+// validateBcryptConfig 验证配置有效性
+func validateBcryptConfig(cfg *BcryptConfig) error {
+	if cfg.Cost < MinBcryptCost || cfg.Cost > MaxBcryptCost {
+		return fmt.Errorf("bcrypt cost must be between %d and %d (current: %d)",
+			MinBcryptCost, MaxBcryptCost, cfg.Cost)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxBodyBytes 请求体默认大小上限（1MB）
+const DefaultMaxBodyBytes = 1 << 20
+
+// BodyLimitConfig 请求体大小限制配置
+type BodyLimitConfig struct {
+	// MaxBodyBytes 请求体允许的最大字节数
+	MaxBodyBytes int64
+}
+
+var (
+	bodyLimitConfig     *BodyLimitConfig
+	bodyLimitConfigErr  error
+	bodyLimitConfigOnce sync.Once
+)
+
+// LoadBodyLimitConfig 加载请求体大小限制配置
+//
+// 从环境变量 MAX_BODY_BYTES 读取上限，未配置时使用默认值 1MB。
+func LoadBodyLimitConfig() (*BodyLimitConfig, error) {
+	cfg := &BodyLimitConfig{
+		MaxBodyBytes: int64(getEnvIntOrDefault("MAX_BODY_BYTES", DefaultMaxBodyBytes)),
+	}
+
+	if err := validateBodyLimitConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid body limit config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetBodyLimitConfig 获取请求体大小限制配置（单例模式）
+func GetBodyLimitConfig() (*BodyLimitConfig, error) {
+	bodyLimitConfigOnce.Do(func() {
+		bodyLimitConfig, bodyLimitConfigErr = LoadBodyLimitConfig()
+	})
+	return bodyLimitConfig, bodyLimitConfigErr
+}
+
+// validateBodyLimitConfig 验证配置有效性
+func validateBodyLimitConfig(cfg *BodyLimitConfig) error {
+	if cfg.MaxBodyBytes <= 0 {
+		return fmt.Errorf("max body bytes must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// CaptchaProviderNone 不启用注册人机验证（默认）
+	CaptchaProviderNone = "none"
+
+	// CaptchaProviderHCaptcha hCaptcha
+	CaptchaProviderHCaptcha = "hcaptcha"
+
+	// CaptchaProviderTurnstile Cloudflare Turnstile
+	CaptchaProviderTurnstile = "turnstile"
+
+	// HCaptchaVerifyURL hCaptcha 服务端校验地址
+	HCaptchaVerifyURL = "https://api.hcaptcha.com/siteverify"
+
+	// TurnstileVerifyURL Turnstile 服务端校验地址
+	TurnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+
+	// DefaultCaptchaTimeout 默认校验请求超时时间
+	DefaultCaptchaTimeout = 5 * time.Second
+)
+
+// CaptchaConfig 注册人机验证配置
+type CaptchaConfig struct {
+	// Provider 验证服务提供方：none、hcaptcha、turnstile
+	Provider string
+
+	// Secret 服务端密钥，启用时必填
+	Secret string
+
+	// VerifyURL 服务端校验地址，未配置时按 Provider 取官方地址
+	VerifyURL string
+
+	// Timeout 校验请求超时时间
+	Timeout time.Duration
+}
+
+var (
+	captchaConfig     *CaptchaConfig
+	captchaConfigErr  error
+	captchaConfigOnce sync.Once
+)
+
+// LoadCaptchaConfig 加载注册人机验证配置
+//
+// 从环境变量 CAPTCHA_PROVIDER、CAPTCHA_SECRET、CAPTCHA_VERIFY_URL、CAPTCHA_TIMEOUT 读取，
+// 未配置 CAPTCHA_PROVIDER 时不启用。
+func LoadCaptchaConfig() (*CaptchaConfig, error) {
+	cfg := &CaptchaConfig{
+		Provider:  getEnvOrDefault("CAPTCHA_PROVIDER", CaptchaProviderNone),
+		Secret:    getEnvOrDefault("CAPTCHA_SECRET", ""),
+		VerifyURL: getEnvOrDefault("CAPTCHA_VERIFY_URL", ""),
+		Timeout:   getEnvDurationOrDefault("CAPTCHA_TIMEOUT", DefaultCaptchaTimeout),
+	}
+
+	switch cfg.Provider {
+	case CaptchaProviderNone:
+		return cfg, nil
+	case CaptchaProviderHCaptcha:
+		if cfg.VerifyURL == "" {
+			cfg.VerifyURL = HCaptchaVerifyURL
+		}
+	case CaptchaProviderTurnstile:
+		if cfg.VerifyURL == "" {
+			cfg.VerifyURL = TurnstileVerifyURL
+		}
+	default:
+		return nil, fmt.Errorf("invalid captcha config: provider must be one of none, hcaptcha, turnstile (current: %q)", cfg.Provider)
+	}
+
+	if err := validateCaptchaConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid captcha config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Enabled 是否启用注册人机验证
+func (c *CaptchaConfig) Enabled() bool {
+	return c.Provider != CaptchaProviderNone
+}
+
+// GetCaptchaConfig 获取注册人机验证配置（单例模式）
+func GetCaptchaConfig() (*CaptchaConfig, error) {
+	captchaConfigOnce.Do(func() {
+		captchaConfig, captchaConfigErr = LoadCaptchaConfig()
+	})
+	return captchaConfig, captchaConfigErr
+}
+
+// validateCaptchaConfig 验证已启用的配置
+func validateCaptchaConfig(cfg *CaptchaConfig) error {
+	if cfg.Secret == "" {
+		return fmt.Errorf("secret cannot be empty when provider is %s", cfg.Provider)
+	}
+	u, err := url.Parse(cfg.VerifyURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("verify url must be an absolute http/https url (current: %q)", cfg.VerifyURL)
+	}
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive (current: %s)", cfg.Timeout)
+	}
+	return nil
+}
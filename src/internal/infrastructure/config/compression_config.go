@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultGzipMinBytes 启用压缩的响应体默认最小字节数（1KB）
+const DefaultGzipMinBytes = 1024
+
+// CompressionConfig 响应压缩配置
+type CompressionConfig struct {
+	// Enabled 是否启用 gzip 响应压缩
+	Enabled bool
+
+	// MinBytes 响应体达到该字节数才压缩，过小的响应压缩收益不足以抵消开销
+	MinBytes int
+}
+
+var (
+	compressionConfig     *CompressionConfig
+	compressionConfigErr  error
+	compressionConfigOnce sync.Once
+)
+
+// LoadCompressionConfig 加载响应压缩配置
+//
+// 从环境变量 GZIP_ENABLED、GZIP_MIN_BYTES 读取，未配置时启用压缩，阈值 1KB。
+func LoadCompressionConfig() (*CompressionConfig, error) {
+	enabled, err := getEnvBool("GZIP_ENABLED", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compression config: %w", err)
+	}
+
+	cfg := &CompressionConfig{
+		Enabled:  enabled,
+		MinBytes: getEnvIntOrDefault("GZIP_MIN_BYTES", DefaultGzipMinBytes),
+	}
+
+	if cfg.MinBytes < 0 {
+		return nil, fmt.Errorf("invalid compression config: min bytes must not be negative (current: %d)", cfg.MinBytes)
+	}
+
+	return cfg, nil
+}
+
+// GetCompressionConfig 获取响应压缩配置（单例模式）
+func GetCompressionConfig() (*CompressionConfig, error) {
+	compressionConfigOnce.Do(func() {
+		compressionConfig, compressionConfigErr = LoadCompressionConfig()
+	})
+	return compressionConfig, compressionConfigErr
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+)
+
+const (
+	// DatabaseDriverMySQL 使用 MySQL（默认）
+	DatabaseDriverMySQL = "mysql"
+
+	// DatabaseDriverSQLite 使用 SQLite，用于本地开发，无需运行 MySQL 实例
+	DatabaseDriverSQLite = "sqlite"
+)
+
+// DatabaseConfig 数据库类型配置
+type DatabaseConfig struct {
+	// Driver 数据库类型，DatabaseDriverMySQL 或 DatabaseDriverSQLite
+	Driver string
+
+	// SQLitePath SQLite 数据库文件路径，仅 Driver 为 sqlite 时生效
+	SQLitePath string
+}
+
+// LoadDatabaseConfig 加载数据库类型配置
+//
+// 从环境变量 DB_DRIVER（默认 mysql）、SQLITE_PATH（默认 todolist.db）读取。
+// 使用 MySQL 时连接参数见 MySQLConfig。
+func LoadDatabaseConfig() (*DatabaseConfig, error) {
+	cfg := &DatabaseConfig{
+		Driver:     getEnvOrDefault("DB_DRIVER", DatabaseDriverMySQL),
+		SQLitePath: getEnvOrDefault("SQLITE_PATH", "todolist.db"),
+	}
+
+	if cfg.Driver != DatabaseDriverMySQL && cfg.Driver != DatabaseDriverSQLite {
+		return nil, fmt.Errorf("invalid database config: unsupported driver %q (supported: %s, %s)",
+			cfg.Driver, DatabaseDriverMySQL, DatabaseDriverSQLite)
+	}
+
+	return cfg, nil
+}
@@ -3,6 +3,18 @@ package config
 import (
 	"fmt"
 	"sync"
+	"time"
+)
+
+const (
+	// DefaultMySQLQueryTimeout 默认单条 SQL 执行超时时间
+	DefaultMySQLQueryTimeout = 5 * time.Second
+
+	// DefaultMySQLConnMaxLifetime 默认连接最大存活时间
+	DefaultMySQLConnMaxLifetime = time.Hour
+
+	// DefaultMySQLConnMaxIdleTime 默认连接最大空闲时间
+	DefaultMySQLConnMaxIdleTime = 10 * time.Minute
 )
 
 // MySQLConfig MySQL 数据库配置
@@ -14,6 +26,30 @@ type MySQLConfig struct {
 	Password     string
 	MaxOpenConns int
 	MaxIdleConns int
+
+	// QueryTimeout 单条 SQL 执行超时时间，请求上下文已有截止时间时不生效，0 表示不限制
+	QueryTimeout time.Duration
+
+	// ConnMaxLifetime 连接最大存活时间，0 表示不限制
+	ConnMaxLifetime time.Duration
+
+	// ConnMaxIdleTime 连接最大空闲时间，0 表示不限制
+	ConnMaxIdleTime time.Duration
+
+	// SlowQueryThreshold 慢查询阈值，超过时记录警告日志，0 表示不记录
+	SlowQueryThreshold time.Duration
+
+	// ReplicaHost 只读副本主机，为空时不启用读写分离，读操作也使用主库
+	ReplicaHost string
+
+	// ReplicaPort 只读副本端口，为 0 时与主库相同
+	ReplicaPort int
+
+	// ReplicaUser 只读副本用户，为空时与主库相同
+	ReplicaUser string
+
+	// ReplicaPassword 只读副本密码，ReplicaUser 为空时使用主库密码
+	ReplicaPassword string
 }
 
 var (
@@ -22,17 +58,14 @@ var (
 )
 
 // LoadMySQLConfig 加载 MySQL 配置
+//
+// 委托 LoadAppConfig 读取，支持配置文件与环境变量覆盖。
 func LoadMySQLConfig() (*MySQLConfig, error) {
-	var cfg MySQLConfig
-
-	// 从环境变量读取配置
-	cfg.Host = getEnvOrDefault("MYSQL_HOST", "localhost")
-	cfg.Port = getEnvIntOrDefault("MYSQL_PORT", 3307)
-	cfg.DB = getEnvOrDefault("MYSQL_DB", "test")
-	cfg.User = getEnvOrDefault("MYSQL_USER", "root")
-	cfg.Password = getEnvOrDefault("MYSQL_PASSWORD", "123456")
-	cfg.MaxOpenConns = getEnvIntOrDefault("MYSQL_MAX_OPEN_CONNS", 100)
-	cfg.MaxIdleConns = getEnvIntOrDefault("MYSQL_MAX_IDLE_CONNS", 10)
+	appCfg, err := LoadAppConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg := appCfg.MySQL
 
 	// 验证配置
 	if err := validateMySQLConfig(&cfg); err != nil {
@@ -72,6 +105,21 @@ func validateMySQLConfig(cfg *MySQLConfig) error {
 	if cfg.MaxIdleConns < 0 {
 		return fmt.Errorf("maxIdleConns cannot be negative")
 	}
+	if cfg.QueryTimeout < 0 {
+		return fmt.Errorf("queryTimeout cannot be negative")
+	}
+	if cfg.ConnMaxLifetime < 0 {
+		return fmt.Errorf("connMaxLifetime cannot be negative")
+	}
+	if cfg.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("connMaxIdleTime cannot be negative")
+	}
+	if cfg.SlowQueryThreshold < 0 {
+		return fmt.Errorf("slowQueryThreshold cannot be negative")
+	}
+	if cfg.ReplicaPort < 0 || cfg.ReplicaPort > 65535 {
+		return fmt.Errorf("mysql replica port must be between 1 and 65535")
+	}
 	return nil
 }
 
@@ -86,6 +134,27 @@ func (c *MySQLConfig) DSN() string {
 	)
 }
 
+// HasReplica 是否配置了只读副本
+func (c *MySQLConfig) HasReplica() bool {
+	return c.ReplicaHost != ""
+}
+
+// ReplicaDSN 生成只读副本的数据源名称，端口、用户和密码未配置时与主库相同
+//
+// 副本与主库使用同一数据库名称。
+func (c *MySQLConfig) ReplicaDSN() string {
+	replica := *c
+	replica.Host = c.ReplicaHost
+	if c.ReplicaPort != 0 {
+		replica.Port = c.ReplicaPort
+	}
+	if c.ReplicaUser != "" {
+		replica.User = c.ReplicaUser
+		replica.Password = c.ReplicaPassword
+	}
+	return replica.DSN()
+}
+
 // String 返回配置的字符串表示（隐藏密码）
 func (c *MySQLConfig) String() string {
 	return fmt.Sprintf("MySQLConfig{Host: %s, Port: %d, User: %s, DB: %s}",
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EmailVerificationConfig 邮箱验证配置
+type EmailVerificationConfig struct {
+	// Required 是否要求邮箱验证后才能登录
+	Required bool
+
+	// TokenExpiration 验证 Token 有效期
+	TokenExpiration time.Duration
+}
+
+var (
+	emailVerificationConfig     *EmailVerificationConfig
+	emailVerificationConfigErr  error
+	emailVerificationConfigOnce sync.Once
+)
+
+// LoadEmailVerificationConfig 加载邮箱验证配置
+func LoadEmailVerificationConfig() (*EmailVerificationConfig, error) {
+	required, err := getEnvBool("REQUIRE_EMAIL_VERIFICATION", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email verification config: %w", err)
+	}
+
+	cfg := &EmailVerificationConfig{
+		Required:        required,
+		TokenExpiration: getEnvDurationOrDefault("EMAIL_VERIFICATION_TOKEN_EXPIRATION", 24*time.Hour),
+	}
+
+	if cfg.TokenExpiration < MinJWTExpiration || cfg.TokenExpiration > MaxJWTExpiration {
+		return nil, fmt.Errorf("invalid email verification config: token expiration must be between %s and %s",
+			MinJWTExpiration, MaxJWTExpiration)
+	}
+
+	return cfg, nil
+}
+
+// GetEmailVerificationConfig 获取邮箱验证配置（单例模式）
+func GetEmailVerificationConfig() (*EmailVerificationConfig, error) {
+	emailVerificationConfigOnce.Do(func() {
+		emailVerificationConfig, emailVerificationConfigErr = LoadEmailVerificationConfig()
+	})
+	return emailVerificationConfig, emailVerificationConfigErr
+}
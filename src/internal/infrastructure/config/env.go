@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"time"
@@ -61,3 +62,29 @@ func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Durati
 	}
 	return defaultValue
 }
+
+// getEnvFloat 获取环境变量并转换为float64，不存在时返回默认值，格式错误时返回错误
+func getEnvFloat(key string, defaultValue float64) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	floatVal, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number (current: %q)", key, value)
+	}
+	return floatVal, nil
+}
+
+// getEnvBool 获取环境变量并转换为bool，不存在时返回默认值，格式错误时返回错误
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	boolVal, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean (current: %q)", key, value)
+	}
+	return boolVal, nil
+}
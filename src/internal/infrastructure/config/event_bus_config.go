@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultEventBusBufferSize 事件队列默认容量
+	DefaultEventBusBufferSize = 1024
+
+	// DefaultEventBusWorkers 默认事件分发 goroutine 数
+	DefaultEventBusWorkers = 1
+)
+
+// EventBusConfig 进程内事件总线配置
+type EventBusConfig struct {
+	// BufferSize 事件队列容量，队列满时丢弃新事件
+	BufferSize int
+
+	// Workers 事件分发 goroutine 数，大于 1 时不保证事件顺序
+	Workers int
+}
+
+var (
+	eventBusConfig     *EventBusConfig
+	eventBusConfigErr  error
+	eventBusConfigOnce sync.Once
+)
+
+// LoadEventBusConfig 加载事件总线配置
+//
+// 从环境变量 EVENT_BUS_BUFFER_SIZE、EVENT_BUS_WORKERS 读取，未配置时使用默认值。
+func LoadEventBusConfig() (*EventBusConfig, error) {
+	cfg := &EventBusConfig{
+		BufferSize: getEnvIntOrDefault("EVENT_BUS_BUFFER_SIZE", DefaultEventBusBufferSize),
+		Workers:    getEnvIntOrDefault("EVENT_BUS_WORKERS", DefaultEventBusWorkers),
+	}
+
+	if cfg.BufferSize <= 0 {
+		return nil, fmt.Errorf("invalid event bus config: buffer size must be positive (current: %d)", cfg.BufferSize)
+	}
+	if cfg.Workers <= 0 {
+		return nil, fmt.Errorf("invalid event bus config: workers must be positive (current: %d)", cfg.Workers)
+	}
+
+	return cfg, nil
+}
+
+// GetEventBusConfig 获取事件总线配置（单例模式）
+func GetEventBusConfig() (*EventBusConfig, error) {
+	eventBusConfigOnce.Do(func() {
+		eventBusConfig, eventBusConfigErr = LoadEventBusConfig()
+	})
+	return eventBusConfig, eventBusConfigErr
+}
@@ -17,6 +17,18 @@ const (
 
 	// MaxJWTExpiration JWT Token 最大过期时间（30天）
 	MaxJWTExpiration = time.Hour * 24 * 30
+
+	// DefaultJWTRefreshExpiration 刷新 Token 默认过期时间（7天）
+	DefaultJWTRefreshExpiration = time.Hour * 24 * 7
+
+	// MaxJWTRefreshExpiration 刷新 Token 最大过期时间（90天）
+	MaxJWTRefreshExpiration = time.Hour * 24 * 90
+
+	// DefaultJWTIssuer Token 默认签发者（iss）
+	DefaultJWTIssuer = "todolist"
+
+	// DefaultJWTAudience Token 默认受众（aud）
+	DefaultJWTAudience = "todolist-api"
 )
 
 // JWTConfig JWT 配置接口。
@@ -27,8 +39,20 @@ type JWTConfig interface {
 	// 密钥长度应至少为 32 字符以保证安全性。
 	GetSecretKey() string
 
-	// GetExpireDuration 获取 Token 过期时间。
+	// GetExpireDuration 获取访问 Token 过期时间。
 	GetExpireDuration() time.Duration
+
+	// GetRefreshExpireDuration 获取刷新 Token 过期时间。
+	// 刷新 Token 有效期应长于访问 Token。
+	GetRefreshExpireDuration() time.Duration
+
+	// GetIssuer 获取 Token 签发者（iss）。
+	// 解析 Token 时拒绝签发者不一致的 Token。
+	GetIssuer() string
+
+	// GetAudience 获取 Token 受众（aud）。
+	// 解析 Token 时拒绝受众中不包含该值的 Token。
+	GetAudience() string
 }
 
 // jwtConfig JWT 配置的具体实现。
@@ -36,8 +60,17 @@ type jwtConfig struct {
 	// secretKey JWT 签名密钥，从环境变量读取
 	secretKey string
 
-	// expireDuration Token 有效期，默认 24 小时
+	// expireDuration 访问 Token 有效期，默认 24 小时
 	expireDuration time.Duration
+
+	// refreshExpireDuration 刷新 Token 有效期，默认 7 天
+	refreshExpireDuration time.Duration
+
+	// issuer Token 签发者，默认 todolist
+	issuer string
+
+	// audience Token 受众，默认 todolist-api
+	audience string
 }
 
 var (
@@ -46,40 +79,64 @@ var (
 	jwtConfigErr      error
 )
 
-// GetJWTConfig 获取 JWT 配置单例。
+// LoadJWTConfig 加载 JWT 配置单例并返回加载错误。
 //
 // 使用 sync.Once 确保线程安全，首次调用时初始化配置。
-// 如果配置未正确加载，将使用默认值并记录警告日志。
+// 启动时应先调用此函数检查配置，失败时输出原因并退出，
+// 之后各处通过 GetJWTConfig 获取同一实例。
 //
 // 返回：
 //
 //	JWTConfig - JWT 配置接口实例
 //	error - 配置加载或验证失败时的错误
-func GetJWTConfig() JWTConfig {
+func LoadJWTConfig() (JWTConfig, error) {
 	jwtConfigOnce.Do(func() {
 		jwtConfigInstance, jwtConfigErr = loadJWTConfig()
 	})
 	if jwtConfigErr != nil {
-		panic(fmt.Sprintf("JWT配置获取失败: %s", jwtConfigErr.Error()))
+		return nil, fmt.Errorf("JWT配置获取失败: %w", jwtConfigErr)
+	}
+
+	return jwtConfigInstance, nil
+}
+
+// GetJWTConfig 获取 JWT 配置单例。
+//
+// 配置加载失败时 panic，启动流程已通过 LoadJWTConfig 提前检查，运行期不会触发。
+//
+// 返回：
+//
+//	JWTConfig - JWT 配置接口实例
+func GetJWTConfig() JWTConfig {
+	cfg, err := LoadJWTConfig()
+	if err != nil {
+		panic(err.Error())
 	}
 
-	return jwtConfigInstance
+	return cfg
 }
 
 // loadJWTConfig 加载并验证 JWT 配置。
 //
-// 从环境变量加载配置，设置默认值，并进行验证。
+// 通过 LoadAppConfig 从配置文件和环境变量加载配置，设置默认值，并进行验证。
 //
 // 返回：
 //
 //	JWTConfig - 加载后的配置实例
 //	error - 配置验证失败时的错误
 func loadJWTConfig() (JWTConfig, error) {
-	cfg := &jwtConfig{}
+	appCfg, err := LoadAppConfig()
+	if err != nil {
+		return nil, err
+	}
 
-	// 从环境变量加载配置
-	cfg.secretKey = getEnvOrDefault("JWT_SECRET_KEY", "")
-	cfg.expireDuration = getEnvDurationOrDefault("JWT_EXPIRE_DURATION", 0)
+	cfg := &jwtConfig{
+		secretKey:             appCfg.JWT.SecretKey,
+		expireDuration:        appCfg.JWT.ExpireDuration,
+		refreshExpireDuration: appCfg.JWT.RefreshExpireDuration,
+		issuer:                appCfg.JWT.Issuer,
+		audience:              appCfg.JWT.Audience,
+	}
 
 	// 设置未配置的字段默认值
 	setJWTDefaults(cfg)
@@ -92,7 +149,10 @@ func loadJWTConfig() (JWTConfig, error) {
 	}
 
 	logger.Info("JWT 配置加载完成",
-		logger.Duration("expire_duration", cfg.GetExpireDuration()))
+		logger.Duration("expire_duration", cfg.GetExpireDuration()),
+		logger.Duration("refresh_expire_duration", cfg.GetRefreshExpireDuration()),
+		logger.String("issuer", cfg.GetIssuer()),
+		logger.String("audience", cfg.GetAudience()))
 
 	return cfg, nil
 }
@@ -108,6 +168,15 @@ func setJWTDefaults(cfg *jwtConfig) {
 	if cfg.expireDuration == 0 {
 		cfg.expireDuration = time.Hour * 24
 	}
+	if cfg.refreshExpireDuration == 0 {
+		cfg.refreshExpireDuration = DefaultJWTRefreshExpiration
+	}
+	if cfg.issuer == "" {
+		cfg.issuer = DefaultJWTIssuer
+	}
+	if cfg.audience == "" {
+		cfg.audience = DefaultJWTAudience
+	}
 }
 
 // validateJWTConfig 验证 JWT 配置的有效性。
@@ -131,6 +200,12 @@ func validateJWTConfig(cfg *jwtConfig) error {
 	if cfg.expireDuration > MaxJWTExpiration {
 		return fmt.Errorf("jwt expire_duration cannot exceed %s", MaxJWTExpiration)
 	}
+	if cfg.refreshExpireDuration <= cfg.expireDuration {
+		return fmt.Errorf("jwt refresh_expire_duration must be longer than expire_duration")
+	}
+	if cfg.refreshExpireDuration > MaxJWTRefreshExpiration {
+		return fmt.Errorf("jwt refresh_expire_duration cannot exceed %s", MaxJWTRefreshExpiration)
+	}
 	return nil
 }
 
@@ -139,7 +214,22 @@ func (c *jwtConfig) GetSecretKey() string {
 	return c.secretKey
 }
 
-// GetExpireDuration 返回 Token 过期时间。
+// GetExpireDuration 返回访问 Token 过期时间。
 func (c *jwtConfig) GetExpireDuration() time.Duration {
 	return c.expireDuration
 }
+
+// GetRefreshExpireDuration 返回刷新 Token 过期时间。
+func (c *jwtConfig) GetRefreshExpireDuration() time.Duration {
+	return c.refreshExpireDuration
+}
+
+// GetIssuer 返回 Token 签发者。
+func (c *jwtConfig) GetIssuer() string {
+	return c.issuer
+}
+
+// GetAudience 返回 Token 受众。
+func (c *jwtConfig) GetAudience() string {
+	return c.audience
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLoginLockoutMaxAttempts 默认触发锁定的登录失败次数
+	DefaultLoginLockoutMaxAttempts = 5
+
+	// DefaultLoginLockoutWindow 默认登录失败次数统计窗口
+	DefaultLoginLockoutWindow = 15 * time.Minute
+
+	// DefaultLoginLockoutDuration 默认账户锁定时长
+	DefaultLoginLockoutDuration = 15 * time.Minute
+)
+
+// LoginLockoutConfig 登录失败锁定配置
+type LoginLockoutConfig struct {
+	// MaxAttempts 统计窗口内触发锁定的失败次数，为 0 时不启用锁定
+	MaxAttempts int
+
+	// Window 失败次数统计窗口
+	Window time.Duration
+
+	// LockDuration 账户锁定时长
+	LockDuration time.Duration
+}
+
+var (
+	loginLockoutConfig     *LoginLockoutConfig
+	loginLockoutConfigErr  error
+	loginLockoutConfigOnce sync.Once
+)
+
+// LoadLoginLockoutConfig 加载登录失败锁定配置
+//
+// 从环境变量 LOGIN_LOCKOUT_MAX_ATTEMPTS、LOGIN_LOCKOUT_WINDOW、
+// LOGIN_LOCKOUT_DURATION 读取，未配置时默认 15 分钟内失败 5 次锁定 15 分钟。
+func LoadLoginLockoutConfig() (*LoginLockoutConfig, error) {
+	cfg := &LoginLockoutConfig{
+		MaxAttempts:  getEnvIntOrDefault("LOGIN_LOCKOUT_MAX_ATTEMPTS", DefaultLoginLockoutMaxAttempts),
+		Window:       getEnvDurationOrDefault("LOGIN_LOCKOUT_WINDOW", DefaultLoginLockoutWindow),
+		LockDuration: getEnvDurationOrDefault("LOGIN_LOCKOUT_DURATION", DefaultLoginLockoutDuration),
+	}
+
+	if err := validateLoginLockoutConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid login lockout config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetLoginLockoutConfig 获取登录失败锁定配置（单例模式）
+func GetLoginLockoutConfig() (*LoginLockoutConfig, error) {
+	loginLockoutConfigOnce.Do(func() {
+		loginLockoutConfig, loginLockoutConfigErr = LoadLoginLockoutConfig()
+	})
+	return loginLockoutConfig, loginLockoutConfigErr
+}
+
+// validateLoginLockoutConfig 验证配置有效性
+func validateLoginLockoutConfig(cfg *LoginLockoutConfig) error {
+	if cfg.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative (current: %d)", cfg.MaxAttempts)
+	}
+	if cfg.MaxAttempts == 0 {
+		return nil
+	}
+	if cfg.Window <= 0 {
+		return fmt.Errorf("window must be positive (current: %s)", cfg.Window)
+	}
+	if cfg.LockDuration <= 0 {
+		return fmt.Errorf("lock duration must be positive (current: %s)", cfg.LockDuration)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultPasswordMinLength 默认密码最小长度
+	DefaultPasswordMinLength = 8
+
+	// DefaultPasswordRequiredClasses 默认至少需要包含的字符类别数
+	DefaultPasswordRequiredClasses = 2
+
+	// maxPasswordLength bcrypt 只处理前 72 字节，最小长度不能超过此值
+	maxPasswordLength = 72
+
+	// passwordCharacterClasses 字符类别总数：大写、小写、数字、特殊字符
+	passwordCharacterClasses = 4
+)
+
+// PasswordPolicyConfig 密码策略配置
+type PasswordPolicyConfig struct {
+	// MinLength 密码最小长度
+	MinLength int
+
+	// RequiredClasses 至少需要包含的字符类别数
+	RequiredClasses int
+
+	// DisallowCommon 是否拒绝常见弱密码
+	DisallowCommon bool
+}
+
+var (
+	passwordPolicyConfig     *PasswordPolicyConfig
+	passwordPolicyConfigErr  error
+	passwordPolicyConfigOnce sync.Once
+)
+
+// LoadPasswordPolicyConfig 加载密码策略配置
+//
+// 从环境变量 PASSWORD_MIN_LENGTH、PASSWORD_REQUIRED_CLASSES、
+// PASSWORD_DISALLOW_COMMON 读取，未配置时与原有规则一致：至少 8 位、两类字符。
+func LoadPasswordPolicyConfig() (*PasswordPolicyConfig, error) {
+	disallowCommon, err := getEnvBool("PASSWORD_DISALLOW_COMMON", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password policy config: %w", err)
+	}
+
+	cfg := &PasswordPolicyConfig{
+		MinLength:       getEnvIntOrDefault("PASSWORD_MIN_LENGTH", DefaultPasswordMinLength),
+		RequiredClasses: getEnvIntOrDefault("PASSWORD_REQUIRED_CLASSES", DefaultPasswordRequiredClasses),
+		DisallowCommon:  disallowCommon,
+	}
+
+	if err := validatePasswordPolicyConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid password policy config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetPasswordPolicyConfig 获取密码策略配置（单例模式）
+func GetPasswordPolicyConfig() (*PasswordPolicyConfig, error) {
+	passwordPolicyConfigOnce.Do(func() {
+		passwordPolicyConfig, passwordPolicyConfigErr = LoadPasswordPolicyConfig()
+	})
+	return passwordPolicyConfig, passwordPolicyConfigErr
+}
+
+// validatePasswordPolicyConfig 验证配置有效性
+func validatePasswordPolicyConfig(cfg *PasswordPolicyConfig) error {
+	if cfg.MinLength < 1 || cfg.MinLength > maxPasswordLength {
+		return fmt.Errorf("min length must be between 1 and %d (current: %d)", maxPasswordLength, cfg.MinLength)
+	}
+	if cfg.RequiredClasses < 0 || cfg.RequiredClasses > passwordCharacterClasses {
+		return fmt.Errorf("required classes must be between 0 and %d (current: %d)", passwordCharacterClasses, cfg.RequiredClasses)
+	}
+	return nil
+}
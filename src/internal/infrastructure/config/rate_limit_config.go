@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimitRPS 默认每秒允许的请求数
+	DefaultRateLimitRPS = 5
+
+	// DefaultRateLimitBurst 默认令牌桶容量
+	DefaultRateLimitBurst = 10
+
+	// DefaultRateLimitIdleTimeout 默认限流器空闲回收时间
+	DefaultRateLimitIdleTimeout = 10 * time.Minute
+)
+
+// RateLimitConfig 限流配置
+type RateLimitConfig struct {
+	// RequestsPerSecond 每秒允许的请求数（令牌补充速率）
+	RequestsPerSecond float64
+
+	// Burst 令牌桶容量，允许的突发请求数
+	Burst int
+
+	// IdleTimeout 限流器空闲超过该时间后被回收
+	IdleTimeout time.Duration
+}
+
+var (
+	rateLimitConfig     *RateLimitConfig
+	rateLimitConfigErr  error
+	rateLimitConfigOnce sync.Once
+)
+
+// LoadRateLimitConfig 加载限流配置
+func LoadRateLimitConfig() (*RateLimitConfig, error) {
+	rps, err := getEnvFloat("RATE_LIMIT_RPS", DefaultRateLimitRPS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	cfg := &RateLimitConfig{
+		RequestsPerSecond: rps,
+		Burst:             getEnvIntOrDefault("RATE_LIMIT_BURST", DefaultRateLimitBurst),
+		IdleTimeout:       getEnvDurationOrDefault("RATE_LIMIT_IDLE_TIMEOUT", DefaultRateLimitIdleTimeout),
+	}
+
+	if err := validateRateLimitConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// GetRateLimitConfig 获取限流配置（单例模式）
+func GetRateLimitConfig() (*RateLimitConfig, error) {
+	rateLimitConfigOnce.Do(func() {
+		rateLimitConfig, rateLimitConfigErr = LoadRateLimitConfig()
+	})
+	return rateLimitConfig, rateLimitConfigErr
+}
+
+// validateRateLimitConfig 验证配置有效性
+func validateRateLimitConfig(cfg *RateLimitConfig) error {
+	if cfg.RequestsPerSecond <= 0 {
+		return fmt.Errorf("rate limit rps must be positive")
+	}
+	if cfg.Burst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1")
+	}
+	if cfg.IdleTimeout <= 0 {
+		return fmt.Errorf("rate limit idle timeout must be positive")
+	}
+	return nil
+}
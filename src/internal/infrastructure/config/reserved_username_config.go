@@ -0,0 +1,39 @@
+package config
+
+import (
+	"strings"
+	"sync"
+)
+
+// ReservedUsernameConfig 保留用户名配置
+type ReservedUsernameConfig struct {
+	// Usernames 普通用户不能注册或改用的用户名，为空时使用内置默认列表
+	Usernames []string
+}
+
+var (
+	reservedUsernameConfig     *ReservedUsernameConfig
+	reservedUsernameConfigOnce sync.Once
+)
+
+// LoadReservedUsernameConfig 加载保留用户名配置
+//
+// 从环境变量 RESERVED_USERNAMES 读取逗号分隔的用户名，配置后替换内置默认列表
+// （admin、root、api、support 等），未配置时使用默认列表。
+func LoadReservedUsernameConfig() *ReservedUsernameConfig {
+	cfg := &ReservedUsernameConfig{}
+	for _, name := range strings.Split(getEnvOrDefault("RESERVED_USERNAMES", ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			cfg.Usernames = append(cfg.Usernames, name)
+		}
+	}
+	return cfg
+}
+
+// GetReservedUsernameConfig 获取保留用户名配置（单例模式）
+func GetReservedUsernameConfig() *ReservedUsernameConfig {
+	reservedUsernameConfigOnce.Do(func() {
+		reservedUsernameConfig = LoadReservedUsernameConfig()
+	})
+	return reservedUsernameConfig
+}
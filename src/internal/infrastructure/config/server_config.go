@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ServerConfig HTTP 服务配置
+type ServerConfig struct {
+	// Addr 监听地址，如 :8080
+	Addr string
+
+	// ReadTimeout 读取整个请求（含请求体）的超时时间
+	ReadTimeout time.Duration
+
+	// ReadHeaderTimeout 读取请求头的超时时间，防止慢速发送请求头占用连接
+	ReadHeaderTimeout time.Duration
+
+	// WriteTimeout 从读完请求头到写完响应的超时时间
+	WriteTimeout time.Duration
+
+	// IdleTimeout keep-alive 连接等待下一个请求的超时时间
+	IdleTimeout time.Duration
+
+	// RequestTimeout 处理单个请求的整体超时时间，超时返回 503
+	RequestTimeout time.Duration
+
+	// LongRequestTimeout 上传、导出等耗时接口的整体超时时间
+	LongRequestTimeout time.Duration
+}
+
+var (
+	serverConfig     *ServerConfig
+	serverConfigErr  error
+	serverConfigOnce sync.Once
+)
+
+// LoadServerConfig 加载 HTTP 服务配置
+//
+// 从环境变量 HTTP_ADDR、HTTP_READ_TIMEOUT、HTTP_READ_HEADER_TIMEOUT、
+// HTTP_WRITE_TIMEOUT、HTTP_IDLE_TIMEOUT、HTTP_REQUEST_TIMEOUT、HTTP_LONG_REQUEST_TIMEOUT 读取。
+// 超时时间必须为正数，net/http 中 0 表示不限制，会重新暴露慢速连接问题。
+// 请求超时必须小于写超时，否则连接先被关闭，客户端收不到 503 响应。
+func LoadServerConfig() (*ServerConfig, error) {
+	cfg := &ServerConfig{
+		Addr:               getEnvOrDefault("HTTP_ADDR", ":8080"),
+		ReadTimeout:        getEnvDurationOrDefault("HTTP_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout:  getEnvDurationOrDefault("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:       getEnvDurationOrDefault("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:        getEnvDurationOrDefault("HTTP_IDLE_TIMEOUT", 60*time.Second),
+		RequestTimeout:     getEnvDurationOrDefault("HTTP_REQUEST_TIMEOUT", 10*time.Second),
+		LongRequestTimeout: getEnvDurationOrDefault("HTTP_LONG_REQUEST_TIMEOUT", 25*time.Second),
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"read timeout", cfg.ReadTimeout},
+		{"read header timeout", cfg.ReadHeaderTimeout},
+		{"write timeout", cfg.WriteTimeout},
+		{"idle timeout", cfg.IdleTimeout},
+		{"request timeout", cfg.RequestTimeout},
+		{"long request timeout", cfg.LongRequestTimeout},
+	}
+	for _, t := range timeouts {
+		if t.value <= 0 {
+			return nil, fmt.Errorf("invalid server config: %s must be positive (current: %s)", t.name, t.value)
+		}
+	}
+
+	if cfg.ReadHeaderTimeout > cfg.ReadTimeout {
+		return nil, fmt.Errorf("invalid server config: read header timeout (%s) must not exceed read timeout (%s)",
+			cfg.ReadHeaderTimeout, cfg.ReadTimeout)
+	}
+
+	if cfg.RequestTimeout >= cfg.WriteTimeout {
+		return nil, fmt.Errorf("invalid server config: request timeout (%s) must be less than write timeout (%s)",
+			cfg.RequestTimeout, cfg.WriteTimeout)
+	}
+	if cfg.LongRequestTimeout >= cfg.WriteTimeout {
+		return nil, fmt.Errorf("invalid server config: long request timeout (%s) must be less than write timeout (%s)",
+			cfg.LongRequestTimeout, cfg.WriteTimeout)
+	}
+
+	return cfg, nil
+}
+
+// GetServerConfig 获取 HTTP 服务配置（单例模式）
+func GetServerConfig() (*ServerConfig, error) {
+	serverConfigOnce.Do(func() {
+		serverConfig, serverConfigErr = LoadServerConfig()
+	})
+	return serverConfig, serverConfigErr
+}
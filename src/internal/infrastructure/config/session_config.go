@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SessionConfig 会话配置
+type SessionConfig struct {
+	// IdleTimeout 会话空闲超时时间，超过该时间没有已认证请求时访问 Token 视为过期。
+	// 与访问 Token 的绝对有效期相互独立，为 0 时不启用。
+	IdleTimeout time.Duration
+}
+
+var (
+	sessionConfig     *SessionConfig
+	sessionConfigErr  error
+	sessionConfigOnce sync.Once
+)
+
+// LoadSessionConfig 加载会话配置
+//
+// 从环境变量 SESSION_IDLE_TIMEOUT 读取，未配置时不启用空闲超时。
+func LoadSessionConfig() (*SessionConfig, error) {
+	cfg := &SessionConfig{
+		IdleTimeout: getEnvDurationOrDefault("SESSION_IDLE_TIMEOUT", 0),
+	}
+
+	if cfg.IdleTimeout < 0 {
+		return nil, fmt.Errorf("invalid session config: idle timeout must not be negative (current: %s)", cfg.IdleTimeout)
+	}
+
+	return cfg, nil
+}
+
+// GetSessionConfig 获取会话配置（单例模式）
+func GetSessionConfig() (*SessionConfig, error) {
+	sessionConfigOnce.Do(func() {
+		sessionConfig, sessionConfigErr = LoadSessionConfig()
+	})
+	return sessionConfig, sessionConfigErr
+}
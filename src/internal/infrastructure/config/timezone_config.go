@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimezoneConfig 服务器时区配置
+type TimezoneConfig struct {
+	// Location 用户未设置时区时确定"今天"使用的默认时区
+	Location *time.Location
+}
+
+var (
+	timezoneConfig     *TimezoneConfig
+	timezoneConfigErr  error
+	timezoneConfigOnce sync.Once
+)
+
+// LoadTimezoneConfig 加载服务器时区配置
+//
+// 从环境变量 SERVER_TIMEZONE 读取 IANA 时区名称（如 Asia/Shanghai），
+// 未配置时使用进程本地时区（time.Local）。
+func LoadTimezoneConfig() (*TimezoneConfig, error) {
+	name := getEnvOrDefault("SERVER_TIMEZONE", "Local")
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone config: unknown SERVER_TIMEZONE %q: %w", name, err)
+	}
+
+	return &TimezoneConfig{Location: loc}, nil
+}
+
+// GetTimezoneConfig 获取服务器时区配置（单例模式）
+func GetTimezoneConfig() (*TimezoneConfig, error) {
+	timezoneConfigOnce.Do(func() {
+		timezoneConfig, timezoneConfigErr = LoadTimezoneConfig()
+	})
+	return timezoneConfig, timezoneConfigErr
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultUsernameChangeCooldown 默认两次修改用户名的最小间隔（30天）
+const DefaultUsernameChangeCooldown = 30 * 24 * time.Hour
+
+// UsernameChangeConfig 修改用户名配置
+type UsernameChangeConfig struct {
+	// Cooldown 两次修改用户名的最小间隔，为 0 时不限制
+	Cooldown time.Duration
+}
+
+var (
+	usernameChangeConfig     *UsernameChangeConfig
+	usernameChangeConfigErr  error
+	usernameChangeConfigOnce sync.Once
+)
+
+// LoadUsernameChangeConfig 加载修改用户名配置
+//
+// 从环境变量 USERNAME_CHANGE_COOLDOWN 读取，未配置时默认 30 天内只能修改一次。
+func LoadUsernameChangeConfig() (*UsernameChangeConfig, error) {
+	cfg := &UsernameChangeConfig{
+		Cooldown: getEnvDurationOrDefault("USERNAME_CHANGE_COOLDOWN", DefaultUsernameChangeCooldown),
+	}
+
+	if cfg.Cooldown < 0 {
+		return nil, fmt.Errorf("invalid username change config: cooldown must not be negative (current: %s)", cfg.Cooldown)
+	}
+
+	return cfg, nil
+}
+
+// GetUsernameChangeConfig 获取修改用户名配置（单例模式）
+func GetUsernameChangeConfig() (*UsernameChangeConfig, error) {
+	usernameChangeConfigOnce.Do(func() {
+		usernameChangeConfig, usernameChangeConfigErr = LoadUsernameChangeConfig()
+	})
+	return usernameChangeConfig, usernameChangeConfigErr
+}
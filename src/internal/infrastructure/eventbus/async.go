@@ -0,0 +1,155 @@
+// Package eventbus 提供进程内的异步事件总线。
+//
+// 应用服务通过 GetBus 获取当前事件总线发布事件，默认为丢弃事件的 event.NopBus；
+// 启动时通过 SetBus 替换为 AsyncBus 并注册订阅者。
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"todolist/internal/domain/event"
+	applogger "todolist/internal/pkg/logger"
+)
+
+// Handler 事件订阅者，返回的错误只记录日志
+type Handler func(ctx context.Context, e event.Event) error
+
+// envelope 队列中的事件及其发布时的上下文
+type envelope struct {
+	ctx   context.Context
+	event event.Event
+}
+
+// AsyncBus 进程内异步事件总线
+//
+// Publish 只把事件放入有界队列，由后台 goroutine 依次分发给所有订阅者；
+// 队列已满或总线已关闭时丢弃事件并记录警告，保证发布方永不阻塞。
+// 订阅者的错误和 panic 会被记录，不影响其他订阅者和后续事件。
+type AsyncBus struct {
+	queue    chan envelope
+	handlers []Handler
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewAsyncBus 创建异步事件总线并启动 workers 个分发 goroutine
+//
+// bufferSize 为队列容量，workers 大于 1 时同一订阅者可能并发执行，事件顺序不再保证。
+func NewAsyncBus(bufferSize, workers int) *AsyncBus {
+	b := &AsyncBus{queue: make(chan envelope, bufferSize)}
+	for i := 0; i < workers; i++ {
+		b.wg.Add(1)
+		go b.run()
+	}
+	return b
+}
+
+// Subscribe 注册订阅者
+//
+// 应在发布事件前（启动时）调用，所有订阅者都会收到每个事件。
+func (b *AsyncBus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish 发布事件，不等待订阅者处理
+//
+// 订阅者使用不随请求结束而取消的上下文，仍可读取请求 ID 等上下文值。
+func (b *AsyncBus) Publish(ctx context.Context, e event.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		applogger.WarnContext(ctx, "事件总线已关闭，丢弃事件",
+			applogger.String("event_type", string(e.Type)),
+			applogger.Int64("user_id", e.UserID))
+		return
+	}
+
+	select {
+	case b.queue <- envelope{ctx: context.WithoutCancel(ctx), event: e}:
+	default:
+		applogger.WarnContext(ctx, "事件队列已满，丢弃事件",
+			applogger.String("event_type", string(e.Type)),
+			applogger.Int64("user_id", e.UserID))
+	}
+}
+
+// Close 停止接收新事件，等待队列中已有的事件分发完成
+func (b *AsyncBus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+// run 从队列中取出事件并分发，队列关闭后退出
+func (b *AsyncBus) run() {
+	defer b.wg.Done()
+	for env := range b.queue {
+		b.mu.RLock()
+		handlers := b.handlers
+		b.mu.RUnlock()
+
+		for _, h := range handlers {
+			if err := dispatch(env.ctx, h, env.event); err != nil {
+				applogger.ErrorContext(env.ctx, "事件订阅者处理失败",
+					applogger.String("event_type", string(env.event.Type)),
+					applogger.Int64("user_id", env.event.UserID),
+					applogger.Err(err))
+			}
+		}
+	}
+}
+
+// dispatch 调用订阅者，将 panic 转换为错误
+func dispatch(ctx context.Context, h Handler, e event.Event) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("event handler panic: %v", p)
+		}
+	}()
+	return h(ctx, e)
+}
+
+// LogHandler 以 Info 级别记录事件的订阅者，用于确认事件已发布
+func LogHandler(ctx context.Context, e event.Event) error {
+	applogger.InfoContext(ctx, "领域事件",
+		applogger.String("event_type", string(e.Type)),
+		applogger.Int64("user_id", e.UserID),
+		applogger.Int64("resource_id", e.ResourceID),
+		applogger.Any("occurred_at", e.OccurredAt))
+	return nil
+}
+
+var (
+	bus   event.EventBus = event.NopBus{}
+	busMu sync.RWMutex
+)
+
+// GetBus 获取当前使用的事件总线，默认为 event.NopBus
+func GetBus() event.EventBus {
+	busMu.RLock()
+	defer busMu.RUnlock()
+	return bus
+}
+
+// SetBus 替换事件总线
+//
+// 应在启动时（处理请求前）调用，测试中可用于注入记录事件的实现。
+func SetBus(b event.EventBus) {
+	busMu.Lock()
+	defer busMu.Unlock()
+	bus = b
+}
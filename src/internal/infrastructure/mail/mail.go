@@ -0,0 +1,57 @@
+// Package mail 提供邮件发送的基础设施接口。
+//
+// 处理器通过 GetSender 获取当前的邮件发送器，默认为不发送邮件的 NopSender；
+// 接入邮件服务后，启动时通过 SetSender 替换。
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Message 待发送的邮件
+type Message struct {
+	// To 收件人邮箱
+	To string
+
+	// Subject 邮件主题
+	Subject string
+
+	// Body 邮件正文，可能包含验证链接等一次性凭证，不应写入日志
+	Body string
+}
+
+// Sender 邮件发送器
+type Sender interface {
+	// Send 发送邮件
+	Send(ctx context.Context, msg Message) error
+}
+
+// NopSender 不发送邮件的 Sender，未接入邮件服务时使用
+type NopSender struct{}
+
+// Send 丢弃邮件
+func (NopSender) Send(context.Context, Message) error {
+	return nil
+}
+
+var (
+	senderMu sync.RWMutex
+	sender   Sender = NopSender{}
+)
+
+// GetSender 获取当前使用的邮件发送器，默认为 NopSender
+func GetSender() Sender {
+	senderMu.RLock()
+	defer senderMu.RUnlock()
+	return sender
+}
+
+// SetSender 替换邮件发送器
+//
+// 应在启动时（处理请求前）调用，测试中可用于注入桩实现。
+func SetSender(s Sender) {
+	senderMu.Lock()
+	defer senderMu.Unlock()
+	sender = s
+}
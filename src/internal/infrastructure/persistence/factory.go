@@ -0,0 +1,106 @@
+// Package persistence 提供仓储的统一创建入口。
+//
+// 处理器通过 GetFactory 获取仓储，不直接依赖具体数据库；
+// 默认使用 MySQL，本地开发和测试可通过 SetFactory 切换为 SQLite 等其他实现。
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"todolist/internal/domain/audit"
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/persistence/mysql"
+)
+
+// Factory 仓储工厂接口
+//
+// 数据库不可用时各方法返回 mysql.ErrDatabaseUnavailable 等错误，由调用方直接返回给客户端。
+type Factory interface {
+	// UserRepository 创建用户仓储
+	UserRepository() (user.Repository, error)
+
+	// DailyNoteRepository 创建每日笔记仓储
+	DailyNoteRepository() (daily_note.DailyNoteRepository, error)
+
+	// AuditLogRepository 创建审计日志仓储
+	AuditLogRepository() (audit.Repository, error)
+}
+
+var (
+	factory   Factory = NewMySQLFactory()
+	factoryMu sync.RWMutex
+)
+
+// GetFactory 获取当前使用的仓储工厂，默认为 MySQL
+func GetFactory() Factory {
+	factoryMu.RLock()
+	defer factoryMu.RUnlock()
+	return factory
+}
+
+// SetFactory 替换仓储工厂
+//
+// 应在启动时（处理请求前）调用，测试中可用于切换到 SQLite。
+func SetFactory(f Factory) {
+	factoryMu.Lock()
+	defer factoryMu.Unlock()
+	factory = f
+}
+
+// clientFactory 基于 mysql.Client 的仓储工厂
+//
+// 仓储实现位于 mysql 包，数据库差异由客户端的 mysql.Dialect 处理。
+type clientFactory struct {
+	// client 获取数据库客户端，每次创建仓储时调用
+	client func() (*mysql.Client, error)
+}
+
+// NewMySQLFactory 创建 MySQL 仓储工厂
+//
+// 每次创建仓储时通过 mysql.GetClient 获取连接，数据库恢复后无需重启服务。
+func NewMySQLFactory() Factory {
+	return clientFactory{client: mysql.GetClient}
+}
+
+// NewClientFactory 创建使用固定数据库客户端的仓储工厂，如 sqlite.Open 返回的客户端
+func NewClientFactory(client *mysql.Client) Factory {
+	return clientFactory{client: func() (*mysql.Client, error) { return client, nil }}
+}
+
+func (f clientFactory) UserRepository() (user.Repository, error) {
+	client, err := f.client()
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewUserRepositoryWithClient(client), nil
+}
+
+func (f clientFactory) DailyNoteRepository() (daily_note.DailyNoteRepository, error) {
+	client, err := f.client()
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewDailyNoteRepositoryWithClient(client), nil
+}
+
+func (f clientFactory) AuditLogRepository() (audit.Repository, error) {
+	client, err := f.client()
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewAuditLogRepositoryWithClient(client), nil
+}
+
+// WithPrimary 返回强制从主库读取的上下文，未配置只读副本时无影响
+//
+// 用于必须读到最新数据的读操作，见 mysql.WithPrimary。
+func WithPrimary(ctx context.Context) context.Context {
+	return mysql.WithPrimary(ctx)
+}
+
+// WithReadYourWrites 返回写入后自动切换到主库读取的上下文，见 mysql.WithReadYourWrites
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return mysql.WithReadYourWrites(ctx)
+}
@@ -6,19 +6,58 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
 
+// ErrUnknownVersion 目标版本不在迁移脚本列表中
+var ErrUnknownVersion = errors.New("unknown migration version")
+
 // Migrator 数据库迁移器
 type Migrator struct {
 	db *sqlx.DB
+
+	// scripts 按版本号升序排列的迁移脚本
+	scripts []Script
+}
+
+// Option 迁移器配置选项
+type Option func(*Migrator)
+
+// WithScripts 替换迁移脚本列表，脚本需按版本号升序排列
+//
+// 默认使用本包内置的迁移脚本，测试中可传入不修改表结构的脚本。
+func WithScripts(scripts []Script) Option {
+	return func(m *Migrator) {
+		m.scripts = scripts
+	}
 }
 
 // NewMigrator 创建迁移器
-func NewMigrator(db *sqlx.DB) *Migrator {
-	return &Migrator{db: db}
+func NewMigrator(db *sqlx.DB, opts ...Option) *Migrator {
+	m := &Migrator{db: db, scripts: migrations}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Script 迁移脚本
+type Script struct {
+	// Version 版本号，按时间戳命名，如 20240117000001
+	Version int64
+
+	// Name 迁移名称
+	Name string
+
+	// Up 执行迁移
+	Up func(db sqlx.Ext) error
+
+	// Down 回滚迁移
+	Down func(db sqlx.Ext) error
 }
 
 // Migration 迁移记录
@@ -29,96 +68,314 @@ type Migration struct {
 }
 
 // migrations 所有迁移脚本
-var migrations = []struct {
-	version int64
-	name    string
-	up      func(db *sqlx.DB) error
-	down    func(db *sqlx.DB) error
-}{
+var migrations = []Script{
+	{
+		Version: 20240117000001,
+		Name:    "create_users_table",
+		Up:      createUsersTable,
+		Down:    dropUsersTable,
+	},
+	{
+		Version: 20240118000001,
+		Name:    "add_users_email_verified",
+		Up:      addUsersEmailVerified,
+		Down:    dropUsersEmailVerified,
+	},
+	{
+		Version: 20240119000001,
+		Name:    "add_daily_notes_version",
+		Up:      addDailyNotesVersion,
+		Down:    dropDailyNotesVersion,
+	},
+	{
+		Version: 20240120000001,
+		Name:    "add_daily_notes_user_date_unique",
+		Up:      addDailyNotesUserDateUnique,
+		Down:    dropDailyNotesUserDateUnique,
+	},
+	{
+		Version: 20240121000001,
+		Name:    "add_users_login_lockout",
+		Up:      addUsersLoginLockout,
+		Down:    dropUsersLoginLockout,
+	},
+	{
+		Version: 20240122000001,
+		Name:    "add_daily_notes_is_pinned",
+		Up:      addDailyNotesIsPinned,
+		Down:    dropDailyNotesIsPinned,
+	},
+	{
+		Version: 20240123000001,
+		Name:    "add_users_username_changed_at",
+		Up:      addUsersUsernameChangedAt,
+		Down:    dropUsersUsernameChangedAt,
+	},
+	{
+		Version: 20240124000001,
+		Name:    "create_audit_logs_table",
+		Up:      createAuditLogsTable,
+		Down:    dropAuditLogsTable,
+	},
+	{
+		Version: 20240125000001,
+		Name:    "add_users_pending_email",
+		Up:      addUsersPendingEmail,
+		Down:    dropUsersPendingEmail,
+	},
+	{
+		Version: 20240126000001,
+		Name:    "create_daily_note_tags_table",
+		Up:      createDailyNoteTagsTable,
+		Down:    dropDailyNoteTagsTable,
+	},
 	{
-		version: 20240117000001,
-		name:    "create_users_table",
-		up:      createUsersTable,
-		down:    dropUsersTable,
+		Version: 20240127000001,
+		Name:    "add_users_username_canonical",
+		Up:      addUsersUsernameCanonical,
+		Down:    dropUsersUsernameCanonical,
+	},
+	{
+		Version: 20240128000001,
+		Name:    "add_users_timezone",
+		Up:      addUsersTimezone,
+		Down:    dropUsersTimezone,
+	},
+	{
+		Version: 20240129000001,
+		Name:    "add_users_role",
+		Up:      addUsersRole,
+		Down:    dropUsersRole,
 	},
 	// 添加新的迁移脚本
 }
 
 // Up 执行所有未执行的迁移
+//
+// 已执行的迁移会被跳过，重复调用不会产生任何变更。
 func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.scripts) == 0 {
+		fmt.Println("No migration to apply")
+		return nil
+	}
+	return m.upTo(ctx, m.scripts[len(m.scripts)-1].Version)
+}
+
+// UpTo 按版本顺序执行未执行的迁移，直到目标版本（含）
+//
+// 目标版本必须存在于迁移脚本列表中，否则返回 ErrUnknownVersion。
+func (m *Migrator) UpTo(ctx context.Context, version int64) error {
+	if !m.hasVersion(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.upTo(ctx, version)
+}
+
+// upTo 执行版本号不大于 target 的未执行迁移
+func (m *Migrator) upTo(ctx context.Context, target int64) error {
 	// 创建迁移记录表
 	if err := m.createMigrationTable(ctx); err != nil {
 		return fmt.Errorf("failed to create migration table: %w", err)
 	}
 
 	// 获取已执行的迁移
-	appliedVersions, err := m.getAppliedVersions(ctx)
+	appliedMigrations, err := m.getAppliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get applied versions: %w", err)
+		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
 	// 执行未执行的迁移
-	for _, migration := range migrations {
-		if _, applied := appliedVersions[migration.version]; applied {
-			fmt.Printf("Migration %d (%s) already applied, skipping\n", migration.version, migration.name)
-			continue
+	for _, script := range m.scripts {
+		if script.Version > target {
+			break
 		}
 
-		fmt.Printf("Applying migration %d (%s)...\n", migration.version, migration.name)
-		if err := migration.up(m.db); err != nil {
-			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.version, migration.name, err)
+		if applied, ok := appliedMigrations[script.Version]; ok {
+			if applied.Name != script.Name {
+				fmt.Printf("Migration %d already applied as %q, now named %q, skipping\n", script.Version, applied.Name, script.Name)
+				continue
+			}
+			fmt.Printf("Migration %d (%s) already applied at %s, skipping\n", script.Version, script.Name, applied.AppliedAt)
+			continue
 		}
 
-		// 记录迁移
-		if err := m.recordMigration(ctx, migration.version, migration.name); err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", migration.version, err)
+		fmt.Printf("Applying migration %d (%s)...\n", script.Version, script.Name)
+		start := time.Now()
+		if err := m.applyMigration(ctx, script); err != nil {
+			return err
 		}
 
-		fmt.Printf("Migration %d (%s) applied successfully\n", migration.version, migration.name)
+		fmt.Printf("Migration %d (%s) applied successfully in %s\n", script.Version, script.Name, time.Since(start).Round(time.Millisecond))
 	}
 
-	fmt.Println("All migrations applied successfully")
+	fmt.Printf("All migrations up to %d applied successfully\n", target)
 	return nil
 }
 
 // Down 回滚最后一个迁移
 func (m *Migrator) Down(ctx context.Context) error {
-	appliedVersions, err := m.getAppliedVersions(ctx)
+	appliedMigrations, err := m.getAppliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get applied versions: %w", err)
+		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
 	// 找到最后一个迁移
-	var lastMigration *struct {
-		version int64
-		name    string
-		up      func(db *sqlx.DB) error
-		down    func(db *sqlx.DB) error
+	for i := len(m.scripts) - 1; i >= 0; i-- {
+		if _, applied := appliedMigrations[m.scripts[i].Version]; applied {
+			return m.rollback(ctx, m.scripts[i])
+		}
 	}
 
-	for i := len(migrations) - 1; i >= 0; i-- {
-		if _, applied := appliedVersions[migrations[i].version]; applied {
-			lastMigration = &migrations[i]
-			break
+	fmt.Println("No migration to rollback")
+	return nil
+}
+
+// DownTo 按版本倒序回滚已执行的迁移，直到目标版本成为最后一个已执行的迁移
+//
+// 目标版本本身不会被回滚；传入 0 时回滚全部迁移。
+// 目标版本非 0 且不存在于迁移脚本列表中时返回 ErrUnknownVersion。
+func (m *Migrator) DownTo(ctx context.Context, version int64) error {
+	if version != 0 && !m.hasVersion(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	if err := m.createMigrationTable(ctx); err != nil {
+		return fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	appliedMigrations, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	rolledBack := 0
+	for i := len(m.scripts) - 1; i >= 0 && m.scripts[i].Version > version; i-- {
+		if _, applied := appliedMigrations[m.scripts[i].Version]; !applied {
+			continue
+		}
+		if err := m.rollback(ctx, m.scripts[i]); err != nil {
+			return err
 		}
+		rolledBack++
 	}
 
-	if lastMigration == nil {
+	if rolledBack == 0 {
 		fmt.Println("No migration to rollback")
 		return nil
 	}
+	fmt.Printf("Rolled back %d migration(s) to %d\n", rolledBack, version)
+	return nil
+}
 
-	fmt.Printf("Rolling back migration %d (%s)...\n", lastMigration.version, lastMigration.name)
-	if err := lastMigration.down(m.db); err != nil {
-		return fmt.Errorf("failed to rollback migration %d (%s): %w", lastMigration.version, lastMigration.name, err)
+// rollback 回滚单个迁移并输出耗时
+func (m *Migrator) rollback(ctx context.Context, script Script) error {
+	fmt.Printf("Rolling back migration %d (%s)...\n", script.Version, script.Name)
+	start := time.Now()
+	if err := m.rollbackMigration(ctx, script); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migration %d (%s) rolled back successfully in %s\n", script.Version, script.Name, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// hasVersion 判断迁移脚本列表中是否存在指定版本
+func (m *Migrator) hasVersion(version int64) bool {
+	for _, script := range m.scripts {
+		if script.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrationStatus 单个迁移脚本的执行状态
+type MigrationStatus struct {
+	// Version 迁移版本号
+	Version int64
+
+	// Name 迁移名称
+	Name string
+
+	// Applied 是否已执行
+	Applied bool
+
+	// AppliedAt 执行时间，未执行时为空
+	AppliedAt string
+}
+
+// Status 按版本顺序列出所有迁移脚本的执行状态
+//
+// 迁移记录表不存在时会先创建，此时所有迁移均为待执行。
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.createMigrationTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	appliedMigrations, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.scripts))
+	for _, script := range m.scripts {
+		applied, ok := appliedMigrations[script.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   script.Version,
+			Name:      script.Name,
+			Applied:   ok,
+			AppliedAt: applied.AppliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// applyMigration 在同一事务中执行迁移脚本并写入迁移记录
+//
+// MySQL 的 DDL 会隐式提交事务，此时只有记录写入受事务保护：
+// DDL 成功而记录写入失败时，下次执行会重新运行该迁移，因此迁移脚本需可重复执行。
+func (m *Migrator) applyMigration(ctx context.Context, script Script) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %d: %w", script.Version, err)
+	}
+	defer tx.Rollback()
+
+	if err := script.Up(tx); err != nil {
+		return fmt.Errorf("failed to apply migration %d (%s): %w", script.Version, script.Name, err)
+	}
+
+	// 记录迁移
+	if err := recordMigration(ctx, tx, script.Version, script.Name); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", script.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", script.Version, err)
+	}
+	return nil
+}
+
+// rollbackMigration 在同一事务中执行回滚脚本并删除迁移记录
+func (m *Migrator) rollbackMigration(ctx context.Context, script Script) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %d: %w", script.Version, err)
+	}
+	defer tx.Rollback()
+
+	if err := script.Down(tx); err != nil {
+		return fmt.Errorf("failed to rollback migration %d (%s): %w", script.Version, script.Name, err)
 	}
 
 	// 删除迁移记录
-	if err := m.deleteMigration(ctx, lastMigration.version); err != nil {
-		return fmt.Errorf("failed to delete migration record %d: %w", lastMigration.version, err)
+	if err := deleteMigration(ctx, tx, script.Version); err != nil {
+		return fmt.Errorf("failed to delete migration record %d: %w", script.Version, err)
 	}
 
-	fmt.Printf("Migration %d (%s) rolled back successfully\n", lastMigration.version, lastMigration.name)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollback of migration %d: %w", script.Version, err)
+	}
 	return nil
 }
 
@@ -135,45 +392,72 @@ func (m *Migrator) createMigrationTable(ctx context.Context) error {
 	return err
 }
 
-// getAppliedVersions 获取已执行的迁移版本
-func (m *Migrator) getAppliedVersions(ctx context.Context) (map[int64]bool, error) {
-	query := `SELECT version, name FROM schema_migrations ORDER BY version`
-	rows, err := m.db.QueryContext(ctx, query)
-	if err != nil {
+// getAppliedMigrations 获取已执行的迁移记录，按版本号索引
+func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int64]Migration, error) {
+	var records []Migration
+	query := `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`
+	if err := m.db.SelectContext(ctx, &records, query); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	versions := make(map[int64]bool)
-	for rows.Next() {
-		var m Migration
-		if err := rows.Scan(&m.Version, &m.Name); err != nil {
-			return nil, err
-		}
-		versions[m.Version] = true
+	applied := make(map[int64]Migration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
 	}
-
-	return versions, rows.Err()
+	return applied, nil
 }
 
 // recordMigration 记录迁移
-func (m *Migrator) recordMigration(ctx context.Context, version int64, name string) error {
-	query := `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`
-	_, err := m.db.ExecContext(ctx, query, version, name)
+//
+// 记录已存在时只更新名称，重复执行不会因主键冲突而失败。
+func recordMigration(ctx context.Context, db sqlx.ExtContext, version int64, name string) error {
+	query := `
+		INSERT INTO schema_migrations (version, name) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name)
+	`
+	_, err := db.ExecContext(ctx, query, version, name)
 	return err
 }
 
 // deleteMigration 删除迁移记录
-func (m *Migrator) deleteMigration(ctx context.Context, version int64) error {
+func deleteMigration(ctx context.Context, db sqlx.ExtContext, version int64) error {
 	query := `DELETE FROM schema_migrations WHERE version = ?`
-	_, err := m.db.ExecContext(ctx, query, version)
+	_, err := db.ExecContext(ctx, query, version)
 	return err
 }
 
 // ==================== 迁移脚本 ====================
 
+// columnExists 判断当前库中的表是否已有指定列
+//
+// 添加列的迁移通过它跳过已完成的变更，迁移被重复执行时不会因列已存在而失败。
+func columnExists(db sqlx.Ext, table, column string) (bool, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?
+	`
+	if err := sqlx.Get(db, &count, query, table, column); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// indexExists 检查当前数据库中表的索引是否存在，用于使添加索引的迁移可重复执行
+func indexExists(db sqlx.Ext, table, index string) (bool, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?
+	`
+	if err := sqlx.Get(db, &count, query, table, index); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // createUsersTable 创建用户表
-func createUsersTable(db *sqlx.DB) error {
+func createUsersTable(db sqlx.Ext) error {
 	query := `
 		CREATE TABLE IF NOT EXISTS users (
 			id BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '用户ID',
@@ -198,7 +482,281 @@ func createUsersTable(db *sqlx.DB) error {
 }
 
 // dropUsersTable 删除用户表
-func dropUsersTable(db *sqlx.DB) error {
+func dropUsersTable(db sqlx.Ext) error {
 	_, err := db.Exec("DROP TABLE IF EXISTS users")
 	return err
 }
+
+// addUsersEmailVerified 为用户表添加邮箱验证标记
+func addUsersEmailVerified(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "email_verified"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN email_verified TINYINT(1) NOT NULL DEFAULT 0 COMMENT '邮箱是否已验证' AFTER status
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersEmailVerified 删除用户表邮箱验证标记
+func dropUsersEmailVerified(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN email_verified")
+	return err
+}
+
+// addDailyNotesVersion 为每日笔记表添加乐观锁版本号
+func addDailyNotesVersion(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "daily_notes", "version"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE daily_notes
+		ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '乐观锁版本号' AFTER content
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropDailyNotesVersion 删除每日笔记表乐观锁版本号
+func dropDailyNotesVersion(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE daily_notes DROP COLUMN version")
+	return err
+}
+
+// addDailyNotesUserDateUnique 为每日笔记表添加 (user_id, note_date) 唯一索引
+//
+// 保证每个用户每天只有一条笔记，避免并发创建产生重复数据。
+// 索引已存在时跳过；表中已有重复数据时迁移会失败，需先手动清理。
+func addDailyNotesUserDateUnique(db sqlx.Ext) error {
+	if exists, err := indexExists(db, "daily_notes", "uk_user_date"); err != nil || exists {
+		return err
+	}
+
+	_, err := db.Exec("ALTER TABLE daily_notes ADD UNIQUE KEY uk_user_date (user_id, note_date)")
+	return err
+}
+
+// dropDailyNotesUserDateUnique 删除每日笔记表 (user_id, note_date) 唯一索引
+func dropDailyNotesUserDateUnique(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE daily_notes DROP INDEX uk_user_date")
+	return err
+}
+
+// addUsersLoginLockout 为用户表添加登录失败计数和锁定时间
+func addUsersLoginLockout(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "failed_login_attempts"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN failed_login_attempts INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '统计窗口内登录失败次数' AFTER email_verified,
+		ADD COLUMN last_failed_login_at DATETIME(3) DEFAULT NULL COMMENT '最近一次登录失败时间' AFTER failed_login_attempts,
+		ADD COLUMN locked_until DATETIME(3) DEFAULT NULL COMMENT '登录锁定截止时间' AFTER last_failed_login_at
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersLoginLockout 删除用户表登录失败计数和锁定时间
+func dropUsersLoginLockout(db sqlx.Ext) error {
+	query := `
+		ALTER TABLE users
+		DROP COLUMN failed_login_attempts,
+		DROP COLUMN last_failed_login_at,
+		DROP COLUMN locked_until
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// addDailyNotesIsPinned 为每日笔记表添加置顶标记
+func addDailyNotesIsPinned(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "daily_notes", "is_pinned"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE daily_notes
+		ADD COLUMN is_pinned TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否置顶' AFTER version
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropDailyNotesIsPinned 删除每日笔记表置顶标记
+func dropDailyNotesIsPinned(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE daily_notes DROP COLUMN is_pinned")
+	return err
+}
+
+// addUsersUsernameChangedAt 为用户表添加最近一次修改用户名的时间
+func addUsersUsernameChangedAt(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "username_changed_at"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN username_changed_at DATETIME(3) DEFAULT NULL COMMENT '最近一次修改用户名的时间' AFTER locked_until
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersUsernameChangedAt 删除用户表最近一次修改用户名的时间
+func dropUsersUsernameChangedAt(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN username_changed_at")
+	return err
+}
+
+// createAuditLogsTable 创建审计日志表
+//
+// 不设置指向用户表的外键，用户被物理删除后审计日志仍然保留。
+func createAuditLogsTable(db sqlx.Ext) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS audit_logs (
+			id BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '日志ID',
+			user_id BIGINT(20) UNSIGNED NOT NULL COMMENT '被操作的用户ID',
+			actor_id BIGINT(20) UNSIGNED NOT NULL DEFAULT 0 COMMENT '操作者用户ID，0 表示未知',
+			action VARCHAR(50) NOT NULL COMMENT '操作类型',
+			metadata JSON DEFAULT NULL COMMENT '附加信息',
+			created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '操作时间',
+			PRIMARY KEY (id),
+			KEY idx_user_created (user_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='审计日志表'
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropAuditLogsTable 删除审计日志表
+func dropAuditLogsTable(db sqlx.Ext) error {
+	_, err := db.Exec("DROP TABLE IF EXISTS audit_logs")
+	return err
+}
+
+// addUsersPendingEmail 为用户表添加待确认的新邮箱
+func addUsersPendingEmail(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "pending_email"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN pending_email VARCHAR(255) NOT NULL DEFAULT '' COMMENT '待确认的新邮箱' AFTER email_verified
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersPendingEmail 删除用户表待确认的新邮箱
+func dropUsersPendingEmail(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN pending_email")
+	return err
+}
+
+// createDailyNoteTagsTable 创建每日笔记标签表
+//
+// 主键 (note_id, tag) 保证同一笔记的标签不重复，idx_tag_note 用于按标签筛选笔记；
+// 笔记删除时标签随外键级联删除。
+func createDailyNoteTagsTable(db sqlx.Ext) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS daily_note_tags (
+			note_id BIGINT(20) UNSIGNED NOT NULL COMMENT '笔记ID',
+			tag VARCHAR(32) NOT NULL COMMENT '标签（小写）',
+			PRIMARY KEY (note_id, tag),
+			KEY idx_tag_note (tag, note_id),
+			CONSTRAINT fk_daily_note_tags_note FOREIGN KEY (note_id) REFERENCES daily_notes (id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='每日笔记标签表'
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropDailyNoteTagsTable 删除每日笔记标签表
+func dropDailyNoteTagsTable(db sqlx.Ext) error {
+	_, err := db.Exec("DROP TABLE IF EXISTS daily_note_tags")
+	return err
+}
+
+// addUsersUsernameCanonical 为用户表添加规范化用户名
+//
+// username_canonical 保存小写形式并使用 utf8mb4_bin 排序规则，用户名唯一性和查找不再依赖表排序规则是否区分大小写。
+// 每一步单独判断，中途失败（如已有仅大小写不同的用户名导致唯一索引创建失败）后处理数据即可重新执行。
+func addUsersUsernameCanonical(db sqlx.Ext) error {
+	exists, err := columnExists(db, "users", "username_canonical")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		query := `
+			ALTER TABLE users
+			ADD COLUMN username_canonical VARCHAR(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '规范化用户名（小写）' AFTER username
+		`
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// 回填已有用户
+	if _, err := db.Exec("UPDATE users SET username_canonical = LOWER(username) WHERE username_canonical = ''"); err != nil {
+		return err
+	}
+
+	if exists, err := indexExists(db, "users", "uk_username_canonical"); err != nil || exists {
+		return err
+	}
+	_, err = db.Exec("ALTER TABLE users ADD UNIQUE KEY uk_username_canonical (username_canonical)")
+	return err
+}
+
+// dropUsersUsernameCanonical 删除用户表规范化用户名（索引随列一起删除）
+func dropUsersUsernameCanonical(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN username_canonical")
+	return err
+}
+
+// addUsersTimezone 添加用户时区，空字符串表示使用服务器默认时区
+func addUsersTimezone(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "timezone"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '' COMMENT '时区（IANA 名称）' AFTER avatar_url
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersTimezone 删除用户时区
+func dropUsersTimezone(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN timezone")
+	return err
+}
+
+// addUsersRole 添加用户角色，已有用户均为普通用户
+func addUsersRole(db sqlx.Ext) error {
+	if exists, err := columnExists(db, "users", "role"); err != nil || exists {
+		return err
+	}
+
+	query := `
+		ALTER TABLE users
+		ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' COMMENT '用户角色: user/admin' AFTER status
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// dropUsersRole 删除用户角色
+func dropUsersRole(db sqlx.Ext) error {
+	_, err := db.Exec("ALTER TABLE users DROP COLUMN role")
+	return err
+}
@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"todolist/internal/domain/audit"
+	"todolist/internal/interfaces/do"
+)
+
+// AuditLogRepository 审计日志仓储实现
+type AuditLogRepository struct {
+	db Executor
+}
+
+// NewAuditLogRepository 创建审计日志仓储实例
+//
+// 数据库不可用时返回 ErrDatabaseUnavailable。
+func NewAuditLogRepository() (*AuditLogRepository, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewAuditLogRepositoryWithClient(client), nil
+}
+
+// NewAuditLogRepositoryWithClient 使用指定的数据库客户端创建审计日志仓储实例
+func NewAuditLogRepositoryWithClient(client *Client) *AuditLogRepository {
+	return &AuditLogRepository{db: client}
+}
+
+// Record 写入一条审计日志，附加信息以 JSON 保存
+func (r *AuditLogRepository) Record(ctx context.Context, entry audit.Entry) error {
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_logs (user_id, actor_id, action, metadata, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	if _, err := r.db.ExecContext(ctx, query, entry.UserID, entry.ActorID, string(entry.Action), metadata, entry.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert audit log: %w", err)
+	}
+	return nil
+}
+
+// ListByUserID 按时间倒序列出目标用户的审计日志
+func (r *AuditLogRepository) ListByUserID(ctx context.Context, userID int64, limit, offset int) ([]audit.Entry, error) {
+	var rows []do.AuditLog
+	query := `
+		SELECT id, user_id, actor_id, action, metadata, created_at
+		FROM audit_logs
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	if err := r.db.SelectContext(ctx, &rows, query, userID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	entries := make([]audit.Entry, len(rows))
+	for i := range rows {
+		entry, err := r.toEntry(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// CountByUserID 统计目标用户的审计日志条数
+func (r *AuditLogRepository) CountByUserID(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM audit_logs WHERE user_id = ?`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+	return count, nil
+}
+
+// toEntry 将数据对象转换为审计日志条目
+func (r *AuditLogRepository) toEntry(row *do.AuditLog) (audit.Entry, error) {
+	var metadata map[string]string
+	if len(row.Metadata) > 0 {
+		if err := json.Unmarshal(row.Metadata, &metadata); err != nil {
+			return audit.Entry{}, fmt.Errorf("failed to decode audit log %d metadata: %w", row.ID, err)
+		}
+	}
+
+	return audit.Entry{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		ActorID:   row.ActorID,
+		Action:    audit.Action(row.Action),
+		Metadata:  metadata,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
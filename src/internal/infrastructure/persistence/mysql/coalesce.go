@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// coalesceRead 合并相同 key 的并发读查询
+//
+// 查询执行期间到达的相同 key 调用不再访问数据库，而是等待并共享第一次查询的结果，
+// 用于 GET /api/v1/users/me 等被客户端高频调用的热点读路径。
+// 上下文要求读主库时（WithPrimary，或 WithReadYourWrites 下已执行过写操作）不合并，
+// 避免加入写入提交前发起的查询而读到旧数据；其余读操作本就可能读到副本的旧数据，共享结果不改变其语义。
+//
+// 共享的查询不受单个调用方取消的影响，但保留第一个调用方的截止时间；
+// 调用方取消时立即返回 ctx.Err()，不影响其他等待者。
+// 返回值由所有等待者共享，调用方不得修改，应据此各自构造实体。
+func coalesceRead[T any](ctx context.Context, group *singleflight.Group, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if group == nil || usePrimary(ctx) {
+		return fn(ctx)
+	}
+
+	ch := group.DoChan(key, func() (interface{}, error) {
+		shared := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			shared, cancel = context.WithDeadline(shared, deadline)
+			defer cancel()
+		}
+		return fn(shared)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			var zero T
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
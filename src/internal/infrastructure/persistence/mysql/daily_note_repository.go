@@ -7,18 +7,44 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"todolist/internal/domain/daily_note"
 	"todolist/internal/interfaces/do"
 )
 
+// Transactor 事务执行接口
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(*Tx) error) error
+
+	// TransactionWithRetry 执行事务，遇到死锁或锁等待超时时重试
+	TransactionWithRetry(ctx context.Context, maxRetries int, fn func(*Tx) error) error
+}
+
+// writeLockRetries 笔记写入事务遇到锁冲突时的最大重试次数
+const writeLockRetries = 3
+
 // DailyNoteRepository 每日笔记仓储实现
 type DailyNoteRepository struct {
-	db Executor
+	db      Executor
+	tx      Transactor
+	dialect Dialect
 }
 
 // NewDailyNoteRepository 创建每日笔记仓储实例
-func NewDailyNoteRepository() *DailyNoteRepository {
-	return &DailyNoteRepository{db: GetClient()}
+//
+// 数据库不可用时返回 ErrDatabaseUnavailable。
+func NewDailyNoteRepository() (*DailyNoteRepository, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewDailyNoteRepositoryWithClient(client), nil
+}
+
+// NewDailyNoteRepositoryWithClient 使用指定的数据库客户端创建每日笔记仓储实例
+func NewDailyNoteRepositoryWithClient(client *Client) *DailyNoteRepository {
+	return &DailyNoteRepository{db: client, tx: client, dialect: client.Dialect()}
 }
 
 // ==================== 查询操作实现 ====================
@@ -27,7 +53,7 @@ func NewDailyNoteRepository() *DailyNoteRepository {
 func (r *DailyNoteRepository) FindByID(ctx context.Context, id int64) (daily_note.DailyNoteEntity, error) {
 	var dn do.DailyNote
 	query := `
-		SELECT id, user_id, note_date, content, created_at, updated_at
+		SELECT id, user_id, note_date, content, created_at, updated_at, version, is_pinned
 		FROM daily_notes
 		WHERE id = ?
 	`
@@ -35,36 +61,52 @@ func (r *DailyNoteRepository) FindByID(ctx context.Context, id int64) (daily_not
 	if err != nil {
 		return nil, r.handleNotFoundError(err, "id", id)
 	}
-	return r.toEntity(&dn), nil
+	return r.withTag(ctx, &dn)
 }
 
 // FindByUserIDAndDate 根据用户ID和日期查找每日笔记
 func (r *DailyNoteRepository) FindByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (daily_note.DailyNoteEntity, error) {
 	var dn do.DailyNote
 	query := `
-		SELECT id, user_id, note_date, content, created_at, updated_at
+		SELECT id, user_id, note_date, content, created_at, updated_at, version, is_pinned
 		FROM daily_notes
-		WHERE user_id = ? AND DATE(note_date) = DATE(?)
+		WHERE user_id = ? AND note_date = ?
 	`
-	err := r.db.GetContext(ctx, &dn, query, userID, noteDate)
+	err := r.db.GetContext(ctx, &dn, query, userID, noteDate.Format(daily_note.NoteDateLayout))
 	if err != nil {
 		return nil, r.handleNotFoundError(err, "user_id and note_date", fmt.Sprintf("%d, %s", userID, noteDate.Format("2006-01-02")))
 	}
-	return r.toEntity(&dn), nil
+	return r.withTag(ctx, &dn)
+}
+
+// sortOrderClauses 排序方式到 ORDER BY 子句的映射
+//
+// ORDER BY 无法使用占位符，只允许拼接此处列出的固定子句，避免 SQL 注入。
+var sortOrderClauses = map[daily_note.SortOrder]string{
+	daily_note.SortDateAsc:     "note_date ASC",
+	daily_note.SortDateDesc:    "note_date DESC",
+	daily_note.SortUpdatedDesc: "updated_at DESC, id DESC",
+	daily_note.SortPinnedFirst: "is_pinned DESC, note_date DESC",
 }
 
-// FindByUserID 根据用户ID分页查找每日笔记列表
-func (r *DailyNoteRepository) FindByUserID(ctx context.Context, userID int64, page, pageSize int) ([]daily_note.DailyNoteEntity, int64, error) {
+// FindByUserID 根据用户ID按指定排序方式分页查找每日笔记列表
+func (r *DailyNoteRepository) FindByUserID(ctx context.Context, userID int64, sort daily_note.SortOrder, page, pageSize int) ([]daily_note.DailyNoteEntity, int64, error) {
 	// 计算偏移量
 	offset := (page - 1) * pageSize
 
+	// 未知排序方式回退到默认排序
+	orderBy, ok := sortOrderClauses[sort]
+	if !ok {
+		orderBy = sortOrderClauses[daily_note.DefaultSortOrder]
+	}
+
 	// 查询每日笔记列表
 	var dns []do.DailyNote
 	query := `
-		SELECT id, user_id, note_date, content, created_at, updated_at
+		SELECT id, user_id, note_date, content, created_at, updated_at, version, is_pinned
 		FROM daily_notes
 		WHERE user_id = ?
-		ORDER BY note_date DESC
+		ORDER BY ` + orderBy + `
 		LIMIT ? OFFSET ?
 	`
 	err := r.db.SelectContext(ctx, &dns, query, userID, pageSize, offset)
@@ -84,7 +126,195 @@ func (r *DailyNoteRepository) FindByUserID(ctx context.Context, userID int64, pa
 		return nil, 0, fmt.Errorf("failed to count daily notes: %w", err)
 	}
 
-	return r.toEntities(dns), total, nil
+	entities, err := r.withTags(ctx, dns)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entities, total, nil
+}
+
+// FindByUserIDAfter 根据用户ID按笔记日期降序游标分页查找每日笔记列表
+//
+// 使用 note_date < ? 键集条件代替 OFFSET，命中 uk_user_date 索引。
+// 多查询一条用于判断是否还有下一页。
+func (r *DailyNoteRepository) FindByUserIDAfter(ctx context.Context, userID int64, afterNoteDate time.Time, limit int) ([]daily_note.DailyNoteEntity, time.Time, error) {
+	var dns []do.DailyNote
+	args := []any{userID}
+	query := `
+		SELECT id, user_id, note_date, content, created_at, updated_at, version, is_pinned
+		FROM daily_notes
+		WHERE user_id = ?`
+	if !afterNoteDate.IsZero() {
+		// 按日期字符串比较，避免时区转换影响 DATE 列的边界
+		query += ` AND note_date < ?`
+		args = append(args, afterNoteDate.Format(daily_note.NoteDateLayout))
+	}
+	query += `
+		ORDER BY note_date DESC
+		LIMIT ?
+	`
+	args = append(args, limit+1)
+
+	err := r.db.SelectContext(ctx, &dns, query, args...)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to find daily notes by user_id after cursor: %w", err)
+	}
+
+	// 多出的一条说明还有下一页，游标为本页最后一条笔记的日期
+	var next time.Time
+	if len(dns) > limit {
+		dns = dns[:limit]
+		next = dns[limit-1].NoteDate
+	}
+
+	entities, err := r.withTags(ctx, dns)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return entities, next, nil
+}
+
+// FindByUserIDAndTagAfter 根据用户ID和标签按笔记日期降序游标分页查找每日笔记列表
+//
+// 通过 idx_tag_note 索引定位带有该标签的笔记，再按用户过滤；游标条件与 FindByUserIDAfter 一致。
+func (r *DailyNoteRepository) FindByUserIDAndTagAfter(ctx context.Context, userID int64, tag string, afterNoteDate time.Time, limit int) ([]daily_note.DailyNoteEntity, time.Time, error) {
+	var dns []do.DailyNote
+	args := []any{tag, userID}
+	query := `
+		SELECT dn.id, dn.user_id, dn.note_date, dn.content, dn.created_at, dn.updated_at, dn.version, dn.is_pinned
+		FROM daily_note_tags t
+		JOIN daily_notes dn ON dn.id = t.note_id
+		WHERE t.tag = ? AND dn.user_id = ?`
+	if !afterNoteDate.IsZero() {
+		query += ` AND dn.note_date < ?`
+		args = append(args, afterNoteDate.Format(daily_note.NoteDateLayout))
+	}
+	query += `
+		ORDER BY dn.note_date DESC
+		LIMIT ?
+	`
+	args = append(args, limit+1)
+
+	err := r.db.SelectContext(ctx, &dns, query, args...)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to find daily notes by user_id and tag after cursor: %w", err)
+	}
+
+	var next time.Time
+	if len(dns) > limit {
+		dns = dns[:limit]
+		next = dns[limit-1].NoteDate
+	}
+
+	entities, err := r.withTags(ctx, dns)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return entities, next, nil
+}
+
+// FindByUserIDAndDateRange 根据用户ID和日期区间（闭区间）分页查找每日笔记列表
+func (r *DailyNoteRepository) FindByUserIDAndDateRange(ctx context.Context, userID int64, from, to time.Time, page, pageSize int) ([]daily_note.DailyNoteEntity, int64, error) {
+	// 计算偏移量
+	offset := (page - 1) * pageSize
+
+	// 按日期字符串比较，避免时区转换影响 DATE 列的边界
+	fromDate := from.Format(daily_note.NoteDateLayout)
+	toDate := to.Format(daily_note.NoteDateLayout)
+
+	// 查询每日笔记列表
+	var dns []do.DailyNote
+	query := `
+		SELECT id, user_id, note_date, content, created_at, updated_at, version, is_pinned
+		FROM daily_notes
+		WHERE user_id = ? AND note_date BETWEEN ? AND ?
+		ORDER BY note_date DESC
+		LIMIT ? OFFSET ?
+	`
+	err := r.db.SelectContext(ctx, &dns, query, userID, fromDate, toDate, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find daily notes by user_id and date range: %w", err)
+	}
+
+	// 查询总记录数
+	var total int64
+	totalQuery := `
+		SELECT COUNT(*)
+		FROM daily_notes
+		WHERE user_id = ? AND note_date BETWEEN ? AND ?
+	`
+	err = r.db.GetContext(ctx, &total, totalQuery, userID, fromDate, toDate)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count daily notes by date range: %w", err)
+	}
+
+	entities, err := r.withTags(ctx, dns)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entities, total, nil
+}
+
+// FindCalendarDaysByUserIDAndDateRange 查询用户日期区间（闭区间）内每篇笔记的日期和字数
+func (r *DailyNoteRepository) FindCalendarDaysByUserIDAndDateRange(ctx context.Context, userID int64, from, to time.Time) ([]daily_note.CalendarDay, error) {
+	// 在数据库中计算字数，避免读取笔记内容
+	var rows []do.DailyNoteCalendarDay
+	query := `
+		SELECT note_date, TRIM(content) <> '' AS has_content, ` + r.dialect.CharLength("content") + ` AS char_count
+		FROM daily_notes
+		WHERE user_id = ? AND note_date BETWEEN ? AND ?
+		ORDER BY note_date ASC
+	`
+	err := r.db.SelectContext(ctx, &rows, query, userID, from.Format(daily_note.NoteDateLayout), to.Format(daily_note.NoteDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find calendar days by user_id and date range: %w", err)
+	}
+
+	days := make([]daily_note.CalendarDay, len(rows))
+	for i, row := range rows {
+		days[i] = daily_note.CalendarDay{
+			Date:       row.NoteDate,
+			HasContent: row.HasContent,
+			CharCount:  row.CharCount,
+		}
+	}
+	return days, nil
+}
+
+// FindNoteDatesByUserID 查询用户所有写过笔记的日期（去重，按日期升序）
+func (r *DailyNoteRepository) FindNoteDatesByUserID(ctx context.Context, userID int64) ([]time.Time, error) {
+	var dates []time.Time
+	query := `
+		SELECT DISTINCT note_date
+		FROM daily_notes
+		WHERE user_id = ?
+		ORDER BY note_date ASC
+	`
+	err := r.db.SelectContext(ctx, &dates, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find note dates by user_id: %w", err)
+	}
+	return dates, nil
+}
+
+// CountByUserID 统计用户的笔记总数
+func (r *DailyNoteRepository) CountByUserID(ctx context.Context, userID int64) (int64, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM daily_notes WHERE user_id = ?`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count daily notes by user_id: %w", err)
+	}
+	return int64(count), nil
+}
+
+// ExistsByUserIDAndDate 检查用户指定日期是否已有笔记
+func (r *DailyNoteRepository) ExistsByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM daily_notes WHERE user_id = ? AND note_date = ?`
+	if err := r.db.GetContext(ctx, &count, query, userID, noteDate.Format(daily_note.NoteDateLayout)); err != nil {
+		return false, fmt.Errorf("failed to check daily note exists: %w", err)
+	}
+	return count > 0, nil
 }
 
 // ==================== 存储操作实现 ====================
@@ -98,19 +328,121 @@ func (r *DailyNoteRepository) Save(ctx context.Context, entity daily_note.DailyN
 	return r.Update(ctx, entity)
 }
 
+// Create 在事务中检查用户当日是否已有笔记并新增，返回带有生成 ID 的实体
+//
+// 通过 SELECT ... FOR UPDATE 锁定 (user_id, note_date) 范围，避免并发创建时检查与写入之间的竞态；
+// uk_user_date 唯一索引作为最终保障。
+// 并发插入时 FOR UPDATE 持有的间隙锁可能导致死锁，此时整个事务重试。
+func (r *DailyNoteRepository) Create(ctx context.Context, entity daily_note.DailyNoteEntity) (daily_note.DailyNoteEntity, error) {
+	noteDate := entity.GetNoteDate().Format(daily_note.NoteDateLayout)
+
+	var id int64
+	err := r.tx.TransactionWithRetry(ctx, writeLockRetries, func(tx *Tx) error {
+		exists, err := tx.Exists(ctx,
+			`SELECT COUNT(*) FROM daily_notes WHERE user_id = ? AND note_date = ?`+r.dialect.ForUpdate(),
+			entity.GetUserID(), noteDate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to check existing daily note: %w", err)
+		}
+		if exists {
+			return daily_note.ErrDailyNoteAlreadyExists
+		}
+
+		id, err = tx.ExecWithID(ctx, `
+			INSERT INTO daily_notes (
+				user_id, note_date, content, created_at, updated_at, version
+			) VALUES (?, ?, ?, ?, ?, ?)
+		`,
+			entity.GetUserID(),
+			noteDate,
+			entity.GetContent(),
+			entity.GetCreatedAt(),
+			entity.GetUpdatedAt(),
+			entity.GetVersion(),
+		)
+		if err != nil {
+			if r.dialect.IsDuplicateKey(err) {
+				return daily_note.ErrDailyNoteAlreadyExists
+			}
+			return fmt.Errorf("failed to insert daily note: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return daily_note.ReconstructDailyNote(
+		id,
+		entity.GetUserID(),
+		entity.GetNoteDate(),
+		entity.GetContent(),
+		entity.GetCreatedAt(),
+		entity.GetUpdatedAt(),
+		entity.GetVersion(),
+		entity.IsPinned(),
+		entity.GetTags(),
+	), nil
+}
+
+// SaveBatch 在同一事务中批量新增每日笔记
+//
+// 使用 Dialect.IgnoreDuplicate 使 (user_id, note_date) 冲突的行不报错，
+// 影响行数为 0 的条目视为重复并跳过。遇到死锁或锁等待超时时整个事务重试。
+func (r *DailyNoteRepository) SaveBatch(ctx context.Context, entities []daily_note.DailyNoteEntity) ([]daily_note.DailyNoteEntity, error) {
+	query := `
+		INSERT INTO daily_notes (
+			user_id, note_date, content, created_at, updated_at, version
+		) VALUES (?, ?, ?, ?, ?, ?)
+	` + r.dialect.IgnoreDuplicate()
+
+	var skipped []daily_note.DailyNoteEntity
+	err := r.tx.TransactionWithRetry(ctx, writeLockRetries, func(tx *Tx) error {
+		skipped = skipped[:0]
+		for _, entity := range entities {
+			affected, err := tx.ExecWithAffected(ctx, query,
+				entity.GetUserID(),
+				entity.GetNoteDate().Format(daily_note.NoteDateLayout),
+				entity.GetContent(),
+				entity.GetCreatedAt(),
+				entity.GetUpdatedAt(),
+				entity.GetVersion(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert daily note %s: %w", entity.GetNoteDate().Format(daily_note.NoteDateLayout), err)
+			}
+			if affected == 0 {
+				skipped = append(skipped, entity)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
 // Update 更新每日笔记
+//
+// 使用版本号实现乐观锁：仅当数据库中的版本号等于更新前版本号时才写入。
 func (r *DailyNoteRepository) Update(ctx context.Context, entity daily_note.DailyNoteEntity) error {
 	query := `
 		UPDATE daily_notes SET
 			content = ?,
-			updated_at = ?
-		WHERE id = ? AND user_id = ?
+			updated_at = ?,
+			version = ?
+		WHERE id = ? AND user_id = ? AND version = ?
 	`
 	result, err := r.db.ExecContext(ctx, query,
 		entity.GetContent(),
 		entity.GetUpdatedAt(),
+		entity.GetVersion(),
 		entity.GetID(),
 		entity.GetUserID(),
+		entity.GetVersion()-1,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update daily note: %w", err)
@@ -122,12 +454,69 @@ func (r *DailyNoteRepository) Update(ctx context.Context, entity daily_note.Dail
 	}
 
 	if rowsAffected == 0 {
-		return daily_note.ErrDailyNoteNotFound
+		// 区分记录不存在和版本冲突
+		if _, err := r.FindByID(ctx, entity.GetID()); err != nil {
+			return err
+		}
+		return daily_note.ErrDailyNoteConflict
 	}
 
 	return nil
 }
 
+// UpdatePinned 更新每日笔记的置顶状态
+//
+// 只写入 is_pinned 列，不影响版本号；显式赋值 updated_at = updated_at 以跳过列上的 ON UPDATE 自动更新。
+func (r *DailyNoteRepository) UpdatePinned(ctx context.Context, entity daily_note.DailyNoteEntity) error {
+	query := `
+		UPDATE daily_notes SET
+			is_pinned = ?,
+			updated_at = updated_at
+		WHERE id = ? AND user_id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, entity.IsPinned(), entity.GetID(), entity.GetUserID())
+	if err != nil {
+		return fmt.Errorf("failed to update daily note pinned: %w", err)
+	}
+
+	// 置顶状态未变化时影响行数同样为 0，需通过查询区分记录不存在
+	if _, err := r.FindByID(ctx, entity.GetID()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateTags 以实体当前的标签替换每日笔记已保存的标签
+//
+// 先删除旧标签再逐条插入，整体在同一事务中完成，遇到死锁或锁等待超时时重试。
+// 不修改 daily_notes 表，版本号和更新时间保持不变。
+func (r *DailyNoteRepository) UpdateTags(ctx context.Context, entity daily_note.DailyNoteEntity) error {
+	return r.tx.TransactionWithRetry(ctx, writeLockRetries, func(tx *Tx) error {
+		// 锁定笔记行，避免并发替换标签时与删除笔记交错
+		exists, err := tx.Exists(ctx,
+			`SELECT COUNT(*) FROM daily_notes WHERE id = ? AND user_id = ?`+r.dialect.ForUpdate(),
+			entity.GetID(), entity.GetUserID(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to lock daily note: %w", err)
+		}
+		if !exists {
+			return daily_note.ErrDailyNoteNotFound
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM daily_note_tags WHERE note_id = ?`, entity.GetID()); err != nil {
+			return fmt.Errorf("failed to delete daily note tags: %w", err)
+		}
+		for _, tag := range entity.GetTags() {
+			if _, err := tx.Exec(ctx, `INSERT INTO daily_note_tags (note_id, tag) VALUES (?, ?)`, entity.GetID(), tag); err != nil {
+				return fmt.Errorf("failed to insert daily note tag %q: %w", tag, err)
+			}
+		}
+		return nil
+	})
+}
+
 // Delete 删除每日笔记
 func (r *DailyNoteRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM daily_notes WHERE id = ?`
@@ -148,45 +537,115 @@ func (r *DailyNoteRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteAllByUserID 永久删除用户的全部每日笔记，返回删除的笔记数
+func (r *DailyNoteRepository) DeleteAllByUserID(ctx context.Context, userID int64) (int64, error) {
+	var deleted int64
+	err := r.tx.TransactionWithRetry(ctx, writeLockRetries, func(tx *Tx) error {
+		n, err := deleteDailyNotesByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		deleted = n
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// deleteDailyNotesByUserID 在事务中删除用户的全部每日笔记
+//
+// 标签随笔记级联删除。注销账户的清理流程与用户删除共用同一事务调用。
+func deleteDailyNotesByUserID(ctx context.Context, tx *Tx, userID int64) (int64, error) {
+	affected, err := tx.ExecWithAffected(ctx, `DELETE FROM daily_notes WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete daily notes of user: %w", err)
+	}
+	return affected, nil
+}
+
 // insert 插入新的每日笔记
 func (r *DailyNoteRepository) insert(ctx context.Context, entity daily_note.DailyNoteEntity) error {
 	query := `
 		INSERT INTO daily_notes (
-			user_id, note_date, content, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?)
+			user_id, note_date, content, created_at, updated_at, version
+		) VALUES (?, ?, ?, ?, ?, ?)
 	`
-	result, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.ExecContext(ctx, query,
 		entity.GetUserID(),
-		entity.GetNoteDate(),
+		entity.GetNoteDate().Format(daily_note.NoteDateLayout),
 		entity.GetContent(),
 		entity.GetCreatedAt(),
 		entity.GetUpdatedAt(),
+		entity.GetVersion(),
 	)
 	if err != nil {
+		// 并发创建时由 uk_user_date 唯一索引兜底
+		if r.dialect.IsDuplicateKey(err) {
+			return daily_note.ErrDailyNoteAlreadyExists
+		}
 		return fmt.Errorf("failed to insert daily note: %w", err)
 	}
 
-	// 获取插入的ID
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert id: %w", err)
+	return nil
+}
+
+// ==================== 辅助方法 ====================
+
+// findTags 批量查询笔记的标签，返回笔记ID到标签列表的映射
+//
+// 同一笔记的标签按字典序返回，与 NormalizeTags 的输出顺序一致。
+func (r *DailyNoteRepository) findTags(ctx context.Context, noteIDs []int64) (map[int64][]string, error) {
+	tags := make(map[int64][]string, len(noteIDs))
+	if len(noteIDs) == 0 {
+		return tags, nil
 	}
 
-	// 验证插入成功：重新查询记录以确认
-	// 注意：由于领域实体是不可变的，我们无法直接设置ID
-	// 所以我们通过查询来验证插入是否成功
-	_, err = r.FindByID(ctx, id)
+	query, args, err := sqlx.In(`
+		SELECT note_id, tag
+		FROM daily_note_tags
+		WHERE note_id IN (?)
+		ORDER BY note_id, tag
+	`, noteIDs)
 	if err != nil {
-		return fmt.Errorf("failed to verify inserted daily note: %w", err)
+		return nil, fmt.Errorf("failed to build daily note tags query: %w", err)
 	}
 
-	return nil
+	var rows []do.DailyNoteTag
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find daily note tags: %w", err)
+	}
+	for _, row := range rows {
+		tags[row.NoteID] = append(tags[row.NoteID], row.Tag)
+	}
+	return tags, nil
 }
 
-// ==================== 辅助方法 ====================
+// withTag 查询单条笔记的标签并转换为领域实体
+func (r *DailyNoteRepository) withTag(ctx context.Context, dn *do.DailyNote) (daily_note.DailyNoteEntity, error) {
+	tags, err := r.findTags(ctx, []int64{dn.ID})
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntity(dn, tags[dn.ID]), nil
+}
+
+// withTags 批量查询笔记的标签并转换为领域实体切片
+func (r *DailyNoteRepository) withTags(ctx context.Context, dns []do.DailyNote) ([]daily_note.DailyNoteEntity, error) {
+	ids := make([]int64, len(dns))
+	for i := range dns {
+		ids[i] = dns[i].ID
+	}
+	tags, err := r.findTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntities(dns, tags), nil
+}
 
 // toEntity 将DO转换为领域实体
-func (r *DailyNoteRepository) toEntity(dn *do.DailyNote) daily_note.DailyNoteEntity {
+func (r *DailyNoteRepository) toEntity(dn *do.DailyNote, tags []string) daily_note.DailyNoteEntity {
 	return daily_note.ReconstructDailyNote(
 		dn.ID,
 		dn.UserID,
@@ -194,14 +653,17 @@ func (r *DailyNoteRepository) toEntity(dn *do.DailyNote) daily_note.DailyNoteEnt
 		dn.Content,
 		dn.CreatedAt,
 		dn.UpdatedAt,
+		dn.Version,
+		dn.IsPinned,
+		tags,
 	)
 }
 
 // toEntities 将DO切片转换为领域实体切片
-func (r *DailyNoteRepository) toEntities(dns []do.DailyNote) []daily_note.DailyNoteEntity {
+func (r *DailyNoteRepository) toEntities(dns []do.DailyNote, tags map[int64][]string) []daily_note.DailyNoteEntity {
 	entities := make([]daily_note.DailyNoteEntity, len(dns))
 	for i := range dns {
-		entities[i] = r.toEntity(&dns[i])
+		entities[i] = r.toEntity(&dns[i], tags[dns[i].ID])
 	}
 	return entities
 }
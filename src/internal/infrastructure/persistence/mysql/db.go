@@ -4,32 +4,72 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand/v2"
+	"strings"
 	"sync"
 	"time"
 
 	"todolist/internal/infrastructure/config"
+	applogger "todolist/internal/pkg/logger"
+	"todolist/internal/pkg/metrics"
 
 	"github.com/jmoiron/sqlx"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client 数据库客户端
 // 封装数据库操作，提供简洁的 API
+//
+// 配置了只读副本时进行读写分离：查询（Query、QueryOne、Count、Exists 及 Executor 的读方法）
+// 走副本，写操作和事务走主库。副本存在复制延迟，需要读到刚写入数据时使用 WithPrimary
+// 或 WithReadYourWrites 派生的上下文。
 type Client struct {
+	// db 主库连接
 	db *sqlx.DB
+
+	// replica 只读副本连接，为 nil 时读操作也使用主库
+	replica *sqlx.DB
+
+	// queryTimeout 单条 SQL 默认超时时间，0 表示不限制
+	queryTimeout time.Duration
+
+	// slowQueryThreshold 慢查询阈值，超过时记录警告日志，0 表示不记录
+	slowQueryThreshold time.Duration
+
+	// dialect SQL 方言，仓储据此生成数据库特有的语句片段
+	dialect Dialect
+
+	// reads 合并相同的并发读查询，由通过该客户端创建的仓储共享，见 coalesceRead
+	reads singleflight.Group
 }
 
 var ClientInstance *Client
-var once sync.Once
+var clientMu sync.Mutex
 
-func GetClient() *Client {
-	once.Do(func() {
-		client, err := NewClient()
-		if err != nil {
-			panic(err)
-		}
-		ClientInstance = client
-	})
-	return ClientInstance
+// GetClient 获取数据库客户端单例
+//
+// 连接失败时返回包装了失败原因的 ErrDatabaseUnavailable，不缓存失败结果，
+// 下次调用会重新尝试连接，数据库恢复后无需重启服务。
+func GetClient() (*Client, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if ClientInstance != nil {
+		return ClientInstance, nil
+	}
+
+	client, err := NewClient()
+	if err != nil {
+		unavailable := ErrDatabaseUnavailable
+		unavailable.InternalError = err
+		return nil, unavailable
+	}
+	ClientInstance = client
+	metrics.RegisterDBStats(client.db.DB, "mysql")
+	if client.replica != nil {
+		metrics.RegisterDBStats(client.replica.DB, "mysql_replica")
+	}
+	return ClientInstance, nil
 }
 
 // NewClient 创建数据库客户端
@@ -39,7 +79,33 @@ func NewClient() (*Client, error) {
 		return nil, fmt.Errorf("failed to get mysql config: %w", err)
 	}
 
-	db, err := sqlx.Connect("mysql", cfg.DSN())
+	db, err := connect(cfg, cfg.DSN())
+	if err != nil {
+		return nil, err
+	}
+
+	// 配置了只读副本时建立副本连接，副本不可用时启动失败，避免读写分离静默失效
+	var replica *sqlx.DB
+	if cfg.HasReplica() {
+		replica, err = connect(cfg, cfg.ReplicaDSN())
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("mysql replica: %w", err)
+		}
+	}
+
+	return &Client{
+		db:                 db,
+		replica:            replica,
+		queryTimeout:       cfg.QueryTimeout,
+		slowQueryThreshold: cfg.SlowQueryThreshold,
+		dialect:            MySQLDialect,
+	}, nil
+}
+
+// connect 按配置的连接池参数连接数据库并验证连接
+func connect(cfg *config.MySQLConfig, dsn string) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to mysql: %w", err)
 	}
@@ -47,42 +113,99 @@ func NewClient() (*Client, error) {
 	// 设置连接池
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(10 * time.Minute)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// 验证连接
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to ping mysql: %w", err)
 	}
 
-	return &Client{db: db}, nil
+	return db, nil
 }
 
-// Close 关闭数据库连接
+// NewClientWithDB 使用已建立的连接和指定方言创建数据库客户端
+//
+// 用于 SQLite 等非 MySQL 数据库，不设置默认查询超时和慢查询阈值。
+func NewClientWithDB(db *sqlx.DB, dialect Dialect) *Client {
+	return &Client{db: db, dialect: dialect}
+}
+
+// WithQueryTimeout 为没有截止时间的上下文派生带超时的上下文。
+//
+// 上下文已有截止时间（如请求级超时）或 timeout 非正数时原样返回，
+// 调用方必须调用返回的 cancel 释放资源。
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// logSlowQuery 执行耗时超过阈值时记录慢查询警告日志。
+//
+// 以 defer 方式调用，start 在 defer 语句处求值即为 SQL 开始执行的时间。
+// threshold 非正数时不记录。
+func logSlowQuery(ctx context.Context, threshold time.Duration, query string, start time.Time) {
+	if threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+	applogger.WarnContext(ctx, "慢查询",
+		applogger.String("query", strings.Join(strings.Fields(query), " ")),
+		applogger.Duration("duration_ms", elapsed),
+		applogger.Duration("threshold_ms", threshold),
+	)
+}
+
+// Close 关闭数据库连接（含只读副本）
 func (c *Client) Close() error {
+	if c.replica != nil {
+		if err := c.replica.Close(); err != nil {
+			return err
+		}
+	}
 	if c.db != nil {
 		return c.db.Close()
 	}
 	return nil
 }
 
-// GetDB 获取底层 *sqlx.DB（用于复杂操作）
+// GetDB 获取主库的底层 *sqlx.DB（用于复杂操作）
 func (c *Client) GetDB() *sqlx.DB {
 	return c.db
 }
 
+// Dialect 获取客户端的 SQL 方言
+func (c *Client) Dialect() Dialect {
+	return c.dialect
+}
+
 // ==================== 查询操作 ====================
 
 // SelectContext 实现 Executor 接口 - 查询多行数据
 func (c *Client) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return c.db.SelectContext(ctx, dest, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.reader(ctx).SelectContext(ctx, dest, query, args...)
 }
 
 // GetContext 实现 Executor 接口 - 查询单行数据
 func (c *Client) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return c.db.GetContext(ctx, dest, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.reader(ctx).GetContext(ctx, dest, query, args...)
 }
 
 // ExecContext 实现 Executor 接口 - 执行 SQL 语句
@@ -90,7 +213,10 @@ func (c *Client) ExecContext(ctx context.Context, query string, args ...interfac
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
 }, error) {
-	return c.db.ExecContext(ctx, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.writer(ctx).ExecContext(ctx, query, args...)
 }
 
 // Query 查询多行数据并映射到切片
@@ -98,7 +224,10 @@ func (c *Client) ExecContext(ctx context.Context, query string, args ...interfac
 // query: SQL 查询语句
 // args: 查询参数
 func (c *Client) Query(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return c.db.SelectContext(ctx, dest, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.reader(ctx).SelectContext(ctx, dest, query, args...)
 }
 
 // QueryOne 查询单行数据并映射到结构体
@@ -106,12 +235,16 @@ func (c *Client) Query(ctx context.Context, dest interface{}, query string, args
 // query: SQL 查询语句
 // args: 查询参数
 func (c *Client) QueryOne(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return c.db.GetContext(ctx, dest, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.reader(ctx).GetContext(ctx, dest, query, args...)
 }
 
 // QueryRow 查询单行数据，返回 *sqlx.Row（用于自定义扫描）
+// 结果在调用方 Scan 时才读取，因此不套用默认超时，超时由 ctx 控制
 func (c *Client) QueryRow(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
-	return c.db.QueryRowxContext(ctx, query, args...)
+	return c.reader(ctx).QueryRowxContext(ctx, query, args...)
 }
 
 // ==================== 执行操作 ====================
@@ -119,12 +252,18 @@ func (c *Client) QueryRow(ctx context.Context, query string, args ...interface{}
 // Exec 执行 SQL 语句（INSERT, UPDATE, DELETE）
 // 返回 sql.Result 包含 LastInsertId 和 RowsAffected
 func (c *Client) Exec(ctx context.Context, query string, args ...interface{}) (sqlResult, error) {
-	return c.db.ExecContext(ctx, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	return c.writer(ctx).ExecContext(ctx, query, args...)
 }
 
 // ExecWithID 执行 INSERT 并返回插入的 ID
 func (c *Client) ExecWithID(ctx context.Context, query string, args ...interface{}) (int64, error) {
-	result, err := c.db.ExecContext(ctx, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	result, err := c.writer(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -137,7 +276,10 @@ func (c *Client) ExecWithID(ctx context.Context, query string, args ...interface
 
 // ExecWithAffected 执行 SQL 并返回影响的行数
 func (c *Client) ExecWithAffected(ctx context.Context, query string, args ...interface{}) (int64, error) {
-	result, err := c.db.ExecContext(ctx, query, args...)
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
+	result, err := c.writer(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -152,20 +294,20 @@ func (c *Client) ExecWithAffected(ctx context.Context, query string, args ...int
 
 // BeginTxs 开启事务
 func (c *Client) BeginTxs(ctx context.Context) (*Tx, error) {
-	tx, err := c.db.BeginTxx(ctx, nil)
+	tx, err := c.writer(ctx).BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return &Tx{tx: tx}, nil
+	return &Tx{tx: tx, queryTimeout: c.queryTimeout, slowQueryThreshold: c.slowQueryThreshold}, nil
 }
 
 // BeginTxWithOpts 开启事务（自定义选项）
 func (c *Client) BeginTxWithOpts(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
-	tx, err := c.db.BeginTxx(ctx, opts)
+	tx, err := c.writer(ctx).BeginTxx(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return &Tx{tx: tx}, nil
+	return &Tx{tx: tx, queryTimeout: c.queryTimeout, slowQueryThreshold: c.slowQueryThreshold}, nil
 }
 
 // Transaction 执行事务函数（自动提交/回滚）。
@@ -202,6 +344,56 @@ func (c *Client) Transaction(ctx context.Context, fn func(*Tx) error) error {
 	return tx.Commit()
 }
 
+// lockRetryBaseDelay 锁冲突重试的初始退避时间，每次重试翻倍
+var lockRetryBaseDelay = 10 * time.Millisecond
+
+// TransactionWithRetry 执行事务函数，遇到死锁或锁等待超时时回滚并重试。
+//
+// 每次重试都会开启新事务并重新执行 fn，因此 fn 必须可重复执行，
+// 不能依赖上一次执行留下的状态。重试策略见 RetryOnLockConflict。
+//
+// 参数：
+//   ctx - 请求上下文
+//   maxRetries - 最大重试次数，0 表示不重试
+//   fn - 要在事务中执行的函数
+//
+// 返回：
+//   error - 最后一次执行的错误
+func (c *Client) TransactionWithRetry(ctx context.Context, maxRetries int, fn func(*Tx) error) error {
+	return RetryOnLockConflict(ctx, maxRetries, func() error {
+		return c.Transaction(ctx, fn)
+	})
+}
+
+// RetryOnLockConflict 执行 fn，返回 MySQL 死锁（1213）或锁等待超时（1205）错误时以指数退避重试。
+//
+// 其他错误立即返回；重试 maxRetries 次后仍失败时返回最后一次的错误。
+// 等待期间上下文取消时返回上下文错误。
+func RetryOnLockConflict(ctx context.Context, maxRetries int, fn func() error) error {
+	delay := lockRetryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isLockConflictError(err) || attempt >= maxRetries {
+			return err
+		}
+
+		applogger.WarnContext(ctx, "事务锁冲突，准备重试",
+			applogger.Int("attempt", attempt+1),
+			applogger.Int("max_retries", maxRetries),
+			applogger.Err(err))
+
+		// 加入随机抖动，避免冲突双方按相同节奏重试再次冲突
+		timer := time.NewTimer(delay + rand.N(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
 // ==================== 批量操作 ====================
 
 // BatchExec 批量执行 SQL 语句
@@ -235,8 +427,11 @@ func (c *Client) BatchExec(ctx context.Context, queries []string, argsList [][]i
 
 // Exists 检查数据是否存在
 func (c *Client) Exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
 	var count int
-	if err := c.db.GetContext(ctx, &count, query, args...); err != nil {
+	if err := c.reader(ctx).GetContext(ctx, &count, query, args...); err != nil {
 		return false, err
 	}
 	return count > 0, nil
@@ -244,8 +439,11 @@ func (c *Client) Exists(ctx context.Context, query string, args ...interface{})
 
 // Count 统计行数
 func (c *Client) Count(ctx context.Context, query string, args ...interface{}) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, c.slowQueryThreshold, query, time.Now())
 	var count int
-	if err := c.db.GetContext(ctx, &count, query, args...); err != nil {
+	if err := c.reader(ctx).GetContext(ctx, &count, query, args...); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -254,8 +452,15 @@ func (c *Client) Count(ctx context.Context, query string, args ...interface{}) (
 // ==================== 事务封装 ====================
 
 // Tx 事务封装
+// 事务的生命周期由开启事务时的 ctx 控制，事务内每条 SQL 另外套用默认超时
 type Tx struct {
 	tx *sqlx.Tx
+
+	// queryTimeout 单条 SQL 默认超时时间，继承自 Client
+	queryTimeout time.Duration
+
+	// slowQueryThreshold 慢查询阈值，继承自 Client
+	slowQueryThreshold time.Duration
 }
 
 // Commit 提交事务
@@ -272,11 +477,17 @@ func (t *Tx) Rollback() error {
 
 // Query 事务中查询多行数据
 func (t *Tx) Query(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	return t.tx.SelectContext(ctx, dest, query, args...)
 }
 
 // QueryOne 事务中查询单行数据
 func (t *Tx) QueryOne(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	return t.tx.GetContext(ctx, dest, query, args...)
 }
 
@@ -289,11 +500,17 @@ func (t *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *s
 
 // Exec 事务中执行 SQL
 func (t *Tx) Exec(ctx context.Context, query string, args ...interface{}) (sqlResult, error) {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	return t.tx.ExecContext(ctx, query, args...)
 }
 
 // ExecWithID 事务中执行 INSERT 并返回 ID
 func (t *Tx) ExecWithID(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	result, err := t.tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
@@ -307,6 +524,9 @@ func (t *Tx) ExecWithID(ctx context.Context, query string, args ...interface{})
 
 // ExecWithAffected 事务中执行 SQL 并返回影响行数
 func (t *Tx) ExecWithAffected(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	result, err := t.tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
@@ -320,6 +540,9 @@ func (t *Tx) ExecWithAffected(ctx context.Context, query string, args ...interfa
 
 // Exists 事务中检查数据是否存在
 func (t *Tx) Exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	var count int
 	if err := t.tx.GetContext(ctx, &count, query, args...); err != nil {
 		return false, err
@@ -329,6 +552,9 @@ func (t *Tx) Exists(ctx context.Context, query string, args ...interface{}) (boo
 
 // Count 事务中统计行数
 func (t *Tx) Count(ctx context.Context, query string, args ...interface{}) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	defer logSlowQuery(ctx, t.slowQueryThreshold, query, time.Now())
 	var count int
 	if err := t.tx.GetContext(ctx, &count, query, args...); err != nil {
 		return 0, err
@@ -341,3 +567,13 @@ type sqlResult interface {
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
 }
+
+// likeEscaper 转义 LIKE 模式中的通配符和转义符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike 转义用户输入中的 %、_ 和 \，使其在 LIKE 模式中按字面匹配
+//
+// 转义符为 \，拼接通配符应在转义之后进行，条件由 Dialect.LikePrefix 生成。
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,58 @@
+package mysql
+
+// Dialect SQL 方言
+//
+// 仓储中的查询尽量使用 MySQL 与 SQLite 通用的语法，
+// 无法通用的少数片段（行锁、冲突忽略、LIKE 转义、错误码）由方言生成，
+// 使同一套仓储实现可以运行在 SQLite 上，用于本地开发和测试。
+type Dialect interface {
+	// Name 方言名称，与 database/sql 驱动名一致
+	Name() string
+
+	// ForUpdate 返回追加在 SELECT 末尾的锁定读子句，不支持行锁时返回空字符串
+	ForUpdate() string
+
+	// IgnoreDuplicate 返回追加在 INSERT 末尾的子句，使唯一键冲突的行被跳过（影响行数为 0）而不报错
+	IgnoreDuplicate() string
+
+	// LikePrefix 返回 column 以参数值开头的条件，参数需先经 EscapeLike 转义
+	LikePrefix(column string) string
+
+	// CharLength 返回按字符（而非字节）计算 column 长度的表达式
+	CharLength(column string) string
+
+	// IsDuplicateKey 判断错误是否为唯一键冲突
+	IsDuplicateKey(err error) bool
+}
+
+// MySQLDialect MySQL 方言
+var MySQLDialect Dialect = mysqlDialect{}
+
+// mysqlDialect MySQL 方言实现
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (mysqlDialect) ForUpdate() string {
+	return " FOR UPDATE"
+}
+
+func (mysqlDialect) IgnoreDuplicate() string {
+	return " ON DUPLICATE KEY UPDATE id = id"
+}
+
+// LikePrefix 依赖 MySQL 默认的转义符 \
+func (mysqlDialect) LikePrefix(column string) string {
+	return column + ` LIKE CONCAT(?, '%')`
+}
+
+// CharLength MySQL 的 LENGTH 按字节计算，需使用 CHAR_LENGTH
+func (mysqlDialect) CharLength(column string) string {
+	return "CHAR_LENGTH(" + column + ")"
+}
+
+func (mysqlDialect) IsDuplicateKey(err error) bool {
+	return isDuplicateKeyError(err)
+}
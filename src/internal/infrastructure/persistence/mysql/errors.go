@@ -0,0 +1,64 @@
+package mysql
+
+import (
+	"errors"
+	"regexp"
+
+	"todolist/internal/pkg/domainerr"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// ErrDatabaseUnavailable 数据库连接不可用
+//
+// 连接失败的原因保存在 InternalError 中，仅记录到日志，不返回给客户端。
+var ErrDatabaseUnavailable = domainerr.BusinessError{
+	Code:    "DATABASE_UNAVAILABLE",
+	Type:    domainerr.UnavailableError,
+	Message: "数据库暂不可用，请稍后重试",
+}
+
+func init() {
+	domainerr.Register(ErrDatabaseUnavailable)
+}
+
+// erDupEntry MySQL 唯一键冲突错误码
+const erDupEntry = 1062
+
+// isDuplicateKeyError 判断错误是否为唯一键冲突
+func isDuplicateKeyError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erDupEntry
+}
+
+// duplicateKeyPattern 匹配唯一键冲突错误信息中的索引名，MySQL 8.0 起索引名带表名前缀（如 users.uk_email）
+var duplicateKeyPattern = regexp.MustCompile(`for key '(?:[^'.]+\.)?([^']+)'`)
+
+// duplicateKeyName 返回唯一键冲突的索引名
+//
+// 错误不是 MySQL 唯一键冲突或信息中没有索引名时返回空字符串。
+func duplicateKeyName(err error) string {
+	var mysqlErr *mysqldriver.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != erDupEntry {
+		return ""
+	}
+	if m := duplicateKeyPattern.FindStringSubmatch(mysqlErr.Message); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+const (
+	// erLockWaitTimeout MySQL 锁等待超时错误码
+	erLockWaitTimeout = 1205
+
+	// erLockDeadlock MySQL 死锁错误码
+	erLockDeadlock = 1213
+)
+
+// isLockConflictError 判断错误是否为死锁或锁等待超时，此类错误重试事务通常可以成功
+func isLockConflictError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) &&
+		(mysqlErr.Number == erLockDeadlock || mysqlErr.Number == erLockWaitTimeout)
+}
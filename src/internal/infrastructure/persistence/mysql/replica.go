@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// primaryKey 强制从主库读取的上下文键
+type primaryKey struct{}
+
+// readYourWritesKey 写入后切换到主库读取的上下文键，值为是否已写入的标记
+type readYourWritesKey struct{}
+
+// WithPrimary 返回强制从主库读取的上下文
+//
+// 用于必须读到最新数据的场景，如写入后立即回读、读取后据此更新的校验。
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// WithReadYourWrites 返回写入后自动切换到主库读取的上下文
+//
+// 通过该上下文（及其派生上下文）执行过写操作或开启过事务后，后续读操作都走主库，
+// 避免副本复制延迟导致读不到刚写入的数据。HTTP 请求由 handler.Wrap 统一设置。
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(readYourWritesKey{}).(*atomic.Bool); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, readYourWritesKey{}, new(atomic.Bool))
+}
+
+// markWritten 记录上下文中已执行过写操作
+func markWritten(ctx context.Context) {
+	if written, ok := ctx.Value(readYourWritesKey{}).(*atomic.Bool); ok {
+		written.Store(true)
+	}
+}
+
+// usePrimary 判断读操作是否需要走主库
+func usePrimary(ctx context.Context) bool {
+	if forced, _ := ctx.Value(primaryKey{}).(bool); forced {
+		return true
+	}
+	written, ok := ctx.Value(readYourWritesKey{}).(*atomic.Bool)
+	return ok && written.Load()
+}
+
+// reader 返回读操作使用的连接：配置了副本且上下文不要求主库时为副本，否则为主库
+func (c *Client) reader(ctx context.Context) *sqlx.DB {
+	if c.replica == nil || usePrimary(ctx) {
+		return c.db
+	}
+	return c.replica
+}
+
+// writer 返回写操作使用的主库连接，并记录上下文中已执行过写操作
+func (c *Client) writer(ctx context.Context) *sqlx.DB {
+	markWritten(ctx)
+	return c.db
+}
+
+// ReadReplica 获取只读副本的 *sqlx.DB，未配置副本时返回主库
+func (c *Client) ReadReplica() *sqlx.DB {
+	if c.replica != nil {
+		return c.replica
+	}
+	return c.db
+}
+
+// NewClientWithReplica 使用已建立的主库和只读副本连接创建数据库客户端
+//
+// replica 为 nil 时与 NewClientWithDB 相同。
+func NewClientWithReplica(db, replica *sqlx.DB, dialect Dialect) *Client {
+	return &Client{db: db, replica: replica, dialect: dialect}
+}
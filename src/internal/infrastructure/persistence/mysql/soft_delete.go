@@ -0,0 +1,73 @@
+package mysql
+
+import "strings"
+
+// 软删除过滤条件
+//
+// users 表通过 deleted_at 实现软删除，面向有效用户的查询和更新必须带上 notDeletedCondition，
+// 只有回收站相关的查询（列出、恢复、清理已注销账户）使用 deletedCondition。
+// 查询语句应通过 buildSelect 构建，避免手写 WHERE 子句时遗漏过滤条件。
+// daily_notes 等其他表直接物理删除，不需要过滤。
+const (
+	// notDeletedCondition 未软删除的行
+	notDeletedCondition = "deleted_at IS NULL"
+
+	// deletedCondition 已软删除的行
+	deletedCondition = "deleted_at IS NOT NULL"
+)
+
+// softDeleteScope 查询的软删除过滤范围
+type softDeleteScope int
+
+const (
+	// activeRows 只返回未软删除的行
+	activeRows softDeleteScope = iota
+
+	// deletedRows 只返回已软删除的行
+	deletedRows
+)
+
+// condition 返回范围对应的过滤条件
+func (s softDeleteScope) condition() string {
+	if s == deletedRows {
+		return deletedCondition
+	}
+	return notDeletedCondition
+}
+
+// buildSelect 构建带软删除过滤条件的 SELECT 语句
+//
+// where 为附加条件（不含 WHERE 关键字，可为空），与软删除条件以 AND 连接；
+// tail 为 ORDER BY、GROUP BY、LIMIT 等后缀（可为空）。
+func buildSelect(columns, table string, scope softDeleteScope, where, tail string) string {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(columns)
+	b.WriteString(" FROM ")
+	b.WriteString(table)
+	b.WriteString(" WHERE ")
+	if where != "" {
+		b.WriteString(where)
+		b.WriteString(" AND ")
+	}
+	b.WriteString(scope.condition())
+	if tail != "" {
+		b.WriteString(" ")
+		b.WriteString(tail)
+	}
+	return b.String()
+}
+
+// userColumns users 表映射到 do.User 的列
+const userColumns = `id, username, email, password_hash, avatar_url, timezone, status, role, email_verified, pending_email,
+	failed_login_attempts, last_failed_login_at, locked_until, username_changed_at, created_at, updated_at`
+
+// selectUsers 构建查询用户的 SELECT 语句，默认排除已软删除的用户
+func selectUsers(scope softDeleteScope, where, tail string) string {
+	return buildSelect(userColumns, "users", scope, where, tail)
+}
+
+// countUsers 构建统计用户数的 SELECT 语句
+func countUsers(scope softDeleteScope, where string) string {
+	return buildSelect("COUNT(*)", "users", scope, where, "")
+}
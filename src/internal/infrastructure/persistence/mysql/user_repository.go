@@ -5,13 +5,20 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"todolist/internal/domain/user"
 	"todolist/internal/interfaces/do"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // Executor 数据库执行器接口
 // 抽象数据库操作，支持 *sqlx.DB 和 *sqlx.Tx
+//
+// 仓储的 Find*、List*、Count* 等查询方法通过 SelectContext、GetContext 读取，
+// 由 Client 在配置了只读副本时路由到副本；ExecContext 和事务始终走主库。
 type Executor interface {
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
@@ -24,25 +31,48 @@ type Executor interface {
 // UserRepository 用户仓储实现
 // 实现 user.Repository 接口
 type UserRepository struct {
-	db Executor
+	db      Executor
+	tx      Transactor
+	dialect Dialect
+
+	// reads 合并 FindByID 的并发查询
+	reads *singleflight.Group
 }
 
 // NewUserRepository 创建用户仓储
-func NewUserRepository() *UserRepository {
-	return &UserRepository{db: GetClient()}
+//
+// 数据库不可用时返回 ErrDatabaseUnavailable。
+func NewUserRepository() (*UserRepository, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewUserRepositoryWithClient(client), nil
+}
+
+// NewUserRepositoryWithClient 使用指定的数据库客户端创建用户仓储
+func NewUserRepositoryWithClient(client *Client) *UserRepository {
+	return &UserRepository{db: client, tx: client, dialect: client.Dialect(), reads: &client.reads}
+}
+
+// NewUserRepositoryWithExecutor 使用指定的执行器创建用户仓储
+//
+// 不支持事务，UpdateStatusBatch 等事务方法不可用，用于测试单条语句的查询逻辑。
+func NewUserRepositoryWithExecutor(db Executor, dialect Dialect) *UserRepository {
+	return &UserRepository{db: db, dialect: dialect, reads: new(singleflight.Group)}
 }
 
 // ==================== 查询操作实现 ====================
 
 // FindByID 根据 ID 查找用户
+//
+// 相同 ID 的并发查询合并为一次数据库查询，每个调用方得到各自的实体，见 coalesceRead。
 func (r *UserRepository) FindByID(ctx context.Context, id int64) (user.UserEntity, error) {
-	var u do.User
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, status, created_at, updated_at
-		FROM users
-		WHERE id = ? AND deleted_at IS NULL
-	`
-	err := r.db.GetContext(ctx, &u, query, id)
+	u, err := coalesceRead(ctx, r.reads, "users:id:"+strconv.FormatInt(id, 10), func(ctx context.Context) (do.User, error) {
+		var u do.User
+		err := r.db.GetContext(ctx, &u, selectUsers(activeRows, "id = ?", ""), id)
+		return u, err
+	})
 	if err != nil {
 		return nil, r.handleNotFoundError(err, "id", id)
 	}
@@ -52,11 +82,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id int64) (user.UserEntit
 // FindByEmail 根据邮箱查找用户
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (user.UserEntity, error) {
 	var u do.User
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, status, created_at, updated_at
-		FROM users
-		WHERE email = ? AND deleted_at IS NULL
-	`
+	query := selectUsers(activeRows, "email = ?", "")
 	err := r.db.GetContext(ctx, &u, query, email)
 	if err != nil {
 		return nil, r.handleNotFoundError(err, "email", email)
@@ -65,14 +91,12 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (user.Us
 }
 
 // FindByUsername 根据用户名查找用户
+//
+// 按 username_canonical 比较，Alice 与 alice 查到同一用户，返回的用户名保留原有大小写。
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (user.UserEntity, error) {
 	var u do.User
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, status, created_at, updated_at
-		FROM users
-		WHERE username = ? AND deleted_at IS NULL
-	`
-	err := r.db.GetContext(ctx, &u, query, username)
+	query := selectUsers(activeRows, "username_canonical = ?", "")
+	err := r.db.GetContext(ctx, &u, query, user.CanonicalUsername(username))
 	if err != nil {
 		return nil, r.handleNotFoundError(err, "username", username)
 	}
@@ -82,13 +106,7 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (u
 // List 列出用户
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]user.UserEntity, error) {
 	var users []do.User
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, status, created_at, updated_at
-		FROM users
-		WHERE deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`
+	query := selectUsers(activeRows, "", "ORDER BY created_at DESC LIMIT ? OFFSET ?")
 	if err := r.db.SelectContext(ctx, &users, query, limit, offset); err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -99,13 +117,7 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]user.Us
 // ListByStatus 根据状态列出用户
 func (r *UserRepository) ListByStatus(ctx context.Context, status user.UserStatus, limit, offset int) ([]user.UserEntity, error) {
 	var users []do.User
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, status, created_at, updated_at
-		FROM users
-		WHERE status = ? AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`
+	query := selectUsers(activeRows, "status = ?", "ORDER BY created_at DESC LIMIT ? OFFSET ?")
 	if err := r.db.SelectContext(ctx, &users, query, string(status), limit, offset); err != nil {
 		return nil, fmt.Errorf("failed to list users by status: %w", err)
 	}
@@ -113,12 +125,59 @@ func (r *UserRepository) ListByStatus(ctx context.Context, status user.UserStatu
 	return r.toEntities(users), nil
 }
 
+// ListByDateRange 列出创建时间在 [from, to] 区间内的用户
+func (r *UserRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]user.UserEntity, error) {
+	var users []do.User
+	query := selectUsers(activeRows, "created_at BETWEEN ? AND ?", "ORDER BY created_at DESC LIMIT ? OFFSET ?")
+	if err := r.db.SelectContext(ctx, &users, query, from, to, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list users by date range: %w", err)
+	}
+
+	return r.toEntities(users), nil
+}
+
+// SearchByUsername 列出用户名以 prefix 开头的用户
+//
+// 在 username_canonical 上做前缀匹配以利用 uk_username_canonical 索引，不区分大小写；
+// prefix 中的通配符经转义后按字面匹配。
+func (r *UserRepository) SearchByUsername(ctx context.Context, prefix string, limit, offset int) ([]user.UserEntity, error) {
+	var users []do.User
+	query := selectUsers(activeRows, r.dialect.LikePrefix("username_canonical"), "ORDER BY username_canonical ASC LIMIT ? OFFSET ?")
+	if err := r.db.SelectContext(ctx, &users, query, EscapeLike(user.CanonicalUsername(prefix)), limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to search users by username: %w", err)
+	}
+
+	return r.toEntities(users), nil
+}
+
+// FindDeletedByID 根据 ID 查找已软删除的用户
+func (r *UserRepository) FindDeletedByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	var u do.User
+	query := selectUsers(deletedRows, "id = ?", "")
+	err := r.db.GetContext(ctx, &u, query, id)
+	if err != nil {
+		return nil, r.handleNotFoundError(err, "deleted id", id)
+	}
+	return r.toEntity(&u), nil
+}
+
+// ListDeleted 列出已软删除的用户，按删除时间倒序
+func (r *UserRepository) ListDeleted(ctx context.Context, limit, offset int) ([]user.UserEntity, error) {
+	var users []do.User
+	query := selectUsers(deletedRows, "", "ORDER BY deleted_at DESC LIMIT ? OFFSET ?")
+	if err := r.db.SelectContext(ctx, &users, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list deleted users: %w", err)
+	}
+
+	return r.toEntities(users), nil
+}
+
 // ==================== 存在性检查实现 ====================
 
 // ExistsByEmail 检查邮箱是否存在
 func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM users WHERE email = ? AND deleted_at IS NULL`
+	query := countUsers(activeRows, "email = ?")
 	if err := r.db.GetContext(ctx, &count, query, email); err != nil {
 		return false, fmt.Errorf("failed to check email exists: %w", err)
 	}
@@ -126,10 +185,12 @@ func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool,
 }
 
 // ExistsByUsername 检查用户名是否存在
+//
+// 按 username_canonical 比较，仅大小写不同的用户名视为已存在。
 func (r *UserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM users WHERE username = ? AND deleted_at IS NULL`
-	if err := r.db.GetContext(ctx, &count, query, username); err != nil {
+	query := countUsers(activeRows, "username_canonical = ?")
+	if err := r.db.GetContext(ctx, &count, query, user.CanonicalUsername(username)); err != nil {
 		return false, fmt.Errorf("failed to check username exists: %w", err)
 	}
 	return count > 0, nil
@@ -140,7 +201,7 @@ func (r *UserRepository) ExistsByUsername(ctx context.Context, username string)
 // Count 统计用户总数
 func (r *UserRepository) Count(ctx context.Context) (int64, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL`
+	query := countUsers(activeRows, "")
 	if err := r.db.GetContext(ctx, &count, query); err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
@@ -150,13 +211,61 @@ func (r *UserRepository) Count(ctx context.Context) (int64, error) {
 // CountByStatus 根据状态统计用户数
 func (r *UserRepository) CountByStatus(ctx context.Context, status user.UserStatus) (int64, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM users WHERE status = ? AND deleted_at IS NULL`
+	query := countUsers(activeRows, "status = ?")
 	if err := r.db.GetContext(ctx, &count, query, string(status)); err != nil {
 		return 0, fmt.Errorf("failed to count users by status: %w", err)
 	}
 	return int64(count), nil
 }
 
+// CountGroupByStatus 一次查询统计各状态的用户数
+func (r *UserRepository) CountGroupByStatus(ctx context.Context) (map[user.UserStatus]int64, error) {
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int64  `db:"count"`
+	}
+	query := buildSelect("status, COUNT(*) AS count", "users", activeRows, "", "GROUP BY status")
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to count users group by status: %w", err)
+	}
+
+	counts := make(map[user.UserStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[user.UserStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+// CountByDateRange 统计创建时间在 [from, to] 区间内的用户数
+func (r *UserRepository) CountByDateRange(ctx context.Context, from, to time.Time) (int64, error) {
+	var count int
+	query := countUsers(activeRows, "created_at BETWEEN ? AND ?")
+	if err := r.db.GetContext(ctx, &count, query, from, to); err != nil {
+		return 0, fmt.Errorf("failed to count users by date range: %w", err)
+	}
+	return int64(count), nil
+}
+
+// CountByUsernamePrefix 统计用户名以 prefix 开头的用户数
+func (r *UserRepository) CountByUsernamePrefix(ctx context.Context, prefix string) (int64, error) {
+	var count int
+	query := countUsers(activeRows, r.dialect.LikePrefix("username_canonical"))
+	if err := r.db.GetContext(ctx, &count, query, EscapeLike(user.CanonicalUsername(prefix))); err != nil {
+		return 0, fmt.Errorf("failed to count users by username prefix: %w", err)
+	}
+	return int64(count), nil
+}
+
+// CountDeleted 统计已软删除的用户数
+func (r *UserRepository) CountDeleted(ctx context.Context) (int64, error) {
+	var count int
+	query := countUsers(deletedRows, "")
+	if err := r.db.GetContext(ctx, &count, query); err != nil {
+		return 0, fmt.Errorf("failed to count deleted users: %w", err)
+	}
+	return int64(count), nil
+}
+
 // ==================== 存储操作实现 ====================
 
 // Save 保存用户（新增或更新）
@@ -169,74 +278,295 @@ func (r *UserRepository) Save(ctx context.Context, entity user.UserEntity) error
 }
 
 // insert 插入新用户
+//
+// 用户名、邮箱的占用检查与写入之间存在并发窗口，同时注册时由唯一索引拒绝，
+// 此时按冲突的索引返回 ErrUsernameTaken 或 ErrEmailAlreadyExists。
 func (r *UserRepository) insert(ctx context.Context, entity user.UserEntity) error {
 	query := `
 		INSERT INTO users (
-			username, email, password_hash, avatar_url, status, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			username, username_canonical, email, password_hash, avatar_url, timezone, status, role, email_verified, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		entity.GetUsername(),
+		user.CanonicalUsername(entity.GetUsername()),
 		entity.GetEmail(),
 		entity.GetPasswordHash(),
 		entity.GetAvatarURL(),
+		entity.GetTimezone(),
 		string(entity.GetStatus()),
+		string(entity.GetRole()),
+		entity.IsEmailVerified(),
 		entity.GetCreatedAt(),
 		entity.GetUpdatedAt(),
 	)
 	if err != nil {
+		if r.dialect.IsDuplicateKey(err) {
+			return fmt.Errorf("failed to insert user: %w", duplicateUserError(err))
+		}
 		return fmt.Errorf("failed to insert user: %w", err)
 	}
 	return nil
 }
 
+// duplicateUserError 将新增用户时的唯一键冲突转换为业务错误
+//
+// 无法确定冲突索引时（如 SQLite 的错误信息只包含列名）返回 ErrUserAlreadyExists。
+func duplicateUserError(err error) error {
+	switch duplicateKeyName(err) {
+	case "uk_username", "uk_username_canonical":
+		return user.ErrUsernameTaken
+	case "uk_email":
+		return user.ErrEmailAlreadyExists
+	default:
+		return user.ErrUserAlreadyExists
+	}
+}
+
 // update 更新用户
 func (r *UserRepository) update(ctx context.Context, entity user.UserEntity) error {
+	_, err := r.db.ExecContext(ctx, updateUserQuery, updateUserArgs(entity)...)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// RecordFailedLogin 在事务中锁定用户行，按最新的失败记录累加一次登录失败
+//
+// 并发的失败登录依次读取和写入，计数不会互相覆盖；只更新失败计数、最近失败时间、锁定截止时间和更新时间，
+// 不覆盖同时发生的其他修改。遇到死锁或锁等待超时时重试。
+func (r *UserRepository) RecordFailedLogin(ctx context.Context, id int64, now time.Time, policy user.LockoutPolicy) (bool, error) {
+	var locked bool
+	err := r.tx.TransactionWithRetry(ctx, writeLockRetries, func(tx *Tx) error {
+		var u do.User
+		if err := tx.QueryOne(ctx, &u, selectUsers(activeRows, "id = ?", "")+r.dialect.ForUpdate(), id); err != nil {
+			return r.handleNotFoundError(err, "id", id)
+		}
+		entity := r.toEntity(&u)
+		locked = entity.RecordFailedLogin(now, policy)
+
+		_, err := tx.Exec(ctx, `
+			UPDATE users SET
+				failed_login_attempts = ?,
+				last_failed_login_at = ?,
+				locked_until = ?,
+				updated_at = ?
+			WHERE id = ?
+		`,
+			entity.GetFailedLoginAttempts(),
+			nullableTime(entity.GetLastFailedLoginAt()),
+			nullableTime(entity.GetLockedUntil()),
+			entity.GetUpdatedAt(),
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record failed login for user %d: %w", id, err)
+		}
+		return nil
+	})
+	return locked, err
+}
+
+// UpdateStatusBatch 在同一事务中更新多个已存在用户的状态
+//
+// 逐个锁定并只更新 status 和 updated_at，实体读取后其他请求写入的字段（如登录失败计数、头像）不会被旧值覆盖。
+// 任一用户不存在（含已软删除）或更新失败时整体回滚。
+func (r *UserRepository) UpdateStatusBatch(ctx context.Context, entities []user.UserEntity) error {
+	return r.tx.Transaction(ctx, func(tx *Tx) error {
+		for _, entity := range entities {
+			count, err := tx.Count(ctx,
+				countUsers(activeRows, "id = ?")+r.dialect.ForUpdate(),
+				entity.GetID(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to lock user %d: %w", entity.GetID(), err)
+			}
+			if count == 0 {
+				return fmt.Errorf("user not found by id %d: %w", entity.GetID(), user.ErrUserNotFound)
+			}
+
+			if _, err := tx.Exec(ctx,
+				`UPDATE users SET status = ?, updated_at = ? WHERE id = ?`,
+				string(entity.GetStatus()), entity.GetUpdatedAt(), entity.GetID(),
+			); err != nil {
+				return fmt.Errorf("failed to update user %d status: %w", entity.GetID(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// InsertBatch 在同一事务中新增多个用户
+//
+// 使用 Dialect.IgnoreDuplicate 使用户名或邮箱冲突的行不报错，影响行数为 0 的用户视为已存在并跳过；
+// strict 为 true 时遇到冲突立即返回 ErrUserAlreadyExists，整个事务回滚。
+func (r *UserRepository) InsertBatch(ctx context.Context, entities []user.UserEntity, strict bool) ([]user.UserEntity, error) {
 	query := `
-		UPDATE users SET
-			username = ?,
-			email = ?,
-			password_hash = ?,
-			avatar_url = ?,
-			status = ?,
-			updated_at = ?
-		WHERE id = ? AND deleted_at IS NULL
-	`
-	_, err := r.db.ExecContext(ctx, query,
+		INSERT INTO users (
+			username, username_canonical, email, password_hash, avatar_url, timezone, status, role, email_verified, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	` + r.dialect.IgnoreDuplicate()
+
+	var skipped []user.UserEntity
+	err := r.tx.Transaction(ctx, func(tx *Tx) error {
+		for _, entity := range entities {
+			affected, err := tx.ExecWithAffected(ctx, query,
+				entity.GetUsername(),
+				user.CanonicalUsername(entity.GetUsername()),
+				entity.GetEmail(),
+				entity.GetPasswordHash(),
+				entity.GetAvatarURL(),
+				entity.GetTimezone(),
+				string(entity.GetStatus()),
+				string(entity.GetRole()),
+				entity.IsEmailVerified(),
+				entity.GetCreatedAt(),
+				entity.GetUpdatedAt(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert user %s: %w", entity.GetUsername(), err)
+			}
+			if affected == 0 {
+				if strict {
+					return fmt.Errorf("failed to insert user %s: %w", entity.GetUsername(), user.ErrUserAlreadyExists)
+				}
+				skipped = append(skipped, entity)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
+// updateUserQuery 按 ID 更新未删除用户的全部可变字段
+const updateUserQuery = `
+	UPDATE users SET
+		username = ?,
+		username_canonical = ?,
+		email = ?,
+		password_hash = ?,
+		avatar_url = ?,
+		timezone = ?,
+		status = ?,
+		email_verified = ?,
+		pending_email = ?,
+		failed_login_attempts = ?,
+		last_failed_login_at = ?,
+		locked_until = ?,
+		username_changed_at = ?,
+		updated_at = ?
+	WHERE id = ? AND ` + notDeletedCondition
+
+// updateUserArgs 返回 updateUserQuery 的参数
+func updateUserArgs(entity user.UserEntity) []interface{} {
+	return []interface{}{
 		entity.GetUsername(),
+		user.CanonicalUsername(entity.GetUsername()),
 		entity.GetEmail(),
 		entity.GetPasswordHash(),
 		entity.GetAvatarURL(),
+		entity.GetTimezone(),
 		string(entity.GetStatus()),
+		entity.IsEmailVerified(),
+		entity.GetPendingEmail(),
+		entity.GetFailedLoginAttempts(),
+		nullableTime(entity.GetLastFailedLoginAt()),
+		nullableTime(entity.GetLockedUntil()),
+		nullableTime(entity.GetUsernameChangedAt()),
 		entity.GetUpdatedAt(),
 		entity.GetID(),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
 	}
-	return nil
 }
 
 // Delete 删除用户（硬删除）
+//
+// 用户的每日笔记在同一事务中一并删除。
 func (r *UserRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM users WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
-	}
-	return nil
+	return r.tx.Transaction(ctx, func(tx *Tx) error {
+		if _, err := deleteDailyNotesByUserID(ctx, tx, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM users WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		return nil
+	})
 }
 
 // SoftDelete 软删除用户
 func (r *UserRepository) SoftDelete(ctx context.Context, id int64) error {
-	query := `UPDATE users SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query, id)
+	query := `UPDATE users SET deleted_at = ? WHERE id = ? AND ` + notDeletedCondition
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete user: %w", err)
 	}
 	return nil
 }
 
+// Restore 恢复软删除的用户
+//
+// 用户名或邮箱与其他账户发生唯一键冲突时返回 ErrUserRestoreConflict。
+func (r *UserRepository) Restore(ctx context.Context, id int64) error {
+	query := `UPDATE users SET deleted_at = NULL WHERE id = ? AND ` + deletedCondition
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		if r.dialect.IsDuplicateKey(err) {
+			return fmt.Errorf("failed to restore user %d: %w", id, user.ErrUserRestoreConflict)
+		}
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("deleted user not found by id %d: %w", id, user.ErrUserNotFound)
+	}
+	return nil
+}
+
+// PurgeSoftDeletedUsers 永久删除软删除时间早于 olderThan 之前的用户
+//
+// 在同一事务中逐个删除这些用户的每日笔记（标签随笔记级联删除）和用户本身；审计日志保留。
+// 任一语句失败时整体回滚，不会留下没有笔记的用户或没有用户的笔记。
+func (r *UserRepository) PurgeSoftDeletedUsers(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan <= 0 {
+		return 0, fmt.Errorf("invalid purge retention %s: must be positive", olderThan)
+	}
+	cutoff := time.Now().Add(-olderThan)
+
+	var purged int64
+	err := r.tx.Transaction(ctx, func(tx *Tx) error {
+		var ids []int64
+		query := buildSelect("id", "users", deletedRows, "deleted_at < ?", "ORDER BY id") + r.dialect.ForUpdate()
+		if err := tx.Query(ctx, &ids, query, cutoff); err != nil {
+			return fmt.Errorf("failed to find deleted users to purge: %w", err)
+		}
+
+		for _, id := range ids {
+			if _, err := deleteDailyNotesByUserID(ctx, tx, id); err != nil {
+				return err
+			}
+			affected, err := tx.ExecWithAffected(ctx, `DELETE FROM users WHERE id = ? AND `+deletedCondition, id)
+			if err != nil {
+				return fmt.Errorf("failed to purge deleted user: %w", err)
+			}
+			purged += affected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
 // ==================== 辅助方法 ====================
 
 // toEntity 将 DO 转换为领域实体
@@ -248,9 +578,17 @@ func (r *UserRepository) toEntity(u *do.User) user.UserEntity {
 		u.Email,
 		u.PasswordHash,
 		u.AvatarURL,
+		u.Timezone,
 		status,
+		u.EmailVerified,
+		u.PendingEmail,
+		u.FailedLoginAttempts,
+		timeOrZero(u.LastFailedLoginAt),
+		timeOrZero(u.LockedUntil),
+		timeOrZero(u.UsernameChangedAt),
 		u.CreatedAt,
 		u.UpdatedAt,
+		user.UserRole(u.Role),
 	)
 }
 
@@ -263,6 +601,22 @@ func (r *UserRepository) toEntities(users []do.User) []user.UserEntity {
 	return entities
 }
 
+// nullableTime 将零值时间转换为 NULL
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// timeOrZero 将 NULL 时间转换为零值
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
 // handleNotFoundError 处理查询未找到错误。
 //
 // 使用 errors.Is 检查 sql.ErrNoRows，而不是字符串比较。
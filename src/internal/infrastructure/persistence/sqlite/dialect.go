@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"strings"
+
+	"todolist/internal/infrastructure/persistence/mysql"
+)
+
+// Dialect SQLite 方言
+var Dialect mysql.Dialect = sqliteDialect{}
+
+// sqliteDialect SQLite 方言实现
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "sqlite3"
+}
+
+// ForUpdate SQLite 不支持行锁，写事务本身串行执行
+func (sqliteDialect) ForUpdate() string {
+	return ""
+}
+
+func (sqliteDialect) IgnoreDuplicate() string {
+	return " ON CONFLICT DO NOTHING"
+}
+
+// LikePrefix SQLite 没有默认转义符，需显式声明 ESCAPE
+func (sqliteDialect) LikePrefix(column string) string {
+	return column + ` LIKE ? || '%' ESCAPE '\'`
+}
+
+// CharLength SQLite 的 LENGTH 对文本按字符计算
+func (sqliteDialect) CharLength(column string) string {
+	return "LENGTH(" + column + ")"
+}
+
+// IsDuplicateKey 按错误信息判断，未启用 cgo 编译时驱动不提供 sqlite3.Error 类型
+func (sqliteDialect) IsDuplicateKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
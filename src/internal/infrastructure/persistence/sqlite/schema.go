@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// schema SQLite 表结构
+//
+// 对应 migrations 包全部迁移执行后的 MySQL 表结构（deployments/db/init/01-init-database.sql），
+// 新增迁移时需同步修改。与 MySQL 的差异：
+//   - 自增主键使用 INTEGER PRIMARY KEY AUTOINCREMENT
+//   - username、email 使用 NOCASE 排序规则，对应 MySQL utf8mb4_unicode_ci 下不区分大小写的唯一索引
+//   - updated_at 没有 ON UPDATE，由仓储在更新时写入
+//   - 索引名在整个数据库内唯一，因此带表名前缀
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS users (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		username VARCHAR(50) NOT NULL COLLATE NOCASE,
+		username_canonical VARCHAR(50) NOT NULL,
+		email VARCHAR(255) NOT NULL COLLATE NOCASE,
+		password_hash VARCHAR(255) NOT NULL,
+		avatar_url VARCHAR(500) DEFAULT '',
+		timezone VARCHAR(64) NOT NULL DEFAULT '',
+		status VARCHAR(20) NOT NULL DEFAULT 'active',
+		role VARCHAR(20) NOT NULL DEFAULT 'user',
+		email_verified BOOLEAN NOT NULL DEFAULT 0,
+		pending_email VARCHAR(255) NOT NULL DEFAULT '',
+		failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+		last_failed_login_at DATETIME DEFAULT NULL,
+		locked_until DATETIME DEFAULT NULL,
+		username_changed_at DATETIME DEFAULT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME DEFAULT NULL,
+		CONSTRAINT uk_username UNIQUE (username),
+		CONSTRAINT uk_username_canonical UNIQUE (username_canonical),
+		CONSTRAINT uk_email UNIQUE (email)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_users_status ON users (status)`,
+	`CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at)`,
+
+	`CREATE TABLE IF NOT EXISTS daily_notes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		note_date DATE NOT NULL,
+		content TEXT NOT NULL,
+		version INTEGER NOT NULL DEFAULT 1,
+		is_pinned BOOLEAN NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		CONSTRAINT uk_user_date UNIQUE (user_id, note_date),
+		CONSTRAINT fk_daily_notes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_daily_notes_note_date ON daily_notes (note_date)`,
+
+	`CREATE TABLE IF NOT EXISTS daily_note_tags (
+		note_id INTEGER NOT NULL,
+		tag VARCHAR(32) NOT NULL,
+		PRIMARY KEY (note_id, tag),
+		CONSTRAINT fk_daily_note_tags_note FOREIGN KEY (note_id) REFERENCES daily_notes (id) ON DELETE CASCADE
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_daily_note_tags_tag_note ON daily_note_tags (tag, note_id)`,
+
+	`CREATE TABLE IF NOT EXISTS audit_logs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		actor_id INTEGER NOT NULL DEFAULT 0,
+		action VARCHAR(50) NOT NULL,
+		metadata TEXT DEFAULT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_logs_user_created ON audit_logs (user_id, created_at)`,
+}
+
+// createSchema 创建表结构，已存在的表和索引保持不变
+func createSchema(ctx context.Context, db *sqlx.DB) error {
+	for _, stmt := range schema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create sqlite schema: %w", err)
+		}
+	}
+	return nil
+}
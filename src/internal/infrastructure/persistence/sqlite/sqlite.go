@@ -0,0 +1,46 @@
+// Package sqlite 提供基于 SQLite 的数据库客户端，用于本地开发和测试。
+//
+// 仓储实现复用 mysql 包，本包只提供 SQLite 方言和对应的表结构，
+// 通过 persistence.NewClientFactory 接入后无需运行 MySQL 实例。
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3" // 导入SQLite驱动
+
+	"todolist/internal/infrastructure/persistence/mysql"
+)
+
+// MemoryPath 内存数据库路径，数据随客户端关闭而销毁
+const MemoryPath = ":memory:"
+
+// Open 打开 SQLite 数据库并创建表结构，返回可供仓储使用的客户端
+//
+// 参数：
+//
+//	path - 数据库文件路径，MemoryPath 表示内存数据库
+func Open(path string) (*mysql.Client, error) {
+	// _loc=auto 使时间按本地时区读取，与 MySQL DSN 的 loc=Local 一致
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=on&_loc=auto&_busy_timeout=5000", path)
+	db, err := sqlx.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite: %w", err)
+	}
+
+	// SQLite 写操作本身串行执行，使用单连接避免 database is locked，
+	// 同时保证内存数据库在各查询之间共享（每个连接各自拥有独立的内存数据库）
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := createSchema(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return mysql.NewClientWithDB(db, Dialect), nil
+}
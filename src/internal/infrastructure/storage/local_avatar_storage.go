@@ -0,0 +1,60 @@
+// Package storage 提供文件存储的基础设施实现。
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"todolist/internal/domain/user"
+)
+
+// LocalAvatarStorage 本地文件系统头像存储
+// 实现 user.AvatarStorage 接口，文件写入 dir 目录，通过 baseURL 对外访问
+type LocalAvatarStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalAvatarStorage 创建本地文件系统头像存储
+//
+// 参数：
+//
+//	dir - 头像文件存储目录，不存在时自动创建
+//	baseURL - 头像文件对外访问的 URL 前缀
+func NewLocalAvatarStorage(dir, baseURL string) *LocalAvatarStorage {
+	return &LocalAvatarStorage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Save 保存头像文件，返回头像 URL
+//
+// 文件名由用户 ID 和随机串组成，避免覆盖和被猜测。
+func (s *LocalAvatarStorage) Save(ctx context.Context, userID int64, contentType string, data []byte) (string, error) {
+	ext, ok := user.AvatarExtension(contentType)
+	if !ok {
+		return "", user.ErrAvatarTypeUnsupported
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create avatar directory: %w", err)
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate avatar file name: %w", err)
+	}
+	name := fmt.Sprintf("%d-%s%s", userID, hex.EncodeToString(suffix), ext)
+
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write avatar file: %w", err)
+	}
+
+	return s.baseURL + "/" + name, nil
+}
@@ -0,0 +1,18 @@
+package do
+
+import "time"
+
+// AuditLog 审计日志数据对象，对应 audit_logs 表
+type AuditLog struct {
+	ID        int64     `db:"id" json:"id"`
+	UserID    int64     `db:"user_id" json:"user_id"`
+	ActorID   int64     `db:"actor_id" json:"actor_id"`
+	Action    string    `db:"action" json:"action"`
+	Metadata  []byte    `db:"metadata" json:"metadata"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
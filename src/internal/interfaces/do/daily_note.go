@@ -10,9 +10,29 @@ type DailyNote struct {
 	Content   string    `db:"content" json:"content"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	Version   int       `db:"version" json:"version"`
+	IsPinned  bool      `db:"is_pinned" json:"is_pinned"`
 }
 
 // TableName 指定表名
 func (DailyNote) TableName() string {
 	return "daily_notes"
 }
+
+// DailyNoteCalendarDay 每日笔记月历数据对象，对应月历查询的统计结果
+type DailyNoteCalendarDay struct {
+	NoteDate   time.Time `db:"note_date" json:"note_date"`
+	HasContent bool      `db:"has_content" json:"has_content"`
+	CharCount  int       `db:"char_count" json:"char_count"`
+}
+
+// DailyNoteTag 每日笔记标签数据对象，对应 daily_note_tags 表
+type DailyNoteTag struct {
+	NoteID int64  `db:"note_id" json:"note_id"`
+	Tag    string `db:"tag" json:"tag"`
+}
+
+// TableName 指定表名
+func (DailyNoteTag) TableName() string {
+	return "daily_note_tags"
+}
@@ -4,15 +4,26 @@ import "time"
 
 // User 用户数据对象，对应 users 表
 type User struct {
-	ID           int64      `db:"id" json:"id"`
-	Username     string     `db:"username" json:"username"`
-	Email        string     `db:"email" json:"email"`
-	PasswordHash string     `db:"password_hash" json:"-"`
-	AvatarURL    string     `db:"avatar_url" json:"avatar_url"`
-	Status       string     `db:"status" json:"status"`
-	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	ID            int64      `db:"id" json:"id"`
+	Username      string     `db:"username" json:"username"`
+	Email         string     `db:"email" json:"email"`
+	PasswordHash  string     `db:"password_hash" json:"-"`
+	AvatarURL     string     `db:"avatar_url" json:"avatar_url"`
+	Timezone      string     `db:"timezone" json:"timezone"`
+	Status        string     `db:"status" json:"status"`
+	Role          string     `db:"role" json:"role"`
+	EmailVerified bool       `db:"email_verified" json:"email_verified"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+
+	FailedLoginAttempts int        `db:"failed_login_attempts" json:"-"`
+	LastFailedLoginAt   *time.Time `db:"last_failed_login_at" json:"-"`
+	LockedUntil         *time.Time `db:"locked_until" json:"-"`
+
+	UsernameChangedAt *time.Time `db:"username_changed_at" json:"-"`
+
+	PendingEmail string `db:"pending_email" json:"-"`
 }
 
 // TableName 指定表名
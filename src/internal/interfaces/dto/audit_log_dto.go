@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"todolist/internal/domain/audit"
+)
+
+// AuditLogDTO 审计日志数据传输对象
+type AuditLogDTO struct {
+	// ID 日志唯一标识
+	ID int64 `json:"id"`
+
+	// UserID 被操作的用户ID
+	UserID int64 `json:"user_id"`
+
+	// ActorID 操作者用户ID，0 表示未知
+	ActorID int64 `json:"actor_id"`
+
+	// Action 操作类型
+	Action string `json:"action"`
+
+	// Metadata 附加信息
+	Metadata map[string]string `json:"metadata"`
+
+	// CreatedAt 操作时间
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditLogPageDTO 审计日志分页结果数据传输对象
+type AuditLogPageDTO = Page[AuditLogDTO]
+
+// ToAuditLogDTO 将审计日志条目转换为DTO
+func ToAuditLogDTO(entry audit.Entry) AuditLogDTO {
+	return AuditLogDTO{
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+		ActorID:   entry.ActorID,
+		Action:    string(entry.Action),
+		Metadata:  entry.Metadata,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+// ToAuditLogPageDTO 将审计日志条目列表转换为分页DTO
+func ToAuditLogPageDTO(entries []audit.Entry, total int64, page, pageSize int) AuditLogPageDTO {
+	dtos := make([]AuditLogDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = ToAuditLogDTO(entry)
+	}
+	return NewPage(dtos, total, page, pageSize)
+}
@@ -14,8 +14,8 @@ type DailyNoteDTO struct {
 	// UserID 用户ID
 	UserID int64 `json:"user_id"`
 
-	// NoteDate 笔记日期
-	NoteDate time.Time `json:"note_date"`
+	// NoteDate 笔记日期（YYYY-MM-DD）
+	NoteDate Date `json:"note_date"`
 
 	// Content 笔记内容
 	Content string `json:"content"`
@@ -25,50 +25,109 @@ type DailyNoteDTO struct {
 
 	// UpdatedAt 最后更新时间
 	UpdatedAt time.Time `json:"updated_at"`
-}
 
-// PaginationDTO 分页信息数据传输对象
-type PaginationDTO struct {
-	// Total 总记录数
-	Total int64 `json:"total"`
+	// Version 版本号，更新时用于乐观锁校验
+	Version int `json:"version"`
+
+	// IsPinned 是否置顶
+	IsPinned bool `json:"is_pinned"`
 
-	// Page 当前页码
-	Page int `json:"page"`
+	// Tags 标签（小写，按字典序排列）
+	Tags []string `json:"tags"`
 
-	// PageSize 每页大小
-	PageSize int `json:"page_size"`
+	// WordCount 内容词数（按空白分隔）
+	WordCount int `json:"word_count"`
 
-	// TotalPages 总页数
-	TotalPages int `json:"total_pages"`
+	// CharCount 内容字符数（按 Unicode 字符计算）
+	CharCount int `json:"char_count"`
 }
 
 // DailyNotePageDTO 每日笔记分页结果数据传输对象
-type DailyNotePageDTO struct {
-	// Data 每日笔记列表
-	Data []DailyNoteDTO `json:"data"`
+type DailyNotePageDTO = Page[DailyNoteDTO]
+
+// DailyNoteCursorPageDTO 每日笔记游标分页结果数据传输对象
+type DailyNoteCursorPageDTO = CursorPage[DailyNoteDTO]
+
+// DailyNoteBatchResultDTO 批量导入每日笔记结果数据传输对象
+type DailyNoteBatchResultDTO struct {
+	// Created 成功创建的条数
+	Created int `json:"created"`
+
+	// Skipped 因当日已存在笔记而跳过的条数
+	Skipped int `json:"skipped"`
+
+	// SkippedDates 被跳过的日期（YYYY-MM-DD）
+	SkippedDates []string `json:"skipped_dates"`
+}
+
+// DailyNoteCalendarDayDTO 月历中有笔记的一天数据传输对象
+type DailyNoteCalendarDayDTO struct {
+	// Date 笔记日期（YYYY-MM-DD）
+	Date string `json:"date"`
+
+	// HasContent 笔记内容去除首尾空格后是否非空
+	HasContent bool `json:"has_content"`
+
+	// CharCount 内容字符数（按 Unicode 字符计算）
+	CharCount int `json:"char_count"`
+}
+
+// DailyNoteRenderDTO 每日笔记渲染结果数据传输对象
+type DailyNoteRenderDTO struct {
+	// ID 每日笔记唯一标识
+	ID int64 `json:"id"`
+
+	// NoteDate 笔记日期（YYYY-MM-DD）
+	NoteDate Date `json:"note_date"`
+
+	// Version 渲染时笔记的版本号
+	Version int `json:"version"`
+
+	// HTML 由 Markdown 内容渲染并经过安全过滤的 HTML
+	HTML string `json:"html"`
+}
+
+// DailyNoteStreakDTO 每日笔记连续天数统计数据传输对象
+type DailyNoteStreakDTO struct {
+	// CurrentStreak 当前连续天数
+	CurrentStreak int `json:"current_streak"`
+
+	// LongestStreak 历史最长连续天数
+	LongestStreak int `json:"longest_streak"`
 
-	// Pagination 分页信息
-	Pagination PaginationDTO `json:"pagination"`
+	// TotalDays 有笔记的总天数
+	TotalDays int `json:"total_days"`
 }
 
 // ToDailyNoteDTO 将每日笔记领域实体转换为DTO
 func ToDailyNoteDTO(entity daily_note.DailyNoteEntity) DailyNoteDTO {
+	// 没有标签时输出空数组而非 null
+	tags := entity.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
 	return DailyNoteDTO{
 		ID:        entity.GetID(),
 		UserID:    entity.GetUserID(),
-		NoteDate:  entity.GetNoteDate(),
+		NoteDate:  NewDate(entity.GetNoteDate()),
 		Content:   entity.GetContent(),
 		CreatedAt: entity.GetCreatedAt(),
 		UpdatedAt: entity.GetUpdatedAt(),
+		Version:   entity.GetVersion(),
+		IsPinned:  entity.IsPinned(),
+		Tags:      tags,
+		WordCount: entity.WordCount(),
+		CharCount: entity.CharCount(),
 	}
 }
 
 // ToDailyNotePageDTO 将每日笔记领域实体列表转换为分页DTO
+//
+// pageSize 非正数时按默认分页大小处理。
 func ToDailyNotePageDTO(entities []daily_note.DailyNoteEntity, total int64, page, pageSize int) DailyNotePageDTO {
-	// 计算总页数
-	totalPages := int(total) / pageSize
-	if int(total)%pageSize != 0 {
-		totalPages++
+	if pageSize < 1 {
+		pageSize = daily_note.DefaultPageSize
 	}
 
 	// 转换实体列表为DTO列表
@@ -77,13 +136,54 @@ func ToDailyNotePageDTO(entities []daily_note.DailyNoteEntity, total int64, page
 		dtos[i] = ToDailyNoteDTO(entity)
 	}
 
-	return DailyNotePageDTO{
-		Data: dtos,
-		Pagination: PaginationDTO{
-			Total:      total,
-			Page:       page,
-			PageSize:   pageSize,
-			TotalPages: totalPages,
-		},
+	return NewPage(dtos, total, page, pageSize)
+}
+
+// ToDailyNoteCursorPageDTO 将每日笔记领域实体列表和下一页游标转换为游标分页DTO
+func ToDailyNoteCursorPageDTO(entities []daily_note.DailyNoteEntity, next time.Time) DailyNoteCursorPageDTO {
+	dtos := make([]DailyNoteDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = ToDailyNoteDTO(entity)
+	}
+
+	return DailyNoteCursorPageDTO{
+		Data:       dtos,
+		NextCursor: daily_note.FormatCursor(next),
+	}
+}
+
+// ToDailyNoteBatchResultDTO 将批量导入结果转换为DTO
+func ToDailyNoteBatchResultDTO(result daily_note.BatchCreateResult) DailyNoteBatchResultDTO {
+	skippedDates := make([]string, len(result.Skipped))
+	for i, date := range result.Skipped {
+		skippedDates[i] = date.Format(daily_note.NoteDateLayout)
+	}
+
+	return DailyNoteBatchResultDTO{
+		Created:      len(result.Created),
+		Skipped:      len(result.Skipped),
+		SkippedDates: skippedDates,
+	}
+}
+
+// ToDailyNoteCalendarDTOs 将月历中有笔记的日期转换为DTO列表
+func ToDailyNoteCalendarDTOs(days []daily_note.CalendarDay) []DailyNoteCalendarDayDTO {
+	dtos := make([]DailyNoteCalendarDayDTO, len(days))
+	for i, day := range days {
+		dtos[i] = DailyNoteCalendarDayDTO{
+			Date:       day.Date.Format(daily_note.NoteDateLayout),
+			HasContent: day.HasContent,
+			CharCount:  day.CharCount,
+		}
+	}
+	return dtos
+}
+
+// ToDailyNoteStreakDTO 将连续天数统计转换为DTO
+func ToDailyNoteStreakDTO(streak daily_note.Streak) DailyNoteStreakDTO {
+	return DailyNoteStreakDTO{
+		CurrentStreak: streak.Current,
+		LongestStreak: streak.Longest,
+		TotalDays:     streak.TotalDays,
 	}
 }
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// DateLayout 日期在 JSON 中的格式
+const DateLayout = "2006-01-02"
+
+// Date 只有日期部分的时间，JSON 序列化为 YYYY-MM-DD
+//
+// 用于笔记日期等表示日历日的字段，避免按完整时间戳输出 2024-01-17T00:00:00Z。
+// 零值序列化为 null。
+type Date struct {
+	time.Time
+}
+
+// NewDate 返回 t 所在日历日的 Date，时间部分清零并统一为 UTC
+//
+// 按 t 自身时区取年月日，不做时区换算，避免日期因换算而偏移一天。
+func NewDate(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	y, m, d := t.Date()
+	return Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+// MarshalJSON 序列化为 "YYYY-MM-DD"
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(DateLayout) + `"`), nil
+}
+
+// UnmarshalJSON 解析 "YYYY-MM-DD"，兼容旧版本输出的 RFC 3339 时间戳（取其日期部分）
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("invalid date %s: must be a string", data)
+	}
+
+	value := string(data[1 : len(data)-1])
+	if t, err := time.Parse(DateLayout, value); err == nil {
+		*d = NewDate(t)
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	*d = NewDate(t)
+	return nil
+}
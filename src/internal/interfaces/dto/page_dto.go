@@ -0,0 +1,89 @@
+package dto
+
+// PaginationDTO 分页信息数据传输对象
+type PaginationDTO struct {
+	// Total 总记录数
+	Total int64 `json:"total"`
+
+	// Page 当前页码
+	Page int `json:"page"`
+
+	// PageSize 每页大小
+	PageSize int `json:"page_size"`
+
+	// TotalPages 总页数
+	TotalPages int `json:"total_pages"`
+
+	// HasNext 是否有下一页
+	HasNext bool `json:"has_next"`
+
+	// HasPrev 是否有上一页
+	HasPrev bool `json:"has_prev"`
+
+	// NextPage 下一页页码，没有下一页时为 nil
+	NextPage *int `json:"next_page"`
+
+	// PrevPage 上一页页码，没有上一页时为 nil
+	PrevPage *int `json:"prev_page"`
+}
+
+// Page 通用分页结果数据传输对象
+type Page[T any] struct {
+	// Data 当前页数据列表
+	Data []T `json:"data"`
+
+	// Pagination 分页信息
+	Pagination PaginationDTO `json:"pagination"`
+}
+
+// CursorPage 通用游标分页结果数据传输对象
+type CursorPage[T any] struct {
+	// Data 当前页数据列表
+	Data []T `json:"data"`
+
+	// NextCursor 下一页游标，为空表示没有更多数据
+	NextCursor string `json:"next_cursor"`
+}
+
+// NewPage 创建分页结果
+//
+// 统一计算总页数（向上取整），各资源的分页DTO均应通过此函数构造。
+func NewPage[T any](items []T, total int64, page, pageSize int) Page[T] {
+	return Page[T]{
+		Data:       items,
+		Pagination: NewPaginationDTO(total, page, pageSize),
+	}
+}
+
+// NewPaginationDTO 创建分页信息
+//
+// pageSize 非正数时无法计算总页数，TotalPages 返回 0。
+// page 超出末页时上一页指向末页，便于客户端回到有数据的页。
+func NewPaginationDTO(total int64, page, pageSize int) PaginationDTO {
+	// 计算总页数
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int(total) / pageSize
+		if int(total)%pageSize != 0 {
+			totalPages++
+		}
+	}
+
+	pagination := PaginationDTO{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+	if pagination.HasNext {
+		next := page + 1
+		pagination.NextPage = &next
+	}
+	if pagination.HasPrev {
+		prev := min(page-1, max(totalPages, 1))
+		pagination.PrevPage = &prev
+	}
+	return pagination
+}
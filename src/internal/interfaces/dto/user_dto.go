@@ -1,8 +1,10 @@
 package dto
 
 import (
+	"iter"
 	"time"
 
+	"todolist/internal/domain/daily_note"
 	"todolist/internal/domain/user"
 )
 
@@ -23,9 +25,18 @@ type UserDTO struct {
 	// AvatarURL 头像 URL
 	AvatarURL string
 
+	// Timezone 用户时区（IANA 名称），为空表示使用服务器默认时区
+	Timezone string
+
 	// Status 账户状态
 	Status string
 
+	// Role 用户角色，签发 Token 时写入角色声明
+	Role string
+
+	// EmailVerified 邮箱是否已验证
+	EmailVerified bool
+
 	// CreatedAt 账户创建时间
 	CreatedAt time.Time
 
@@ -45,12 +56,118 @@ type UserDTO struct {
 //   UserDTO - 用户数据传输对象
 func ToUserDTO(entity user.UserEntity) UserDTO {
 	return UserDTO{
+		ID:            entity.GetID(),
+		Username:      entity.GetUsername(),
+		Email:         entity.GetEmail(),
+		AvatarURL:     entity.GetAvatarURL(),
+		Timezone:      entity.GetTimezone(),
+		Status:        string(entity.GetStatus()),
+		Role:          string(entity.GetRole()),
+		EmailVerified: entity.IsEmailVerified(),
+		CreatedAt:     entity.GetCreatedAt(),
+		UpdatedAt:     entity.GetUpdatedAt(),
+	}
+}
+
+// UserPageDTO 用户分页结果数据传输对象
+type UserPageDTO = Page[UserDTO]
+
+// ToUserPageDTO 将用户领域实体列表转换为分页DTO
+func ToUserPageDTO(entities []user.UserEntity, total int64, page, pageSize int) UserPageDTO {
+	dtos := make([]UserDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = ToUserDTO(entity)
+	}
+
+	return NewPage(dtos, total, page, pageSize)
+}
+
+// UserSummaryDTO 用户首页概览数据传输对象
+type UserSummaryDTO struct {
+	// User 用户资料
+	User UserDTO
+
+	// TotalNotes 笔记总数
+	TotalNotes int64
+
+	// HasTodayNote 今天是否已写笔记
+	HasTodayNote bool
+
+	// CurrentStreak 当前连续天数
+	CurrentStreak int
+}
+
+// UserExportDTO 用户数据导出数据传输对象
+type UserExportDTO struct {
+	// ExportedAt 导出时间
+	ExportedAt time.Time
+
+	// User 用户资料
+	User UserDTO
+
+	// DailyNotes 按笔记日期降序排列的全部笔记
+	//
+	// 遍历时才分批查询，遍历出错时产出错误并结束。
+	DailyNotes iter.Seq2[DailyNoteDTO, error]
+}
+
+// PublicProfileDTO 用户公开资料数据传输对象
+//
+// 供查看其他用户使用，不包含邮箱、状态等非公开信息。
+type PublicProfileDTO struct {
+	// ID 用户唯一标识
+	ID int64
+
+	// Username 用户名
+	Username string
+
+	// AvatarURL 头像 URL
+	AvatarURL string
+}
+
+// ToPublicProfileDTO 将用户领域实体转换为公开资料DTO
+func ToPublicProfileDTO(entity user.UserEntity) PublicProfileDTO {
+	return PublicProfileDTO{
 		ID:        entity.GetID(),
 		Username:  entity.GetUsername(),
-		Email:     entity.GetEmail(),
 		AvatarURL: entity.GetAvatarURL(),
-		Status:    string(entity.GetStatus()),
-		CreatedAt: entity.GetCreatedAt(),
-		UpdatedAt: entity.GetUpdatedAt(),
+	}
+}
+
+// UserStatsDTO 用户数量统计数据传输对象
+type UserStatsDTO struct {
+	// Total 用户总数（不含已注销用户）
+	Total int64
+
+	// Active 活跃用户数
+	Active int64
+
+	// Inactive 未激活用户数
+	Inactive int64
+
+	// Banned 已封禁用户数
+	Banned int64
+}
+
+// ToUserStatsDTO 将各状态用户数转换为DTO，总数为各状态之和
+func ToUserStatsDTO(counts map[user.UserStatus]int64) UserStatsDTO {
+	stats := UserStatsDTO{
+		Active:   counts[user.UserStatusActive],
+		Inactive: counts[user.UserStatusInactive],
+		Banned:   counts[user.UserStatusBanned],
+	}
+	for _, count := range counts {
+		stats.Total += count
+	}
+	return stats
+}
+
+// ToUserSummaryDTO 将用户实体和笔记概览转换为DTO
+func ToUserSummaryDTO(entity user.UserEntity, summary daily_note.Summary) UserSummaryDTO {
+	return UserSummaryDTO{
+		User:          ToUserDTO(entity),
+		TotalNotes:    summary.TotalNotes,
+		HasTodayNote:  summary.HasTodayNote,
+		CurrentStreak: summary.Streak.Current,
 	}
 }
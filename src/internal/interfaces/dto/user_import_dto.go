@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"errors"
+
+	"todolist/internal/domain/user"
+	domainerr "todolist/internal/pkg/domainerr"
+)
+
+// UserImportRowDTO 批量导入用户单行结果数据传输对象
+type UserImportRowDTO struct {
+	// Index 在请求中的位置（从 0 开始）
+	Index int
+
+	// Username 输入的用户名
+	Username string
+
+	// Email 输入的邮箱
+	Email string
+
+	// Status 处理结果：created、skipped、error
+	Status string
+
+	// Code 跳过或失败原因的错误码，非业务错误或创建成功时为空
+	Code string
+
+	// Reason 跳过或失败的原因，创建成功时为空
+	Reason string
+}
+
+// UserImportResultDTO 批量导入用户结果数据传输对象
+type UserImportResultDTO struct {
+	// Created 成功创建的用户数
+	Created int
+
+	// Skipped 跳过的用户数
+	Skipped int
+
+	// Failed 校验失败的用户数
+	Failed int
+
+	// Rows 与请求顺序一致的逐行结果
+	Rows []UserImportRowDTO
+}
+
+// ToUserImportResultDTO 将批量导入逐行结果转换为DTO
+func ToUserImportResultDTO(results []user.ImportResult) UserImportResultDTO {
+	resultDTO := UserImportResultDTO{Rows: make([]UserImportRowDTO, len(results))}
+	for i, result := range results {
+		row := UserImportRowDTO{
+			Index:    i,
+			Username: result.Username,
+			Email:    result.Email,
+			Status:   string(result.Status),
+		}
+		if result.Err != nil {
+			var be domainerr.BusinessError
+			if errors.As(result.Err, &be) {
+				row.Code = be.Code
+			}
+			row.Reason = result.Err.Error()
+		}
+		resultDTO.Rows[i] = row
+
+		switch result.Status {
+		case user.ImportStatusCreated:
+			resultDTO.Created++
+		case user.ImportStatusSkipped:
+			resultDTO.Skipped++
+		case user.ImportStatusError:
+			resultDTO.Failed++
+		}
+	}
+	return resultDTO
+}
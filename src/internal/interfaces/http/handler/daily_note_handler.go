@@ -3,54 +3,149 @@ package handler
 import (
 	"context"
 	"errors"
+	"time"
 
-	"todolist/internal/interfaces/http/middleware"
 	request "todolist/internal/interfaces/http/request"
 	response "todolist/internal/interfaces/http/response"
 
 	dailynoteapp "todolist/internal/application/daily_note"
 	dailynote "todolist/internal/domain/daily_note"
-	"todolist/internal/infrastructure/persistence/mysql"
+	domainuser "todolist/internal/domain/user"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/infrastructure/eventbus"
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/pkg/contextx"
 )
 
 // CreateDailyNoteHandler 创建每日笔记处理器
-func CreateDailyNoteHandler(ctx context.Context, req request.DailyNoteRequest) (response.DailyNoteResponse, error) {
+//
+// 创建成功返回 201。
+func CreateDailyNoteHandler(ctx context.Context, req request.DailyNoteRequest) (response.Created[response.DailyNoteResponse], error) {
 	// 1. 初始化服务层
-	repo := mysql.NewDailyNoteRepository()
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.Created[response.DailyNoteResponse]{}, err
+	}
 	dailyNoteService := dailynote.NewService(repo)
-	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService, dailynoteapp.WithEventBus(eventbus.GetBus()))
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
-		return response.DailyNoteResponse{}, errors.New("unauthorized: invalid user context")
+		return response.Created[response.DailyNoteResponse]{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.Created[response.DailyNoteResponse]{}, err
 	}
 
 	// 3. 调用应用服务创建每日笔记
-	dailyNoteDTO, err := dailyNoteAppService.CreateDailyNote(ctx, user.UserID, req.Content)
+	dailyNoteDTO, err := dailyNoteAppService.CreateDailyNote(ctx, user.UserID, loc, req.Content)
 	if err != nil {
-		return response.DailyNoteResponse{}, err
+		return response.Created[response.DailyNoteResponse]{}, err
 	}
 
 	// 4. 转换为HTTP响应
-	return response.ToDailyNoteResponse(*dailyNoteDTO), nil
+	return response.NewCreated(response.ToDailyNoteResponse(*dailyNoteDTO)), nil
+}
+
+// CopyPreviousDailyNoteHandler 复制最近一篇历史笔记为今日笔记处理器
+//
+// 与创建今日笔记相同，成功返回 201。
+func CopyPreviousDailyNoteHandler(ctx context.Context, req request.EmptyRequest) (response.Created[response.DailyNoteResponse], error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.Created[response.DailyNoteResponse]{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService, dailynoteapp.WithEventBus(eventbus.GetBus()))
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.Created[response.DailyNoteResponse]{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.Created[response.DailyNoteResponse]{}, err
+	}
+
+	// 3. 调用应用服务复制历史笔记
+	dailyNoteDTO, err := dailyNoteAppService.CopyPreviousDayNote(ctx, user.UserID, loc)
+	if err != nil {
+		return response.Created[response.DailyNoteResponse]{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.NewCreated(response.ToDailyNoteResponse(*dailyNoteDTO)), nil
+}
+
+// BatchCreateDailyNotesHandler 批量导入每日笔记处理器
+//
+// 请求体为 JSON 数组，所有笔记在同一事务中写入，
+// 当日已存在笔记的条目会被跳过并在响应中返回。
+func BatchCreateDailyNotesHandler(ctx context.Context, req request.BatchCreateDailyNotesRequest) (response.DailyNoteBatchResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteBatchResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteBatchResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 转换请求参数
+	notes := make([]dailynoteapp.BatchNoteInput, len(req))
+	for i, item := range req {
+		notes[i] = dailynoteapp.BatchNoteInput{Date: item.Date, Content: item.Content}
+	}
+
+	// 4. 调用应用服务批量导入笔记
+	resultDTO, err := dailyNoteAppService.BatchCreateDailyNotes(ctx, user.UserID, notes)
+	if err != nil {
+		return response.DailyNoteBatchResponse{}, err
+	}
+
+	// 5. 转换为HTTP响应
+	return response.ToDailyNoteBatchResponse(*resultDTO), nil
 }
 
 // GetTodayDailyNoteHandler 获取今日的每日笔记处理器
+//
+// 响应带有 ETag，客户端通过 If-None-Match 轮询时笔记未变化返回 304。
 func GetTodayDailyNoteHandler(ctx context.Context, req request.EmptyRequest) (response.DailyNoteResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewDailyNoteRepository()
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
 	dailyNoteService := dailynote.NewService(repo)
 	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.DailyNoteResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+
 	// 3. 调用应用服务获取今日笔记
-	dailyNoteDTO, err := dailyNoteAppService.GetTodayDailyNote(ctx, user.UserID)
+	dailyNoteDTO, err := dailyNoteAppService.GetTodayDailyNote(ctx, user.UserID, loc)
 	if err != nil {
 		return response.DailyNoteResponse{}, err
 	}
@@ -60,48 +155,185 @@ func GetTodayDailyNoteHandler(ctx context.Context, req request.EmptyRequest) (re
 }
 
 // GetDailyNoteListHandler 分页获取每日笔记列表处理器
-func GetDailyNoteListHandler(ctx context.Context, req request.EmptyRequest) (response.DailyNoteListResponse, error) {
+//
+// 从查询参数 page、page_size、sort 读取分页和排序方式，
+// 排序方式不在允许列表中时返回 400。
+func GetDailyNoteListHandler(ctx context.Context, req request.DailyNoteListRequest) (response.DailyNoteListResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewDailyNoteRepository()
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteListResponse{}, err
+	}
 	dailyNoteService := dailynote.NewService(repo)
 	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.DailyNoteListResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
-	// 3. 设置默认分页参数
-	// 注意：当前实现不支持从查询参数获取page和pageSize，使用默认值
-	page := 1
-	pageSize := 10
+	// 3. 调用应用服务获取笔记列表
+	dailyNotePageDTO, err := dailyNoteAppService.GetDailyNoteList(ctx, user.UserID, req.Sort, req.Page, req.PageSize)
+	if err != nil {
+		return response.DailyNoteListResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteListResponse(*dailyNotePageDTO), nil
+}
+
+// GetDailyNoteListByCursorHandler 游标分页获取每日笔记列表处理器
+//
+// 从查询参数 cursor、limit 读取游标和每页大小，按笔记日期降序返回，
+// 响应中的 next_cursor 作为下一页的 cursor，为空表示没有更多数据。
+// 查询参数 tag 非空时只返回带有该标签的笔记，翻页时需携带相同的 tag。
+func GetDailyNoteListByCursorHandler(ctx context.Context, req request.DailyNoteCursorRequest) (response.DailyNoteCursorListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteCursorListResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteCursorListResponse{}, errors.New("unauthorized: invalid user context")
+	}
 
-	// 4. 调用应用服务获取笔记列表
-	dailyNotePageDTO, err := dailyNoteAppService.GetDailyNoteList(ctx, user.UserID, page, pageSize)
+	// 3. 调用应用服务按游标获取笔记列表
+	cursorPageDTO, err := dailyNoteAppService.GetDailyNoteListByCursor(ctx, user.UserID, req.Tag, req.Cursor, req.Limit)
+	if err != nil {
+		return response.DailyNoteCursorListResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteCursorListResponse(*cursorPageDTO), nil
+}
+
+// GetDailyNoteListByRangeHandler 按日期区间分页获取每日笔记列表处理器
+//
+// 从查询参数 from、to（YYYY-MM-DD）读取日期区间，
+// 日期格式无效或开始日期晚于结束日期时返回 400。
+func GetDailyNoteListByRangeHandler(ctx context.Context, req request.DailyNoteRangeRequest) (response.DailyNoteListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
 	if err != nil {
 		return response.DailyNoteListResponse{}, err
 	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
 
-	// 5. 转换为HTTP响应
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteListResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务按日期区间获取笔记列表
+	dailyNotePageDTO, err := dailyNoteAppService.GetDailyNoteListByRange(ctx, user.UserID, req.From, req.To, req.Page, req.PageSize)
+	if err != nil {
+		return response.DailyNoteListResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
 	return response.ToDailyNoteListResponse(*dailyNotePageDTO), nil
 }
 
+// GetDailyNoteCalendarHandler 获取每日笔记月历处理器
+//
+// 返回指定月份有笔记的日期及字数，不返回笔记内容。
+func GetDailyNoteCalendarHandler(ctx context.Context, req request.DailyNoteCalendarRequest) (response.DailyNoteCalendarResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteCalendarResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteCalendarResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 按用户时区确定当前月份
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.DailyNoteCalendarResponse{}, err
+	}
+
+	// 3. 调用应用服务获取月历
+	days, err := dailyNoteAppService.GetNotesForMonth(ctx, user.UserID, loc, req.Year, req.Month)
+	if err != nil {
+		return response.DailyNoteCalendarResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteCalendarResponse(days), nil
+}
+
+// GetDailyNoteStatsHandler 获取每日笔记统计处理器
+//
+// 返回当前连续天数、历史最长连续天数和有笔记的总天数。
+func GetDailyNoteStatsHandler(ctx context.Context, req request.EmptyRequest) (response.DailyNoteStatsResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteStatsResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteStatsResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.DailyNoteStatsResponse{}, err
+	}
+
+	// 3. 调用应用服务获取统计
+	streakDTO, err := dailyNoteAppService.GetStreak(ctx, user.UserID, loc)
+	if err != nil {
+		return response.DailyNoteStatsResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteStatsResponse(*streakDTO), nil
+}
+
 // UpdateDailyNoteHandler 更新今日的每日笔记处理器
 func UpdateDailyNoteHandler(ctx context.Context, req request.DailyNoteRequest) (response.DailyNoteResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewDailyNoteRepository()
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
 	dailyNoteService := dailynote.NewService(repo)
 	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.DailyNoteResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+
 	// 3. 调用应用服务更新今日笔记
-	dailyNoteDTO, err := dailyNoteAppService.UpdateDailyNote(ctx, user.UserID, req.Content)
+	dailyNoteDTO, err := dailyNoteAppService.UpdateDailyNote(ctx, user.UserID, loc, req.Content, req.Version)
 	if err != nil {
 		return response.DailyNoteResponse{}, err
 	}
@@ -113,18 +345,27 @@ func UpdateDailyNoteHandler(ctx context.Context, req request.DailyNoteRequest) (
 // DeleteDailyNoteHandler 删除今日的每日笔记处理器
 func DeleteDailyNoteHandler(ctx context.Context, req request.EmptyRequest) (response.MessageResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewDailyNoteRepository()
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
 	dailyNoteService := dailynote.NewService(repo)
 	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
+	// 按用户时区确定"今天"
+	loc, err := userLocation(ctx, user.UserID)
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+
 	// 3. 调用应用服务删除今日笔记
-	err := dailyNoteAppService.DeleteDailyNote(ctx, user.UserID)
+	err = dailyNoteAppService.DeleteDailyNote(ctx, user.UserID, loc)
 	if err != nil {
 		return response.MessageResponse{}, err
 	}
@@ -134,3 +375,163 @@ func DeleteDailyNoteHandler(ctx context.Context, req request.EmptyRequest) (resp
 		Message: "每日笔记删除成功",
 	}, nil
 }
+
+// DeleteDailyNoteByIDHandler 删除指定 ID 的每日笔记处理器
+//
+// 笔记 ID 通过路径参数 {id} 传递。笔记不存在或不属于当前用户时均返回 404。
+func DeleteDailyNoteByIDHandler(ctx context.Context, req request.DailyNoteIDRequest) (response.MessageResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务删除笔记
+	if err := dailyNoteAppService.DeleteDailyNoteByID(ctx, user.UserID, req.ID); err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	// 4. 返回成功消息
+	return response.MessageResponse{
+		Message: "每日笔记删除成功",
+	}, nil
+}
+
+// DeleteAllDailyNotesHandler 删除当前用户全部每日笔记处理器
+//
+// 请求体中 confirm 必须为 true，删除后不可恢复。
+func DeleteAllDailyNotesHandler(ctx context.Context, req request.DeleteAllDailyNotesRequest) (response.DailyNoteDeleteAllResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteDeleteAllResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteDeleteAllResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务删除全部笔记
+	deleted, err := dailyNoteAppService.DeleteAllDailyNotes(ctx, user.UserID, req.Confirm)
+	if err != nil {
+		return response.DailyNoteDeleteAllResponse{}, err
+	}
+
+	// 4. 返回删除的笔记数
+	return response.DailyNoteDeleteAllResponse{Deleted: deleted}, nil
+}
+
+// RenderDailyNoteHandler 将指定 ID 的每日笔记渲染为 HTML 处理器
+//
+// 笔记 ID 通过路径参数 {id} 传递。笔记不存在或不属于当前用户时均返回 404。
+// 渲染结果已过滤脚本、事件属性等不安全内容。
+func RenderDailyNoteHandler(ctx context.Context, req request.DailyNoteIDRequest) (response.DailyNoteRenderResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteRenderResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteRenderResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务渲染笔记
+	renderDTO, err := dailyNoteAppService.RenderDailyNote(ctx, user.UserID, req.ID)
+	if err != nil {
+		return response.DailyNoteRenderResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteRenderResponse(*renderDTO), nil
+}
+
+// PinDailyNoteHandler 置顶或取消置顶指定 ID 的每日笔记处理器
+//
+// 笔记 ID 通过路径参数 {id} 传递。笔记不存在或不属于当前用户时均返回 404。
+func PinDailyNoteHandler(ctx context.Context, req request.PinDailyNoteRequest) (response.DailyNoteResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务更新置顶状态
+	dailyNoteDTO, err := dailyNoteAppService.SetDailyNotePinned(ctx, user.UserID, req.ID, *req.Pinned)
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteResponse(*dailyNoteDTO), nil
+}
+
+// SetDailyNoteTagsHandler 设置指定 ID 的每日笔记标签处理器
+//
+// 笔记 ID 通过路径参数 {id} 传递，请求体中的 tags 整体替换已有标签。
+// 笔记不存在或不属于当前用户时均返回 404，标签数量或长度超出限制时返回 400。
+func SetDailyNoteTagsHandler(ctx context.Context, req request.SetDailyNoteTagsRequest) (response.DailyNoteResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(repo)
+	dailyNoteAppService := dailynoteapp.NewDailyNoteApplicationService(dailyNoteService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.DailyNoteResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务替换标签
+	dailyNoteDTO, err := dailyNoteAppService.SetDailyNoteTags(ctx, user.UserID, req.ID, req.Tags)
+	if err != nil {
+		return response.DailyNoteResponse{}, err
+	}
+
+	// 4. 转换为HTTP响应
+	return response.ToDailyNoteResponse(*dailyNoteDTO), nil
+}
+
+// userLocation 返回用户时区，用户未设置时区时使用 SERVER_TIMEZONE 配置的默认时区
+func userLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	tzCfg, err := config.GetTimezoneConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return nil, err
+	}
+	entity, err := repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return domainuser.ResolveLocation(entity.GetTimezone(), tzCfg.Location), nil
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+
+	"todolist/internal/interfaces/http/response"
+)
+
+// NotFoundHandler 返回未匹配路由时的处理器，输出统一 JSON 错误结构
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteJSON(w, http.StatusNotFound, response.BaseResponse[struct{}]{
+			Code:    http.StatusNotFound,
+			Message: "resource not found",
+		})
+	})
+}
+
+// MethodNotAllowedHandler 返回路径匹配但方法不被允许时的处理器，输出统一 JSON 错误结构
+//
+// Allow 响应头需由调用方在调用前设置。
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteJSON(w, http.StatusMethodNotAllowed, response.BaseResponse[struct{}]{
+			Code:    http.StatusMethodNotAllowed,
+			Message: "method not allowed",
+		})
+	})
+}
+
+// WithFallback 为 ServeMux 设置 JSON 格式的 404/405 兜底处理器
+//
+// ServeMux 未匹配路由时返回纯文本响应，此处先通过 mux.Handler 判断是否匹配，
+// 未匹配时执行 ServeMux 自带的兜底处理器以区分 404 与 405（并保留 Allow 响应头），
+// 再改为输出统一 JSON 错误结构。
+func WithFallback(mux *http.ServeMux) http.Handler {
+	notFound := NotFoundHandler()
+	methodNotAllowed := MethodNotAllowedHandler()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &fallbackRecorder{header: make(http.Header)}
+		h.ServeHTTP(rec, r)
+
+		switch rec.status {
+		case http.StatusNotFound:
+			notFound.ServeHTTP(w, r)
+		case http.StatusMethodNotAllowed:
+			if allow := rec.header.Get("Allow"); allow != "" {
+				w.Header().Set("Allow", allow)
+			}
+			methodNotAllowed.ServeHTTP(w, r)
+		default:
+			mux.ServeHTTP(w, r)
+		}
+	})
+}
+
+// fallbackRecorder 记录 ServeMux 兜底处理器的状态码和响应头，丢弃响应体
+type fallbackRecorder struct {
+	header http.Header
+	status int
+}
+
+func (r *fallbackRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *fallbackRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *fallbackRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return len(b), nil
+}
@@ -4,10 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/interfaces/http/middleware"
 	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
 )
 
 // HandlerFunc 定义业务处理函数类型
@@ -18,29 +27,144 @@ type HandlerFunc[Req any, Resp any] func(
 
 // Wrap 封装业务处理函数为 http.HandlerFunc
 // 支持泛型请求/响应类型，自动处理 JSON 编解码和错误处理
+// GET 请求的响应实现 response.ETagger 时支持条件请求（If-None-Match）
+// GET 请求的响应实现 response.FieldSelector 时支持 fields 查询参数，只返回指定的字段
+// 返回 response.Created[T] 时以 201 写出，其余成功响应为 200
+// 非 GET 请求带请求体时要求 Content-Type 为 application/json，否则返回 415
+// 带 path 标签的字段从路由模式中的同名通配段绑定（如 {id}），
+// 用于按 ID 操作资源的接口：DELETE /api/v1/daily-notes/{id}、POST /api/v1/admin/users/{id}/restore
+// 成功响应实现 response.Versioned 时按上下文中的 API 版本（Accept-Version）调整结构
+// 绑定完成后按 validate 标签做结构性校验，失败时返回 400 并在 data.errors 中列出每个字段的错误
 func Wrap[Req any, Resp any](h HandlerFunc[Req, Resp]) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req Req
 
 		// 解析请求体（非 GET 请求且有 body 时）
 		if r.Method != http.MethodGet && r.ContentLength > 0 {
+			if !isJSONContentType(r.Header.Get("Content-Type")) {
+				slog.Warn("unsupported content type", "content_type", r.Header.Get("Content-Type"), "path", r.URL.Path)
+				response.WriteJSON(w, http.StatusUnsupportedMediaType, response.BaseResponse[struct{}]{
+					Code:    http.StatusUnsupportedMediaType,
+					Message: "content type must be application/json",
+				})
+				return
+			}
 			if err := decodeJSON(r.Body, &req); err != nil {
 				slog.Warn("failed to decode request", "error", err, "path", r.URL.Path)
+				// 请求体超出 MaxBodyBytes 中间件设置的上限
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.WriteJSON(w, http.StatusRequestEntityTooLarge, response.BaseResponse[struct{}]{
+						Code:    http.StatusRequestEntityTooLarge,
+						Message: "request body too large",
+					})
+					return
+				}
 				response.WriteBadRequest(w, "invalid request body")
 				return
 			}
 		}
 
-		// 调用业务处理函数
-		resp, err := h(r.Context(), req)
+		// 解析查询参数（GET 请求，按 form 标签绑定）
+		if r.Method == http.MethodGet && len(r.URL.RawQuery) > 0 {
+			if err := decodeQuery(r.URL.Query(), &req); err != nil {
+				slog.Warn("failed to decode query", "error", err, "path", r.URL.Path)
+				response.WriteBadRequest(w, "invalid query parameters")
+				return
+			}
+		}
+
+		// 解析路径参数（按 path 标签绑定）
+		if err := decodePath(r, &req); err != nil {
+			slog.Warn("failed to decode path", "error", err, "path", r.URL.Path)
+			response.WriteBadRequest(w, "invalid path parameters")
+			return
+		}
+
+		// 按 validate 标签校验必填、长度、格式，领域值对象仍会做完整校验
+		if fieldErrs := validateRequest(req); fieldErrs != nil {
+			slog.Warn("request validation failed", "errors", fieldErrs, "path", r.URL.Path)
+			response.WriteValidationError(w, fieldErrs)
+			return
+		}
+
+		// 调用业务处理函数，客户端信息供记录登录会话等使用；
+		// 请求内写入数据库后，后续读操作切换到主库，避免读不到刚写入的数据
+		ctx := contextx.WithClient(persistence.WithReadYourWrites(r.Context()), contextx.ClientInfo{
+			IP:        middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		resp, err := h(ctx, req)
 		if err != nil {
-			slog.Error("handler error", "error", err, "path", r.URL.Path)
+			if response.IsRequestCanceled(err) {
+				slog.Info("handler canceled", "error", err, "path", r.URL.Path)
+			} else {
+				slog.Error("handler error", "error", err, "path", r.URL.Path)
+			}
 			response.WriteError(w, err)
 			return
 		}
 
-		response.WriteOK(w, resp)
+		// 条件请求：ETag 匹配时返回 304，不再发送响应体
+		if r.Method == http.MethodGet {
+			if tagger, ok := any(resp).(response.ETagger); ok {
+				etag := tagger.ETag()
+				w.Header().Set("ETag", etag)
+				if etagMatches(r.Header.Get("If-None-Match"), etag) {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+		}
+
+		// 按 fields 查询参数裁剪响应字段，未携带时返回完整响应
+		if r.Method == http.MethodGet {
+			if selector, ok := any(resp).(response.FieldSelector); ok {
+				if fields := response.ParseFields(r.URL.Query().Get("fields")); len(fields) > 0 {
+					projected, err := selector.SelectFields(fields)
+					if err != nil {
+						slog.Error("failed to select response fields", "error", err, "path", r.URL.Path)
+						response.WriteError(w, err)
+						return
+					}
+					response.WriteOK(w, projected)
+					return
+				}
+			}
+		}
+
+		// 按请求的 API 版本调整响应结构
+		version := contextx.GetAPIVersionFromContext(ctx)
+
+		// 创建资源的接口以 201 返回
+		if created, ok := any(resp).(response.CreatedResponse); ok {
+			response.WriteCreated(w, response.ForVersion(created.CreatedData(), version))
+			return
+		}
+
+		response.WriteOK(w, response.ForVersion(resp, version))
+	}
+}
+
+// etagMatches 判断 If-None-Match 请求头是否匹配 etag
+// 按 RFC 9110 使用弱比较，忽略 W/ 前缀；支持逗号分隔的多个值和 *
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
 	}
+	return false
+}
+
+// isJSONContentType 判断 Content-Type 是否为 application/json（忽略 charset 等参数）
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
 }
 
 // decodeJSON 解码 JSON 请求体
@@ -65,3 +189,96 @@ func decodeJSON(body io.ReadCloser, v any) error {
 
 	return nil
 }
+
+// decodeQuery 将查询参数按 form 标签绑定到结构体字段
+// 支持 string、bool、整数和浮点数类型，未出现的参数保持零值
+func decodeQuery(values url.Values, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+
+		if err := setField(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid query parameter %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// decodePath 将路由模式中的通配段按 path 标签绑定到结构体字段
+// 字段类型支持范围同 decodeQuery，路由模式中不存在的通配段保持零值
+func decodePath(r *http.Request, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := field.Tag.Get("path")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		raw := r.PathValue(name)
+		if raw == "" {
+			continue
+		}
+
+		if err := setField(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid path parameter %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField 将字符串值转换为字段类型并赋值
+func setField(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
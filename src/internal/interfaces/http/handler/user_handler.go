@@ -3,21 +3,38 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"todolist/internal/interfaces/dto"
 	"todolist/internal/interfaces/http/middleware"
 	request "todolist/internal/interfaces/http/request"
 	response "todolist/internal/interfaces/http/response"
 
 	"todolist/internal/application/user"
+	dailynote "todolist/internal/domain/daily_note"
 	appuser "todolist/internal/domain/user"
-	"todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/infrastructure/captcha"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/infrastructure/eventbus"
+	"todolist/internal/infrastructure/mail"
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/infrastructure/storage"
 	appauth "todolist/internal/pkg/auth"
+	"todolist/internal/pkg/contextx"
+	applogger "todolist/internal/pkg/logger"
 )
 
 func LoginUserHandler(ctx context.Context, req request.LoginUserRequest) (response.LoginResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewUserRepository()
-	hasher := appauth.NewHasher()
-	userService := appuser.NewService(repo, hasher)
+	userService, err := newLoginUserService()
+	if err != nil {
+		return response.LoginResponse{}, err
+	}
 	userAppService := user.NewUserApplicationService(userService)
 
 	// 2. 调用应用服务登录
@@ -26,18 +43,89 @@ func LoginUserHandler(ctx context.Context, req request.LoginUserRequest) (respon
 		return response.LoginResponse{}, err
 	}
 
-	token, err := middleware.GenerateToken(userDTO)
-	// 3. 返回登录响应
+	// 3. 签发 Token 并返回登录响应
+	return issueLoginResponse(ctx, userDTO, req.RememberMe)
+}
+
+// ReactivateAccountHandler 恢复停用账户处理器
+//
+// 校验邮箱和密码后将停用的账户恢复为 active，并与登录一样签发 Token。
+// 封禁的账户返回 403 ACCOUNT_BANNED，未停用的账户返回 409 ACCOUNT_NOT_INACTIVE。
+func ReactivateAccountHandler(ctx context.Context, req request.ReactivateAccountRequest) (response.LoginResponse, error) {
+	// 1. 初始化服务层
+	userService, err := newLoginUserService()
+	if err != nil {
+		return response.LoginResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.LoginResponse{}, err
+	}
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
+
+	// 2. 调用应用服务恢复账户
+	userDTO, err := userAppService.ReactivateAccount(ctx, req.Email, req.Password)
+	if err != nil {
+		return response.LoginResponse{}, err
+	}
+
+	// 3. 签发 Token 并返回登录响应
+	return issueLoginResponse(ctx, userDTO, req.RememberMe)
+}
+
+// newLoginUserService 创建按邮箱验证和登录锁定配置校验密码的用户领域服务
+func newLoginUserService() (appuser.UserService, error) {
+	verifyCfg, err := config.GetEmailVerificationConfig()
+	if err != nil {
+		return nil, err
+	}
+	lockoutCfg, err := config.GetLoginLockoutConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return nil, err
+	}
+	hasher := appauth.NewHasher()
+	return appuser.NewService(repo, hasher,
+		appuser.WithRequireEmailVerification(verifyCfg.Required),
+		appuser.WithLockoutPolicy(appuser.LockoutPolicy{
+			MaxAttempts:  lockoutCfg.MaxAttempts,
+			Window:       lockoutCfg.Window,
+			LockDuration: lockoutCfg.LockDuration,
+		}),
+	), nil
+}
+
+// issueLoginResponse 为已通过身份校验的用户签发 Token，记住登录时额外签发刷新 Token
+func issueLoginResponse(ctx context.Context, userDTO *dto.UserDTO, rememberMe bool) (response.LoginResponse, error) {
+	var tokenPair middleware.TokenPair
+	var err error
+	if rememberMe {
+		tokenPair, err = middleware.GenerateTokenPair(ctx, userDTO.ID, userDTO.Username, userDTO.Role)
+	} else {
+		tokenPair.AccessToken, tokenPair.ExpiresAt, err = middleware.GenerateAccessTokenWithExpiry(userDTO.ID, userDTO.Username, userDTO.Role)
+	}
+	if err != nil {
+		return response.LoginResponse{}, err
+	}
+
+	// 附带访问 Token 过期时间便于客户端提前刷新
 	return response.LoginResponse{
-		Token: token,
+		Token:        tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresAt:    tokenPair.ExpiresAt,
+		ExpiresIn:    int64(config.GetJWTConfig().GetExpireDuration().Seconds()),
 		User: response.UserResponse{
-			ID:        userDTO.ID,
-			Username:  userDTO.Username,
-			Email:     userDTO.Email,
-			AvatarURL: userDTO.AvatarURL,
-			Status:    userDTO.Status,
-			CreatedAt: userDTO.CreatedAt,
-			UpdatedAt: userDTO.UpdatedAt,
+			ID:            userDTO.ID,
+			Username:      userDTO.Username,
+			Email:         userDTO.Email,
+			AvatarURL:     userDTO.AvatarURL,
+			Status:        userDTO.Status,
+			EmailVerified: userDTO.EmailVerified,
+			CreatedAt:     userDTO.CreatedAt,
+			UpdatedAt:     userDTO.UpdatedAt,
 		},
 	}, nil
 }
@@ -52,31 +140,41 @@ func LoginUserHandler(ctx context.Context, req request.LoginUserRequest) (respon
 // 注意：
 //   - 参数验证和值对象创建由应用层负责
 //   - 应用层返回 DTO，Handler 负责转换为 HTTP 响应格式
-func RegisterUserHandler(ctx context.Context, req request.RegisterUserRequest) (response.UserResponse, error) {
+//   - 注册成功返回 201
+func RegisterUserHandler(ctx context.Context, req request.RegisterUserRequest) (response.Created[response.UserResponse], error) {
 	// 1. 初始化领域服务（未来可以改为依赖注入）
-	repo := mysql.NewUserRepository()
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.Created[response.UserResponse]{}, err
+	}
 	hasher := appauth.NewHasher()
 	userService := appuser.NewService(repo, hasher)
 
 	// 2. 初始化应用服务
-	userAppService := user.NewUserApplicationService(userService)
+	userAppService := user.NewUserApplicationService(userService,
+		user.WithEventBus(eventbus.GetBus()),
+		user.WithRegistrationGuard(captcha.GetGuard()))
 
 	// 3. 调用应用服务（传递原始值，值对象创建由应用层负责）
-	userDTO, err := userAppService.RegisterUser(ctx, req.Username, req.Email, req.Password)
+	userDTO, err := userAppService.RegisterUser(ctx, req.Username, req.Email, req.Password, req.CaptchaToken)
 	if err != nil {
-		return response.UserResponse{}, err
+		return response.Created[response.UserResponse]{}, err
 	}
 
-	// 4. DTO 转换为 HTTP 响应格式
-	return response.UserResponse{
-		ID:        userDTO.ID,
-		Username:  userDTO.Username,
-		Email:     userDTO.Email,
-		AvatarURL: userDTO.AvatarURL,
-		Status:    userDTO.Status,
-		CreatedAt: userDTO.CreatedAt,
-		UpdatedAt: userDTO.UpdatedAt,
-	}, nil
+	// 4. 生成邮箱验证 Token（失败不影响注册结果，用户可重新申请）
+	issueEmailVerification(ctx, userDTO)
+
+	// 5. DTO 转换为 HTTP 响应格式
+	return response.NewCreated(response.UserResponse{
+		ID:            userDTO.ID,
+		Username:      userDTO.Username,
+		Email:         userDTO.Email,
+		AvatarURL:     userDTO.AvatarURL,
+		Status:        userDTO.Status,
+		EmailVerified: userDTO.EmailVerified,
+		CreatedAt:     userDTO.CreatedAt,
+		UpdatedAt:     userDTO.UpdatedAt,
+	}), nil
 }
 
 // ChangePasswordHandler 修改密码处理器
@@ -87,19 +185,26 @@ func RegisterUserHandler(ctx context.Context, req request.RegisterUserRequest) (
 //  3. 返回成功消息
 func ChangePasswordHandler(ctx context.Context, req request.ChangePasswordRequest) (response.MessageResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewUserRepository()
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
 	hasher := appauth.NewHasher()
 	userService := appuser.NewService(repo, hasher)
-	userAppService := user.NewUserApplicationService(userService)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
 	// 3. 调用应用服务修改密码
-	err := userAppService.ChangePassword(ctx, user.UserID, req.OldPassword, req.NewPassword)
+	err = userAppService.ChangePassword(ctx, user.UserID, req.OldPassword, req.NewPassword)
 	if err != nil {
 		return response.MessageResponse{}, err
 	}
@@ -109,33 +214,176 @@ func ChangePasswordHandler(ctx context.Context, req request.ChangePasswordReques
 	}, nil
 }
 
+// DeleteAccountHandler 注销账户处理器
+//
+// 职责：
+//  1. 初始化服务层
+//  2. 校验密码后调用应用服务注销当前用户
+//  3. 吊销当前用户的全部登录会话和已签发的访问 Token
+//  4. 返回成功消息
+func DeleteAccountHandler(ctx context.Context, req request.DeleteAccountRequest) (response.MessageResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务注销账户
+	err = userAppService.DeleteAccount(ctx, user.UserID, req.Password)
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	// 4. 吊销全部登录会话，已签发的访问 Token 随之失效
+	revoked := middleware.RevokeUserSessions(user.UserID)
+	applogger.InfoContext(ctx, "已注销账户的登录会话已吊销",
+		applogger.Int64("user_id", user.UserID),
+		applogger.Int("sessions", revoked))
+
+	return response.MessageResponse{
+		Message: "Account deleted successfully",
+	}, nil
+}
+
 // UpdateEmailHandler 更新邮箱处理器
 //
 // 职责：
 //  1. 初始化服务层
-//  2. 调用应用服务更新邮箱
-//  3. 返回成功消息
+//  2. 调用应用服务发起邮箱变更
+//  3. 生成发往新邮箱的确认链接
+//  4. 返回成功消息
 func UpdateEmailHandler(ctx context.Context, req request.UpdateEmailRequest) (response.MessageResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewUserRepository()
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
 	hasher := appauth.NewHasher()
 	userService := appuser.NewService(repo, hasher)
-	userAppService := user.NewUserApplicationService(userService)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
-	// 3. 调用应用服务更新邮箱
-	err := userAppService.UpdateEmail(ctx, user.UserID, req.NewEmail)
+	// 3. 调用应用服务发起邮箱变更
+	err = userAppService.UpdateEmail(ctx, user.UserID, req.NewEmail)
 	if err != nil {
 		return response.MessageResponse{}, err
 	}
 
+	// 4. 生成确认链接，确认前登录邮箱保持不变
+	if err := issueEmailChangeConfirmation(ctx, user.UserID, user.Username, req.NewEmail); err != nil {
+		return response.MessageResponse{}, err
+	}
+
 	return response.MessageResponse{
-		Message: "Email updated successfully",
+		Message: "Confirmation link sent to the new email address",
+	}, nil
+}
+
+// ConfirmEmailChangeHandler 确认邮箱变更处理器
+//
+// 职责：
+//  1. 解析并校验确认 Token
+//  2. 调用应用服务将待确认邮箱设为登录邮箱
+//  3. 返回成功消息
+func ConfirmEmailChangeHandler(ctx context.Context, req request.ConfirmEmailChangeRequest) (response.MessageResponse, error) {
+	// 1. 解析确认 Token
+	if req.Token == "" {
+		return response.MessageResponse{}, appuser.ErrEmailChangeTokenInvalid
+	}
+	claims, err := appauth.NewTokenTool(config.GetJWTConfig()).ParseEmailChangeToken(req.Token)
+	if err != nil {
+		applogger.WarnContext(ctx, "邮箱变更确认 Token 无效", applogger.Err(err))
+		return response.MessageResponse{}, appuser.ErrEmailChangeTokenInvalid
+	}
+
+	// 2. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService,
+		user.WithAuditLogger(auditRepo),
+		user.WithEventBus(eventbus.GetBus()),
+	)
+
+	// 3. 调用应用服务确认邮箱变更
+	if err := userAppService.ConfirmEmailChange(ctx, claims.UserID, claims.Email); err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	return response.MessageResponse{
+		Message: "Email changed successfully",
+	}, nil
+}
+
+// ChangeUsernameHandler 修改用户名处理器
+//
+// 职责：
+//  1. 初始化服务层（读取修改用户名冷却期配置）
+//  2. 调用应用服务修改用户名
+//  3. 返回成功消息
+//
+// 已签发的 Token 中的用户名在刷新前不会更新。
+func ChangeUsernameHandler(ctx context.Context, req request.ChangeUsernameRequest) (response.MessageResponse, error) {
+	// 1. 初始化服务层
+	usernameCfg, err := config.GetUsernameChangeConfig()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher, appuser.WithUsernameChangeCooldown(usernameCfg.Cooldown))
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务修改用户名
+	err = userAppService.ChangeUsername(ctx, user.UserID, req.NewUsername)
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	return response.MessageResponse{
+		Message: "Username updated successfully",
 	}, nil
 }
 
@@ -147,19 +395,23 @@ func UpdateEmailHandler(ctx context.Context, req request.UpdateEmailRequest) (re
 //  3. 返回成功消息
 func UpdateAvatarHandler(ctx context.Context, req request.UpdateAvatarRequest) (response.MessageResponse, error) {
 	// 1. 初始化服务层
-	repo := mysql.NewUserRepository()
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
 	hasher := appauth.NewHasher()
 	userService := appuser.NewService(repo, hasher)
 	userAppService := user.NewUserApplicationService(userService)
 
 	// 2. 从上下文中获取用户信息（由认证中间件设置）
-	user, ok := middleware.GetDataFromContext(ctx)
+	user, ok := contextx.GetDataFromContext(ctx)
 	if !ok {
 		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
 	}
 
 	// 3. 调用应用服务更新头像
-	err := userAppService.UpdateAvatar(ctx, user.UserID, req.AvatarURL)
+	// URL 格式由领域层校验，空字符串表示清除头像
+	err = userAppService.UpdateAvatar(ctx, user.UserID, *req.AvatarURL)
 	if err != nil {
 		return response.MessageResponse{}, err
 	}
@@ -168,3 +420,710 @@ func UpdateAvatarHandler(ctx context.Context, req request.UpdateAvatarRequest) (
 		Message: "Avatar updated successfully",
 	}, nil
 }
+
+// UpdateTimezoneHandler 更新时区处理器
+//
+// 时区决定每日笔记的"今天"，空字符串表示清除设置，改用服务器默认时区。
+func UpdateTimezoneHandler(ctx context.Context, req request.UpdateTimezoneRequest) (response.MessageResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务更新时区，时区名称由领域层校验
+	if err := userAppService.UpdateTimezone(ctx, user.UserID, *req.Timezone); err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	return response.MessageResponse{
+		Message: "Timezone updated successfully",
+	}, nil
+}
+
+// CheckAvailabilityHandler 用户名/邮箱可用性检查处理器
+//
+// 供注册页面提交前检查，无需认证；路由上需启用限流以降低账户枚举风险。
+func CheckAvailabilityHandler(ctx context.Context, req request.AvailabilityRequest) (response.AvailabilityResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.AvailabilityResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务检查可用性
+	available, err := userAppService.CheckAvailability(ctx, req.Username, req.Email)
+	if err != nil {
+		return response.AvailabilityResponse{}, err
+	}
+
+	return response.AvailabilityResponse{Available: available}, nil
+}
+
+// GetCurrentUserHandler 获取当前用户信息处理器
+//
+// 响应带有 ETag，客户端通过 If-None-Match 轮询时资料未变化返回 304。
+func GetCurrentUserHandler(ctx context.Context, req request.EmptyRequest) (response.UserResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.UserResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务获取用户信息
+	userDTO, err := userAppService.GetCurrentUser(ctx, user.UserID)
+	if err != nil {
+		return response.UserResponse{}, err
+	}
+
+	return response.UserResponse{
+		ID:            userDTO.ID,
+		Username:      userDTO.Username,
+		Email:         userDTO.Email,
+		AvatarURL:     userDTO.AvatarURL,
+		Timezone:      userDTO.Timezone,
+		Status:        userDTO.Status,
+		EmailVerified: userDTO.EmailVerified,
+		CreatedAt:     userDTO.CreatedAt,
+		UpdatedAt:     userDTO.UpdatedAt,
+	}, nil
+}
+
+// GetPublicProfileHandler 查看用户公开资料处理器
+//
+// 路由使用可选认证，匿名访问和登录用户目前看到相同的公开字段，
+// 需要区分时可通过 contextx.GetDataFromContext 获取访问者。
+func GetPublicProfileHandler(ctx context.Context, req request.PublicProfileRequest) (response.PublicProfileResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.PublicProfileResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务获取公开资料
+	profileDTO, err := userAppService.GetPublicProfile(ctx, req.Username)
+	if err != nil {
+		return response.PublicProfileResponse{}, err
+	}
+
+	return response.ToPublicProfileResponse(*profileDTO), nil
+}
+
+// GetUserSummaryHandler 获取当前用户首页概览处理器
+//
+// 返回用户资料、笔记总数、今日是否已写和当前连续天数。
+func GetUserSummaryHandler(ctx context.Context, req request.EmptyRequest) (response.UserSummaryResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserSummaryResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	dailyNoteRepo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		return response.UserSummaryResponse{}, err
+	}
+	dailyNoteService := dailynote.NewService(dailyNoteRepo)
+	tzCfg, err := config.GetTimezoneConfig()
+	if err != nil {
+		return response.UserSummaryResponse{}, err
+	}
+	userAppService := user.NewUserApplicationService(userService,
+		user.WithDailyNoteService(dailyNoteService),
+		user.WithDefaultLocation(tzCfg.Location),
+	)
+
+	// 2. 从上下文中获取用户信息（由认证中间件设置）
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.UserSummaryResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	// 3. 调用应用服务获取用户概览
+	summaryDTO, err := userAppService.GetUserSummary(ctx, user.UserID)
+	if err != nil {
+		return response.UserSummaryResponse{}, err
+	}
+
+	return response.ToUserSummaryResponse(*summaryDTO), nil
+}
+
+// VerifyEmailHandler 邮箱验证处理器
+//
+// 职责：
+//  1. 解析并校验验证 Token
+//  2. 调用应用服务标记邮箱已验证
+//  3. 返回成功消息
+func VerifyEmailHandler(ctx context.Context, req request.VerifyEmailRequest) (response.MessageResponse, error) {
+	// 1. 解析验证 Token
+	if req.Token == "" {
+		return response.MessageResponse{}, appuser.ErrVerificationTokenInvalid
+	}
+	claims, err := appauth.NewTokenTool(config.GetJWTConfig()).ParsePurposeToken(req.Token, appauth.TokenPurposeVerify)
+	if err != nil {
+		applogger.WarnContext(ctx, "邮箱验证 Token 无效", applogger.Err(err))
+		return response.MessageResponse{}, appuser.ErrVerificationTokenInvalid
+	}
+
+	// 2. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 3. 调用应用服务验证邮箱
+	if err := userAppService.VerifyEmail(ctx, claims.UserID); err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	return response.MessageResponse{
+		Message: "Email verified successfully",
+	}, nil
+}
+
+// issueEmailVerification 为新注册用户生成邮箱验证链接，通过 mail.GetSender 发往注册邮箱
+//
+// 验证链接相当于一次性凭证，只随邮件发送，日志中仅记录用户 ID 和过期时间。
+func issueEmailVerification(ctx context.Context, userDTO *dto.UserDTO) {
+	verifyCfg, err := config.GetEmailVerificationConfig()
+	if err != nil {
+		applogger.ErrorContext(ctx, "邮箱验证配置无效", applogger.Err(err))
+		return
+	}
+
+	tokenTool := appauth.NewTokenTool(config.GetJWTConfig())
+	token, err := tokenTool.GeneratePurposeToken(userDTO.ID, userDTO.Username, appauth.TokenPurposeVerify, verifyCfg.TokenExpiration)
+	if err != nil {
+		applogger.ErrorContext(ctx, "生成邮箱验证 Token 失败",
+			applogger.Int64("user_id", userDTO.ID),
+			applogger.Err(err))
+		return
+	}
+
+	err = mail.GetSender().Send(ctx, mail.Message{
+		To:      userDTO.Email,
+		Subject: "验证你的邮箱",
+		Body:    "点击以下链接完成邮箱验证：/api/v1/auth/verify?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		applogger.ErrorContext(ctx, "发送邮箱验证邮件失败",
+			applogger.Int64("user_id", userDTO.ID),
+			applogger.Err(err))
+		return
+	}
+
+	applogger.InfoContext(ctx, "邮箱验证链接已生成",
+		applogger.Int64("user_id", userDTO.ID),
+		applogger.Any("expires_at", time.Now().Add(verifyCfg.TokenExpiration)))
+}
+
+// issueEmailChangeConfirmation 为发起的邮箱变更生成确认链接，通过 mail.GetSender 发往新邮箱
+//
+// 与注册验证不同，没有确认链接就无法完成变更，因此生成或发送失败时返回错误。
+// 确认链接持有者可将账户邮箱改为新邮箱，只随邮件发送，日志中仅记录用户 ID 和过期时间。
+func issueEmailChangeConfirmation(ctx context.Context, userID int64, username, newEmail string) error {
+	verifyCfg, err := config.GetEmailVerificationConfig()
+	if err != nil {
+		applogger.ErrorContext(ctx, "邮箱验证配置无效", applogger.Err(err))
+		return err
+	}
+
+	tokenTool := appauth.NewTokenTool(config.GetJWTConfig())
+	token, err := tokenTool.GenerateEmailChangeToken(userID, username, newEmail, verifyCfg.TokenExpiration)
+	if err != nil {
+		applogger.ErrorContext(ctx, "生成邮箱变更确认 Token 失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	err = mail.GetSender().Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "确认你的新邮箱",
+		Body:    "点击以下链接确认邮箱变更：/api/v1/auth/email/confirm?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		applogger.ErrorContext(ctx, "发送邮箱变更确认邮件失败",
+			applogger.Int64("user_id", userID),
+			applogger.Err(err))
+		return err
+	}
+
+	applogger.InfoContext(ctx, "邮箱变更确认链接已生成",
+		applogger.Int64("user_id", userID),
+		applogger.Any("expires_at", time.Now().Add(verifyCfg.TokenExpiration)))
+	return nil
+}
+
+// RefreshTokenHandler 刷新访问 Token 处理器
+//
+// 职责：
+//  1. 解析并校验刷新 Token，只有会话存储中存在的会话可以刷新
+//  2. 重新读取用户，已注销、停用或封禁的用户不能再刷新
+//  3. 按用户当前信息签发新的访问 Token
+func RefreshTokenHandler(ctx context.Context, req request.RefreshTokenRequest) (response.RefreshTokenResponse, error) {
+	// 1. 解析刷新 Token
+	if req.RefreshToken == "" {
+		return response.RefreshTokenResponse{}, appuser.ErrRefreshTokenInvalid
+	}
+	claims, err := appauth.NewTokenTool(config.GetJWTConfig()).ParseRefreshToken(req.RefreshToken)
+	if err != nil {
+		applogger.WarnContext(ctx, "刷新 Token 无效", applogger.Err(err))
+		return response.RefreshTokenResponse{}, appuser.ErrRefreshTokenInvalid
+	}
+	sessions := middleware.GetSessionStore()
+	if !sessions.IsActive(claims.UserID, claims.ID) {
+		applogger.WarnContext(ctx, "刷新 Token 的会话不存在或已吊销",
+			applogger.Int64("user_id", claims.UserID),
+			applogger.String("session_id", claims.ID))
+		return response.RefreshTokenResponse{}, appuser.ErrRefreshTokenInvalid
+	}
+
+	// 2. 重新读取用户，不信任刷新 Token 中可能已过时的用户名和角色
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.RefreshTokenResponse{}, err
+	}
+	userAppService := user.NewUserApplicationService(appuser.NewService(repo, appauth.NewHasher()))
+	userDTO, err := userAppService.RefreshSession(ctx, claims.UserID)
+	if err != nil {
+		return response.RefreshTokenResponse{}, err
+	}
+	sessions.Touch(claims.ID)
+
+	// 3. 签发新的访问 Token，携带会话 ID 以便吊销会话时一并失效
+	token, _, err := middleware.GenerateSessionAccessToken(claims.ID, userDTO.ID, userDTO.Username, userDTO.Role)
+	if err != nil {
+		return response.RefreshTokenResponse{}, err
+	}
+
+	return response.RefreshTokenResponse{
+		Token: token,
+	}, nil
+}
+
+// ListSessionsHandler 列出当前用户登录会话处理器
+//
+// 返回记住登录时签发的、未过期且未吊销的刷新 Token 会话。
+func ListSessionsHandler(ctx context.Context, req request.EmptyRequest) (response.SessionListResponse, error) {
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.SessionListResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	return response.ToSessionListResponse(middleware.GetSessionStore().List(user.UserID)), nil
+}
+
+// IntrospectTokenHandler 查看当前访问 Token 声明处理器
+//
+// 返回认证中间件解析出的用户信息和有效期，不回显 Token 本身；
+// 用户名、角色为签发时的值，签发后修改不会反映在已签发的 Token 中。
+func IntrospectTokenHandler(ctx context.Context, req request.EmptyRequest) (response.TokenIntrospectionResponse, error) {
+	claims, ok := contextx.GetClaims(ctx)
+	if !ok {
+		return response.TokenIntrospectionResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	return response.ToTokenIntrospectionResponse(claims), nil
+}
+
+// RevokeSessionHandler 吊销当前用户登录会话处理器
+//
+// 删除会话记录后，其刷新 Token 不能再换取访问 Token，该会话签发的访问 Token 也不能再通过认证。
+// 会话不存在、已过期或不属于当前用户时均返回 404。
+func RevokeSessionHandler(ctx context.Context, req request.RevokeSessionRequest) (response.MessageResponse, error) {
+	user, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		return response.MessageResponse{}, errors.New("unauthorized: invalid user context")
+	}
+
+	if !middleware.GetSessionStore().Revoke(user.UserID, req.ID) {
+		return response.MessageResponse{}, appuser.ErrSessionNotFound
+	}
+	applogger.InfoContext(ctx, "登录会话已吊销",
+		applogger.Int64("user_id", user.UserID),
+		applogger.String("session_id", req.ID))
+
+	return response.MessageResponse{
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+// avatarFormField 头像上传的表单字段名
+const avatarFormField = "avatar"
+
+// avatarMultipartOverhead multipart 编码（边界、字段头）额外占用的字节数上限
+const avatarMultipartOverhead = 4 << 10
+
+// UploadAvatarHandler 上传头像处理器
+//
+// 接收 multipart/form-data 中 avatar 字段的图片文件，
+// 请求格式不是 multipart 直接返回 400，因此不使用 Wrap 的 JSON 解码。
+//
+// 职责：
+//  1. 限制并读取上传文件
+//  2. 调用应用服务校验内容类型、存储文件并更新头像
+//  3. 返回头像 URL
+func UploadAvatarHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. 从上下文中获取用户信息（由认证中间件设置）
+	authUser, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		response.WriteError(w, errors.New("unauthorized: invalid user context"))
+		return
+	}
+
+	cfg, err := config.GetAvatarConfig()
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	// 2. 限制请求体大小并读取文件
+	r.Body = http.MaxBytesReader(w, r.Body, cfg.MaxBytes+avatarMultipartOverhead)
+	file, _, err := r.FormFile(avatarFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.WriteError(w, appuser.ErrAvatarTooLarge)
+			return
+		}
+		response.WriteBadRequest(w, "avatar file is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, cfg.MaxBytes+1))
+	if err != nil {
+		response.WriteBadRequest(w, "failed to read avatar file")
+		return
+	}
+	if int64(len(data)) > cfg.MaxBytes {
+		response.WriteError(w, appuser.ErrAvatarTooLarge)
+		return
+	}
+
+	// 3. 初始化服务层并上传头像
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	hasher := appauth.NewHasher()
+	avatarStorage := storage.NewLocalAvatarStorage(cfg.StorageDir, cfg.BaseURL)
+	userService := appuser.NewService(repo, hasher, appuser.WithAvatarStorage(avatarStorage))
+	userAppService := user.NewUserApplicationService(userService)
+
+	avatarURL, err := userAppService.UploadAvatar(ctx, authUser.UserID, data)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	response.WriteOK(w, response.AvatarUploadResponse{
+		AvatarURL: avatarURL,
+	})
+}
+
+// AvatarFileHandler 本地头像文件访问处理器
+//
+// 以 dir 为根目录提供头像文件下载，禁止列出目录，避免暴露其他用户的头像文件名。
+func AvatarFileHandler(dir string) http.Handler {
+	fileServer := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	})
+}
+
+// ExportUserDataHandler 导出当前用户数据处理器
+//
+// 以 JSON 附件形式返回用户资料和全部笔记。响应不包裹统一结构，因此不使用 Wrap。
+// 笔记分批读取并流式写出，开始写出后发生的错误无法再改为错误响应，只记录日志。
+func ExportUserDataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. 从上下文中获取用户信息（由认证中间件设置）
+	authUser, ok := contextx.GetDataFromContext(ctx)
+	if !ok {
+		response.WriteError(w, errors.New("unauthorized: invalid user context"))
+		return
+	}
+
+	// 2. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	dailyNoteRepo, err := persistence.GetFactory().DailyNoteRepository()
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	dailyNoteService := dailynote.NewService(dailyNoteRepo)
+	userAppService := user.NewUserApplicationService(userService, user.WithDailyNoteService(dailyNoteService))
+
+	// 3. 调用应用服务获取导出数据
+	exportDTO, err := userAppService.ExportUserData(ctx, authUser.UserID)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	// 4. 以附件形式流式写出
+	filename := fmt.Sprintf("todolist-export-%s-%s.json", exportDTO.User.Username, exportDTO.ExportedAt.Format("20060102"))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	if err := response.WriteUserExport(w, *exportDTO); err != nil {
+		applogger.ErrorContext(ctx, "写出用户数据导出失败",
+			applogger.Int64("user_id", authUser.UserID),
+			applogger.Err(err))
+	}
+}
+
+// ListUsersHandler 管理端用户列表处理器
+//
+// 支持通过 created_from、created_to 查询参数按创建日期区间筛选，
+// 日期格式无效或区间不合法时返回 400。
+func ListUsersHandler(ctx context.Context, req request.ListUsersRequest) (response.UserListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务查询用户列表
+	userPageDTO, err := userAppService.ListUsers(ctx, req.CreatedFrom, req.CreatedTo, req.Page, req.PageSize)
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.ToUserListResponse(*userPageDTO), nil
+}
+
+// ListDeletedUsersHandler 管理端已注销用户列表处理器
+//
+// 列出可通过 RestoreUserHandler 恢复的用户。
+func ListDeletedUsersHandler(ctx context.Context, req request.ListDeletedUsersRequest) (response.UserListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务查询已注销用户
+	userPageDTO, err := userAppService.ListDeletedUsers(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.ToUserListResponse(*userPageDTO), nil
+}
+
+// SearchUsersHandler 管理端按用户名前缀搜索用户处理器
+//
+// 结果按用户名升序排列，不含已注销用户。
+func SearchUsersHandler(ctx context.Context, req request.SearchUsersRequest) (response.UserListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务搜索用户
+	userPageDTO, err := userAppService.SearchUsers(ctx, req.Q, req.Page, req.PageSize)
+	if err != nil {
+		return response.UserListResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.ToUserListResponse(*userPageDTO), nil
+}
+
+// GetUserStatsHandler 管理端用户数量统计处理器
+//
+// 返回用户总数及 active、inactive、banned 各状态的用户数。
+func GetUserStatsHandler(ctx context.Context, req request.EmptyRequest) (response.UserStatsResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserStatsResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务统计用户数量
+	statsDTO, err := userAppService.GetUserStats(ctx)
+	if err != nil {
+		return response.UserStatsResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.ToUserStatsResponse(*statsDTO), nil
+}
+
+// ListAuditLogsHandler 管理端用户审计日志列表处理器
+//
+// 用户 ID 通过路径参数 {id} 传递，按时间倒序分页返回。
+func ListAuditLogsHandler(ctx context.Context, req request.ListAuditLogsRequest) (response.AuditLogListResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.AuditLogListResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.AuditLogListResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogReader(auditRepo))
+
+	// 2. 调用应用服务查询审计日志
+	auditLogPageDTO, err := userAppService.ListAuditLogs(ctx, req.ID, req.Page, req.PageSize)
+	if err != nil {
+		return response.AuditLogListResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.ToAuditLogListResponse(*auditLogPageDTO), nil
+}
+
+// RestoreUserHandler 管理端恢复已注销用户处理器
+//
+// 用户 ID 通过路径参数 {id} 传递。
+// 用户名或邮箱已被其他账户占用时返回 409。
+func RestoreUserHandler(ctx context.Context, req request.RestoreUserRequest) (response.UserResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.UserResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
+
+	// 2. 调用应用服务恢复用户
+	userDTO, err := userAppService.RestoreUser(ctx, req.ID)
+	if err != nil {
+		return response.UserResponse{}, err
+	}
+
+	// 3. 转换为HTTP响应
+	return response.UserResponse{
+		ID:            userDTO.ID,
+		Username:      userDTO.Username,
+		Email:         userDTO.Email,
+		AvatarURL:     userDTO.AvatarURL,
+		Status:        userDTO.Status,
+		EmailVerified: userDTO.EmailVerified,
+		CreatedAt:     userDTO.CreatedAt,
+		UpdatedAt:     userDTO.UpdatedAt,
+	}, nil
+}
+
+// ChangeUsersStatusHandler 管理端批量修改用户状态处理器
+//
+// 全部用户在同一事务中更新，任一用户不存在时整体回滚并返回 404。
+func ChangeUsersStatusHandler(ctx context.Context, req request.ChangeUsersStatusRequest) (response.MessageResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	auditRepo, err := persistence.GetFactory().AuditLogRepository()
+	if err != nil {
+		return response.MessageResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService, user.WithAuditLogger(auditRepo))
+
+	// 2. 调用应用服务批量修改状态
+	if err := userAppService.ChangeUsersStatus(ctx, req.IDs, req.Status); err != nil {
+		return response.MessageResponse{}, err
+	}
+
+	// 3. 返回成功消息
+	return response.MessageResponse{
+		Message: "用户状态修改成功",
+	}, nil
+}
+
+// ImportUsersHandler 管理端批量导入用户处理器
+//
+// 返回逐行结果；严格模式下有无效行时不创建任何用户，仍返回 200 和逐行原因。
+func ImportUsersHandler(ctx context.Context, req request.ImportUsersRequest) (response.UserImportResponse, error) {
+	// 1. 初始化服务层
+	repo, err := persistence.GetFactory().UserRepository()
+	if err != nil {
+		return response.UserImportResponse{}, err
+	}
+	hasher := appauth.NewHasher()
+	userService := appuser.NewService(repo, hasher)
+	userAppService := user.NewUserApplicationService(userService)
+
+	// 2. 调用应用服务批量导入
+	users := make([]user.ImportUserInput, len(req.Users))
+	for i, item := range req.Users {
+		users[i] = user.ImportUserInput{Username: item.Username, Email: item.Email, Password: item.Password}
+	}
+	resultDTO, err := userAppService.BulkRegisterUsers(ctx, users, req.Strict)
+	if err != nil {
+		return response.UserImportResponse{}, err
+	}
+
+	// 3. 转换为响应
+	return response.ToUserImportResponse(*resultDTO), nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"todolist/internal/interfaces/http/response"
+)
+
+// validate 请求结构体校验器，按 validate 标签做必填、长度、格式等结构性检查
+//
+// 只作为进入业务逻辑前的廉价拦截，领域值对象的校验仍是最终依据，
+// 因此标签的约束不应比领域规则更严格。
+var validate = newValidator()
+
+// newValidator 创建校验器，错误中的字段名使用 json 标签名
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// validateRequest 按 validate 标签校验请求，返回每个字段的错误，校验通过时返回 nil
+//
+// 请求为结构体切片（如批量导入）时逐个校验元素。
+func validateRequest(req any) []response.FieldError {
+	t := reflect.TypeOf(req)
+	if t == nil {
+		return nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Struct:
+		err = validate.Struct(req)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		err = validate.Var(req, "dive")
+	default:
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	fieldErrs := make([]response.FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		fieldErrs[i] = response.FieldError{
+			Field:   fieldPath(fe, t.Kind() == reflect.Struct),
+			Message: fieldMessage(fe),
+		}
+	}
+	return fieldErrs
+}
+
+// fieldPath 返回字段在请求中的路径，去掉结构体类型名前缀
+func fieldPath(fe validator.FieldError, trimRoot bool) string {
+	ns := fe.Namespace()
+	if trimRoot {
+		if _, rest, ok := strings.Cut(ns, "."); ok {
+			return rest
+		}
+	}
+	return ns
+}
+
+// fieldMessage 将校验失败的规则转换为错误说明
+func fieldMessage(fe validator.FieldError) string {
+	unit := "characters"
+	if k := fe.Kind(); k == reflect.Slice || k == reflect.Array || k == reflect.Map {
+		unit = "items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "min":
+		return fmt.Sprintf("must be at least %s %s", fe.Param(), unit)
+	case "max":
+		return fmt.Sprintf("must be at most %s %s", fe.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed on the %q rule", fe.Tag())
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+)
+
+// supportedAPIVersions 可通过 Accept-Version 请求的 API 版本
+var supportedAPIVersions = map[string]bool{
+	contextx.APIVersionV1: true,
+	contextx.APIVersionV2: true,
+}
+
+// APIVersionMiddleware API 版本协商中间件。
+//
+// 从请求头 Accept-Version 读取客户端期望的响应版本（不区分大小写，未携带时为 v1），
+// 写入上下文供 handler.Wrap 按版本调整响应结构，并通过响应头 X-API-Version 返回实际使用的版本。
+// 请求不支持的版本时返回 400。
+func APIVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := strings.ToLower(strings.TrimSpace(r.Header.Get("Accept-Version")))
+		if version == "" {
+			version = contextx.APIVersionV1
+		}
+
+		w.Header().Add("Vary", "Accept-Version")
+		if !supportedAPIVersions[version] {
+			response.WriteBadRequest(w, "unsupported api version")
+			return
+		}
+		w.Header().Set("X-API-Version", version)
+
+		next.ServeHTTP(w, r.WithContext(contextx.WithAPIVersion(r.Context(), version)))
+	})
+}
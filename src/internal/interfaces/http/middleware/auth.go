@@ -2,41 +2,302 @@ package middleware
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"time"
 
 	"todolist/internal/infrastructure/config"
 	"todolist/internal/interfaces/dto"
+	"todolist/internal/interfaces/http/response"
+	appauth "todolist/internal/pkg/auth"
+	"todolist/internal/pkg/contextx"
 
 	core "github.com/frigidom1024/go-jwt-middleware/core"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-type User struct {
-	UserID   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+var auth core.AuthMiddleware[contextx.UserContext]
+var initonce sync.Once
+
+var (
+	sessionStore     *appauth.SessionStore
+	sessionStoreOnce sync.Once
+)
+
+// GetSessionStore 获取全局登录会话存储单例
+//
+// 记录记住登录时签发的刷新 Token，吊销后的刷新 Token 不能再换取访问 Token。
+func GetSessionStore() *appauth.SessionStore {
+	sessionStoreOnce.Do(func() {
+		sessionStore = appauth.NewSessionStore(nil)
+	})
+	return sessionStore
 }
 
-var auth core.AuthMiddleware[User]
-var initonce sync.Once
+// RevokeUserSessions 吊销用户的全部登录会话，并使其此前签发的访问 Token 失效
+//
+// 用于注销账户等场景；吊销记录保留一个访问 Token 有效期，之后此前签发的 Token 均已过期。
+func RevokeUserSessions(userID int64) int {
+	return GetSessionStore().RevokeUser(userID, time.Now().Add(config.GetJWTConfig().GetExpireDuration()))
+}
 
-func GetAuthMiddleware() core.AuthMiddleware[User] {
+// GetAuthMiddleware 获取认证中间件单例
+//
+// 认证通过后用户信息写入上下文，通过 contextx.GetDataFromContext 读取，
+// Token 声明（含签发和过期时间）通过 contextx.GetClaims 读取。
+// 配置了 SESSION_IDLE_TIMEOUT 时，空闲超时的会话同样返回 401。
+func GetAuthMiddleware() core.AuthMiddleware[contextx.UserContext] {
 	initonce.Do(func() {
 		config := config.GetJWTConfig()
-		auth = core.NewAuthMiddleware[User](config.GetSecretKey(), config.GetExpireDuration())
+		mw := NewAuthMiddleware(config.GetSecretKey(), config.GetExpireDuration(), config.GetIssuer(), config.GetAudience())
+		auth = WithIdleTimeout(mw, GetSessionTracker())
 	})
 	return auth
 }
 
+// NewAuthMiddleware 使用指定密钥、有效期、签发者和受众创建认证中间件
+//
+// 不读取 JWT 配置，便于测试中使用固定密钥构造中间件。
+// 签发的 Token 携带 iss、aud、iat、nbf 声明；认证时要求 nbf 存在，
+// issuer、audience 不为空时要求 iss、aud 一致，为空的项不校验。
+func NewAuthMiddleware(secretKey string, expireDuration time.Duration, issuer, audience string) core.AuthMiddleware[contextx.UserContext] {
+	return &authMiddleware{
+		AuthMiddleware: core.NewAuthMiddleware[contextx.UserContext](secretKey, expireDuration),
+		secretKey:      secretKey,
+		issuer:         issuer,
+		audience:       audience,
+	}
+}
+
+// authMiddleware 将认证用户信息写入 contextx 私有上下文键的认证中间件
+//
+// 底层库以字符串 core.DEFAULT_CTX_KEY 为键写入用户信息，可能与其他包写入的值冲突，
+// 此处在认证通过后改用 contextx.WithUser 写入，读取统一通过 contextx.GetDataFromContext。
+// 底层库只写入载荷，不保留签发和过期时间，也不校验 iss、aud、nbf，
+// 因此认证通过后再按这些声明解析一次 Token，通过后写入 contextx.WithClaims。
+// 携带会话 ID 的 Token 还要求会话仍在 GetSessionStore 中，会话吊销后 Token 立即失效；
+// 用户被整体吊销（RevokeUserSessions）前签发的 Token 同样失效。
+type authMiddleware struct {
+	core.AuthMiddleware[contextx.UserContext]
+	secretKey string
+	issuer    string
+	audience  string
+}
+
+// Authenticate 强制认证，Token 无效时返回 401
+func (m *authMiddleware) Authenticate(next http.Handler) http.Handler {
+	return clearLibraryUser(m.AuthMiddleware.Authenticate(m.withContextUser(next, true)))
+}
+
+// OptionalAuthenticate 可选认证，Token 无效时按匿名请求处理
+func (m *authMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
+	return clearLibraryUser(m.AuthMiddleware.OptionalAuthenticate(m.withContextUser(next, false)))
+}
+
+// GenerateToken 签发在指定时间过期的访问 Token，携带 iss、aud、iat、nbf 声明
+//
+// 底层库签发的 Token 只有 exp，无法通过 parseClaims 的校验，因此在此覆盖。
+func (m *authMiddleware) GenerateToken(data contextx.UserContext, expiresAt time.Time) (string, error) {
+	now := time.Now()
+	claims := core.CustomClaims[contextx.UserContext]{
+		Data: data,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(m.secretKey))
+}
+
+// GenerateTokenWithDuration 签发指定有效期的访问 Token
+func (m *authMiddleware) GenerateTokenWithDuration(data contextx.UserContext, duration time.Duration) (string, error) {
+	return m.GenerateToken(data, time.Now().Add(duration))
+}
+
+// GetDataFromContext 获取当前请求的认证用户信息
+func (m *authMiddleware) GetDataFromContext(ctx context.Context) (contextx.UserContext, bool) {
+	return contextx.GetDataFromContext(ctx)
+}
+
+// clearLibraryUser 清除上游以 core.DEFAULT_CTX_KEY 写入的值
+//
+// 确保 withContextUser 读到的只可能是底层库本次认证写入的用户信息。
+func clearLibraryUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(core.DEFAULT_CTX_KEY) != nil {
+			r = r.WithContext(context.WithValue(r.Context(), core.DEFAULT_CTX_KEY, nil))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withContextUser 校验底层库本次认证通过的 Token 声明，通过后将用户信息转存到 contextx 上下文键并写入 Token 声明
+//
+// 声明校验失败或 Token 所属会话已吊销时，required 为 true 返回 401，否则按匿名请求处理。
+func (m *authMiddleware) withContextUser(next http.Handler, required bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, ok := r.Context().Value(core.DEFAULT_CTX_KEY).(contextx.UserContext); ok {
+			message := ""
+			claims, ok := m.parseClaims(r)
+			switch {
+			case !ok:
+				message = "invalid token claims"
+			case claims.SessionID != "" && !GetSessionStore().IsActive(claims.UserID, claims.SessionID):
+				message = "session revoked"
+			case GetSessionStore().IsUserRevoked(claims.UserID, claims.IssuedAt):
+				message = "token revoked"
+			}
+			if message != "" {
+				if required {
+					response.WriteJSON(w, http.StatusUnauthorized, response.BaseResponse[struct{}]{
+						Code:    http.StatusUnauthorized,
+						Message: message,
+					})
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			r = r.WithContext(contextx.WithClaims(contextx.WithUser(r.Context(), user), claims))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseClaims 解析并校验请求携带的访问 Token 声明，仅在底层库认证通过后调用
+//
+// 要求 nbf 存在，配置了签发者和受众时要求 iss、aud 一致。
+func (m *authMiddleware) parseClaims(r *http.Request) (contextx.Claims, bool) {
+	token, err := m.GetTokenExtractor().Extract(r)
+	if err != nil {
+		return contextx.Claims{}, false
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})}
+	if m.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(m.issuer))
+	}
+	if m.audience != "" {
+		opts = append(opts, jwt.WithAudience(m.audience))
+	}
+	var claims core.CustomClaims[contextx.UserContext]
+	_, err = jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(m.secretKey), nil
+	}, opts...)
+	if err != nil || claims.NotBefore == nil {
+		return contextx.Claims{}, false
+	}
+
+	result := contextx.Claims{UserContext: claims.Data}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return result, true
+}
+
 func GenerateToken(dto *dto.UserDTO) (string, error) {
-	user := User{
-		UserID:   dto.ID,
-		Username: dto.Username,
-		Role:     dto.Status,
+	return GenerateAccessToken(dto.ID, dto.Username, dto.Role)
+}
+
+// TokenPair 访问 Token 与刷新 Token 组合
+type TokenPair struct {
+	// AccessToken 短期访问 Token，用于访问受保护接口
+	AccessToken string
+
+	// RefreshToken 长期刷新 Token，只能用于换取新的访问 Token
+	RefreshToken string
+
+	// ExpiresAt 访问 Token 过期时间
+	ExpiresAt time.Time
+}
+
+// GenerateAccessToken 按配置的有效期生成访问 Token
+func GenerateAccessToken(userID int64, username, role string) (string, error) {
+	token, _, err := GenerateAccessTokenWithExpiry(userID, username, role)
+	return token, err
+}
+
+// GenerateAccessTokenWithExpiry 按配置的有效期生成访问 Token，并返回其过期时间
+//
+// 过期时间与 Token 的 exp 声明一致，截断到秒；Token 同时携带 iss、aud、iat、nbf 声明。
+// 签发 Token 视为一次用户活动，重新开始会话空闲计时。
+func GenerateAccessTokenWithExpiry(userID int64, username, role string) (string, time.Time, error) {
+	return generateAccessToken(contextx.UserContext{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+	})
+}
+
+// GenerateSessionAccessToken 为记住登录的会话生成访问 Token
+//
+// Token 携带会话 ID，会话吊销后即使未过期也不能再通过认证。
+func GenerateSessionAccessToken(sessionID string, userID int64, username, role string) (string, time.Time, error) {
+	return generateAccessToken(contextx.UserContext{
+		UserID:    userID,
+		Username:  username,
+		Role:      role,
+		SessionID: sessionID,
+	})
+}
+
+// generateAccessToken 按配置的有效期为 user 签发访问 Token，并返回其过期时间
+func generateAccessToken(user contextx.UserContext) (string, time.Time, error) {
+	expiresAt := time.Now().Add(config.GetJWTConfig().GetExpireDuration()).Truncate(time.Second)
+
+	// 由认证中间件签发，签发者和受众与认证时的校验一致
+	token, err := GetAuthMiddleware().GenerateToken(user, expiresAt)
+	if err != nil {
+		return "", time.Time{}, err
 	}
-	return GetAuthMiddleware().GenerateTokenWithDuration(user, time.Hour*24)
+	GetSessionTracker().Touch(user.UserID)
+	return token, expiresAt, nil
 }
 
-func GetDataFromContext(ctx context.Context) (User, bool) {
-	return GetAuthMiddleware().GetDataFromContext(ctx)
+// GenerateTokenPair 生成访问 Token 与刷新 Token。
+//
+// 刷新 Token 使用派生密钥签名，Authenticate 无法解析，
+// 因此不能直接用于访问受保护接口。
+// 刷新 Token 作为一次登录会话记录到 GetSessionStore，ctx 中的客户端信息一并保存；
+// 访问 Token 携带该会话 ID，会话吊销后随之失效。
+func GenerateTokenPair(ctx context.Context, userID int64, username, role string) (TokenPair, error) {
+	tokenTool := appauth.NewTokenTool(config.GetJWTConfig())
+	refreshToken, err := tokenTool.GenerateRefreshToken(userID, username, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	// 从签发的 Token 读取 ID 和有效期，保证会话记录与 Token 声明一致
+	claims, err := tokenTool.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	client, _ := contextx.GetClientFromContext(ctx)
+	GetSessionStore().Add(appauth.Session{
+		ID:        claims.ID,
+		UserID:    userID,
+		CreatedAt: claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	})
+
+	accessToken, expiresAt, err := GenerateSessionAccessToken(claims.ID, userID, username, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
 }
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+)
+
+// RoleAdmin 管理员角色，可访问任意用户的资源
+const RoleAdmin = "admin"
+
+// OwnerIDResolver 从请求中解析目标资源所属用户 ID
+type OwnerIDResolver func(r *http.Request) (int64, error)
+
+// RequireRole 角色授权中间件。
+//
+// 当前用户拥有 roles 中任一角色时放行，否则返回 403；未认证时返回 401。
+// 需放在 Authenticate 之后使用。
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := contextx.GetDataFromContext(r.Context())
+			if !ok {
+				response.WriteJSON(w, http.StatusUnauthorized, response.BaseResponse[struct{}]{
+					Code:    http.StatusUnauthorized,
+					Message: "unauthorized",
+				})
+				return
+			}
+
+			if !slices.Contains(roles, user.Role) {
+				response.WriteJSON(w, http.StatusForbidden, response.BaseResponse[struct{}]{
+					Code:    http.StatusForbidden,
+					Message: "forbidden",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireOwnerOrRole 资源归属授权中间件。
+//
+// 当前用户是资源所有者，或拥有 roles 中任一角色时放行，否则返回 403。
+// 未认证时返回 401；解析所有者失败时按错误类型写入响应（如资源不存在返回 404）。
+// 需放在 Authenticate 之后使用，才能读取到用户信息。
+//
+// 参数：
+//
+//	ownerIDFromRequest - 解析目标资源所属用户 ID
+//	roles - 无需所有权即可访问的角色，例如 RoleAdmin
+func RequireOwnerOrRole(ownerIDFromRequest OwnerIDResolver, roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := contextx.GetDataFromContext(r.Context())
+			if !ok {
+				response.WriteJSON(w, http.StatusUnauthorized, response.BaseResponse[struct{}]{
+					Code:    http.StatusUnauthorized,
+					Message: "unauthorized",
+				})
+				return
+			}
+
+			// 拥有指定角色时无需校验所有权
+			if slices.Contains(roles, user.Role) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ownerID, err := ownerIDFromRequest(r)
+			if err != nil {
+				response.WriteError(w, err)
+				return
+			}
+
+			if ownerID != user.UserID {
+				response.WriteJSON(w, http.StatusForbidden, response.BaseResponse[struct{}]{
+					Code:    http.StatusForbidden,
+					Message: "forbidden",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"todolist/internal/infrastructure/config"
+	applogger "todolist/internal/pkg/logger"
+)
+
+// MaxBodyBytes 返回限制请求体大小的中间件。
+//
+// 使用 http.MaxBytesReader 包装 r.Body，读取超过 limit 字节时返回错误，
+// 由 handler.Wrap 转换为 413 响应。
+func MaxBodyBytes(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MaxBodyBytesMiddleware 使用配置中上限的请求体大小限制中间件。
+//
+// 配置应在启动时校验；此处读取失败时记录警告并使用默认上限 config.DefaultMaxBodyBytes。
+func MaxBodyBytesMiddleware(next http.Handler) http.Handler {
+	cfg, err := config.GetBodyLimitConfig()
+	if err != nil {
+		applogger.Warn("请求体大小限制配置获取失败，使用默认上限", applogger.Err(err))
+		return MaxBodyBytes(config.DefaultMaxBodyBytes)(next)
+	}
+	return MaxBodyBytes(cfg.MaxBodyBytes)(next)
+}
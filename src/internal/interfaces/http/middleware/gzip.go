@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"todolist/internal/infrastructure/config"
+	applogger "todolist/internal/pkg/logger"
+)
+
+// incompressibleTypes 已压缩的内容类型前缀，再次压缩没有收益
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zip",
+	"application/zstd",
+	"application/octet-stream",
+	"text/event-stream",
+}
+
+// gzipWriterPool 复用 gzip.Writer，避免每个响应重新分配压缩缓冲区
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// gzipResponseWriter 按需压缩响应体的 ResponseWriter。
+//
+// 状态码和响应体先缓冲，响应体达到阈值时才决定压缩并写出响应头；
+// 处理器结束时仍未达到阈值则原样输出，因此小响应不带 Content-Encoding。
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minBytes int
+	status   int
+	buf      []byte
+	decided  bool
+	gz       *gzip.Writer
+}
+
+// WriteHeader 暂存状态码，决定是否压缩后再写出
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write 缓冲响应体直到达到阈值，之后直接写入压缩流或原始连接
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.minBytes {
+			return len(b), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 透传 http.Flusher。
+//
+// 未达到阈值时按不压缩输出，保证流式响应及时送达客户端。
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap 返回原始 ResponseWriter，供 http.ResponseController 使用
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close 输出剩余缓冲并结束压缩流，必须在处理器返回后调用
+func (w *gzipResponseWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.gz == nil {
+		return nil
+	}
+	err := w.gz.Close()
+	w.gz.Reset(nil)
+	gzipWriterPool.Put(w.gz)
+	w.gz = nil
+	return err
+}
+
+// decide 决定是否压缩，写出响应头和已缓冲的响应体。
+//
+// compress 为 false 或内容不适合压缩时原样输出。
+func (w *gzipResponseWriter) decide(compress bool) error {
+	w.decided = true
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := w.Header()
+	// 处理器未设置内容类型时按原始内容探测，避免 net/http 对压缩后的字节探测
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	if compress && shouldCompress(status, header) {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(status)
+
+		gz := gzipWriterPool.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.gz = gz
+	} else {
+		w.ResponseWriter.WriteHeader(status)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.gz != nil {
+		_, err = w.gz.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// shouldCompress 判断响应是否适合压缩
+func shouldCompress(status int, header http.Header) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptsGzip 判断客户端是否接受 gzip 编码，q=0 视为不接受
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Gzip 返回 gzip 响应压缩中间件。
+//
+// 仅在请求头 Accept-Encoding 包含 gzip、响应体达到 minBytes 字节、
+// 且内容类型不是图片等已压缩格式时压缩，并设置 Content-Encoding: gzip。
+// 放在 LoggingMiddleware 内层时，访问日志记录的是压缩后的字节数。
+//
+// 参数：
+//
+//	minBytes - 启用压缩的响应体最小字节数
+func Gzip(minBytes int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gw := &gzipResponseWriter{ResponseWriter: w, minBytes: minBytes}
+			defer gw.Close()
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
+
+// GzipMiddleware 使用配置中阈值的 gzip 响应压缩中间件，未启用时直接返回原处理器
+//
+// 配置应在启动时校验；此处读取失败时记录警告并不压缩响应。
+func GzipMiddleware(next http.Handler) http.Handler {
+	cfg, err := config.GetCompressionConfig()
+	if err != nil {
+		applogger.Warn("响应压缩配置获取失败，已禁用压缩", applogger.Err(err))
+		return next
+	}
+	if !cfg.Enabled {
+		return next
+	}
+	return Gzip(cfg.MinBytes)(next)
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+	applogger "todolist/internal/pkg/logger"
+
+	core "github.com/frigidom1024/go-jwt-middleware/core"
+)
+
+// SessionTracker 记录每个用户最近一次活动时间，用于判定会话空闲超时。
+//
+// 数据仅保存在进程内存中，服务重启后重新计时；多实例部署时各实例独立计时。
+// 超过保留时间的记录会被回收，保留时间不短于访问 Token 有效期，
+// 确保记录被回收前签发的 Token 均已过期，不会因记录丢失而绕过空闲超时。
+type SessionTracker struct {
+	mu          sync.Mutex
+	lastSeen    map[int64]time.Time
+	idleTimeout time.Duration
+	retention   time.Duration
+	lastCleanup time.Time
+}
+
+var (
+	sessionTracker     *SessionTracker
+	sessionTrackerOnce sync.Once
+)
+
+// NewSessionTracker 创建会话活动记录器。
+//
+// 参数：
+//
+//	idleTimeout - 空闲超时时间，为 0 时不启用
+//	tokenLifetime - 访问 Token 有效期，决定记录的保留时间
+func NewSessionTracker(idleTimeout, tokenLifetime time.Duration) *SessionTracker {
+	return &SessionTracker{
+		lastSeen:    make(map[int64]time.Time),
+		idleTimeout: idleTimeout,
+		retention:   max(idleTimeout, tokenLifetime),
+		lastCleanup: time.Now(),
+	}
+}
+
+// GetSessionTracker 获取全局会话活动记录器单例，配置从环境变量加载。
+//
+// 配置应在启动时校验；此处读取失败时记录警告并不启用空闲超时。
+func GetSessionTracker() *SessionTracker {
+	sessionTrackerOnce.Do(func() {
+		var idleTimeout time.Duration
+		cfg, err := config.GetSessionConfig()
+		if err != nil {
+			applogger.Warn("会话配置获取失败，已禁用空闲超时", applogger.Err(err))
+		} else {
+			idleTimeout = cfg.IdleTimeout
+		}
+		sessionTracker = NewSessionTracker(idleTimeout, config.GetJWTConfig().GetExpireDuration())
+	})
+	return sessionTracker
+}
+
+// Enabled 是否启用空闲超时
+func (t *SessionTracker) Enabled() bool {
+	return t.idleTimeout > 0
+}
+
+// Touch 记录用户活动，签发访问 Token 时调用以开始新的空闲计时。
+func (t *SessionTracker) Touch(userID int64) {
+	t.touchAt(userID, time.Now())
+}
+
+// Check 判断用户会话是否仍处于活跃状态。
+//
+// 距上次活动不超过空闲超时时间时刷新活动时间并返回 true；
+// 已超时时返回 false 且不刷新，之后的请求同样被拒绝，直到重新签发 Token。
+// 没有活动记录（如服务重启后）时视为活跃并开始计时。
+func (t *SessionTracker) Check(userID int64) bool {
+	if !t.Enabled() {
+		return true
+	}
+
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cleanup(now)
+	if last, ok := t.lastSeen[userID]; ok && now.Sub(last) > t.idleTimeout {
+		return false
+	}
+	t.lastSeen[userID] = now
+	return true
+}
+
+// Len 返回当前保存的活动记录数量。
+func (t *SessionTracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.lastSeen)
+}
+
+// touchAt 以指定时间记录用户活动。
+func (t *SessionTracker) touchAt(userID int64, now time.Time) {
+	if !t.Enabled() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cleanup(now)
+	t.lastSeen[userID] = now
+}
+
+// cleanup 回收超过保留时间的活动记录，最多每个空闲超时周期执行一次，调用方需持有锁。
+func (t *SessionTracker) cleanup(now time.Time) {
+	if now.Sub(t.lastCleanup) < t.idleTimeout {
+		return
+	}
+	for userID, last := range t.lastSeen {
+		if now.Sub(last) >= t.retention {
+			delete(t.lastSeen, userID)
+		}
+	}
+	t.lastCleanup = now
+}
+
+// idleAuthMiddleware 在认证中间件基础上增加会话空闲超时检查
+type idleAuthMiddleware struct {
+	core.AuthMiddleware[contextx.UserContext]
+	sessions *SessionTracker
+}
+
+// WithIdleTimeout 为认证中间件增加会话空闲超时检查。
+//
+// Authenticate 在 Token 校验通过后检查用户会话是否空闲超时，超时返回 401；
+// OptionalAuthenticate 不做检查。
+func WithIdleTimeout(mw core.AuthMiddleware[contextx.UserContext], sessions *SessionTracker) core.AuthMiddleware[contextx.UserContext] {
+	if !sessions.Enabled() {
+		return mw
+	}
+	return &idleAuthMiddleware{AuthMiddleware: mw, sessions: sessions}
+}
+
+// Authenticate 校验 Token 并检查会话空闲超时
+func (m *idleAuthMiddleware) Authenticate(next http.Handler) http.Handler {
+	return m.AuthMiddleware.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := contextx.GetDataFromContext(r.Context())
+		if ok && !m.sessions.Check(user.UserID) {
+			response.WriteJSON(w, http.StatusUnauthorized, response.BaseResponse[struct{}]{
+				Code:    http.StatusUnauthorized,
+				Message: "session expired due to inactivity",
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	}))
+}
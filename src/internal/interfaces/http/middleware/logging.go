@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	applogger "todolist/internal/pkg/logger"
+)
+
+// RequestIDHeader 请求 ID 请求头
+const RequestIDHeader = "X-Request-ID"
+
+// skipAccessLogPaths 成功时不记录访问日志的路径，避免健康检查和指标抓取刷屏
+var skipAccessLogPaths = map[string]bool{
+	"/health":  true,
+	"/metrics": true,
+}
+
+// statusRecorder 记录响应状态码和写入字节数的 ResponseWriter
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+// WriteHeader 记录状态码
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write 记录写入字节数，未显式写状态码时视为 200
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Flush 透传 http.Flusher，保证流式响应可用
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+// Unwrap 返回原始 ResponseWriter，供 http.ResponseController 使用
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// LoggingMiddleware 访问日志中间件。
+//
+// 每个请求记录一条日志，包含方法、路径、状态码、响应字节数、耗时，
+// 以及请求头中的请求 ID（如果存在）。
+// 健康检查等路径仅在失败时记录。
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if skipAccessLogPaths[r.URL.Path] && status < http.StatusBadRequest {
+			return
+		}
+
+		fields := []any{
+			applogger.String("method", r.Method),
+			applogger.String("path", r.URL.Path),
+			applogger.Int("status", status),
+			applogger.Int("bytes", recorder.bytes),
+			applogger.Int64("duration_ms", time.Since(start).Milliseconds()),
+		}
+		if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+			fields = append(fields, applogger.String("request_id", requestID))
+		}
+
+		applogger.InfoContext(r.Context(), "http request", fields...)
+	})
+}
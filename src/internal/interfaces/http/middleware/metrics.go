@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"todolist/internal/pkg/metrics"
+)
+
+// unmatchedRoute 未匹配到路由时使用的标签值，避免按原始路径产生高基数标签
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware HTTP 指标中间件。
+//
+// 记录请求数和耗时分布，路由标签取自 ServeMux 匹配到的路由模式。
+// 应包裹在 ServeMux 外层，与访问日志等中间件可任意组合。
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		// ServeMux 在路由时写入 r.Pattern
+		route := r.Pattern
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		labels := []string{route, r.Method, strconv.Itoa(status)}
+		metrics.HTTPRequestsTotal.WithLabelValues(labels...).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	})
+}
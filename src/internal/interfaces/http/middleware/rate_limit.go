@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+	applogger "todolist/internal/pkg/logger"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter 基于令牌桶的限流器。
+//
+// 为每个 key（客户端 IP 或用户 ID）维护独立的令牌桶，
+// 空闲超过 idleTimeout 的令牌桶会被回收，避免内存无限增长。
+type RateLimiter struct {
+	mu          sync.Mutex
+	limiters    map[string]*limiterEntry
+	rate        rate.Limit
+	burst       int
+	idleTimeout time.Duration
+	lastCleanup time.Time
+}
+
+// limiterEntry 单个 key 对应的令牌桶及最近访问时间
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+var (
+	rateLimiter     *RateLimiter
+	rateLimiterOnce sync.Once
+)
+
+// NewRateLimiter 创建限流器。
+//
+// 参数：
+//
+//	requestsPerSecond - 每秒补充的令牌数
+//	burst - 令牌桶容量
+//	idleTimeout - 令牌桶空闲回收时间
+func NewRateLimiter(requestsPerSecond float64, burst int, idleTimeout time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limiters:    make(map[string]*limiterEntry),
+		rate:        rate.Limit(requestsPerSecond),
+		burst:       burst,
+		idleTimeout: idleTimeout,
+		lastCleanup: time.Now(),
+	}
+}
+
+// GetRateLimiter 获取全局限流器单例，配置从环境变量加载。
+//
+// 配置应在启动时校验；此处读取失败时记录警告并使用默认限流参数。
+func GetRateLimiter() *RateLimiter {
+	rateLimiterOnce.Do(func() {
+		cfg, err := config.GetRateLimitConfig()
+		if err != nil {
+			applogger.Warn("限流配置获取失败，使用默认配置", applogger.Err(err))
+			cfg = &config.RateLimitConfig{
+				RequestsPerSecond: config.DefaultRateLimitRPS,
+				Burst:             config.DefaultRateLimitBurst,
+				IdleTimeout:       config.DefaultRateLimitIdleTimeout,
+			}
+		}
+		rateLimiter = NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst, cfg.IdleTimeout)
+	})
+	return rateLimiter
+}
+
+// RateLimitMiddleware 使用全局限流器的限流中间件。
+//
+// 已认证请求按用户 ID 限流，匿名请求按客户端 IP 限流。
+// 用于已认证路由时应放在 Authenticate 之后，才能读取到用户信息。
+func RateLimitMiddleware(next http.Handler) http.Handler {
+	return GetRateLimiter().Limit(next)
+}
+
+// Limit 返回限流包装后的 http.Handler。
+//
+// 令牌耗尽时返回 429，并通过 Retry-After 头告知客户端需等待的秒数。
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, retryAfter := l.Allow(rateLimitKey(r))
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			response.WriteJSON(w, http.StatusTooManyRequests, response.BaseResponse[struct{}]{
+				Code:    http.StatusTooManyRequests,
+				Message: "too many requests",
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allow 判断 key 是否还有可用令牌。
+//
+// 返回：
+//
+//	bool - 是否允许本次请求
+//	time.Duration - 不允许时，距离下一个令牌可用的等待时间
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	now := time.Now()
+	limiter := l.getLimiter(key, now)
+
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// getLimiter 获取 key 对应的令牌桶，不存在时创建，并顺带回收空闲令牌桶。
+func (l *RateLimiter) getLimiter(key string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) >= l.idleTimeout {
+		for k, entry := range l.limiters {
+			if now.Sub(entry.lastSeen) >= l.idleTimeout {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(l.rate, l.burst)}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+// Len 返回当前维护的令牌桶数量。
+func (l *RateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.limiters)
+}
+
+// rateLimitKey 计算限流 key：已认证用户使用用户 ID，否则使用客户端 IP。
+func rateLimitKey(r *http.Request) string {
+	if user, ok := contextx.GetDataFromContext(r.Context()); ok {
+		return "user:" + strconv.FormatInt(user.UserID, 10)
+	}
+	return "ip:" + ClientIP(r)
+}
+
+// ClientIP 从 RemoteAddr 中解析客户端 IP。
+//
+// 不信任 X-Forwarded-For 等可伪造的请求头。
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+
+	"todolist/internal/pkg/contextx"
+)
+
+// WithAuthContext 返回已写入认证用户信息的上下文
+//
+// 效果等同于请求通过 Authenticate，供测试直接调用需要登录用户的处理器。
+func WithAuthContext(userID int64, username, role string) context.Context {
+	return contextx.WithUser(context.Background(), contextx.UserContext{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+	})
+}
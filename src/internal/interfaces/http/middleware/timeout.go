@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"todolist/internal/interfaces/http/response"
+	applogger "todolist/internal/pkg/logger"
+)
+
+// TimeoutMiddleware 返回为请求设置整体截止时间的中间件。
+//
+// 请求上下文通过 context.WithTimeout 派生，处理器中的数据库查询使用同一上下文，
+// 超时后查询随之取消。与 http.TimeoutHandler 相同，处理器在独立的 goroutine 中执行，
+// 响应先写入缓冲区，按时完成后再写给客户端；超时时丢弃缓冲内容并返回 503 JSON 响应，
+// 处理器之后的写入返回 http.ErrHandlerTimeout。缓冲写入不支持 http.Flusher。
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			tw := &timeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicChan := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicChan <- p
+					}
+				}()
+				next.ServeHTTP(tw, r)
+				close(done)
+			}()
+
+			select {
+			case p := <-panicChan:
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				dst := w.Header()
+				for k, v := range tw.header {
+					dst[k] = v
+				}
+				if !tw.wroteHeader {
+					tw.status = http.StatusOK
+				}
+				w.WriteHeader(tw.status)
+				w.Write(tw.buf.Bytes())
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				tw.timedOut = true
+				// 客户端断开时无需响应
+				if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return
+				}
+				applogger.WarnContext(r.Context(), "请求处理超时",
+					applogger.String("method", r.Method),
+					applogger.String("path", r.URL.Path),
+					applogger.Duration("timeout_ms", d),
+				)
+				response.WriteJSON(w, http.StatusServiceUnavailable, response.BaseResponse[struct{}]{
+					Code:    http.StatusServiceUnavailable,
+					Message: "request timeout",
+				})
+			}
+		})
+	}
+}
+
+// RouteTimeoutMiddleware 返回按路由选择超时时间的中间件。
+//
+// 通过 mux.Handler 查出请求匹配的路由模式（如 POST /api/v1/users/me/export），
+// overrides 中存在该模式时使用其中的超时时间，否则使用 d。
+// 未匹配任何路由的请求同样使用 d。overrides 中超时时间非正数的路由不设置整体超时，
+// 用于流式响应等不能缓冲的接口。
+func RouteTimeoutMiddleware(mux *http.ServeMux, d time.Duration, overrides map[string]time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		byPattern := make(map[string]http.Handler, len(overrides))
+		for pattern, timeout := range overrides {
+			if timeout <= 0 {
+				byPattern[pattern] = next
+				continue
+			}
+			byPattern[pattern] = TimeoutMiddleware(timeout)(next)
+		}
+		fallback := TimeoutMiddleware(d)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, pattern := mux.Handler(r)
+			if h, ok := byPattern[pattern]; ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+			fallback.ServeHTTP(w, r)
+		})
+	}
+}
+
+// timeoutWriter 缓冲处理器响应的 ResponseWriter，超时后拒绝写入
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.status = status
+	tw.wroteHeader = true
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.status = http.StatusOK
+		tw.wroteHeader = true
+	}
+	return tw.buf.Write(b)
+}
@@ -0,0 +1,88 @@
+// Package openapi 生成并提供 OpenAPI 3 接口描述。
+//
+// 路由表在 routes.go 中手工维护，请求/响应的 Schema 通过反射
+// request、response 包中的结构体（json、form、validate 标签）生成，
+// 结构体字段变化时文档自动同步。
+package openapi
+
+// Version 使用的 OpenAPI 规范版本
+const Version = "3.0.3"
+
+// Document OpenAPI 文档根对象
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info 接口基本信息
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem 单个路径下各 HTTP 方法的操作
+type PathItem map[string]*Operation
+
+// Operation 单个接口操作
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter 查询参数
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType 特定内容类型的 Schema
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components 可复用的 Schema 与认证方式
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme 认证方式
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Schema 数据结构描述（OpenAPI Schema Object 的子集）
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	MaxLength  *int               `json:"maxLength,omitempty"`
+}
@@ -0,0 +1,254 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"todolist/internal/interfaces/http/response"
+)
+
+const (
+	// bearerAuth 认证方式名称
+	bearerAuth = "bearerAuth"
+
+	// errorSchema 错误响应的组件名称
+	errorSchema = "Error"
+)
+
+var (
+	specJSON     []byte
+	specJSONErr  error
+	specJSONOnce sync.Once
+)
+
+// Build 根据路由表生成 OpenAPI 文档
+func Build() *Document {
+	registry := newSchemaRegistry()
+	registry.schemas[errorSchema] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+			// 仅请求参数校验失败时返回，列出每个字段的错误
+			"data": registry.schemaOf(reflect.TypeOf(response.ValidationErrorResponse{})),
+		},
+		Required: []string{"code", "message"},
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "TodoList API",
+			Version:     "1.0.0",
+			Description: "所有响应均包裹在 {code, message, data} 结构中。",
+		},
+		Paths: make(map[string]*PathItem),
+		Components: Components{
+			Schemas: registry.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, rt := range apiRoutes {
+		item, ok := doc.Paths[rt.path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[rt.path] = item
+		}
+		(*item)[strings.ToLower(rt.method)] = registry.operation(rt)
+	}
+
+	return doc
+}
+
+// operation 生成单个接口的操作描述
+func (r *schemaRegistry) operation(rt route) *Operation {
+	op := &Operation{
+		Tags:        []string{rt.tag},
+		Summary:     rt.summary,
+		OperationID: operationID(rt),
+		Responses: map[string]*Response{
+			"200": {
+				Description: "成功",
+				Content:     jsonContent(r.envelope(rt.response)),
+			},
+			"default": {
+				Description: "错误",
+				Content:     jsonContent(ref(errorSchema)),
+			},
+		},
+	}
+
+	if rt.created {
+		op.Responses["201"] = op.Responses["200"]
+		op.Responses["201"].Description = "创建成功"
+		delete(op.Responses, "200")
+	}
+
+	if rt.attachment {
+		op.Responses["200"] = &Response{
+			Description: "成功，响应体为 JSON 附件",
+			Content:     jsonContent(r.schemaOf(reflect.TypeOf(rt.response))),
+		}
+	}
+
+	if rt.auth {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	op.Parameters = r.pathParameters(rt)
+
+	switch {
+	case rt.uploadField != "":
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				"multipart/form-data": {Schema: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						rt.uploadField: {Type: "string", Format: "binary"},
+					},
+					Required: []string{rt.uploadField},
+				}},
+			},
+		}
+	case rt.request == nil:
+	case rt.method == http.MethodGet:
+		op.Parameters = append(op.Parameters, r.queryParameters(reflect.TypeOf(rt.request))...)
+		if _, ok := rt.response.(response.FieldSelector); ok {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:   "fields",
+				In:     "query",
+				Schema: &Schema{Type: "string"},
+			})
+		}
+	default:
+		t := reflect.TypeOf(rt.request)
+		if t.Kind() == reflect.Struct && !hasBodyFields(t) {
+			break
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(r.schemaOf(t)),
+		}
+	}
+
+	return op
+}
+
+// envelope 生成 {code, message, data} 统一响应结构
+func (r *schemaRegistry) envelope(data any) *Schema {
+	s := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"code", "message"},
+	}
+	if data != nil {
+		s.Properties["data"] = r.schemaOf(reflect.TypeOf(data))
+	}
+	return s
+}
+
+// pathParameters 解析路径中的 {name} 参数
+//
+// 参数类型取自请求结构体中 path 标签同名的字段，未声明时按资源 ID（int64）处理。
+func (r *schemaRegistry) pathParameters(rt route) []Parameter {
+	types := make(map[string]reflect.Type)
+	if rt.request != nil {
+		if t := reflect.TypeOf(rt.request); t.Kind() == reflect.Struct {
+			for _, f := range fields(t) {
+				if name := f.Tag.Get("path"); name != "" && name != "-" {
+					types[name] = f.Type
+				}
+			}
+		}
+	}
+
+	var params []Parameter
+	for _, segment := range strings.Split(rt.path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.Trim(segment, "{}")
+			schema := &Schema{Type: "integer", Format: "int64"}
+			if t, ok := types[name]; ok {
+				schema = r.schemaOf(t)
+			}
+			params = append(params, Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   schema,
+			})
+		}
+	}
+	return params
+}
+
+// jsonContent 返回 application/json 内容描述
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// operationID 由方法和路径生成唯一的操作 ID，如 get_api_v1_users_me
+func operationID(rt route) string {
+	path := strings.NewReplacer("/", "_", "-", "_", "{", "", "}", "").Replace(strings.Trim(rt.path, "/"))
+	return strings.ToLower(rt.method) + "_" + path
+}
+
+// Handler 返回提供 OpenAPI JSON 的处理器
+//
+// 文档在首次请求时生成并缓存。
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		specJSONOnce.Do(func() {
+			specJSON, specJSONErr = json.Marshal(Build())
+		})
+		if specJSONErr != nil {
+			slog.Error("failed to encode openapi document", "error", specJSONErr)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(specJSON)
+	})
+}
+
+// docsPage Swagger UI 页面模板，%q 处填入 OpenAPI 文档地址
+const docsPage = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+  <meta charset="utf-8">
+  <title>TodoList API 文档</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler 返回 Swagger UI 页面处理器
+//
+// 参数：
+//
+//	specURL - OpenAPI 文档地址，如 /openapi.json
+func DocsHandler(specURL string) http.Handler {
+	page := []byte(fmt.Sprintf(docsPage, specURL))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+}
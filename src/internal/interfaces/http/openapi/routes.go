@@ -0,0 +1,146 @@
+package openapi
+
+import (
+	"net/http"
+
+	"todolist/internal/interfaces/http/request"
+	"todolist/internal/interfaces/http/response"
+)
+
+// route 单个接口的文档描述
+//
+// 新增或修改 routes 包中的路由时需同步更新 apiRoutes。
+type route struct {
+	// method HTTP 方法
+	method string
+
+	// path 请求路径
+	path string
+
+	// tag 接口分组
+	tag string
+
+	// summary 接口说明
+	summary string
+
+	// auth 是否需要 Bearer Token
+	auth bool
+
+	// request 请求结构体零值：GET 请求展开为查询参数，其余作为 JSON 请求体
+	request any
+
+	// response 响应 data 字段的结构体零值
+	response any
+
+	// created 为 true 时成功状态码为 201（处理函数返回 response.Created）
+	created bool
+
+	// uploadField 非空时请求体为 multipart/form-data，该字段为上传文件
+	uploadField string
+
+	// attachment 为 true 时响应为 JSON 下载附件，不包裹 {code, message, data}
+	attachment bool
+}
+
+// apiRoutes 对外公开的接口列表
+var apiRoutes = []route{
+	// 认证
+	{method: http.MethodPost, path: "/api/v1/users/login", tag: "auth", summary: "用户登录",
+		request: request.LoginUserRequest{}, response: response.LoginResponse{}},
+	{method: http.MethodPost, path: "/api/v1/auth/reactivate", tag: "auth", summary: "恢复停用的账户并登录（封禁账户不可自助恢复）",
+		request: request.ReactivateAccountRequest{}, response: response.LoginResponse{}},
+	{method: http.MethodPost, path: "/api/v1/auth/refresh", tag: "auth", summary: "使用刷新 Token 换取访问 Token",
+		request: request.RefreshTokenRequest{}, response: response.RefreshTokenResponse{}},
+	{method: http.MethodGet, path: "/api/v1/auth/verify", tag: "auth", summary: "验证邮箱",
+		request: request.VerifyEmailRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodGet, path: "/api/v1/auth/email/confirm", tag: "auth", summary: "确认邮箱变更",
+		request: request.ConfirmEmailChangeRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodGet, path: "/api/v1/auth/introspect", tag: "auth", summary: "查看当前访问 Token 的声明", auth: true,
+		request: request.EmptyRequest{}, response: response.TokenIntrospectionResponse{}},
+
+	// 用户
+	{method: http.MethodPost, path: "/api/v1/users/register", tag: "users", summary: "用户注册",
+		request: request.RegisterUserRequest{}, response: response.UserResponse{}, created: true},
+	{method: http.MethodGet, path: "/api/v1/users/availability", tag: "users", summary: "检查用户名或邮箱是否可用",
+		request: request.AvailabilityRequest{}, response: response.AvailabilityResponse{}},
+	{method: http.MethodGet, path: "/api/v1/users/me", tag: "users", summary: "获取当前用户信息", auth: true,
+		request: request.EmptyRequest{}, response: response.UserResponse{}},
+	{method: http.MethodGet, path: "/api/v1/users/me/summary", tag: "users", summary: "获取首页概览（资料、笔记总数、今日是否已写、连续天数）", auth: true,
+		request: request.EmptyRequest{}, response: response.UserSummaryResponse{}},
+	{method: http.MethodPost, path: "/api/v1/users/me/export", tag: "users", summary: "导出个人数据（资料和全部笔记，JSON 附件）", auth: true,
+		response: response.UserExportResponse{}, attachment: true},
+	{method: http.MethodGet, path: "/api/v1/users/me/sessions", tag: "users", summary: "列出记住登录的会话", auth: true,
+		request: request.EmptyRequest{}, response: response.SessionListResponse{}},
+	{method: http.MethodDelete, path: "/api/v1/users/me/sessions/{id}", tag: "users", summary: "吊销登录会话（刷新 Token 失效）", auth: true,
+		request: request.RevokeSessionRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodDelete, path: "/api/v1/users/me/daily-notes", tag: "daily-notes", summary: "永久删除当前用户的全部笔记（需 confirm 为 true）", auth: true,
+		request: request.DeleteAllDailyNotesRequest{}, response: response.DailyNoteDeleteAllResponse{}},
+	{method: http.MethodGet, path: "/api/v1/users/{username}", tag: "users", summary: "查看用户公开资料（仅活跃用户）",
+		request: request.PublicProfileRequest{}, response: response.PublicProfileResponse{}},
+	{method: http.MethodDelete, path: "/api/v1/users/me", tag: "users", summary: "注销账户", auth: true,
+		request: request.DeleteAccountRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPut, path: "/api/v1/users/password", tag: "users", summary: "修改密码", auth: true,
+		request: request.ChangePasswordRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPut, path: "/api/v1/users/email", tag: "users", summary: "发起邮箱变更（需通过发往新邮箱的链接确认）", auth: true,
+		request: request.UpdateEmailRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPatch, path: "/api/v1/users/username", tag: "users", summary: "修改用户名（有冷却期）", auth: true,
+		request: request.ChangeUsernameRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPut, path: "/api/v1/users/avatar", tag: "users", summary: "更新头像 URL", auth: true,
+		request: request.UpdateAvatarRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPut, path: "/api/v1/users/timezone", tag: "users", summary: "设置时区（决定每日笔记的今天）", auth: true,
+		request: request.UpdateTimezoneRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPost, path: "/api/v1/users/avatar/upload", tag: "users", summary: "上传头像图片", auth: true,
+		uploadField: "avatar", response: response.AvatarUploadResponse{}},
+	{method: http.MethodGet, path: "/api/v1/admin/users", tag: "admin", summary: "按创建日期分页查询用户（管理员）", auth: true,
+		request: request.ListUsersRequest{}, response: response.UserListResponse{}},
+	{method: http.MethodGet, path: "/api/v1/admin/users/search", tag: "admin", summary: "按用户名前缀搜索用户（管理员）", auth: true,
+		request: request.SearchUsersRequest{}, response: response.UserListResponse{}},
+	{method: http.MethodGet, path: "/api/v1/admin/stats", tag: "admin", summary: "按状态统计用户数量（管理员）", auth: true,
+		request: request.EmptyRequest{}, response: response.UserStatsResponse{}},
+	{method: http.MethodGet, path: "/api/v1/admin/users/deleted", tag: "admin", summary: "分页查询可恢复的已注销用户（管理员）", auth: true,
+		request: request.ListDeletedUsersRequest{}, response: response.UserListResponse{}},
+	{method: http.MethodGet, path: "/api/v1/admin/users/{id}/audit-logs", tag: "admin", summary: "分页查询用户敏感操作审计日志（管理员）", auth: true,
+		request: request.ListAuditLogsRequest{}, response: response.AuditLogListResponse{}},
+	{method: http.MethodPost, path: "/api/v1/admin/users/{id}/restore", tag: "admin", summary: "恢复已注销用户（管理员）", auth: true,
+		request: request.RestoreUserRequest{}, response: response.UserResponse{}},
+	{method: http.MethodPost, path: "/api/v1/admin/users/status", tag: "admin", summary: "批量修改用户状态（管理员）", auth: true,
+		request: request.ChangeUsersStatusRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodPost, path: "/api/v1/admin/users/import", tag: "admin", summary: "批量导入用户（管理员）", auth: true,
+		request: request.ImportUsersRequest{}, response: response.UserImportResponse{}},
+
+	// 每日笔记
+	{method: http.MethodPost, path: "/api/v1/daily-notes", tag: "daily-notes", summary: "创建今日笔记", auth: true,
+		request: request.DailyNoteRequest{}, response: response.DailyNoteResponse{}, created: true},
+	{method: http.MethodGet, path: "/api/v1/daily-notes", tag: "daily-notes", summary: "游标分页获取笔记列表（按日期降序，可按标签筛选）", auth: true,
+		request: request.DailyNoteCursorRequest{}, response: response.DailyNoteCursorListResponse{}},
+	{method: http.MethodPost, path: "/api/v1/daily-notes/copy-previous", tag: "daily-notes", summary: "以最近一篇历史笔记的内容创建今日笔记", auth: true,
+		request: request.EmptyRequest{}, response: response.DailyNoteResponse{}, created: true},
+	{method: http.MethodPost, path: "/api/v1/daily-notes/batch", tag: "daily-notes", summary: "批量导入笔记", auth: true,
+		request: request.BatchCreateDailyNotesRequest{}, response: response.DailyNoteBatchResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/today", tag: "daily-notes", summary: "获取今日笔记", auth: true,
+		request: request.EmptyRequest{}, response: response.DailyNoteResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/list", tag: "daily-notes", summary: "分页获取笔记列表", auth: true,
+		request: request.DailyNoteListRequest{}, response: response.DailyNoteListResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/range", tag: "daily-notes", summary: "按日期区间分页获取笔记列表", auth: true,
+		request: request.DailyNoteRangeRequest{}, response: response.DailyNoteListResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/calendar", tag: "daily-notes", summary: "获取月历（指定月份有笔记的日期及字数）", auth: true,
+		request: request.DailyNoteCalendarRequest{}, response: response.DailyNoteCalendarResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/stats", tag: "daily-notes", summary: "获取连续天数统计", auth: true,
+		request: request.EmptyRequest{}, response: response.DailyNoteStatsResponse{}},
+	{method: http.MethodPut, path: "/api/v1/daily-notes/today/update", tag: "daily-notes", summary: "更新今日笔记", auth: true,
+		request: request.DailyNoteRequest{}, response: response.DailyNoteResponse{}},
+	{method: http.MethodDelete, path: "/api/v1/daily-notes/today/delete", tag: "daily-notes", summary: "删除今日笔记", auth: true,
+		request: request.EmptyRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodDelete, path: "/api/v1/daily-notes/{id}", tag: "daily-notes", summary: "删除指定笔记", auth: true,
+		request: request.DailyNoteIDRequest{}, response: response.MessageResponse{}},
+	{method: http.MethodGet, path: "/api/v1/daily-notes/{id}/render", tag: "daily-notes", summary: "将指定笔记的 Markdown 内容渲染为安全过滤后的 HTML", auth: true,
+		request: request.DailyNoteIDRequest{}, response: response.DailyNoteRenderResponse{}},
+	{method: http.MethodPatch, path: "/api/v1/daily-notes/{id}/pin", tag: "daily-notes", summary: "置顶或取消置顶指定笔记", auth: true,
+		request: request.PinDailyNoteRequest{}, response: response.DailyNoteResponse{}},
+	{method: http.MethodPut, path: "/api/v1/daily-notes/{id}/tags", tag: "daily-notes", summary: "设置指定笔记的标签（整体替换）", auth: true,
+		request: request.SetDailyNoteTagsRequest{}, response: response.DailyNoteResponse{}},
+
+	// 健康检查
+	{method: http.MethodGet, path: "/health", tag: "health", summary: "健康检查",
+		request: request.HealthRequest{}, response: response.HealthData{}},
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"todolist/internal/interfaces/dto"
+)
+
+// timeType time.Time 的反射类型，序列化为 RFC 3339 字符串
+var timeType = reflect.TypeOf(time.Time{})
+
+// dateType dto.Date 的反射类型，序列化为 YYYY-MM-DD 字符串
+var dateType = reflect.TypeOf(dto.Date{})
+
+// schemaRegistry 反射生成 Schema，并将具名结构体登记到 components.schemas
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+// newSchemaRegistry 创建 Schema 登记表
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: make(map[string]*Schema)}
+}
+
+// ref 返回 components.schemas 中指定名称的引用
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// schemaOf 返回类型 t 的 Schema。
+//
+// 具名结构体登记为组件并返回引用，其余类型内联展开。
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t == dateType {
+		return &Schema{Type: "string", Format: "date"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if _, ok := r.schemas[t.Name()]; !ok {
+			// 先占位，避免自引用结构体无限递归
+			r.schemas[t.Name()] = &Schema{}
+			*r.schemas[t.Name()] = *r.structSchema(t)
+		}
+		return ref(t.Name())
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema 按 json 标签展开结构体字段
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t) {
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		prop := r.schemaOf(f.Type)
+		applyValidateTag(prop, f.Tag.Get("validate"))
+		s.Properties[name] = prop
+		if isRequired(f) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// queryParameters 将请求结构体中带 form 标签的字段转换为查询参数
+func (r *schemaRegistry) queryParameters(t reflect.Type) []Parameter {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []Parameter
+	for _, f := range fields(t) {
+		name := strings.Split(f.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := r.schemaOf(f.Type)
+		applyValidateTag(schema, f.Tag.Get("validate"))
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: isRequired(f),
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// fields 返回结构体的导出字段，匿名嵌入的结构体字段会被展开
+func fields(t reflect.Type) []reflect.StructField {
+	var result []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			result = append(result, fields(f.Type)...)
+			continue
+		}
+		if f.IsExported() {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// jsonName 返回字段的 JSON 名称，json:"-" 的字段返回 false
+func jsonName(f reflect.StructField) (string, bool) {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// hasBodyFields 结构体是否包含需要从 JSON 请求体读取的字段
+//
+// 仅含路径参数（path 标签）的请求结构体不生成请求体。
+func hasBodyFields(t reflect.Type) bool {
+	for _, f := range fields(t) {
+		if _, ok := jsonName(f); ok && f.Tag.Get("path") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired 字段的 validate 标签是否包含 required
+func isRequired(f reflect.StructField) bool {
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyValidateTag 将 validate 标签中的格式与长度约束写入字符串 Schema
+func applyValidateTag(s *Schema, tag string) {
+	if s.Type != "string" || tag == "" {
+		return
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "min":
+			if n, err := strconv.Atoi(value); err == nil {
+				s.MinLength = &n
+			}
+		case "max":
+			if n, err := strconv.Atoi(value); err == nil {
+				s.MaxLength = &n
+			}
+		}
+	}
+}
@@ -6,8 +6,11 @@ package request
 // 包含每日笔记的内容
 
 type DailyNoteRequest struct {
-	// Content 笔记内容，不能为空
-	Content string `json:"content" validate:"required"`
+	// Content 笔记内容，不能为空，最多 10000 个字符
+	Content string `json:"content" validate:"required,max=10000"`
+
+	// Version 客户端持有的版本号（仅更新时使用），为 0 时不做版本检查
+	Version int `json:"version"`
 }
 
 // DailyNoteListRequest 每日笔记列表请求结构
@@ -21,11 +24,118 @@ type DailyNoteListRequest struct {
 
 	// PageSize 每页大小，默认为10，最大为50
 	PageSize int `json:"page_size" form:"page_size"`
+
+	// Sort 排序方式：date_asc、date_desc（默认）、updated_desc、pinned_first
+	Sort string `json:"sort" form:"sort"`
+}
+
+// DailyNoteCursorRequest 游标分页查询每日笔记列表请求结构
+//
+// 用于按笔记日期降序游标分页查询
+// cursor 为上一页响应中的 next_cursor，首页留空；tag 非空时只返回带有该标签的笔记
+
+type DailyNoteCursorRequest struct {
+	// Tag 按标签筛选，不区分大小写
+	Tag string `json:"tag" form:"tag" validate:"max=32"`
+
+	// Cursor 分页游标
+	Cursor string `json:"cursor" form:"cursor"`
+
+	// Limit 每页大小，默认为10，最大为50
+	Limit int `json:"limit" form:"limit"`
+}
+
+// DailyNoteRangeRequest 按日期区间查询每日笔记列表请求结构
+//
+// 用于按日期区间分页查询每日笔记列表
+// 日期格式为 YYYY-MM-DD，区间为闭区间
+type DailyNoteRangeRequest struct {
+	// From 开始日期
+	From string `json:"from" form:"from"`
+
+	// To 结束日期
+	To string `json:"to" form:"to"`
+
+	// Page 页码，默认为1
+	Page int `json:"page" form:"page"`
+
+	// PageSize 每页大小，默认为10，最大为50
+	PageSize int `json:"page_size" form:"page_size"`
 }
 
+// DailyNoteCalendarRequest 获取每日笔记月历请求结构
+//
+// 年份和月份均不传时查询用户时区的当前月份
+
+type DailyNoteCalendarRequest struct {
+	// Year 年份，范围为1900到9999
+	Year int `json:"year" form:"year"`
+
+	// Month 月份，范围为1到12
+	Month int `json:"month" form:"month"`
+}
+
+// BatchDailyNoteItem 批量导入的单条每日笔记
+
+type BatchDailyNoteItem struct {
+	// Date 笔记日期，格式为 YYYY-MM-DD
+	Date string `json:"date" validate:"required"`
+
+	// Content 笔记内容，不能为空，最多 10000 个字符
+	Content string `json:"content" validate:"required,max=10000"`
+}
+
+// BatchCreateDailyNotesRequest 批量导入每日笔记请求结构
+//
+// 请求体为 JSON 数组，最多 365 条
+
+type BatchCreateDailyNotesRequest []BatchDailyNoteItem
+
 // EmptyRequest 空请求结构
 //
 // 用于不需要请求体的请求
 
 type EmptyRequest struct {}
 
+// DailyNoteIDRequest 按 ID 操作每日笔记请求结构
+//
+// 笔记 ID 通过路径参数 {id} 传递
+
+type DailyNoteIDRequest struct {
+	// ID 笔记 ID
+	ID int64 `json:"-" path:"id"`
+}
+
+// PinDailyNoteRequest 置顶每日笔记请求结构
+//
+// 笔记 ID 通过路径参数 {id} 传递，pinned 使用指针区分“未提供”和 false，省略时校验失败
+
+type PinDailyNoteRequest struct {
+	// ID 笔记 ID
+	ID int64 `json:"-" path:"id"`
+
+	// Pinned 是否置顶，false 表示取消置顶
+	Pinned *bool `json:"pinned" validate:"required"`
+}
+
+// DeleteAllDailyNotesRequest 删除全部每日笔记请求结构
+//
+// 操作不可恢复，confirm 必须为 true，省略或为 false 时返回 400
+
+type DeleteAllDailyNotesRequest struct {
+	// Confirm 确认删除全部笔记
+	Confirm bool `json:"confirm"`
+}
+
+// SetDailyNoteTagsRequest 设置每日笔记标签请求结构
+//
+// 笔记 ID 通过路径参数 {id} 传递，tags 整体替换已有标签，传空数组表示清空
+// 标签会去除首尾空白、转为小写并去重，数量和长度限制由领域层校验
+
+type SetDailyNoteTagsRequest struct {
+	// ID 笔记 ID
+	ID int64 `json:"-" path:"id"`
+
+	// Tags 新的标签列表
+	Tags []string `json:"tags" validate:"required"`
+}
@@ -4,14 +4,17 @@ package request
 //
 // 包含用户注册所需的所有信息。
 type RegisterUserRequest struct {
-	// Username 用户名，3-20个字符，只能包含字母、数字和下划线
-	Username string `json:"username" validate:"required,min=3,max=20,alphanum"`
+	// Username 用户名，3-32个字符，只能包含字母、数字和下划线
+	Username string `json:"username" validate:"required,min=3,max=32"`
 
 	// Email 邮箱地址，必须格式有效且唯一
 	Email string `json:"email" validate:"required,email"`
 
 	// Password 密码，至少8个字符，必须包含大写字母、小写字母、数字中的两种
-	Password string `json:"password" validate:"required,min=8,max=50"`
+	Password string `json:"password" validate:"required,max=72"`
+
+	// CaptchaToken 人机验证凭证，启用注册防护（CAPTCHA_PROVIDER）时必填
+	CaptchaToken string `json:"captcha_token,omitempty"`
 }
 
 // LoginUserRequest 用户登录请求。
@@ -22,7 +25,24 @@ type LoginUserRequest struct {
 	Email string `json:"email" validate:"required,email"`
 
 	// Password 登录密码
-	Password string `json:"password" validate:"required"`
+	Password string `json:"password" validate:"required,max=72"`
+
+	// RememberMe 是否记住登录，为 true 时额外签发长期有效的刷新 Token
+	RememberMe bool `json:"remember_me"`
+}
+
+// ReactivateAccountRequest 恢复停用账户请求。
+//
+// 使用邮箱和密码确认身份，字段含义与登录请求相同。
+type ReactivateAccountRequest struct {
+	// Email 邮箱地址
+	Email string `json:"email" validate:"required,email"`
+
+	// Password 登录密码
+	Password string `json:"password" validate:"required,max=72"`
+
+	// RememberMe 是否记住登录，为 true 时额外签发长期有效的刷新 Token
+	RememberMe bool `json:"remember_me"`
 }
 
 // ChangePasswordRequest 修改密码请求。
@@ -33,7 +53,7 @@ type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" validate:"required"`
 
 	// NewPassword 新密码，必须满足密码强度要求
-	NewPassword string `json:"new_password" validate:"required,min=8,max=50"`
+	NewPassword string `json:"new_password" validate:"required,max=72"`
 }
 
 // UpdateEmailRequest 更新邮箱请求。
@@ -44,10 +64,182 @@ type UpdateEmailRequest struct {
 	NewEmail string `json:"new_email" validate:"required,email"`
 }
 
+// ChangeUsernameRequest 修改用户名请求。
+//
+// 用于用户修改自己的用户名，两次修改之间有冷却期。
+type ChangeUsernameRequest struct {
+	// NewUsername 新用户名，3-32个字符，只能包含字母、数字和下划线
+	NewUsername string `json:"new_username" validate:"required,min=3,max=32"`
+}
+
 // UpdateAvatarRequest 更新头像请求。
 //
-// 用于用户修改头像 URL。
+// 用于用户修改头像 URL。字段使用指针区分“未提供”和“设为空”：
+// 省略或为 null 时校验失败，空字符串表示清除头像。
 type UpdateAvatarRequest struct {
-	// AvatarURL 头像图片 URL，必须以 http:// 或 https:// 开头
-	AvatarURL string `json:"avatar_url" validate:"required,url"`
+	// AvatarURL 头像图片 URL，必须以 http:// 或 https:// 开头，空字符串表示清除头像
+	AvatarURL *string `json:"avatar_url" validate:"required,max=500"`
+}
+
+// UpdateTimezoneRequest 更新时区请求。
+//
+// 与 UpdateAvatarRequest 相同，省略或为 null 时校验失败，空字符串表示清除时区设置。
+type UpdateTimezoneRequest struct {
+	// Timezone IANA 时区名称，如 Asia/Shanghai
+	Timezone *string `json:"timezone" validate:"required,max=64"`
+}
+
+// DeleteAccountRequest 注销账户请求。
+//
+// 需要提供当前密码，防止 Token 泄露后账户被直接注销。
+type DeleteAccountRequest struct {
+	// Password 当前密码，用于验证身份
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshTokenRequest 刷新访问 Token 请求。
+//
+// 使用登录时签发的刷新 Token 换取新的访问 Token。
+type RefreshTokenRequest struct {
+	// RefreshToken 刷新 Token
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// VerifyEmailRequest 邮箱验证请求。
+//
+// 通过查询参数传递注册时生成的验证 Token。
+type VerifyEmailRequest struct {
+	// Token 邮箱验证 Token
+	Token string `json:"token" form:"token" validate:"required"`
+}
+
+// ConfirmEmailChangeRequest 确认邮箱变更请求。
+//
+// 通过查询参数传递发起邮箱变更时生成的确认 Token。
+type ConfirmEmailChangeRequest struct {
+	// Token 邮箱变更确认 Token
+	Token string `json:"token" form:"token" validate:"required"`
+}
+
+// ListUsersRequest 管理端用户列表请求。
+//
+// 通过查询参数传递，创建日期区间为闭区间，格式为 YYYY-MM-DD，两端需同时提供。
+type ListUsersRequest struct {
+	// Page 页码，默认为1
+	Page int `json:"page" form:"page"`
+
+	// PageSize 每页大小，默认为20，最大为100
+	PageSize int `json:"page_size" form:"page_size"`
+
+	// CreatedFrom 创建日期下界
+	CreatedFrom string `json:"created_from" form:"created_from"`
+
+	// CreatedTo 创建日期上界
+	CreatedTo string `json:"created_to" form:"created_to"`
+}
+
+// ListDeletedUsersRequest 管理端已注销用户列表请求。
+//
+// 通过查询参数传递分页参数。
+type ListDeletedUsersRequest struct {
+	// Page 页码，默认为1
+	Page int `json:"page" form:"page"`
+
+	// PageSize 每页大小，默认为20，最大为100
+	PageSize int `json:"page_size" form:"page_size"`
+}
+
+// SearchUsersRequest 管理端按用户名前缀搜索用户请求。
+//
+// 通过查询参数传递。
+type SearchUsersRequest struct {
+	// Q 用户名前缀
+	Q string `json:"q" form:"q" validate:"required,max=50"`
+
+	// Page 页码，默认为1
+	Page int `json:"page" form:"page"`
+
+	// PageSize 每页大小，默认为20，最大为100
+	PageSize int `json:"page_size" form:"page_size"`
+}
+
+// PublicProfileRequest 查看用户公开资料请求。
+//
+// 用户名通过路径参数 {username} 传递。
+type PublicProfileRequest struct {
+	// Username 用户名
+	Username string `json:"-" path:"username" validate:"required"`
+}
+
+// RestoreUserRequest 管理端恢复已注销用户请求。
+//
+// 用户 ID 通过路径参数 {id} 传递。
+type RestoreUserRequest struct {
+	// ID 用户 ID
+	ID int64 `json:"-" path:"id"`
+}
+
+// ListAuditLogsRequest 管理端用户审计日志列表请求。
+//
+// 用户 ID 通过路径参数 {id} 传递，分页参数通过查询参数传递。
+type ListAuditLogsRequest struct {
+	// ID 用户 ID
+	ID int64 `json:"-" path:"id"`
+
+	// Page 页码，默认为1
+	Page int `json:"page" form:"page"`
+
+	// PageSize 每页大小，默认为20，最大为100
+	PageSize int `json:"page_size" form:"page_size"`
+}
+
+// ChangeUsersStatusRequest 管理端批量修改用户状态请求。
+type ChangeUsersStatusRequest struct {
+	// IDs 用户 ID 列表，最多 100 个
+	IDs []int64 `json:"ids" validate:"required,min=1,max=100"`
+
+	// Status 目标状态：active、inactive、banned
+	Status string `json:"status" validate:"required,oneof=active inactive banned"`
+}
+
+// ImportUserItem 批量导入的单个用户
+//
+// 字段在领域层逐行校验，单行无效不会拒绝整个请求。
+type ImportUserItem struct {
+	// Username 用户名
+	Username string `json:"username"`
+
+	// Email 邮箱
+	Email string `json:"email"`
+
+	// Password 初始密码，需满足密码策略
+	Password string `json:"password"`
+}
+
+// ImportUsersRequest 管理端批量导入用户请求。
+type ImportUsersRequest struct {
+	// Users 待导入的用户，最多 100 个
+	Users []ImportUserItem `json:"users" validate:"required,min=1,max=100"`
+
+	// Strict 为 true 时任一用户无效或已存在则不导入任何用户
+	Strict bool `json:"strict"`
+}
+
+// AvailabilityRequest 用户名/邮箱可用性检查请求。
+//
+// 通过查询参数传递，username 与 email 必须且只能提供一个。
+type AvailabilityRequest struct {
+	// Username 待检查的用户名
+	Username string `json:"username" form:"username"`
+
+	// Email 待检查的邮箱
+	Email string `json:"email" form:"email"`
+}
+
+// RevokeSessionRequest 吊销登录会话请求。
+//
+// 会话 ID 通过路径参数 {id} 传递。
+type RevokeSessionRequest struct {
+	// ID 会话 ID
+	ID string `json:"-" path:"id"`
 }
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"time"
+
+	"todolist/internal/interfaces/dto"
+)
+
+// AuditLogResponse 审计日志响应。
+type AuditLogResponse struct {
+	// ID 日志唯一标识
+	ID int64 `json:"id"`
+
+	// UserID 被操作的用户ID
+	UserID int64 `json:"user_id"`
+
+	// ActorID 操作者用户ID，0 表示未知
+	ActorID int64 `json:"actor_id"`
+
+	// Action 操作类型，如 password_changed、email_changed、status_changed
+	Action string `json:"action"`
+
+	// Metadata 附加信息，如新邮箱、新状态
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// CreatedAt 操作时间
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditLogListResponse 审计日志列表响应。
+//
+// 包含审计日志列表和分页信息。
+type AuditLogListResponse struct {
+	// Data 审计日志列表，按时间倒序
+	Data []AuditLogResponse `json:"data"`
+
+	// Pagination 分页信息
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// ToAuditLogListResponse 将审计日志分页DTO转换为响应对象。
+//
+// 参数：
+//
+//	auditLogPageDTO - 审计日志分页数据传输对象
+//
+// 返回：
+//
+//	AuditLogListResponse - HTTP 响应对象
+func ToAuditLogListResponse(auditLogPageDTO dto.AuditLogPageDTO) AuditLogListResponse {
+	data := make([]AuditLogResponse, len(auditLogPageDTO.Data))
+	for i, logDTO := range auditLogPageDTO.Data {
+		data[i] = AuditLogResponse{
+			ID:        logDTO.ID,
+			UserID:    logDTO.UserID,
+			ActorID:   logDTO.ActorID,
+			Action:    logDTO.Action,
+			Metadata:  logDTO.Metadata,
+			CreatedAt: logDTO.CreatedAt,
+		}
+	}
+
+	return AuditLogListResponse{
+		Data:       data,
+		Pagination: ToPaginationResponse(auditLogPageDTO.Pagination),
+	}
+}
@@ -0,0 +1,27 @@
+package response
+
+// Created 资源创建成功的响应。
+//
+// Wrap 的业务处理函数返回 Created[T] 时以 201 写出 Data，
+// 返回其他类型时仍以 200 写出。
+type Created[T Data] struct {
+	// Data 新创建的资源
+	Data T
+}
+
+// NewCreated 返回资源创建成功的响应
+func NewCreated[T Data](data T) Created[T] {
+	return Created[T]{Data: data}
+}
+
+// CreatedData 返回新创建的资源，实现 CreatedResponse 接口
+func (c Created[T]) CreatedData() any {
+	return c.Data
+}
+
+// CreatedResponse 需以 201 写出的响应。
+//
+// Wrap 通过此接口识别任意类型参数的 Created[T]。
+type CreatedResponse interface {
+	CreatedData() any
+}
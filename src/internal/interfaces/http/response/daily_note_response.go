@@ -20,8 +20,8 @@ type DailyNoteResponse struct {
 	// UserID 所属用户ID
 	UserID int64 `json:"user_id"`
 
-	// NoteDate 笔记日期
-	NoteDate time.Time `json:"note_date"`
+	// NoteDate 笔记日期（YYYY-MM-DD）
+	NoteDate dto.Date `json:"note_date"`
 
 	// Content 笔记内容
 	Content string `json:"content"`
@@ -31,6 +31,21 @@ type DailyNoteResponse struct {
 
 	// UpdatedAt 最后更新时间
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Version 版本号，更新时回传以检测并发修改
+	Version int `json:"version"`
+
+	// IsPinned 是否置顶
+	IsPinned bool `json:"is_pinned"`
+
+	// Tags 标签（小写，按字典序排列），没有标签时为空数组
+	Tags []string `json:"tags"`
+
+	// WordCount 内容词数（按空白分隔），用于展示写作进度
+	WordCount int `json:"word_count"`
+
+	// CharCount 内容字符数（按 Unicode 字符计算）
+	CharCount int `json:"char_count"`
 }
 
 // DailyNoteListResponse 每日笔记列表响应。
@@ -44,6 +59,17 @@ type DailyNoteListResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
+// DailyNoteCursorListResponse 每日笔记游标分页列表响应。
+//
+// 包含每日笔记列表和下一页游标。
+type DailyNoteCursorListResponse struct {
+	// Data 每日笔记列表
+	Data []DailyNoteResponse `json:"data"`
+
+	// NextCursor 下一页游标，为空表示没有更多数据
+	NextCursor string `json:"next_cursor"`
+}
+
 // PaginationResponse 分页信息响应。
 //
 // 包含分页查询的元数据。
@@ -59,6 +85,79 @@ type PaginationResponse struct {
 
 	// TotalPages 总页数
 	TotalPages int `json:"total_pages"`
+
+	// HasNext 是否有下一页
+	HasNext bool `json:"has_next"`
+
+	// HasPrev 是否有上一页
+	HasPrev bool `json:"has_prev"`
+
+	// NextPage 下一页页码，没有下一页时为 null
+	NextPage *int `json:"next_page"`
+
+	// PrevPage 上一页页码，没有上一页时为 null
+	PrevPage *int `json:"prev_page"`
+}
+
+// DailyNoteBatchResponse 批量导入每日笔记响应。
+//
+// 包含创建与跳过的统计信息。
+type DailyNoteBatchResponse struct {
+	// Created 成功创建的条数
+	Created int `json:"created"`
+
+	// Skipped 因当日已存在笔记而跳过的条数
+	Skipped int `json:"skipped"`
+
+	// SkippedDates 被跳过的日期（YYYY-MM-DD）
+	SkippedDates []string `json:"skipped_dates"`
+}
+
+// DailyNoteDeleteAllResponse 删除全部每日笔记响应。
+//
+// 包含删除的笔记数。
+type DailyNoteDeleteAllResponse struct {
+	// Deleted 删除的笔记数
+	Deleted int64 `json:"deleted"`
+}
+
+// DailyNoteRenderResponse 每日笔记渲染响应。
+//
+// 包含由 Markdown 内容渲染的 HTML，已过滤脚本等不安全内容，可直接插入页面。
+type DailyNoteRenderResponse struct {
+	// ID 每日笔记唯一标识
+	ID int64 `json:"id"`
+
+	// NoteDate 笔记日期（YYYY-MM-DD）
+	NoteDate dto.Date `json:"note_date"`
+
+	// Version 渲染时笔记的版本号
+	Version int `json:"version"`
+
+	// HTML 渲染后的 HTML
+	HTML string `json:"html"`
+}
+
+// DailyNoteStatsResponse 每日笔记统计响应。
+//
+// 包含连续写笔记天数统计。
+type DailyNoteStatsResponse struct {
+	// CurrentStreak 当前连续天数（今天或昨天为终点）
+	CurrentStreak int `json:"current_streak"`
+
+	// LongestStreak 历史最长连续天数
+	LongestStreak int `json:"longest_streak"`
+
+	// TotalDays 有笔记的总天数
+	TotalDays int `json:"total_days"`
+}
+
+// DailyNoteCalendarResponse 每日笔记月历响应。
+//
+// 只列出有笔记的日期，不包含笔记内容。
+type DailyNoteCalendarResponse struct {
+	// Data 有笔记的日期，按日期升序
+	Data []dto.DailyNoteCalendarDayDTO `json:"data"`
 }
 
 // ToDailyNoteResponse 将每日笔记DTO转换为响应对象。
@@ -78,6 +177,29 @@ func ToDailyNoteResponse(dailyNoteDTO dto.DailyNoteDTO) DailyNoteResponse {
 		Content:   dailyNoteDTO.Content,
 		CreatedAt: dailyNoteDTO.CreatedAt,
 		UpdatedAt: dailyNoteDTO.UpdatedAt,
+		Version:   dailyNoteDTO.Version,
+		IsPinned:  dailyNoteDTO.IsPinned,
+		Tags:      dailyNoteDTO.Tags,
+		WordCount: dailyNoteDTO.WordCount,
+		CharCount: dailyNoteDTO.CharCount,
+	}
+}
+
+// ToDailyNoteRenderResponse 将每日笔记渲染结果DTO转换为响应对象。
+//
+// 参数：
+//
+//	renderDTO - 每日笔记渲染结果数据传输对象
+//
+// 返回：
+//
+//	DailyNoteRenderResponse - HTTP 响应对象
+func ToDailyNoteRenderResponse(renderDTO dto.DailyNoteRenderDTO) DailyNoteRenderResponse {
+	return DailyNoteRenderResponse{
+		ID:       renderDTO.ID,
+		NoteDate: renderDTO.NoteDate,
+		Version:  renderDTO.Version,
+		HTML:     renderDTO.HTML,
 	}
 }
 
@@ -97,16 +219,98 @@ func ToDailyNoteListResponse(dailyNotePageDTO dto.DailyNotePageDTO) DailyNoteLis
 		data[i] = ToDailyNoteResponse(dto)
 	}
 
-	// 转换分页信息
-	pagination := PaginationResponse{
-		Total:      dailyNotePageDTO.Pagination.Total,
-		Page:       dailyNotePageDTO.Pagination.Page,
-		PageSize:   dailyNotePageDTO.Pagination.PageSize,
-		TotalPages: dailyNotePageDTO.Pagination.TotalPages,
+	return DailyNoteListResponse{
+		Data:       data,
+		Pagination: ToPaginationResponse(dailyNotePageDTO.Pagination),
 	}
+}
 
-	return DailyNoteListResponse{
+// ToDailyNoteCursorListResponse 将每日笔记游标分页DTO转换为响应对象。
+//
+// 参数：
+//
+//	cursorPageDTO - 每日笔记游标分页数据传输对象
+//
+// 返回：
+//
+//	DailyNoteCursorListResponse - HTTP 响应对象
+func ToDailyNoteCursorListResponse(cursorPageDTO dto.DailyNoteCursorPageDTO) DailyNoteCursorListResponse {
+	data := make([]DailyNoteResponse, len(cursorPageDTO.Data))
+	for i, dto := range cursorPageDTO.Data {
+		data[i] = ToDailyNoteResponse(dto)
+	}
+
+	return DailyNoteCursorListResponse{
 		Data:       data,
-		Pagination: pagination,
+		NextCursor: cursorPageDTO.NextCursor,
+	}
+}
+
+// ToDailyNoteBatchResponse 将批量导入结果DTO转换为响应对象。
+//
+// 参数：
+//
+//	resultDTO - 批量导入结果数据传输对象
+//
+// 返回：
+//
+//	DailyNoteBatchResponse - HTTP 响应对象
+func ToDailyNoteBatchResponse(resultDTO dto.DailyNoteBatchResultDTO) DailyNoteBatchResponse {
+	return DailyNoteBatchResponse{
+		Created:      resultDTO.Created,
+		Skipped:      resultDTO.Skipped,
+		SkippedDates: resultDTO.SkippedDates,
+	}
+}
+
+// ToDailyNoteCalendarResponse 将月历DTO列表转换为响应对象。
+//
+// 参数：
+//
+//	days - 有笔记的日期数据传输对象列表
+//
+// 返回：
+//
+//	DailyNoteCalendarResponse - HTTP 响应对象
+func ToDailyNoteCalendarResponse(days []dto.DailyNoteCalendarDayDTO) DailyNoteCalendarResponse {
+	return DailyNoteCalendarResponse{Data: days}
+}
+
+// ToDailyNoteStatsResponse 将连续天数统计DTO转换为响应对象。
+//
+// 参数：
+//
+//	streakDTO - 连续天数统计数据传输对象
+//
+// 返回：
+//
+//	DailyNoteStatsResponse - HTTP 响应对象
+func ToDailyNoteStatsResponse(streakDTO dto.DailyNoteStreakDTO) DailyNoteStatsResponse {
+	return DailyNoteStatsResponse{
+		CurrentStreak: streakDTO.CurrentStreak,
+		LongestStreak: streakDTO.LongestStreak,
+		TotalDays:     streakDTO.TotalDays,
+	}
+}
+
+// ToPaginationResponse 将分页信息DTO转换为响应对象。
+//
+// 参数：
+//
+//	paginationDTO - 分页信息数据传输对象
+//
+// 返回：
+//
+//	PaginationResponse - HTTP 响应对象
+func ToPaginationResponse(paginationDTO dto.PaginationDTO) PaginationResponse {
+	return PaginationResponse{
+		Total:      paginationDTO.Total,
+		Page:       paginationDTO.Page,
+		PageSize:   paginationDTO.PageSize,
+		TotalPages: paginationDTO.TotalPages,
+		HasNext:    paginationDTO.HasNext,
+		HasPrev:    paginationDTO.HasPrev,
+		NextPage:   paginationDTO.NextPage,
+		PrevPage:   paginationDTO.PrevPage,
 	}
 }
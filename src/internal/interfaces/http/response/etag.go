@@ -0,0 +1,45 @@
+package response
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// ETagger 可生成 ETag 的响应。
+//
+// Wrap 在 GET 请求成功后检查响应是否实现此接口，
+// 实现时设置 ETag 响应头，并在 If-None-Match 匹配时返回 304。
+type ETagger interface {
+	ETag() string
+}
+
+// WeakETag 根据资源 ID 和最后更新时间生成弱 ETag。
+//
+// 资源的大部分修改都会刷新 updated_at，因此二者组合即可标识资源版本；
+// 不刷新 updated_at 的状态（如笔记置顶和标签）通过 extra 传入，其哈希追加到 ETag 末尾。
+func WeakETag(id int64, updatedAt time.Time, extra ...string) string {
+	if len(extra) == 0 {
+		return fmt.Sprintf(`W/"%d-%x"`, id, updatedAt.UnixNano())
+	}
+	h := fnv.New64a()
+	for _, s := range extra {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf(`W/"%d-%x-%x"`, id, updatedAt.UnixNano(), h.Sum64())
+}
+
+// ETag 实现 ETagger 接口
+func (r UserResponse) ETag() string {
+	return WeakETag(r.ID, r.UpdatedAt)
+}
+
+// ETag 实现 ETagger 接口
+//
+// 置顶和设置标签不修改 updated_at，需计入置顶状态和标签，否则修改后条件请求仍返回 304。
+// 标签已按字典序排列，相同的标签集合得到相同的 ETag。
+func (r DailyNoteResponse) ETag() string {
+	return WeakETag(r.ID, r.UpdatedAt, append([]string{strconv.FormatBool(r.IsPinned)}, r.Tags...)...)
+}
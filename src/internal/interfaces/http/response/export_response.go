@@ -0,0 +1,88 @@
+package response
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"todolist/internal/interfaces/dto"
+)
+
+// UserExportResponse 用户数据导出文档。
+//
+// 作为下载附件直接返回，不包裹统一响应结构。
+// 笔记数量不限，实际由 WriteUserExport 逐条写出，此结构用于描述文档格式。
+type UserExportResponse struct {
+	// ExportedAt 导出时间
+	ExportedAt time.Time `json:"exported_at"`
+
+	// User 用户资料
+	User UserResponse `json:"user"`
+
+	// DailyNotes 按笔记日期降序排列的全部笔记
+	DailyNotes []DailyNoteResponse `json:"daily_notes"`
+}
+
+// WriteUserExport 以流式方式写出用户数据导出文档。
+//
+// 先写出导出时间和用户资料，再逐条写出笔记，内存占用与笔记数量无关。
+// 遍历笔记出错时停止写出并返回错误，此时已写出的 JSON 不完整。
+//
+// 参数：
+//
+//	w - 输出目标
+//	exportDTO - 用户数据导出
+//
+// 返回：
+//
+//	error - 读取笔记或写出失败时的错误
+func WriteUserExport(w io.Writer, exportDTO dto.UserExportDTO) error {
+	exportedAt, err := json.Marshal(exportDTO.ExportedAt)
+	if err != nil {
+		return err
+	}
+	user, err := json.Marshal(UserResponse{
+		ID:            exportDTO.User.ID,
+		Username:      exportDTO.User.Username,
+		Email:         exportDTO.User.Email,
+		AvatarURL:     exportDTO.User.AvatarURL,
+		Status:        exportDTO.User.Status,
+		EmailVerified: exportDTO.User.EmailVerified,
+		CreatedAt:     exportDTO.User.CreatedAt,
+		UpdatedAt:     exportDTO.User.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`{"exported_at":`)
+	bw.Write(exportedAt)
+	bw.WriteString(`,"user":`)
+	bw.Write(user)
+	bw.WriteString(`,"daily_notes":[`)
+
+	first := true
+	for note, err := range exportDTO.DailyNotes {
+		if err != nil {
+			bw.Flush()
+			return err
+		}
+
+		data, err := json.Marshal(ToDailyNoteResponse(note))
+		if err != nil {
+			bw.Flush()
+			return err
+		}
+		if !first {
+			bw.WriteByte(',')
+		}
+		first = false
+		bw.Write(data)
+	}
+
+	bw.WriteString("]}\n")
+	// bufio.Writer 会保留首次写入错误，由 Flush 统一返回
+	return bw.Flush()
+}
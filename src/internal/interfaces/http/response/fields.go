@@ -0,0 +1,130 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldSelector 支持按 fields 查询参数裁剪字段的响应。
+//
+// Wrap 在 GET 请求携带 fields 参数时检查响应是否实现此接口，
+// 实现时只写出请求的字段，用于减少移动端的响应体积。
+type FieldSelector interface {
+	SelectFields(fields []string) (any, error)
+}
+
+// ParseFields 解析逗号分隔的字段列表，去除空白、空项和重复项
+func ParseFields(raw string) []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// selectFields 只保留 v 的 JSON 表示中 fields 指定的顶层字段
+//
+// 字段名按 v 的 json 标签校验，未知字段被忽略；
+// 没有任何已知字段时返回完整的 v，与不携带 fields 参数一致。
+func selectFields(v any, fields []string) (any, error) {
+	known := jsonFieldNames(reflect.TypeOf(v))
+	selected := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if known[field] {
+			selected = append(selected, field)
+		}
+	}
+	if len(selected) == 0 {
+		return v, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	projected := make(map[string]json.RawMessage, len(selected))
+	for _, field := range selected {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected, nil
+}
+
+// selectEach 对列表中的每一项按 fields 裁剪字段
+func selectEach[T any](items []T, fields []string) ([]any, error) {
+	result := make([]any, len(items))
+	for i, item := range items {
+		projected, err := selectFields(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = projected
+	}
+	return result, nil
+}
+
+// jsonFieldNames 返回结构体类型可序列化的顶层 JSON 字段名
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	if t == nil || t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// SelectFields 实现 FieldSelector 接口
+func (r DailyNoteResponse) SelectFields(fields []string) (any, error) {
+	return selectFields(r, fields)
+}
+
+// SelectFields 实现 FieldSelector 接口，只裁剪 data 中的每条笔记，分页信息保持完整
+func (r DailyNoteListResponse) SelectFields(fields []string) (any, error) {
+	data, err := selectEach(r.Data, fields)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Data       []any              `json:"data"`
+		Pagination PaginationResponse `json:"pagination"`
+	}{data, r.Pagination}, nil
+}
+
+// SelectFields 实现 FieldSelector 接口，只裁剪 data 中的每条笔记，下一页游标保持完整
+func (r DailyNoteCursorListResponse) SelectFields(fields []string) (any, error) {
+	data, err := selectEach(r.Data, fields)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Data       []any  `json:"data"`
+		NextCursor string `json:"next_cursor"`
+	}{data, r.NextCursor}, nil
+}
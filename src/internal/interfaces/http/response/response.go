@@ -1,6 +1,7 @@
 package response
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
@@ -15,6 +16,15 @@ var TypeToHTTP = map[domainerr.ErrorType]int{
 	domainerr.ConflictError:        http.StatusConflict,
 	domainerr.AuthenticationError:  http.StatusUnauthorized,
 	domainerr.InternalError:        http.StatusInternalServerError,
+	domainerr.UnavailableError:     http.StatusServiceUnavailable,
+}
+
+// StatusClientClosedRequest 客户端在响应前断开连接，沿用 nginx 的非标准状态码 499
+const StatusClientClosedRequest = 499
+
+// IsRequestCanceled 判断错误是否由请求上下文取消（客户端断开）或超时引起
+func IsRequestCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 // Data 约束：可序列化为 JSON 的数据类型
@@ -48,6 +58,15 @@ func WriteOK[T Data](w http.ResponseWriter, data T) {
 	})
 }
 
+// WriteCreated 写入资源创建成功响应（201）
+func WriteCreated[T Data](w http.ResponseWriter, data T) {
+	WriteJSON(w, http.StatusCreated, BaseResponse[T]{
+		Code:    201,
+		Message: "created",
+		Data:    data,
+	})
+}
+
 // WriteBadRequest 写入请求错误响应
 func WriteBadRequest(w http.ResponseWriter, message string) {
 	WriteJSON(w, http.StatusBadRequest, BaseResponse[struct{}]{
@@ -58,7 +77,25 @@ func WriteBadRequest(w http.ResponseWriter, message string) {
 
 // WriteError 写入错误响应
 // 使用 errors.As 来正确处理领域错误的类型断言
+// 请求上下文取消导致的错误不是服务端错误：客户端断开返回 499，超时返回 503，均不记录 Error 日志
 func WriteError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.Canceled) {
+		slog.Info("request canceled", "error", err)
+		WriteJSON(w, StatusClientClosedRequest, BaseResponse[struct{}]{
+			Code:    StatusClientClosedRequest,
+			Message: "request canceled",
+		})
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.Warn("request deadline exceeded", "error", err)
+		WriteJSON(w, http.StatusServiceUnavailable, BaseResponse[struct{}]{
+			Code:    http.StatusServiceUnavailable,
+			Message: "request timeout",
+		})
+		return
+	}
+
 	var be domainerr.BusinessError
 	if errors.As(err, &be) {
 		status := TypeToHTTP[be.Type]
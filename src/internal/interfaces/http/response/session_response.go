@@ -0,0 +1,50 @@
+package response
+
+import (
+	"time"
+
+	appauth "todolist/internal/pkg/auth"
+)
+
+// SessionResponse 登录会话响应。
+type SessionResponse struct {
+	// ID 会话 ID，吊销会话时使用
+	ID string `json:"id"`
+
+	// CreatedAt 登录时间
+	CreatedAt time.Time `json:"created_at"`
+
+	// LastUsedAt 最近一次刷新访问 Token 的时间
+	LastUsedAt time.Time `json:"last_used_at"`
+
+	// ExpiresAt 会话（刷新 Token）过期时间
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// UserAgent 登录时的 User-Agent
+	UserAgent string `json:"user_agent"`
+
+	// IP 登录时的客户端 IP
+	IP string `json:"ip"`
+}
+
+// SessionListResponse 登录会话列表响应。
+type SessionListResponse struct {
+	// Data 未过期的会话，按登录时间倒序
+	Data []SessionResponse `json:"data"`
+}
+
+// ToSessionListResponse 将会话列表转换为响应对象。
+func ToSessionListResponse(sessions []appauth.Session) SessionListResponse {
+	data := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		data[i] = SessionResponse{
+			ID:         session.ID,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+		}
+	}
+	return SessionListResponse{Data: data}
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+
+	"todolist/internal/pkg/contextx"
+)
+
+// TokenIntrospectionResponse 当前访问 Token 的声明，不包含 Token 本身
+type TokenIntrospectionResponse struct {
+	// UserID 用户 ID
+	UserID int64 `json:"user_id"`
+
+	// Username 签发时的用户名
+	Username string `json:"username"`
+
+	// Role 签发时的用户角色
+	Role string `json:"role"`
+
+	// IssuedAt 签发时间，旧版本签发的 Token 没有签发时间时省略
+	IssuedAt *time.Time `json:"issued_at,omitempty"`
+
+	// ExpiresAt 过期时间
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ToTokenIntrospectionResponse 将 Token 声明转换为响应对象
+func ToTokenIntrospectionResponse(claims contextx.Claims) TokenIntrospectionResponse {
+	resp := TokenIntrospectionResponse{
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		Role:      claims.Role,
+		ExpiresAt: claims.ExpiresAt,
+	}
+	if !claims.IssuedAt.IsZero() {
+		issuedAt := claims.IssuedAt
+		resp.IssuedAt = &issuedAt
+	}
+	return resp
+}
@@ -0,0 +1,70 @@
+package response
+
+import "todolist/internal/interfaces/dto"
+
+// UserImportRowResponse 批量导入用户单行结果响应。
+type UserImportRowResponse struct {
+	// Index 在请求中的位置（从 0 开始）
+	Index int `json:"index"`
+
+	// Username 输入的用户名
+	Username string `json:"username"`
+
+	// Email 输入的邮箱
+	Email string `json:"email"`
+
+	// Status 处理结果：created、skipped、error
+	Status string `json:"status"`
+
+	// Code 跳过或失败原因的错误码
+	Code string `json:"code,omitempty"`
+
+	// Reason 跳过或失败的原因
+	Reason string `json:"reason,omitempty"`
+}
+
+// UserImportResponse 批量导入用户响应。
+//
+// 包含统计信息和与请求顺序一致的逐行结果。
+type UserImportResponse struct {
+	// Created 成功创建的用户数
+	Created int `json:"created"`
+
+	// Skipped 跳过的用户数
+	Skipped int `json:"skipped"`
+
+	// Failed 校验失败的用户数
+	Failed int `json:"failed"`
+
+	// Rows 逐行结果
+	Rows []UserImportRowResponse `json:"rows"`
+}
+
+// ToUserImportResponse 将批量导入用户结果DTO转换为响应对象。
+//
+// 参数：
+//
+//	resultDTO - 批量导入用户结果数据传输对象
+//
+// 返回：
+//
+//	UserImportResponse - HTTP 响应对象
+func ToUserImportResponse(resultDTO dto.UserImportResultDTO) UserImportResponse {
+	rows := make([]UserImportRowResponse, len(resultDTO.Rows))
+	for i, row := range resultDTO.Rows {
+		rows[i] = UserImportRowResponse{
+			Index:    row.Index,
+			Username: row.Username,
+			Email:    row.Email,
+			Status:   row.Status,
+			Code:     row.Code,
+			Reason:   row.Reason,
+		}
+	}
+	return UserImportResponse{
+		Created: resultDTO.Created,
+		Skipped: resultDTO.Skipped,
+		Failed:  resultDTO.Failed,
+		Rows:    rows,
+	}
+}
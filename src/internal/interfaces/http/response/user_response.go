@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"todolist/internal/domain/user"
+	"todolist/internal/interfaces/dto"
 )
 
 // UserResponse 用户信息响应。
@@ -26,9 +27,15 @@ type UserResponse struct {
 	// AvatarURL 头像 URL，可能为空
 	AvatarURL string `json:"avatar_url,omitempty"`
 
+	// Timezone 用户时区（IANA 名称），未设置时为空
+	Timezone string `json:"timezone,omitempty"`
+
 	// Status 账户状态（active/inactive/banned）
 	Status string `json:"status"`
 
+	// EmailVerified 邮箱是否已验证
+	EmailVerified bool `json:"email_verified"`
+
 	// CreatedAt 账户创建时间
 	CreatedAt time.Time `json:"created_at"`
 
@@ -43,10 +50,25 @@ type LoginResponse struct {
 	// Token JWT 访问令牌
 	Token string `json:"token"`
 
+	// RefreshToken 刷新令牌，仅在记住登录时返回
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// ExpiresAt 访问令牌过期时间（RFC 3339）
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// ExpiresIn 访问令牌有效期（秒）
+	ExpiresIn int64 `json:"expires_in"`
+
 	// User 用户信息
 	User UserResponse `json:"user"`
 }
 
+// RefreshTokenResponse 刷新访问 Token 响应。
+type RefreshTokenResponse struct {
+	// Token 新的 JWT 访问令牌
+	Token string `json:"token"`
+}
+
 // ErrorResponse 错误响应。
 //
 // 统一的错误响应格式。
@@ -66,6 +88,73 @@ type MessageResponse struct {
 	Message string `json:"message"`
 }
 
+// AvatarUploadResponse 头像上传响应。
+type AvatarUploadResponse struct {
+	// AvatarURL 上传后的头像 URL
+	AvatarURL string `json:"avatar_url"`
+}
+
+// AvailabilityResponse 用户名/邮箱可用性检查响应。
+type AvailabilityResponse struct {
+	// Available 是否可用于注册
+	Available bool `json:"available"`
+}
+
+// UserSummaryResponse 用户首页概览响应。
+type UserSummaryResponse struct {
+	// User 用户信息
+	User UserResponse `json:"user"`
+
+	// TotalNotes 笔记总数
+	TotalNotes int64 `json:"total_notes"`
+
+	// HasTodayNote 今天是否已写笔记
+	HasTodayNote bool `json:"has_today_note"`
+
+	// CurrentStreak 当前连续天数
+	CurrentStreak int `json:"current_streak"`
+}
+
+// PublicProfileResponse 用户公开资料响应。
+//
+// 不包含邮箱、状态等非公开信息。
+type PublicProfileResponse struct {
+	// ID 用户唯一标识
+	ID int64 `json:"id"`
+
+	// Username 用户名
+	Username string `json:"username"`
+
+	// AvatarURL 头像 URL
+	AvatarURL string `json:"avatar_url"`
+}
+
+// UserStatsResponse 用户数量统计响应。
+type UserStatsResponse struct {
+	// Total 用户总数（不含已注销用户）
+	Total int64 `json:"total"`
+
+	// Active 活跃用户数
+	Active int64 `json:"active"`
+
+	// Inactive 未激活用户数
+	Inactive int64 `json:"inactive"`
+
+	// Banned 已封禁用户数
+	Banned int64 `json:"banned"`
+}
+
+// UserListResponse 用户列表响应。
+//
+// 包含用户列表和分页信息。
+type UserListResponse struct {
+	// Data 用户列表
+	Data []UserResponse `json:"data"`
+
+	// Pagination 分页信息
+	Pagination PaginationResponse `json:"pagination"`
+}
+
 // ToUserResponse 将用户实体转换为响应对象。
 //
 // 参数：
@@ -77,12 +166,107 @@ type MessageResponse struct {
 //	UserResponse - HTTP 响应对象
 func ToUserResponse(userEntity user.UserEntity) UserResponse {
 	return UserResponse{
-		ID:        userEntity.GetID(),
-		Username:  userEntity.GetUsername(),
-		Email:     userEntity.GetEmail(),
-		AvatarURL: userEntity.GetAvatarURL(),
-		Status:    string(userEntity.GetStatus()),
-		CreatedAt: userEntity.GetCreatedAt(),
-		UpdatedAt: userEntity.GetUpdatedAt(),
+		ID:            userEntity.GetID(),
+		Username:      userEntity.GetUsername(),
+		Email:         userEntity.GetEmail(),
+		AvatarURL:     userEntity.GetAvatarURL(),
+		Timezone:      userEntity.GetTimezone(),
+		Status:        string(userEntity.GetStatus()),
+		EmailVerified: userEntity.IsEmailVerified(),
+		CreatedAt:     userEntity.GetCreatedAt(),
+		UpdatedAt:     userEntity.GetUpdatedAt(),
+	}
+}
+
+// ToUserListResponse 将用户分页DTO转换为响应对象。
+//
+// 参数：
+//
+//	userPageDTO - 用户分页数据传输对象
+//
+// 返回：
+//
+//	UserListResponse - HTTP 响应对象
+func ToUserListResponse(userPageDTO dto.UserPageDTO) UserListResponse {
+	data := make([]UserResponse, len(userPageDTO.Data))
+	for i, userDTO := range userPageDTO.Data {
+		data[i] = UserResponse{
+			ID:            userDTO.ID,
+			Username:      userDTO.Username,
+			Email:         userDTO.Email,
+			AvatarURL:     userDTO.AvatarURL,
+			Status:        userDTO.Status,
+			EmailVerified: userDTO.EmailVerified,
+			CreatedAt:     userDTO.CreatedAt,
+			UpdatedAt:     userDTO.UpdatedAt,
+		}
+	}
+
+	return UserListResponse{
+		Data:       data,
+		Pagination: ToPaginationResponse(userPageDTO.Pagination),
+	}
+}
+
+// ToPublicProfileResponse 将用户公开资料DTO转换为响应对象。
+//
+// 参数：
+//
+//	profileDTO - 用户公开资料数据传输对象
+//
+// 返回：
+//
+//	PublicProfileResponse - HTTP 响应对象
+func ToPublicProfileResponse(profileDTO dto.PublicProfileDTO) PublicProfileResponse {
+	return PublicProfileResponse{
+		ID:        profileDTO.ID,
+		Username:  profileDTO.Username,
+		AvatarURL: profileDTO.AvatarURL,
+	}
+}
+
+// ToUserStatsResponse 将用户数量统计DTO转换为响应对象。
+//
+// 参数：
+//
+//	statsDTO - 用户数量统计数据传输对象
+//
+// 返回：
+//
+//	UserStatsResponse - HTTP 响应对象
+func ToUserStatsResponse(statsDTO dto.UserStatsDTO) UserStatsResponse {
+	return UserStatsResponse{
+		Total:    statsDTO.Total,
+		Active:   statsDTO.Active,
+		Inactive: statsDTO.Inactive,
+		Banned:   statsDTO.Banned,
+	}
+}
+
+// ToUserSummaryResponse 将用户概览DTO转换为响应对象。
+//
+// 参数：
+//
+//	summaryDTO - 用户概览数据传输对象
+//
+// 返回：
+//
+//	UserSummaryResponse - HTTP 响应对象
+func ToUserSummaryResponse(summaryDTO dto.UserSummaryDTO) UserSummaryResponse {
+	userDTO := summaryDTO.User
+	return UserSummaryResponse{
+		User: UserResponse{
+			ID:            userDTO.ID,
+			Username:      userDTO.Username,
+			Email:         userDTO.Email,
+			AvatarURL:     userDTO.AvatarURL,
+			Status:        userDTO.Status,
+			EmailVerified: userDTO.EmailVerified,
+			CreatedAt:     userDTO.CreatedAt,
+			UpdatedAt:     userDTO.UpdatedAt,
+		},
+		TotalNotes:    summaryDTO.TotalNotes,
+		HasTodayNote:  summaryDTO.HasTodayNote,
+		CurrentStreak: summaryDTO.CurrentStreak,
 	}
 }
@@ -0,0 +1,27 @@
+package response
+
+import "net/http"
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	// Field 字段名，与请求中的 JSON 字段名一致，数组元素形如 [0].content
+	Field string `json:"field"`
+
+	// Message 错误说明
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse 请求参数校验失败时的 data 字段
+type ValidationErrorResponse struct {
+	// Errors 校验失败的字段列表
+	Errors []FieldError `json:"errors"`
+}
+
+// WriteValidationError 写入请求参数校验失败响应，data 中列出每个字段的错误
+func WriteValidationError(w http.ResponseWriter, errs []FieldError) {
+	WriteJSON(w, http.StatusBadRequest, BaseResponse[ValidationErrorResponse]{
+		Code:    http.StatusBadRequest,
+		Message: "invalid request parameters",
+		Data:    ValidationErrorResponse{Errors: errs},
+	})
+}
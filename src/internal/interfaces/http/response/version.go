@@ -0,0 +1,138 @@
+package response
+
+import (
+	"time"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/pkg/contextx"
+)
+
+// Versioned 随 API 版本调整结构的响应。
+//
+// Wrap 在写出响应前调用 ForVersion，传入请求的 API 版本（见 contextx.GetAPIVersionFromContext），
+// 返回值作为 data 写出。新增版本时只需在此类响应中增加分支，未识别的版本按 v1 输出。
+type Versioned interface {
+	ForVersion(version string) any
+}
+
+// ForVersion 按 API 版本调整响应结构，未实现 Versioned 的响应原样返回
+func ForVersion(data any, version string) any {
+	if v, ok := data.(Versioned); ok {
+		return v.ForVersion(version)
+	}
+	return data
+}
+
+// AccountStatusResponse 结构化的账户状态（v2）。
+type AccountStatusResponse struct {
+	// Value 状态值（active/inactive/banned）
+	Value string `json:"value"`
+
+	// Active 是否为正常状态
+	Active bool `json:"active"`
+
+	// Banned 是否已被封禁
+	Banned bool `json:"banned"`
+}
+
+// UserResponseV2 用户信息响应（v2）。
+//
+// 与 UserResponse 相同，但 status 为结构化对象，取代 v1 中已弃用的状态字符串。
+type UserResponseV2 struct {
+	// ID 用户唯一标识
+	ID int64 `json:"id"`
+
+	// Username 用户名
+	Username string `json:"username"`
+
+	// Email 邮箱地址
+	Email string `json:"email"`
+
+	// AvatarURL 头像 URL，可能为空
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// Timezone 用户时区（IANA 名称），未设置时为空
+	Timezone string `json:"timezone,omitempty"`
+
+	// Status 账户状态
+	Status AccountStatusResponse `json:"status"`
+
+	// EmailVerified 邮箱是否已验证
+	EmailVerified bool `json:"email_verified"`
+
+	// CreatedAt 账户创建时间
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt 最后更新时间
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// toV2 转换为 v2 用户信息响应
+func (r UserResponse) toV2() UserResponseV2 {
+	status := user.UserStatus(r.Status)
+	return UserResponseV2{
+		ID:        r.ID,
+		Username:  r.Username,
+		Email:     r.Email,
+		AvatarURL: r.AvatarURL,
+		Timezone:  r.Timezone,
+		Status: AccountStatusResponse{
+			Value:  r.Status,
+			Active: status == user.UserStatusActive,
+			Banned: status == user.UserStatusBanned,
+		},
+		EmailVerified: r.EmailVerified,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// ForVersion 实现 Versioned 接口
+func (r UserResponse) ForVersion(version string) any {
+	if version == contextx.APIVersionV2 {
+		return r.toV2()
+	}
+	return r
+}
+
+// ForVersion 实现 Versioned 接口，v2 中 user 的 status 为结构化对象
+func (r LoginResponse) ForVersion(version string) any {
+	if version != contextx.APIVersionV2 {
+		return r
+	}
+	return struct {
+		Token        string         `json:"token"`
+		RefreshToken string         `json:"refresh_token,omitempty"`
+		ExpiresAt    time.Time      `json:"expires_at"`
+		ExpiresIn    int64          `json:"expires_in"`
+		User         UserResponseV2 `json:"user"`
+	}{r.Token, r.RefreshToken, r.ExpiresAt, r.ExpiresIn, r.User.toV2()}
+}
+
+// ForVersion 实现 Versioned 接口，v2 中 user 的 status 为结构化对象
+func (r UserSummaryResponse) ForVersion(version string) any {
+	if version != contextx.APIVersionV2 {
+		return r
+	}
+	return struct {
+		User          UserResponseV2 `json:"user"`
+		TotalNotes    int64          `json:"total_notes"`
+		HasTodayNote  bool           `json:"has_today_note"`
+		CurrentStreak int            `json:"current_streak"`
+	}{r.User.toV2(), r.TotalNotes, r.HasTodayNote, r.CurrentStreak}
+}
+
+// ForVersion 实现 Versioned 接口，v2 中每个用户的 status 为结构化对象
+func (r UserListResponse) ForVersion(version string) any {
+	if version != contextx.APIVersionV2 {
+		return r
+	}
+	data := make([]UserResponseV2, len(r.Data))
+	for i, u := range r.Data {
+		data[i] = u.toV2()
+	}
+	return struct {
+		Data       []UserResponseV2   `json:"data"`
+		Pagination PaginationResponse `json:"pagination"`
+	}{data, r.Pagination}
+}
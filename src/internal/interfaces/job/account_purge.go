@@ -0,0 +1,32 @@
+// Package job 后台定时任务入口。
+//
+// 与 HTTP 处理器相同，任务在每次执行时通过仓储工厂初始化服务，再调用应用服务完成用例。
+package job
+
+import (
+	"context"
+	"time"
+
+	"todolist/internal/application/user"
+	domainuser "todolist/internal/domain/user"
+	"todolist/internal/infrastructure/persistence"
+	appauth "todolist/internal/pkg/auth"
+	"todolist/internal/pkg/scheduler"
+)
+
+// PurgeDeletedUsers 返回清理注销超过 retention 的账户的定时任务
+//
+// 数据库暂不可用时返回错误，由调度器记录日志后在下个周期重试。
+func PurgeDeletedUsers(retention time.Duration) scheduler.Job {
+	return func(ctx context.Context) error {
+		repo, err := persistence.GetFactory().UserRepository()
+		if err != nil {
+			return err
+		}
+		userService := domainuser.NewService(repo, appauth.NewHasher())
+		userAppService := user.NewUserApplicationService(userService)
+
+		_, err = userAppService.PurgeDeletedUsers(ctx, retention)
+		return err
+	}
+}
@@ -8,14 +8,20 @@ package auth
 
 import (
 	"fmt"
+	"sync"
 
 	"golang.org/x/crypto/bcrypt"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/pkg/logger"
 )
 
 // Hasher 密码哈希工具。
 //
 // 使用 bcrypt 算法进行密码哈希，提供安全的密码存储。
-type Hasher struct{}
+type Hasher struct {
+	// cost bcrypt 计算成本
+	cost int
+}
 
 // Hash 对密码进行哈希处理。
 //
@@ -28,7 +34,7 @@ type Hasher struct{}
 //   string - 哈希后的密码字符串
 //   error - 哈希失败时的错误
 func (h *Hasher) Hash(password string) (string, error) {
-	hashBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -39,21 +45,84 @@ func (h *Hasher) Hash(password string) (string, error) {
 //
 // 使用恒定时间比较，防止时序攻击。
 //
+// 参数顺序与领域层 user.Hasher 接口一致：先哈希值，后明文。
+//
 // 参数：
-//   password - 明文密码
 //   hash - 密码哈希值
+//   password - 明文密码
 //
 // 返回：
 //   bool - 密码是否匹配
-func (h *Hasher) Verify(password, hash string) bool {
+func (h *Hasher) Verify(hash, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
 
+// hashers 按计算成本复用的密码哈希工具
+var hashers sync.Map
+
 // NewHasher 创建新的密码哈希工具。
 //
+// 计算成本从 BCRYPT_COST 配置读取，配置无效时使用默认值。
+// 哈希工具无状态，相同计算成本返回同一实例，领域服务据此复用按实例缓存的占位哈希。
+//
 // 返回：
 //   *Hasher - 密码哈希工具实例
 func NewHasher() *Hasher {
-	return &Hasher{}
+	cost := config.DefaultBcryptCost
+	if cfg, err := config.GetBcryptConfig(); err != nil {
+		logger.Warn("bcrypt 配置无效，使用默认计算成本",
+			logger.Int("cost", cost),
+			logger.Err(err))
+	} else {
+		cost = cfg.Cost
+	}
+
+	if hasher, ok := hashers.Load(cost); ok {
+		return hasher.(*Hasher)
+	}
+	hasher, err := NewHasherWithCost(cost)
+	if err != nil {
+		return &Hasher{cost: config.DefaultBcryptCost}
+	}
+	actual, _ := hashers.LoadOrStore(cost, hasher)
+	return actual.(*Hasher)
+}
+
+// NewHasherWithCost 使用指定计算成本创建密码哈希工具。
+//
+// 参数：
+//   cost - bcrypt 计算成本，必须在 4-31 之间
+//
+// 返回：
+//   *Hasher - 密码哈希工具实例
+//   error - 计算成本超出范围时的错误
+func NewHasherWithCost(cost int) (*Hasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d, got %d",
+			bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+	return &Hasher{cost: cost}, nil
+}
+
+// Cost 返回当前使用的 bcrypt 计算成本。
+func (h *Hasher) Cost() int {
+	return h.cost
+}
+
+// NeedsRehash 判断哈希值的计算成本是否低于当前配置。
+//
+// 实现领域层 user.RehashChecker 接口，无法解析的哈希值返回 false。
+//
+// 参数：
+//   hash - 已存储的密码哈希值
+//
+// 返回：
+//   bool - 是否需要用当前计算成本重新生成哈希
+func (h *Hasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return cost < h.cost
 }
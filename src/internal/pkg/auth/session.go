@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"todolist/internal/pkg/clock"
+)
+
+// NewTokenID 生成随机的 Token ID（128 位，十六进制）
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Session 一次记住登录签发的刷新 Token 对应的会话
+type Session struct {
+	// ID 会话 ID，即刷新 Token 的 jti 声明
+	ID string
+
+	// UserID 会话所属用户 ID
+	UserID int64
+
+	// CreatedAt 登录时间
+	CreatedAt time.Time
+
+	// LastUsedAt 最近一次使用刷新 Token 换取访问 Token 的时间，未使用过时与 CreatedAt 相同
+	LastUsedAt time.Time
+
+	// ExpiresAt 刷新 Token 过期时间
+	ExpiresAt time.Time
+
+	// UserAgent 登录时的 User-Agent
+	UserAgent string
+
+	// IP 登录时的客户端 IP
+	IP string
+}
+
+// SessionStore 记录已签发且未吊销的刷新 Token（白名单）
+//
+// 只有记录中存在的会话可以刷新，吊销即删除记录。数据仅保存在进程内存中，
+// 服务重启后全部会话失效，需重新登录；多实例部署时各实例独立记录，
+// 刷新请求需路由到签发会话的实例。过期的会话会被回收。
+// 另记录整体吊销的用户（如注销账户），其吊销前签发的访问 Token 不再有效。
+// 可在多个 goroutine 中并发使用。
+type SessionStore struct {
+	mu           sync.Mutex
+	clock        clock.Clock
+	sessions     map[string]Session
+	revokedUsers map[int64]userRevocation
+	lastCleanup  time.Time
+}
+
+// userRevocation 用户整体吊销记录
+type userRevocation struct {
+	// at 吊销时间，此前签发的访问 Token 失效
+	at time.Time
+
+	// until 记录保留到的时间，此后吊销前签发的访问 Token 均已过期
+	until time.Time
+}
+
+// sessionCleanupInterval 回收过期会话的最小间隔
+const sessionCleanupInterval = time.Hour
+
+// NewSessionStore 创建会话存储，c 为 nil 时使用系统时间
+func NewSessionStore(c clock.Clock) *SessionStore {
+	if c == nil {
+		c = clock.RealClock{}
+	}
+	return &SessionStore{
+		clock:        c,
+		sessions:     make(map[string]Session),
+		revokedUsers: make(map[int64]userRevocation),
+		lastCleanup:  c.Now(),
+	}
+}
+
+// Add 记录新签发的刷新 Token，LastUsedAt 为空时取 CreatedAt
+func (s *SessionStore) Add(session Session) {
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = session.CreatedAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	s.sessions[session.ID] = session
+}
+
+// List 返回用户未过期的会话，按登录时间倒序
+func (s *SessionStore) List(userID int64) []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	now := s.clock.Now()
+	sessions := make([]Session, 0)
+	for _, session := range s.sessions {
+		if session.UserID == userID && now.Before(session.ExpiresAt) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions
+}
+
+// Touch 记录会话的刷新 Token 被使用，会话不存在时忽略
+func (s *SessionStore) Touch(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[id]; ok {
+		session.LastUsedAt = s.clock.Now()
+		s.sessions[id] = session
+	}
+}
+
+// Revoke 吊销用户的会话，删除记录后其刷新 Token 不能再刷新
+//
+// 会话不存在、已过期或不属于该用户时返回 false。
+func (s *SessionStore) Revoke(userID int64, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok || session.UserID != userID || !s.clock.Now().Before(session.ExpiresAt) {
+		return false
+	}
+	delete(s.sessions, id)
+	return true
+}
+
+// RevokeUser 吊销用户的全部会话，并使此前签发的访问 Token 失效，返回吊销的会话数
+//
+// 吊销记录保留到 until，until 应不早于此前签发的访问 Token 的最晚过期时间。
+func (s *SessionStore) RevokeUser(userID int64, until time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	revoked := 0
+	for id, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, id)
+			revoked++
+		}
+	}
+	s.revokedUsers[userID] = userRevocation{at: s.clock.Now(), until: until}
+	return revoked
+}
+
+// IsUserRevoked 判断在 issuedAt 签发的访问 Token 是否已因用户整体吊销而失效
+//
+// Token 的 iat 截断到秒，与吊销时间同一秒签发的 Token 同样视为失效；
+// 没有 iat 声明（issuedAt 为零值）的 Token 在用户被吊销后一律失效。
+func (s *SessionStore) IsUserRevoked(userID int64, issuedAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revocation, ok := s.revokedUsers[userID]
+	if !ok || !s.clock.Now().Before(revocation.until) {
+		return false
+	}
+	return !issuedAt.After(revocation.at.Truncate(time.Second))
+}
+
+// IsActive 判断刷新 Token 对应的会话是否可用
+//
+// 会话必须存在、属于该用户且未过期；未携带 ID 的刷新 Token（会话记录功能上线前签发）、
+// 已吊销或服务重启前签发的刷新 Token 均返回 false。
+func (s *SessionStore) IsActive(userID int64, id string) bool {
+	if id == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	return ok && session.UserID == userID && s.clock.Now().Before(session.ExpiresAt)
+}
+
+// cleanup 回收已过期的会话和吊销记录，最多每小时执行一次，调用方需持有锁
+func (s *SessionStore) cleanup() {
+	now := s.clock.Now()
+	if now.Sub(s.lastCleanup) < sessionCleanupInterval {
+		return
+	}
+	for id, session := range s.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+	for userID, revocation := range s.revokedUsers {
+		if !now.Before(revocation.until) {
+			delete(s.revokedUsers, userID)
+		}
+	}
+	s.lastCleanup = now
+}
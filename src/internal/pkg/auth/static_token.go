@@ -0,0 +1,26 @@
+package auth
+
+import "time"
+
+const (
+	// StaticTokenSecret 测试用 Token 工具的固定签名密钥
+	StaticTokenSecret = "static-test-secret-key-do-not-use-in-production"
+
+	// StaticTokenExpiration 测试用访问 Token 的有效期
+	StaticTokenExpiration = time.Hour
+)
+
+// NewStaticTokenTool 创建使用固定内存密钥的 Token 工具。
+//
+// 不读取 JWT 配置，签发者和受众为空（不校验），仅用于测试。
+//
+// 返回：
+//
+//	TokenTool - Token 工具实例
+func NewStaticTokenTool() TokenTool {
+	return &jwtToken{
+		secretKey:             []byte(StaticTokenSecret),
+		expireDuration:        StaticTokenExpiration,
+		refreshExpireDuration: 24 * time.Hour,
+	}
+}
@@ -10,6 +10,17 @@ import (
 	"todolist/internal/pkg/logger"
 )
 
+const (
+	// TokenPurposeVerify 邮箱验证 Token 用途
+	TokenPurposeVerify = "verify"
+
+	// TokenPurposeRefresh 刷新 Token 用途
+	TokenPurposeRefresh = "refresh"
+
+	// TokenPurposeEmailChange 邮箱变更确认 Token 用途
+	TokenPurposeEmailChange = "email_change"
+)
+
 // CustomClaims 自定义 JWT Claims。
 //
 // 扩展标准 Claims，添加用户特定信息。
@@ -24,6 +35,12 @@ type CustomClaims struct {
 
 	// Role 用户角色
 	Role string `json:"role"`
+
+	// Purpose Token 用途，访问 Token 为空
+	Purpose string `json:"purpose,omitempty"`
+
+	// Email 待确认的新邮箱，仅邮箱变更确认 Token 携带
+	Email string `json:"email,omitempty"`
 }
 
 // TokenTool Token 工具接口。
@@ -60,13 +77,96 @@ type TokenTool interface {
 	// 返回：
 	//   string - 新生成的 Token
 	//   error - 刷新失败时的错误
+	//
+	// Deprecated: 新 Token 与旧 Token 有效期相同，无法实现长期登录。
+	// 使用 GenerateRefreshToken 和 ParseRefreshToken 代替。
 	RefreshToken(token string) (string, error)
+
+	// GenerateRefreshToken 生成长期有效的刷新 Token。
+	//
+	// 刷新 Token 使用派生密钥签名，只能用于换取新的访问 Token，
+	// 不能直接用于访问受保护的接口。Claims 中的 ID 唯一标识一次登录会话。
+	//
+	// 参数：
+	//   userID - 用户 ID
+	//   username - 用户名
+	//   role - 用户角色
+	//
+	// 返回：
+	//   string - 生成的刷新 Token
+	//   error - 生成失败时的错误信息
+	GenerateRefreshToken(userID int64, username, role string) (string, error)
+
+	// ParseRefreshToken 解析刷新 Token。
+	//
+	// 参数：
+	//   token - 刷新 Token 字符串
+	//
+	// 返回：
+	//   *CustomClaims - 解析后的 Claims
+	//   error - Token 无效、过期或不是刷新 Token 时的错误
+	ParseRefreshToken(token string) (*CustomClaims, error)
+
+	// GeneratePurposeToken 生成特定用途的一次性 Token（如邮箱验证）。
+	//
+	// 用途 Token 使用派生密钥签名，不能作为访问 Token 使用。
+	//
+	// 参数：
+	//   userID - 用户 ID
+	//   username - 用户名
+	//   purpose - Token 用途
+	//   expireDuration - 有效期
+	//
+	// 返回：
+	//   string - 生成的 Token 字符串
+	//   error - 生成失败时的错误信息
+	GeneratePurposeToken(userID int64, username, purpose string, expireDuration time.Duration) (string, error)
+
+	// ParsePurposeToken 解析特定用途的 Token。
+	//
+	// 参数：
+	//   token - Token 字符串
+	//   purpose - 期望的 Token 用途
+	//
+	// 返回：
+	//   *CustomClaims - 解析后的 Claims
+	//   error - Token 无效、过期或用途不符时的错误
+	ParsePurposeToken(token, purpose string) (*CustomClaims, error)
+
+	// GenerateEmailChangeToken 生成邮箱变更确认 Token。
+	//
+	// Token 携带待确认的新邮箱，确认时需与用户当前的待确认邮箱一致，
+	// 再次发起变更后旧 Token 即失效。
+	//
+	// 参数：
+	//   userID - 用户 ID
+	//   username - 用户名
+	//   email - 待确认的新邮箱
+	//   expireDuration - 有效期
+	//
+	// 返回：
+	//   string - 生成的 Token 字符串
+	//   error - 生成失败时的错误信息
+	GenerateEmailChangeToken(userID int64, username, email string, expireDuration time.Duration) (string, error)
+
+	// ParseEmailChangeToken 解析邮箱变更确认 Token。
+	//
+	// 参数：
+	//   token - Token 字符串
+	//
+	// 返回：
+	//   *CustomClaims - 解析后的 Claims，Email 为待确认的新邮箱
+	//   error - Token 无效、过期、用途不符或未携带邮箱时的错误
+	ParseEmailChangeToken(token string) (*CustomClaims, error)
 }
 
 // jwtToken Token 工具的具体实现。
 type jwtToken struct {
-	secretKey      []byte
-	expireDuration time.Duration
+	secretKey             []byte
+	expireDuration        time.Duration
+	refreshExpireDuration time.Duration
+	issuer                string
+	audience              string
 }
 
 var (
@@ -87,8 +187,11 @@ func GetTokenTool() TokenTool {
 	jwtTokenOnce.Do(func() {
 		logger.Warn("使用默认配置初始化 Token 工具（不推荐生产环境）")
 		jwtTokenInstance = &jwtToken{
-			secretKey:      []byte("development-secret-key-change-in-production-min-32-chars"),
-			expireDuration: time.Hour * 24,
+			secretKey:             []byte("development-secret-key-change-in-production-min-32-chars"),
+			expireDuration:        time.Hour * 24,
+			refreshExpireDuration: config.DefaultJWTRefreshExpiration,
+			issuer:                config.DefaultJWTIssuer,
+			audience:              config.DefaultJWTAudience,
 		}
 	})
 	return jwtTokenInstance
@@ -103,8 +206,11 @@ func GetTokenTool() TokenTool {
 //   TokenTool - Token 工具实例
 func NewTokenTool(cfg config.JWTConfig) TokenTool {
 	return &jwtToken{
-		secretKey:      []byte(cfg.GetSecretKey()),
-		expireDuration: cfg.GetExpireDuration(),
+		secretKey:             []byte(cfg.GetSecretKey()),
+		expireDuration:        cfg.GetExpireDuration(),
+		refreshExpireDuration: cfg.GetRefreshExpireDuration(),
+		issuer:                cfg.GetIssuer(),
+		audience:              cfg.GetAudience(),
 	}
 }
 
@@ -122,17 +228,13 @@ func NewTokenTool(cfg config.JWTConfig) TokenTool {
 //   error - 生成失败时的错误信息
 func (j *jwtToken) GenerateToken(userID int64, username, role string) (string, error) {
 	claims := CustomClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expireDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-		UserID:   userID,
-		Username: username,
-		Role:     role,
+		RegisteredClaims: j.registeredClaims(j.expireDuration),
+		UserID:           userID,
+		Username:         username,
+		Role:             role,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
+	tokenString, err := j.sign(claims, j.secretKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token for user %d: %w", userID, err)
 	}
@@ -155,23 +257,7 @@ func (j *jwtToken) GenerateToken(userID int64, username, role string) (string, e
 //   *CustomClaims - 解析后的 Claims
 //   error - Token 无效或过期时的错误
 func (j *jwtToken) ParseToken(tokenString string) (*CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (any, error) {
-		// 验证签名算法
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return j.secretKey, nil
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
-
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, jwt.ErrSignatureInvalid
+	return j.parse(tokenString, j.secretKey)
 }
 
 // RefreshToken 刷新 JWT Token。
@@ -184,6 +270,8 @@ func (j *jwtToken) ParseToken(tokenString string) (*CustomClaims, error) {
 // 返回：
 //   string - 新生成的 Token
 //   error - 刷新失败时的错误
+//
+// Deprecated: 使用 GenerateRefreshToken 和 ParseRefreshToken 代替。
 func (j *jwtToken) RefreshToken(tokenString string) (string, error) {
 	claims, err := j.ParseToken(tokenString)
 	if err != nil {
@@ -192,3 +280,211 @@ func (j *jwtToken) RefreshToken(tokenString string) (string, error) {
 
 	return j.GenerateToken(claims.UserID, claims.Username, claims.Role)
 }
+
+// GeneratePurposeToken 生成特定用途的一次性 Token。
+//
+// 参数：
+//   userID - 用户 ID
+//   username - 用户名
+//   purpose - Token 用途
+//   expireDuration - 有效期
+//
+// 返回：
+//   string - 生成的 Token 字符串
+//   error - 生成失败时的错误信息
+func (j *jwtToken) GeneratePurposeToken(userID int64, username, purpose string, expireDuration time.Duration) (string, error) {
+	return j.generatePurposeToken(userID, username, "", purpose, expireDuration)
+}
+
+// GenerateRefreshToken 生成长期有效的刷新 Token。
+//
+// 每个刷新 Token 带有随机的 ID（jti 声明），用于记录登录会话和吊销。
+//
+// 参数：
+//   userID - 用户 ID
+//   username - 用户名
+//   role - 用户角色
+//
+// 返回：
+//   string - 生成的刷新 Token
+//   error - 生成失败时的错误信息
+func (j *jwtToken) GenerateRefreshToken(userID int64, username, role string) (string, error) {
+	id, err := NewTokenID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token id for user %d: %w", userID, err)
+	}
+
+	claims := CustomClaims{
+		RegisteredClaims: j.registeredClaims(j.refreshExpireDuration),
+		UserID:           userID,
+		Username:         username,
+		Role:             role,
+		Purpose:          TokenPurposeRefresh,
+	}
+	claims.ID = id
+	return j.signPurposeToken(claims)
+}
+
+// ParseRefreshToken 解析刷新 Token。
+//
+// 参数：
+//   tokenString - 刷新 Token 字符串
+//
+// 返回：
+//   *CustomClaims - 解析后的 Claims
+//   error - Token 无效、过期或不是刷新 Token 时的错误
+func (j *jwtToken) ParseRefreshToken(tokenString string) (*CustomClaims, error) {
+	return j.ParsePurposeToken(tokenString, TokenPurposeRefresh)
+}
+
+// GenerateEmailChangeToken 生成邮箱变更确认 Token。
+//
+// 参数：
+//   userID - 用户 ID
+//   username - 用户名
+//   email - 待确认的新邮箱
+//   expireDuration - 有效期
+//
+// 返回：
+//   string - 生成的 Token 字符串
+//   error - 生成失败时的错误信息
+func (j *jwtToken) GenerateEmailChangeToken(userID int64, username, email string, expireDuration time.Duration) (string, error) {
+	if email == "" {
+		return "", fmt.Errorf("email change token requires an email")
+	}
+	return j.signPurposeToken(CustomClaims{
+		RegisteredClaims: j.registeredClaims(expireDuration),
+		UserID:           userID,
+		Username:         username,
+		Purpose:          TokenPurposeEmailChange,
+		Email:            email,
+	})
+}
+
+// ParseEmailChangeToken 解析邮箱变更确认 Token。
+//
+// 参数：
+//   tokenString - Token 字符串
+//
+// 返回：
+//   *CustomClaims - 解析后的 Claims
+//   error - Token 无效、过期、用途不符或未携带邮箱时的错误
+func (j *jwtToken) ParseEmailChangeToken(tokenString string) (*CustomClaims, error) {
+	claims, err := j.ParsePurposeToken(tokenString, TokenPurposeEmailChange)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Email == "" {
+		return nil, fmt.Errorf("email change token has no email")
+	}
+	return claims, nil
+}
+
+// generatePurposeToken 使用派生密钥生成特定用途的 Token。
+func (j *jwtToken) generatePurposeToken(userID int64, username, role, purpose string, expireDuration time.Duration) (string, error) {
+	return j.signPurposeToken(CustomClaims{
+		RegisteredClaims: j.registeredClaims(expireDuration),
+		UserID:           userID,
+		Username:         username,
+		Role:             role,
+		Purpose:          purpose,
+	})
+}
+
+// signPurposeToken 使用 claims.Purpose 对应的派生密钥签名。
+func (j *jwtToken) signPurposeToken(claims CustomClaims) (string, error) {
+	if claims.Purpose == "" {
+		return "", fmt.Errorf("token purpose cannot be empty")
+	}
+
+	tokenString, err := j.sign(claims, j.purposeKey(claims.Purpose))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s token for user %d: %w", claims.Purpose, claims.UserID, err)
+	}
+	return tokenString, nil
+}
+
+// ParsePurposeToken 解析特定用途的 Token。
+//
+// 参数：
+//   tokenString - Token 字符串
+//   purpose - 期望的 Token 用途
+//
+// 返回：
+//   *CustomClaims - 解析后的 Claims
+//   error - Token 无效、过期或用途不符时的错误
+func (j *jwtToken) ParsePurposeToken(tokenString, purpose string) (*CustomClaims, error) {
+	claims, err := j.parse(tokenString, j.purposeKey(purpose))
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != purpose {
+		return nil, fmt.Errorf("unexpected token purpose: %q", claims.Purpose)
+	}
+	return claims, nil
+}
+
+// purposeKey 派生特定用途的签名密钥，防止用途 Token 被当作访问 Token 使用。
+func (j *jwtToken) purposeKey(purpose string) []byte {
+	key := make([]byte, 0, len(j.secretKey)+len(purpose)+1)
+	key = append(key, j.secretKey...)
+	key = append(key, ':')
+	return append(key, purpose...)
+}
+
+// registeredClaims 生成标准 Claims，包含签发者、受众和有效期。
+func (j *jwtToken) registeredClaims(expireDuration time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    j.issuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(expireDuration)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	if j.audience != "" {
+		claims.Audience = jwt.ClaimStrings{j.audience}
+	}
+	return claims
+}
+
+// sign 使用 HS256 对 Claims 签名。
+func (j *jwtToken) sign(claims CustomClaims, key []byte) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(key)
+}
+
+// parse 使用指定密钥解析并验证 Token。
+//
+// 配置了签发者和受众时，iss/aud 不一致的 Token 会被拒绝，
+// 防止其他服务签发的 Token 被误接受。
+func (j *jwtToken) parse(tokenString string, key []byte) (*CustomClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (any, error) {
+		// 验证签名算法
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key, nil
+	}, j.parserOptions()...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, jwt.ErrSignatureInvalid
+}
+
+// parserOptions 返回签发者和受众校验选项，未配置的项不校验。
+func (j *jwtToken) parserOptions() []jwt.ParserOption {
+	var opts []jwt.ParserOption
+	if j.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		opts = append(opts, jwt.WithAudience(j.audience))
+	}
+	return opts
+}
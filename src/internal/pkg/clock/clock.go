@@ -0,0 +1,58 @@
+// Package clock 提供可替换的时间来源。
+//
+// 领域服务通过 Clock 获取当前时间，生产环境使用 RealClock，
+// 测试中使用 FakeClock 固定或推进时间，使"今天"、连续天数等逻辑可重复验证。
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock 时间来源
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+}
+
+// RealClock 使用系统时间的 Clock
+type RealClock struct{}
+
+// Now 返回 time.Now()
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock 测试用的 Clock，时间只在调用 Set 或 Advance 时变化
+//
+// 可在多个 goroutine 中并发使用。
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock 创建固定在 now 的 FakeClock
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now 返回当前设置的时间
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set 将时间设置为 now
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance 将时间向后推进 d，d 为负数时回退
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,28 @@
+package contextx
+
+import (
+	"context"
+)
+
+const (
+	// APIVersionV1 默认 API 版本
+	APIVersionV1 = "v1"
+
+	// APIVersionV2 用户响应中的账户状态改为结构化对象
+	APIVersionV2 = "v2"
+)
+
+// GetAPIVersionFromContext 获取客户端请求的 API 版本
+//
+// 未经过版本协商中间件（如后台任务、直接调用处理器）时返回 APIVersionV1。
+func GetAPIVersionFromContext(ctx context.Context) string {
+	if version, ok := ctx.Value(apiVersionKey).(string); ok {
+		return version
+	}
+	return APIVersionV1
+}
+
+// WithAPIVersion 返回携带 API 版本的上下文
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey, version)
+}
@@ -0,0 +1,32 @@
+package contextx
+
+import (
+	"context"
+	"time"
+)
+
+// Claims 当前请求访问 Token 中解析出的声明
+//
+// 只包含用户信息和有效期，不保存原始 Token。
+type Claims struct {
+	UserContext
+
+	// IssuedAt 签发时间（iat），旧版本签发的 Token 没有该声明，此时为零值
+	IssuedAt time.Time
+
+	// ExpiresAt 过期时间（exp）
+	ExpiresAt time.Time
+}
+
+// GetClaims 获取当前请求访问 Token 的声明
+//
+// 未经过认证中间件或认证失败时返回 false。
+func GetClaims(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(Claims)
+	return claims, ok
+}
+
+// WithClaims 返回携带访问 Token 声明的上下文
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
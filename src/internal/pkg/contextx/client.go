@@ -0,0 +1,27 @@
+package contextx
+
+import (
+	"context"
+)
+
+// ClientInfo 发起请求的客户端信息
+type ClientInfo struct {
+	// IP 客户端 IP，取自连接的远端地址
+	IP string
+
+	// UserAgent 请求头 User-Agent
+	UserAgent string
+}
+
+// GetClientFromContext 获取当前请求的客户端信息
+//
+// 未经过 HTTP 处理器封装（如后台任务）时返回 false。
+func GetClientFromContext(ctx context.Context) (ClientInfo, bool) {
+	client, ok := ctx.Value(clientKey).(ClientInfo)
+	return client, ok
+}
+
+// WithClient 返回携带客户端信息的上下文
+func WithClient(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientKey, client)
+}
@@ -0,0 +1,61 @@
+// Package contextx 提供请求上下文中用户信息的统一访问方式。
+//
+// 认证中间件将 Token 中的用户信息写入上下文，
+// 中间件和处理器均通过 GetDataFromContext 读取，避免出现多套访问接口。
+package contextx
+
+import (
+	"context"
+)
+
+// ctxKey 上下文键类型
+//
+// 使用未导出的类型作为键，其他包以字符串等类型写入的值不会与之冲突，
+// 只能通过本包的访问函数读写。
+type ctxKey int
+
+const (
+	// userKey 认证用户信息的上下文键
+	userKey ctxKey = iota
+
+	// clientKey 请求客户端信息的上下文键
+	clientKey
+
+	// apiVersionKey 请求 API 版本的上下文键
+	apiVersionKey
+
+	// claimsKey 访问 Token 声明的上下文键
+	claimsKey
+)
+
+// UserContext 当前请求的认证用户信息
+//
+// 同时作为访问 Token 的载荷，字段的 JSON 名称变化会使已签发的 Token 失效。
+type UserContext struct {
+	// UserID 用户唯一标识
+	UserID int64 `json:"user_id"`
+
+	// Username 用户名
+	Username string `json:"username"`
+
+	// Role 用户角色
+	Role string `json:"role"`
+
+	// SessionID 签发该 Token 的登录会话 ID，仅记住登录签发的访问 Token 携带，会话吊销后 Token 失效
+	SessionID string `json:"sid,omitempty"`
+}
+
+// GetDataFromContext 获取当前请求的认证用户信息
+//
+// 未经过认证中间件或认证失败时返回 false。
+func GetDataFromContext(ctx context.Context) (UserContext, bool) {
+	user, ok := ctx.Value(userKey).(UserContext)
+	return user, ok
+}
+
+// WithUser 返回携带认证用户信息的上下文
+//
+// 认证中间件通过此函数写入用户信息，测试和内部调用也可直接使用。
+func WithUser(ctx context.Context, user UserContext) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
@@ -13,6 +13,7 @@ package domainerr
 //   - ConflictError: HTTP 409 (Conflict)
 //   - AuthenticationError: HTTP 401 (Unauthorized)
 //   - InternalError: HTTP 500 (Internal Server Error)
+//   - UnavailableError: HTTP 503 (Service Unavailable)
 type ErrorType string
 
 const (
@@ -33,6 +34,9 @@ const (
 
 	// InternalError indicates unexpected system errors.
 	InternalError ErrorType = "internal_error"
+
+	// UnavailableError indicates a dependency (such as the database) is temporarily unavailable.
+	UnavailableError ErrorType = "unavailable"
 )
 
 // BusinessError represents a domain-level error with structured metadata.
@@ -58,3 +62,15 @@ func (e BusinessError) Error() string {
 func (e BusinessError) Unwrap() error {
 	return e.InternalError
 }
+
+// Is reports whether target is a BusinessError with the same Code.
+//
+// Comparing by Code lets errors.Is match a sentinel even when the error
+// carries a different InternalError or has been wrapped with fmt.Errorf.
+func (e BusinessError) Is(target error) bool {
+	t, ok := target.(BusinessError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
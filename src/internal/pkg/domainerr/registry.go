@@ -0,0 +1,52 @@
+package domainerr
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]BusinessError)
+)
+
+// Register records business errors by their Code.
+//
+// Each domain registers its errors from its error_mapping.go so that
+// callers can enumerate or look up every error the API may return.
+// Registering two different errors under the same Code panics, since
+// codes must be unique for programmatic handling by clients.
+func Register(errs ...BusinessError) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, e := range errs {
+		if existing, ok := registry[e.Code]; ok && existing != e {
+			panic(fmt.Sprintf("domainerr: duplicate error code %q", e.Code))
+		}
+		registry[e.Code] = e
+	}
+}
+
+// Lookup returns the registered error for the given code.
+func Lookup(code string) (BusinessError, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	e, ok := registry[code]
+	return e, ok
+}
+
+// Registered returns all registered errors sorted by Code.
+func Registered() []BusinessError {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	errs := make([]BusinessError, 0, len(registry))
+	for _, e := range registry {
+		errs = append(errs, e)
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Code < errs[j].Code })
+	return errs
+}
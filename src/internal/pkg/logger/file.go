@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	// DefaultMaxSizeMB 单个日志文件默认最大大小（MB）
+	DefaultMaxSizeMB = 100
+
+	// DefaultMaxBackups 默认保留的旧日志文件个数
+	DefaultMaxBackups = 7
+
+	// DefaultMaxAgeDays 旧日志文件默认保留天数
+	DefaultMaxAgeDays = 30
+)
+
+// ConfigFromEnv 使用环境变量覆盖日志文件相关配置
+//
+// 支持的环境变量：
+//
+//	LOG_FILE - 日志文件路径，为空时输出到标准输出
+//	LOG_FILE_MAX_SIZE_MB - 单个日志文件最大大小（MB）
+//	LOG_FILE_MAX_BACKUPS - 保留的旧日志文件个数
+//	LOG_FILE_MAX_AGE_DAYS - 旧日志文件保留天数
+func ConfigFromEnv(cfg Config) Config {
+	if path := os.Getenv("LOG_FILE"); path != "" {
+		cfg.FilePath = path
+	}
+	cfg.MaxSizeMB = envIntOrDefault("LOG_FILE_MAX_SIZE_MB", cfg.MaxSizeMB)
+	cfg.MaxBackups = envIntOrDefault("LOG_FILE_MAX_BACKUPS", cfg.MaxBackups)
+	cfg.MaxAgeDays = envIntOrDefault("LOG_FILE_MAX_AGE_DAYS", cfg.MaxAgeDays)
+	return cfg
+}
+
+// newFileWriter 创建按大小滚动的日志文件写入器
+//
+// 未配置或配置为非正数的滚动参数使用默认值。
+func newFileWriter(cfg Config) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   cfg.FilePath,
+		MaxSize:    positiveOrDefault(cfg.MaxSizeMB, DefaultMaxSizeMB),
+		MaxBackups: positiveOrDefault(cfg.MaxBackups, DefaultMaxBackups),
+		MaxAge:     positiveOrDefault(cfg.MaxAgeDays, DefaultMaxAgeDays),
+	}
+}
+
+// envIntOrDefault 读取整数环境变量，未设置或格式无效时返回默认值
+func envIntOrDefault(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// positiveOrDefault 非正数时返回默认值
+func positiveOrDefault(value, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// ecsVersion 输出的 ECS（Elastic Common Schema）版本
+const ecsVersion = "8.11.0"
+
+// ParseFormat 解析日志格式名称（不区分大小写）：json、text、ecs、stackdriver
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "json":
+		return FormatJSON, nil
+	case "text":
+		return FormatText, nil
+	case "ecs":
+		return FormatECS, nil
+	case "stackdriver":
+		return FormatStackdriver, nil
+	default:
+		return 0, fmt.Errorf("unknown log format %q", name)
+	}
+}
+
+// ecsReplaceAttr 将 slog 的默认字段映射为 ECS 字段
+//
+// time、level、msg、source 分别映射为 @timestamp、log.level（小写）、message、log.origin。
+func ecsReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "@timestamp"
+	case slog.LevelKey:
+		a = slog.String("log.level", levelName(a, "debug", "info", "warn", "error"))
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.SourceKey:
+		if src, ok := a.Value.Any().(*slog.Source); ok {
+			a = slog.Group("log.origin",
+				slog.Group("file", slog.String("name", src.File), slog.Int("line", src.Line)),
+				slog.String("function", src.Function),
+			)
+		}
+	}
+	return a
+}
+
+// stackdriverReplaceAttr 将 slog 的默认字段映射为 Google Cloud Logging 结构化日志字段
+//
+// time、level、msg、source 分别映射为 timestamp、severity（DEBUG/INFO/WARNING/ERROR）、
+// message、logging.googleapis.com/sourceLocation。
+func stackdriverReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	case slog.LevelKey:
+		a = slog.String("severity", levelName(a, "DEBUG", "INFO", "WARNING", "ERROR"))
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.SourceKey:
+		if src, ok := a.Value.Any().(*slog.Source); ok {
+			a = slog.Group("logging.googleapis.com/sourceLocation",
+				slog.String("file", src.File),
+				slog.String("line", strconv.Itoa(src.Line)),
+				slog.String("function", src.Function),
+			)
+		}
+	}
+	return a
+}
+
+// levelName 按级别区间返回对应名称，介于标准级别之间的自定义级别归入较低的一档
+func levelName(a slog.Attr, debug, info, warn, err string) string {
+	level, ok := a.Value.Any().(slog.Level)
+	if !ok {
+		return a.Value.String()
+	}
+	switch {
+	case level < LevelInfo:
+		return debug
+	case level < LevelWarn:
+		return info
+	case level < LevelError:
+		return warn
+	default:
+		return err
+	}
+}
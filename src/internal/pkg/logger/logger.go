@@ -6,12 +6,17 @@ import (
 	"log/slog"
 	"os"
 	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
 	mu      sync.RWMutex
 	logger  *slog.Logger
 	config  Config  // 保存当前配置
+
+	// fileWriter 当前使用的滚动日志文件，重新初始化时关闭
+	fileWriter *lumberjack.Logger
 )
 
 // Level 日志级别
@@ -30,6 +35,12 @@ type Format int
 const (
 	FormatJSON Format = iota
 	FormatText
+
+	// FormatECS JSON 格式，字段名符合 Elastic Common Schema（@timestamp、log.level、message）
+	FormatECS
+
+	// FormatStackdriver JSON 格式，字段名符合 Google Cloud Logging（timestamp、severity、message）
+	FormatStackdriver
 )
 
 // Config 日志配置
@@ -39,6 +50,13 @@ type Config struct {
 	Output     io.Writer // 输出目标，默认为 os.Stdout
 	AddSource  bool   // 是否添加源代码位置
 	TimeFormat string // 时间格式，默认为 "2006-01-02 15:04:05"
+
+	FilePath   string // 日志文件路径，设置后按大小滚动写入文件并忽略 Output
+	MaxSizeMB  int    // 单个日志文件最大大小（MB），默认为 100
+	MaxBackups int    // 保留的旧日志文件个数，默认为 7
+	MaxAgeDays int    // 旧日志文件保留天数，默认为 30
+
+	SensitiveKeys []string // 需要脱敏的字段名，为空时使用 DefaultSensitiveKeys
 }
 
 // DefaultConfig 默认配置
@@ -60,29 +78,22 @@ func Init(cfg Config) {
 	// 保存配置
 	config = cfg
 
-	// 设置默认输出
-	if config.Output == nil {
-		config.Output = os.Stdout
+	// 关闭上一次初始化打开的日志文件
+	if fileWriter != nil {
+		_ = fileWriter.Close()
+		fileWriter = nil
 	}
 
-	// 创建 handler 选项
-	opts := &slog.HandlerOptions{
-		Level:     config.Level,
-		AddSource: config.AddSource,
+	// 设置输出：配置了文件路径时写入滚动文件，否则默认为标准输出
+	if config.FilePath != "" {
+		fileWriter = newFileWriter(config)
+		config.Output = fileWriter
 	}
-
-	// 根据格式创建 handler
-	var handler slog.Handler
-	switch config.Format {
-	case FormatJSON:
-		handler = slog.NewJSONHandler(config.Output, opts)
-	case FormatText:
-		handler = slog.NewTextHandler(config.Output, opts)
-	default:
-		handler = slog.NewJSONHandler(config.Output, opts)
+	if config.Output == nil {
+		config.Output = os.Stdout
 	}
 
-	logger = slog.New(handler)
+	logger = slog.New(newHandler(config))
 	slog.SetDefault(logger)
 }
 
@@ -97,12 +108,15 @@ func InitDev() {
 }
 
 // InitProd 初始化生产环境日志（JSON 格式，Info 级别）
+//
+// 设置 LOG_FILE 环境变量时写入按大小滚动的日志文件，
+// 否则输出到标准输出。
 func InitProd() {
-	Init(Config{
+	Init(ConfigFromEnv(Config{
 		Level:      LevelInfo,
 		Format:     FormatJSON,
 		AddSource:  false,
-	})
+	}))
 }
 
 // SetLevel 设置日志级别
@@ -112,24 +126,37 @@ func SetLevel(level Level) {
 
 	// 更新配置并重新创建 handler
 	config.Level = level
+	if config.Output == nil {
+		config.Output = os.Stdout
+	}
+
+	logger = slog.New(newHandler(config))
+	slog.SetDefault(logger)
+}
 
+// newHandler 根据配置创建 handler，并包装敏感字段脱敏
+func newHandler(cfg Config) slog.Handler {
 	opts := &slog.HandlerOptions{
-		Level:     config.Level,
-		AddSource: config.AddSource,
+		Level:     cfg.Level,
+		AddSource: cfg.AddSource,
 	}
 
 	var handler slog.Handler
-	switch config.Format {
-	case FormatJSON:
-		handler = slog.NewJSONHandler(config.Output, opts)
+	switch cfg.Format {
 	case FormatText:
-		handler = slog.NewTextHandler(config.Output, opts)
+		handler = slog.NewTextHandler(cfg.Output, opts)
+	case FormatECS:
+		opts.ReplaceAttr = ecsReplaceAttr
+		handler = slog.NewJSONHandler(cfg.Output, opts).
+			WithAttrs([]slog.Attr{slog.String("ecs.version", ecsVersion)})
+	case FormatStackdriver:
+		opts.ReplaceAttr = stackdriverReplaceAttr
+		handler = slog.NewJSONHandler(cfg.Output, opts)
 	default:
-		handler = slog.NewJSONHandler(config.Output, opts)
+		handler = slog.NewJSONHandler(cfg.Output, opts)
 	}
 
-	logger = slog.New(handler)
-	slog.SetDefault(logger)
+	return NewRedactHandler(handler, cfg.SensitiveKeys)
 }
 
 // L 获取 logger 实例
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue 敏感字段脱敏后的值
+const RedactedValue = "***"
+
+// DefaultSensitiveKeys 默认脱敏的字段名
+var DefaultSensitiveKeys = []string{"password", "token", "secret", "password_hash"}
+
+// RedactHandler 对敏感字段脱敏的 slog.Handler 包装器。
+//
+// 在日志记录交给底层 handler 之前，将字段名命中敏感列表（不区分大小写）
+// 的字段值替换为 ***，分组内的字段同样生效。
+type RedactHandler struct {
+	next slog.Handler
+	keys map[string]struct{}
+}
+
+// NewRedactHandler 创建脱敏 handler
+//
+// keys 为需要脱敏的字段名，为空时使用 DefaultSensitiveKeys。
+func NewRedactHandler(next slog.Handler, keys []string) *RedactHandler {
+	if len(keys) == 0 {
+		keys = DefaultSensitiveKeys
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[strings.ToLower(key)] = struct{}{}
+	}
+	return &RedactHandler{next: next, keys: set}
+}
+
+// Enabled 实现 slog.Handler
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle 实现 slog.Handler，脱敏后交给底层 handler
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs 实现 slog.Handler，预设字段同样脱敏
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redact(a)
+	}
+	return &RedactHandler{next: h.next.WithAttrs(redacted), keys: h.keys}
+}
+
+// WithGroup 实现 slog.Handler
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{next: h.next.WithGroup(name), keys: h.keys}
+}
+
+// redact 对单个字段脱敏，分组字段递归处理
+func (h *RedactHandler) redact(a slog.Attr) slog.Attr {
+	if _, ok := h.keys[strings.ToLower(a.Key)]; ok {
+		return slog.String(a.Key, RedactedValue)
+	}
+
+	value := a.Value.Resolve()
+	if value.Kind() != slog.KindGroup {
+		return slog.Attr{Key: a.Key, Value: value}
+	}
+
+	group := value.Group()
+	redacted := make([]slog.Attr, len(group))
+	for i, ga := range group {
+		redacted[i] = h.redact(ga)
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+}
@@ -0,0 +1,69 @@
+// Package markdown 将笔记的 Markdown 内容渲染为可直接展示的 HTML。
+//
+// 渲染结果经过白名单过滤，移除脚本、事件属性和 javascript: 链接等可能导致 XSS 的内容，
+// 客户端可以直接插入页面。
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Renderer Markdown 渲染器
+type Renderer interface {
+	// Render 将 Markdown 源文本渲染为经过安全过滤的 HTML
+	Render(source string) (string, error)
+}
+
+// goldmarkRenderer 使用 goldmark 渲染、bluemonday 过滤的渲染器
+type goldmarkRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+// NewRenderer 创建 Markdown 渲染器
+//
+// 支持 GitHub 风格扩展（表格、删除线、任务列表、自动链接）。
+// 原始 HTML 不会被渲染，渲染结果再按用户生成内容策略过滤，
+// 链接统一添加 rel="nofollow noopener" 并在新窗口打开。
+// 返回的渲染器可在多个 goroutine 中并发使用。
+func NewRenderer() Renderer {
+	policy := bluemonday.UGCPolicy()
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	// 保留任务列表的复选框
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return &goldmarkRenderer{
+		md:     goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		policy: policy,
+	}
+}
+
+// Render 将 Markdown 源文本渲染为经过安全过滤的 HTML
+func (r *goldmarkRenderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+var (
+	defaultRenderer     Renderer
+	defaultRendererOnce sync.Once
+)
+
+// GetRenderer 获取默认 Markdown 渲染器（单例模式）
+func GetRenderer() Renderer {
+	defaultRendererOnce.Do(func() {
+		defaultRenderer = NewRenderer()
+	})
+	return defaultRenderer
+}
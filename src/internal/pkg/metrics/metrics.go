@@ -0,0 +1,55 @@
+// Package metrics 提供 Prometheus 监控指标。
+//
+// 所有指标注册到 client_golang 的默认注册表，
+// 通过 promhttp.Handler() 暴露给 Prometheus 抓取。
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// namespace 指标命名空间
+const namespace = "todolist"
+
+const (
+	// LoginResultSuccess 登录成功
+	LoginResultSuccess = "success"
+
+	// LoginResultFailure 登录失败
+	LoginResultFailure = "failure"
+)
+
+var (
+	// HTTPRequestsTotal HTTP 请求总数，按路由、方法和状态码区分
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration HTTP 请求耗时分布（秒），按路由、方法和状态码区分
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// LoginAttemptsTotal 登录次数，按结果区分
+	LoginAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_attempts_total",
+		Help:      "Total number of login attempts by result.",
+	}, []string{"result"})
+)
+
+// RegisterDBStats 注册数据库连接池指标（打开、空闲、使用中连接数等）。
+//
+// 基于 db.Stats() 采集，每个数据库连接池只应注册一次。
+func RegisterDBStats(db *sql.DB, dbName string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
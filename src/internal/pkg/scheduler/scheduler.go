@@ -0,0 +1,64 @@
+// Package scheduler 提供进程内的周期任务调度。
+//
+// 用于清理过期数据等不需要精确时间点的后台任务：启动时立即执行一次，
+// 之后按固定间隔执行，上下文取消后停止。多实例部署时每个实例都会执行，任务需可重复执行。
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	applogger "todolist/internal/pkg/logger"
+)
+
+// Job 周期任务，返回的错误只记录日志，不影响后续执行
+type Job func(ctx context.Context) error
+
+// Every 在后台 goroutine 中每隔 interval 执行一次 job，ctx 取消后停止
+//
+// 返回的 channel 在 goroutine 退出后关闭，关闭服务时可等待正在执行的任务结束。
+// 任务执行时间超过 interval 时跳过错过的周期，不会并发执行。
+func Every(ctx context.Context, name string, interval time.Duration, job Job) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(ctx, name, job)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return done
+}
+
+// run 执行一次任务，记录错误并将 panic 转换为错误
+func run(ctx context.Context, name string, job Job) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("job panic: %v", p)
+			}
+		}()
+		return job(ctx)
+	}()
+	if err != nil {
+		applogger.ErrorContext(ctx, "周期任务执行失败",
+			applogger.String("job", name),
+			applogger.Err(err))
+	}
+}
@@ -14,13 +14,33 @@ func InitDailyNoteRoute(mux *http.ServeMux) {
 
 	// 每日笔记路由，所有路由都需要认证
 	// 创建每日笔记
-	mux.Handle("/api/v1/daily-notes", authmiddle.Authenticate(handler.Wrap(handler.CreateDailyNoteHandler)))
+	mux.Handle("POST /api/v1/daily-notes", authmiddle.Authenticate(handler.Wrap(handler.CreateDailyNoteHandler)))
+	// 游标分页获取每日笔记列表
+	mux.Handle("GET /api/v1/daily-notes", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteListByCursorHandler)))
+	// 以最近一篇历史笔记的内容创建今日笔记
+	mux.Handle("POST /api/v1/daily-notes/copy-previous", authmiddle.Authenticate(handler.Wrap(handler.CopyPreviousDailyNoteHandler)))
+	// 批量导入每日笔记
+	mux.Handle("POST /api/v1/daily-notes/batch", authmiddle.Authenticate(handler.Wrap(handler.BatchCreateDailyNotesHandler)))
 	// 获取今日每日笔记
-	mux.Handle("/api/v1/daily-notes/today", authmiddle.Authenticate(handler.Wrap(handler.GetTodayDailyNoteHandler)))
+	mux.Handle("GET /api/v1/daily-notes/today", authmiddle.Authenticate(handler.Wrap(handler.GetTodayDailyNoteHandler)))
 	// 分页获取每日笔记列表
-	mux.Handle("/api/v1/daily-notes/list", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteListHandler)))
+	mux.Handle("GET /api/v1/daily-notes/list", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteListHandler)))
+	// 按日期区间分页获取每日笔记列表
+	mux.Handle("GET /api/v1/daily-notes/range", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteListByRangeHandler)))
+	// 获取每日笔记月历（指定月份有笔记的日期）
+	mux.Handle("GET /api/v1/daily-notes/calendar", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteCalendarHandler)))
+	// 获取每日笔记统计（连续天数）
+	mux.Handle("GET /api/v1/daily-notes/stats", authmiddle.Authenticate(handler.Wrap(handler.GetDailyNoteStatsHandler)))
 	// 更新今日每日笔记
 	mux.Handle("/api/v1/daily-notes/today/update", authmiddle.Authenticate(handler.Wrap(handler.UpdateDailyNoteHandler)))
 	// 删除今日每日笔记
 	mux.Handle("/api/v1/daily-notes/today/delete", authmiddle.Authenticate(handler.Wrap(handler.DeleteDailyNoteHandler)))
+	// 删除指定 ID 的每日笔记
+	mux.Handle("DELETE /api/v1/daily-notes/{id}", authmiddle.Authenticate(handler.Wrap(handler.DeleteDailyNoteByIDHandler)))
+	// 将指定 ID 的每日笔记渲染为 HTML
+	mux.Handle("GET /api/v1/daily-notes/{id}/render", authmiddle.Authenticate(handler.Wrap(handler.RenderDailyNoteHandler)))
+	// 置顶或取消置顶指定 ID 的每日笔记
+	mux.Handle("PATCH /api/v1/daily-notes/{id}/pin", authmiddle.Authenticate(handler.Wrap(handler.PinDailyNoteHandler)))
+	// 设置指定 ID 的每日笔记标签
+	mux.Handle("PUT /api/v1/daily-notes/{id}/tags", authmiddle.Authenticate(handler.Wrap(handler.SetDailyNoteTagsHandler)))
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"net/http"
+
+	"todolist/internal/interfaces/http/openapi"
+)
+
+// InitDocsRoute 初始化接口文档路由
+func InitDocsRoute(mux *http.ServeMux) {
+	// OpenAPI 3 接口描述，供前端和客户端代码生成工具使用
+	mux.Handle("GET /openapi.json", openapi.Handler())
+	// Swagger UI 页面
+	mux.Handle("GET /docs", openapi.DocsHandler("/openapi.json"))
+}
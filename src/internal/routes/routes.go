@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"net/http"
+
+	"todolist/internal/infrastructure/config"
+)
+
+// InitRoutes 注册全部业务路由
+//
+// cmd/server 与路由冒烟测试共用，新增的路由文件需在此注册，否则不会对外提供。
+// avatarCfg 需在启动时校验后传入。
+func InitRoutes(mux *http.ServeMux, avatarCfg *config.AvatarConfig) {
+	InitUserRoute(mux, avatarCfg)
+	InitDailyNoteRoute(mux)
+	InitHealthRoute(mux)
+	InitDocsRoute(mux)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"time"
+
+	"todolist/internal/infrastructure/config"
+)
+
+// longRunningRoutes 使用 LongRequestTimeout 的路由模式，需与注册时的模式完全一致
+var longRunningRoutes = []string{
+	"POST /api/v1/users/avatar/upload",
+	"POST /api/v1/daily-notes/batch",
+	"POST /api/v1/admin/users/import",
+}
+
+// streamingRoutes 流式写出响应的路由模式，不设置整体超时（响应无法缓冲），仅受 HTTP_WRITE_TIMEOUT 限制
+var streamingRoutes = []string{
+	"POST /api/v1/users/me/export",
+}
+
+// RequestTimeoutOverrides 返回需要单独设置请求超时的路由，供 middleware.RouteTimeoutMiddleware 使用
+func RequestTimeoutOverrides(cfg *config.ServerConfig) map[string]time.Duration {
+	overrides := make(map[string]time.Duration, len(longRunningRoutes)+len(streamingRoutes))
+	for _, pattern := range longRunningRoutes {
+		overrides[pattern] = cfg.LongRequestTimeout
+	}
+	for _, pattern := range streamingRoutes {
+		overrides[pattern] = 0
+	}
+	return overrides
+}
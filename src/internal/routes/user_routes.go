@@ -2,17 +2,56 @@ package routes
 
 import (
 	"net/http"
+	"todolist/internal/infrastructure/config"
 	"todolist/internal/interfaces/http/handler"
 	"todolist/internal/interfaces/http/middleware"
 )
 
-func InitUserRoute(mux *http.ServeMux) {
+// InitUserRoute 注册用户、认证和管理端路由
+//
+// avatarCfg 为启动时校验过的头像配置，用于提供本地存储的头像文件。
+func InitUserRoute(mux *http.ServeMux, avatarCfg *config.AvatarConfig) {
 	authmiddle := middleware.GetAuthMiddleware()
-	mux.Handle("/api/v1/users/login", handler.Wrap(handler.LoginUserHandler))
+	// 用户相关路由需声明请求方法，否则会与 GET /api/v1/users/{username} 冲突
+	mux.Handle("POST /api/v1/users/login", middleware.RateLimitMiddleware(handler.Wrap(handler.LoginUserHandler)))
 
 	// 用户路由
-	mux.Handle("/api/v1/users/register", handler.Wrap(handler.RegisterUserHandler))
-	mux.Handle("/api/v1/users/password", authmiddle.Authenticate(handler.Wrap(handler.ChangePasswordHandler)))
-	mux.Handle("/api/v1/users/email", authmiddle.Authenticate(handler.Wrap(handler.UpdateEmailHandler)))
-	mux.Handle("/api/v1/users/avatar", authmiddle.Authenticate(handler.Wrap(handler.UpdateAvatarHandler)))
+	mux.Handle("POST /api/v1/users/register", middleware.RateLimitMiddleware(handler.Wrap(handler.RegisterUserHandler)))
+	mux.Handle("GET /api/v1/users/availability", middleware.RateLimitMiddleware(handler.Wrap(handler.CheckAvailabilityHandler)))
+	mux.Handle("PUT /api/v1/users/password", authmiddle.Authenticate(middleware.RateLimitMiddleware(handler.Wrap(handler.ChangePasswordHandler))))
+	mux.Handle("PUT /api/v1/users/email", authmiddle.Authenticate(handler.Wrap(handler.UpdateEmailHandler)))
+	mux.Handle("PATCH /api/v1/users/username", authmiddle.Authenticate(handler.Wrap(handler.ChangeUsernameHandler)))
+	mux.Handle("PUT /api/v1/users/avatar", authmiddle.Authenticate(handler.Wrap(handler.UpdateAvatarHandler)))
+	mux.Handle("PUT /api/v1/users/timezone", authmiddle.Authenticate(handler.Wrap(handler.UpdateTimezoneHandler)))
+	mux.Handle("POST /api/v1/users/avatar/upload", authmiddle.Authenticate(http.HandlerFunc(handler.UploadAvatarHandler)))
+	mux.Handle("GET /api/v1/users/me", authmiddle.Authenticate(handler.Wrap(handler.GetCurrentUserHandler)))
+	mux.Handle("GET /api/v1/users/me/summary", authmiddle.Authenticate(handler.Wrap(handler.GetUserSummaryHandler)))
+	mux.Handle("POST /api/v1/users/me/export", authmiddle.Authenticate(http.HandlerFunc(handler.ExportUserDataHandler)))
+	mux.Handle("GET /api/v1/users/me/sessions", authmiddle.Authenticate(handler.Wrap(handler.ListSessionsHandler)))
+	mux.Handle("DELETE /api/v1/users/me/sessions/{id}", authmiddle.Authenticate(handler.Wrap(handler.RevokeSessionHandler)))
+	mux.Handle("DELETE /api/v1/users/me/daily-notes", authmiddle.Authenticate(middleware.RateLimitMiddleware(handler.Wrap(handler.DeleteAllDailyNotesHandler))))
+	mux.Handle("DELETE /api/v1/users/me", authmiddle.Authenticate(middleware.RateLimitMiddleware(handler.Wrap(handler.DeleteAccountHandler))))
+	// 公开资料，登录与否均可访问；me、availability 等固定路径优先匹配
+	mux.Handle("GET /api/v1/users/{username}", authmiddle.OptionalAuthenticate(handler.Wrap(handler.GetPublicProfileHandler)))
+
+	// 本地存储的头像文件，AVATAR_BASE_URL 应指向此路径
+	mux.Handle("GET /uploads/avatars/", http.StripPrefix("/uploads/avatars/", handler.AvatarFileHandler(avatarCfg.StorageDir)))
+
+	// 管理端路由
+	requireAdmin := middleware.RequireRole(middleware.RoleAdmin)
+	mux.Handle("GET /api/v1/admin/users", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.ListUsersHandler))))
+	mux.Handle("GET /api/v1/admin/stats", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.GetUserStatsHandler))))
+	mux.Handle("GET /api/v1/admin/users/search", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.SearchUsersHandler))))
+	mux.Handle("GET /api/v1/admin/users/deleted", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.ListDeletedUsersHandler))))
+	mux.Handle("GET /api/v1/admin/users/{id}/audit-logs", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.ListAuditLogsHandler))))
+	mux.Handle("POST /api/v1/admin/users/{id}/restore", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.RestoreUserHandler))))
+	mux.Handle("POST /api/v1/admin/users/status", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.ChangeUsersStatusHandler))))
+	mux.Handle("POST /api/v1/admin/users/import", authmiddle.Authenticate(requireAdmin(handler.Wrap(handler.ImportUsersHandler))))
+
+	// 认证路由
+	mux.Handle("GET /api/v1/auth/verify", handler.Wrap(handler.VerifyEmailHandler))
+	mux.Handle("GET /api/v1/auth/email/confirm", handler.Wrap(handler.ConfirmEmailChangeHandler))
+	mux.Handle("POST /api/v1/auth/refresh", middleware.RateLimitMiddleware(handler.Wrap(handler.RefreshTokenHandler)))
+	mux.Handle("POST /api/v1/auth/reactivate", middleware.RateLimitMiddleware(handler.Wrap(handler.ReactivateAccountHandler)))
+	mux.Handle("GET /api/v1/auth/introspect", authmiddle.Authenticate(handler.Wrap(handler.IntrospectTokenHandler)))
 }
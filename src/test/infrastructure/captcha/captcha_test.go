@@ -0,0 +1,62 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/captcha"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSiteVerifier 测试通过 siteverify 接口校验人机验证凭证
+func TestSiteVerifier(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+
+		switch r.PostForm.Get("response") {
+		case "valid":
+			json.NewEncoder(w).Encode(map[string]any{"success": true})
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error-codes": []string{"invalid-input-response"}})
+		}
+	}))
+	defer server.Close()
+
+	verifier := captcha.NewSiteVerifier(server.URL, "secret", time.Second)
+
+	t.Run("accepted", func(t *testing.T) {
+		assert.NoError(t, verifier.Verify(ctx, "valid"))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		err := verifier.Verify(ctx, "forged")
+		assert.ErrorIs(t, err, user.ErrCaptchaFailed)
+		assert.Contains(t, err.Error(), "invalid-input-response")
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		assert.ErrorIs(t, verifier.Verify(ctx, "  "), user.ErrCaptchaFailed)
+	})
+
+	t.Run("service unavailable fails closed", func(t *testing.T) {
+		err := verifier.Verify(ctx, "broken")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, user.ErrCaptchaFailed)
+	})
+}
+
+// TestGuard 测试默认注册防护不做校验
+func TestGuard(t *testing.T) {
+	assert.NoError(t, captcha.GetGuard().Verify(context.Background(), ""))
+}
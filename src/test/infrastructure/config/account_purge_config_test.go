@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadAccountPurgeConfig 测试已注销账户清理配置的默认值、关闭和校验
+func TestLoadAccountPurgeConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("ACCOUNT_PURGE_RETENTION", "")
+		t.Setenv("ACCOUNT_PURGE_INTERVAL", "")
+		cfg, err := config.LoadAccountPurgeConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, cfg.Retention)
+		assert.Equal(t, 24*time.Hour, cfg.Interval)
+		assert.True(t, cfg.Enabled())
+	})
+
+	t.Run("zero retention disables purge", func(t *testing.T) {
+		t.Setenv("ACCOUNT_PURGE_RETENTION", "0s")
+		t.Setenv("ACCOUNT_PURGE_INTERVAL", "")
+		cfg, err := config.LoadAccountPurgeConfig()
+		assert.NoError(t, err)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("invalid values rejected", func(t *testing.T) {
+		t.Setenv("ACCOUNT_PURGE_RETENTION", "-1h")
+		t.Setenv("ACCOUNT_PURGE_INTERVAL", "")
+		_, err := config.LoadAccountPurgeConfig()
+		assert.Error(t, err)
+
+		t.Setenv("ACCOUNT_PURGE_RETENTION", "")
+		t.Setenv("ACCOUNT_PURGE_INTERVAL", "0s")
+		_, err = config.LoadAccountPurgeConfig()
+		assert.Error(t, err)
+	})
+}
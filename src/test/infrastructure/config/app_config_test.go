@@ -0,0 +1,202 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfigFile 在临时目录写入配置文件并返回路径
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestLoadAppConfig_FileAndEnvPrecedence 测试配置文件与环境变量的优先级
+func TestLoadAppConfig_FileAndEnvPrecedence(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+mysql:
+  host: file_host
+  port: 3310
+  db: file_db
+  conn_max_lifetime: 45m
+jwt:
+  secret_key: file-secret-key-with-at-least-32-chars
+  expire_duration: 2h
+logger:
+  level: debug
+  file: /tmp/file.log
+`)
+	t.Setenv("CONFIG_FILE", path)
+	for _, key := range []string{"MYSQL_HOST", "MYSQL_PORT", "MYSQL_DB", "MYSQL_CONN_MAX_LIFETIME",
+		"JWT_SECRET_KEY", "JWT_EXPIRE_DURATION", "LOG_LEVEL", "LOG_FILE"} {
+		t.Setenv(key, "")
+	}
+
+	t.Run("file values", func(t *testing.T) {
+		cfg, err := config.LoadAppConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "file_host", cfg.MySQL.Host)
+		assert.Equal(t, 3310, cfg.MySQL.Port)
+		assert.Equal(t, "file_db", cfg.MySQL.DB)
+		assert.Equal(t, 45*time.Minute, cfg.MySQL.ConnMaxLifetime)
+		assert.Equal(t, "file-secret-key-with-at-least-32-chars", cfg.JWT.SecretKey)
+		assert.Equal(t, 2*time.Hour, cfg.JWT.ExpireDuration)
+		assert.Equal(t, "debug", cfg.Logger.Level)
+		assert.Equal(t, "/tmp/file.log", cfg.Logger.FilePath)
+
+		// 文件未设置的项使用默认值
+		assert.Equal(t, 100, cfg.MySQL.MaxOpenConns)
+		assert.Equal(t, config.DefaultMySQLQueryTimeout, cfg.MySQL.QueryTimeout)
+		assert.Equal(t, logger.DefaultMaxBackups, cfg.Logger.MaxBackups)
+	})
+
+	t.Run("env overrides file", func(t *testing.T) {
+		t.Setenv("MYSQL_HOST", "env_host")
+		t.Setenv("MYSQL_CONN_MAX_LIFETIME", "5m")
+		t.Setenv("LOG_LEVEL", "WARN")
+
+		cfg, err := config.LoadAppConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "env_host", cfg.MySQL.Host)
+		assert.Equal(t, 5*time.Minute, cfg.MySQL.ConnMaxLifetime)
+		assert.Equal(t, "warn", cfg.Logger.Level)
+		assert.Equal(t, 3310, cfg.MySQL.Port)
+
+		// 旧的访问器委托给统一加载器
+		mysqlCfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "env_host", mysqlCfg.Host)
+		assert.Equal(t, "file_db", mysqlCfg.DB)
+	})
+}
+
+// TestLoadAppConfig_JSONFile 测试 JSON 格式配置文件
+func TestLoadAppConfig_JSONFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.json", `{"mysql": {"user": "json_user"}}`))
+	t.Setenv("MYSQL_USER", "")
+
+	cfg, err := config.LoadAppConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "json_user", cfg.MySQL.User)
+}
+
+// TestMySQLConfig_Replica 测试只读副本配置及 DSN 中未配置项继承主库
+func TestMySQLConfig_Replica(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("MYSQL_HOST", "primary")
+	t.Setenv("MYSQL_PORT", "3306")
+	t.Setenv("MYSQL_DB", "todolist")
+	t.Setenv("MYSQL_USER", "app")
+	t.Setenv("MYSQL_PASSWORD", "secret")
+	t.Setenv("MYSQL_REPLICA_HOST", "")
+	t.Setenv("MYSQL_REPLICA_PORT", "")
+	t.Setenv("MYSQL_REPLICA_USER", "")
+	t.Setenv("MYSQL_REPLICA_PASSWORD", "")
+
+	t.Run("disabled by default", func(t *testing.T) {
+		cfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.False(t, cfg.HasReplica())
+	})
+
+	t.Run("inherits primary settings", func(t *testing.T) {
+		t.Setenv("MYSQL_REPLICA_HOST", "replica")
+
+		cfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.True(t, cfg.HasReplica())
+		assert.Contains(t, cfg.ReplicaDSN(), "app:secret@tcp(replica:3306)/todolist?")
+		assert.Contains(t, cfg.DSN(), "@tcp(primary:3306)/")
+	})
+
+	t.Run("own port and credentials", func(t *testing.T) {
+		t.Setenv("MYSQL_REPLICA_HOST", "replica")
+		t.Setenv("MYSQL_REPLICA_PORT", "3307")
+		t.Setenv("MYSQL_REPLICA_USER", "reader")
+		t.Setenv("MYSQL_REPLICA_PASSWORD", "readonly")
+
+		cfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.Contains(t, cfg.ReplicaDSN(), "reader:readonly@tcp(replica:3307)/todolist?")
+	})
+
+	t.Run("invalid port", func(t *testing.T) {
+		t.Setenv("MYSQL_REPLICA_HOST", "replica")
+		t.Setenv("MYSQL_REPLICA_PORT", "70000")
+
+		_, err := config.LoadMySQLConfig()
+		assert.Error(t, err)
+	})
+}
+
+// TestLoadAppConfig_Invalid 测试无效配置
+func TestLoadAppConfig_Invalid(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown log level", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("LOG_LEVEL", "verbose")
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown log format", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("LOG_FORMAT", "logfmt")
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed duration", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("JWT_EXPIRE_DURATION", "two hours")
+
+		_, err := config.LoadAppConfig()
+		assert.ErrorContains(t, err, "JWT_EXPIRE_DURATION")
+	})
+
+	t.Run("malformed integer", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("MYSQL_PORT", "33o7")
+
+		_, err := config.LoadAppConfig()
+		assert.ErrorContains(t, err, "MYSQL_PORT")
+	})
+
+	t.Run("malformed file value", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.yaml", "logger:\n  max_backups: many\n"))
+		t.Setenv("LOG_FILE_MAX_BACKUPS", "")
+
+		_, err := config.LoadAppConfig()
+		assert.ErrorContains(t, err, "logger.max_backups")
+	})
+}
+
+// TestLoggerConfig_Apply 测试日志配置项应用到 logger.Config
+func TestLoggerConfig_Apply(t *testing.T) {
+	cfg := config.LoggerConfig{Level: "error", FilePath: "/var/log/app.log", MaxSizeMB: 10}.Apply(logger.DefaultConfig())
+
+	assert.Equal(t, logger.LevelError, cfg.Level)
+	assert.Equal(t, "/var/log/app.log", cfg.FilePath)
+	assert.Equal(t, 10, cfg.MaxSizeMB)
+	assert.Equal(t, logger.FormatJSON, cfg.Format)
+
+	cfg = config.LoggerConfig{Level: "info", Format: "ecs"}.Apply(logger.DefaultConfig())
+	assert.Equal(t, logger.FormatECS, cfg.Format)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadConfig_BoolEnv 测试布尔型环境变量的解析，格式错误时加载失败而不是回退默认值
+func TestLoadConfig_BoolEnv(t *testing.T) {
+	loaders := []struct {
+		key  string
+		load func() (bool, error)
+	}{
+		{"REQUIRE_EMAIL_VERIFICATION", func() (bool, error) {
+			cfg, err := config.LoadEmailVerificationConfig()
+			if err != nil {
+				return false, err
+			}
+			return cfg.Required, nil
+		}},
+		{"PASSWORD_DISALLOW_COMMON", func() (bool, error) {
+			cfg, err := config.LoadPasswordPolicyConfig()
+			if err != nil {
+				return false, err
+			}
+			return cfg.DisallowCommon, nil
+		}},
+		{"GZIP_ENABLED", func() (bool, error) {
+			cfg, err := config.LoadCompressionConfig()
+			if err != nil {
+				return false, err
+			}
+			return cfg.Enabled, nil
+		}},
+	}
+
+	for _, l := range loaders {
+		t.Run(l.key, func(t *testing.T) {
+			t.Setenv(l.key, "true")
+			value, err := l.load()
+			assert.NoError(t, err)
+			assert.True(t, value)
+
+			t.Setenv(l.key, "0")
+			value, err = l.load()
+			assert.NoError(t, err)
+			assert.False(t, value)
+
+			t.Setenv(l.key, "ture")
+			_, err = l.load()
+			assert.ErrorContains(t, err, l.key)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadCaptchaConfig 测试注册人机验证配置，默认不启用
+func TestLoadCaptchaConfig(t *testing.T) {
+	t.Setenv("CAPTCHA_PROVIDER", "")
+	t.Setenv("CAPTCHA_SECRET", "")
+	t.Setenv("CAPTCHA_VERIFY_URL", "")
+	t.Setenv("CAPTCHA_TIMEOUT", "")
+
+	cfg, err := config.LoadCaptchaConfig()
+	require.NoError(t, err)
+	assert.False(t, cfg.Enabled())
+
+	t.Setenv("CAPTCHA_PROVIDER", "turnstile")
+	_, err = config.LoadCaptchaConfig()
+	assert.Error(t, err, "secret is required when enabled")
+
+	t.Setenv("CAPTCHA_SECRET", "secret")
+	cfg, err = config.LoadCaptchaConfig()
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, config.TurnstileVerifyURL, cfg.VerifyURL)
+
+	t.Setenv("CAPTCHA_PROVIDER", "recaptcha")
+	_, err = config.LoadCaptchaConfig()
+	assert.Error(t, err)
+}
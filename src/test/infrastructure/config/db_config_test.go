@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"todolist/internal/infrastructure/config"
 
@@ -121,6 +122,40 @@ func TestLoadMySQLConfig_DefaultValues(t *testing.T) {
 	assert.Equal(t, 10, cfg.MaxIdleConns)
 }
 
+// TestLoadMySQLConfig_ConnPoolTuning 测试连接池存活时间与慢查询阈值配置
+func TestLoadMySQLConfig_ConnPoolTuning(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("MYSQL_CONN_MAX_LIFETIME", "")
+		t.Setenv("MYSQL_CONN_MAX_IDLE_TIME", "")
+		t.Setenv("MYSQL_SLOW_QUERY_THRESHOLD", "")
+
+		cfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, cfg.ConnMaxLifetime)
+		assert.Equal(t, 10*time.Minute, cfg.ConnMaxIdleTime)
+		assert.Equal(t, time.Duration(0), cfg.SlowQueryThreshold)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("MYSQL_CONN_MAX_LIFETIME", "30m")
+		t.Setenv("MYSQL_CONN_MAX_IDLE_TIME", "5m")
+		t.Setenv("MYSQL_SLOW_QUERY_THRESHOLD", "200ms")
+
+		cfg, err := config.LoadMySQLConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, cfg.ConnMaxLifetime)
+		assert.Equal(t, 5*time.Minute, cfg.ConnMaxIdleTime)
+		assert.Equal(t, 200*time.Millisecond, cfg.SlowQueryThreshold)
+	})
+
+	t.Run("negative threshold", func(t *testing.T) {
+		t.Setenv("MYSQL_SLOW_QUERY_THRESHOLD", "-1s")
+
+		_, err := config.LoadMySQLConfig()
+		assert.Error(t, err)
+	})
+}
+
 // TestLoadMySQLConfig_InvalidConfig 测试无效配置
 func TestLoadMySQLConfig_InvalidConfig(t *testing.T) {
 	// 保存并清理环境变量
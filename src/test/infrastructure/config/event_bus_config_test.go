@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadEventBusConfig 测试事件总线配置的默认值、环境变量覆盖和校验
+func TestLoadEventBusConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("EVENT_BUS_BUFFER_SIZE", "")
+		t.Setenv("EVENT_BUS_WORKERS", "")
+		cfg, err := config.LoadEventBusConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, config.DefaultEventBusBufferSize, cfg.BufferSize)
+		assert.Equal(t, config.DefaultEventBusWorkers, cfg.Workers)
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		t.Setenv("EVENT_BUS_BUFFER_SIZE", "16")
+		t.Setenv("EVENT_BUS_WORKERS", "4")
+		cfg, err := config.LoadEventBusConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 16, cfg.BufferSize)
+		assert.Equal(t, 4, cfg.Workers)
+	})
+
+	t.Run("non-positive rejected", func(t *testing.T) {
+		t.Setenv("EVENT_BUS_BUFFER_SIZE", "0")
+		t.Setenv("EVENT_BUS_WORKERS", "")
+		_, err := config.LoadEventBusConfig()
+		assert.Error(t, err)
+
+		t.Setenv("EVENT_BUS_BUFFER_SIZE", "")
+		t.Setenv("EVENT_BUS_WORKERS", "-1")
+		_, err = config.LoadEventBusConfig()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadRateLimitConfig 测试限流配置的默认值、环境变量覆盖和格式错误
+func TestLoadRateLimitConfig(t *testing.T) {
+	unsetEnv := func(t *testing.T) {
+		for _, key := range []string{"RATE_LIMIT_RPS", "RATE_LIMIT_BURST", "RATE_LIMIT_IDLE_TIMEOUT"} {
+			t.Setenv(key, "")
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		unsetEnv(t)
+		cfg, err := config.LoadRateLimitConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, float64(config.DefaultRateLimitRPS), cfg.RequestsPerSecond)
+		assert.Equal(t, config.DefaultRateLimitBurst, cfg.Burst)
+		assert.Equal(t, config.DefaultRateLimitIdleTimeout, cfg.IdleTimeout)
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("RATE_LIMIT_RPS", "0.5")
+		t.Setenv("RATE_LIMIT_IDLE_TIMEOUT", "1m")
+		cfg, err := config.LoadRateLimitConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5, cfg.RequestsPerSecond)
+		assert.Equal(t, time.Minute, cfg.IdleTimeout)
+	})
+
+	t.Run("malformed rps", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("RATE_LIMIT_RPS", "1O")
+		_, err := config.LoadRateLimitConfig()
+		assert.ErrorContains(t, err, "RATE_LIMIT_RPS")
+	})
+
+	t.Run("non-positive rps", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("RATE_LIMIT_RPS", "0")
+		_, err := config.LoadRateLimitConfig()
+		assert.Error(t, err)
+	})
+}
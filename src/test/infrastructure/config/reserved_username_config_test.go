@@ -0,0 +1,18 @@
+package config
+
+import (
+	"testing"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadReservedUsernameConfig 测试保留用户名列表的解析，未配置时使用默认列表
+func TestLoadReservedUsernameConfig(t *testing.T) {
+	t.Setenv("RESERVED_USERNAMES", "")
+	assert.Empty(t, config.LoadReservedUsernameConfig().Usernames)
+
+	t.Setenv("RESERVED_USERNAMES", " admin, Staff ,,ops")
+	assert.Equal(t, []string{"admin", "Staff", "ops"}, config.LoadReservedUsernameConfig().Usernames)
+}
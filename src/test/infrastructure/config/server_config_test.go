@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadServerConfig 测试 HTTP 服务配置的默认值、环境变量覆盖和校验
+func TestLoadServerConfig(t *testing.T) {
+	keys := []string{"HTTP_ADDR", "HTTP_READ_TIMEOUT", "HTTP_READ_HEADER_TIMEOUT", "HTTP_WRITE_TIMEOUT", "HTTP_IDLE_TIMEOUT",
+		"HTTP_REQUEST_TIMEOUT", "HTTP_LONG_REQUEST_TIMEOUT"}
+	unsetEnv := func(t *testing.T) {
+		for _, key := range keys {
+			t.Setenv(key, "")
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		unsetEnv(t)
+		cfg, err := config.LoadServerConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, ":8080", cfg.Addr)
+		assert.Equal(t, 15*time.Second, cfg.ReadTimeout)
+		assert.Equal(t, 5*time.Second, cfg.ReadHeaderTimeout)
+		assert.Equal(t, 30*time.Second, cfg.WriteTimeout)
+		assert.Equal(t, 60*time.Second, cfg.IdleTimeout)
+		assert.Equal(t, 10*time.Second, cfg.RequestTimeout)
+		assert.Equal(t, 25*time.Second, cfg.LongRequestTimeout)
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("HTTP_ADDR", "127.0.0.1:9090")
+		t.Setenv("HTTP_WRITE_TIMEOUT", "2m")
+		cfg, err := config.LoadServerConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:9090", cfg.Addr)
+		assert.Equal(t, 2*time.Minute, cfg.WriteTimeout)
+	})
+
+	t.Run("zero timeout rejected", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("HTTP_IDLE_TIMEOUT", "0s")
+		_, err := config.LoadServerConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("header timeout exceeds read timeout", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("HTTP_READ_TIMEOUT", "2s")
+		t.Setenv("HTTP_READ_HEADER_TIMEOUT", "3s")
+		_, err := config.LoadServerConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("request timeout not less than write timeout", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("HTTP_LONG_REQUEST_TIMEOUT", "30s")
+		_, err := config.LoadServerConfig()
+		assert.Error(t, err)
+	})
+}
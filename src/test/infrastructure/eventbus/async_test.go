@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/event"
+	"todolist/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collector 记录收到的事件
+type collector struct {
+	mu     sync.Mutex
+	events []event.Event
+}
+
+func (c *collector) handle(ctx context.Context, e event.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, e)
+	return nil
+}
+
+func (c *collector) received() []event.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]event.Event(nil), c.events...)
+}
+
+// TestAsyncBus_Deliver 测试事件分发给所有订阅者，Close 等待队列处理完成
+func TestAsyncBus_Deliver(t *testing.T) {
+	bus := eventbus.NewAsyncBus(8, 1)
+	first, second := &collector{}, &collector{}
+	bus.Subscribe(first.handle)
+	bus.Subscribe(second.handle)
+
+	bus.Publish(context.Background(), event.New(event.TypeUserRegistered, 1, 0))
+	bus.Publish(context.Background(), event.New(event.TypeDailyNoteCreated, 1, 10))
+	bus.Close()
+
+	for _, c := range []*collector{first, second} {
+		events := c.received()
+		assert.Len(t, events, 2)
+		assert.Equal(t, event.TypeUserRegistered, events[0].Type)
+		assert.Equal(t, event.TypeDailyNoteCreated, events[1].Type)
+		assert.Equal(t, int64(10), events[1].ResourceID)
+	}
+}
+
+// TestAsyncBus_HandlerFailure 测试订阅者出错或 panic 不影响其他订阅者和后续事件
+func TestAsyncBus_HandlerFailure(t *testing.T) {
+	bus := eventbus.NewAsyncBus(8, 1)
+	c := &collector{}
+	bus.Subscribe(func(ctx context.Context, e event.Event) error {
+		panic("boom")
+	})
+	bus.Subscribe(func(ctx context.Context, e event.Event) error {
+		return errors.New("webhook down")
+	})
+	bus.Subscribe(c.handle)
+
+	bus.Publish(context.Background(), event.New(event.TypeUserRegistered, 1, 0))
+	bus.Publish(context.Background(), event.New(event.TypeUserEmailChanged, 1, 0))
+	bus.Close()
+
+	assert.Len(t, c.received(), 2)
+}
+
+// TestAsyncBus_NonBlocking 测试队列已满和总线关闭后发布立即返回并丢弃事件
+func TestAsyncBus_NonBlocking(t *testing.T) {
+	bus := eventbus.NewAsyncBus(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	c := &collector{}
+	bus.Subscribe(func(ctx context.Context, e event.Event) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	bus.Subscribe(c.handle)
+
+	// 第一个事件占住 worker，第二个填满队列，其余被丢弃
+	bus.Publish(context.Background(), event.New(event.TypeUserRegistered, 1, 0))
+	<-started
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			bus.Publish(context.Background(), event.New(event.TypeUserRegistered, 2, 0))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on full queue")
+	}
+
+	close(release)
+	bus.Close()
+	assert.Len(t, c.received(), 2)
+
+	bus.Publish(context.Background(), event.New(event.TypeUserRegistered, 3, 0))
+	bus.Close()
+	assert.Len(t, c.received(), 2)
+}
+
+// TestAsyncBus_DetachedContext 测试请求上下文取消后订阅者仍可正常处理
+func TestAsyncBus_DetachedContext(t *testing.T) {
+	bus := eventbus.NewAsyncBus(1, 1)
+	var handlerErr error
+	bus.Subscribe(func(ctx context.Context, e event.Event) error {
+		handlerErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Publish(ctx, event.New(event.TypeUserRegistered, 1, 0))
+	bus.Close()
+
+	assert.NoError(t, handlerErr)
+}
+
+// TestSetBus 测试默认事件总线为 NopBus，可被替换
+func TestSetBus(t *testing.T) {
+	assert.Equal(t, event.NopBus{}, eventbus.GetBus())
+
+	bus := eventbus.NewAsyncBus(1, 1)
+	defer bus.Close()
+	eventbus.SetBus(bus)
+	defer eventbus.SetBus(event.NopBus{})
+
+	assert.Same(t, bus, eventbus.GetBus())
+}
@@ -0,0 +1,128 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	migrations "todolist/internal/infrastructure/persistence/migrations"
+	mysql "todolist/internal/infrastructure/persistence/mysql"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+// noopScripts 返回不修改表结构的迁移脚本，执行顺序记录到 calls 中
+//
+// 版本号远小于内置迁移，测试结束后通过 DownTo(0) 清理迁移记录。
+func noopScripts(calls *[]string) []migrations.Script {
+	script := func(version int64, name string) migrations.Script {
+		return migrations.Script{
+			Version: version,
+			Name:    name,
+			Up: func(db sqlx.Ext) error {
+				*calls = append(*calls, "up:"+name)
+				return nil
+			},
+			Down: func(db sqlx.Ext) error {
+				*calls = append(*calls, "down:"+name)
+				return nil
+			},
+		}
+	}
+	return []migrations.Script{
+		script(1, "noop_one"),
+		script(2, "noop_two"),
+		script(3, "noop_three"),
+	}
+}
+
+func TestMigrator_UnknownTargetVersion(t *testing.T) {
+	// 目标版本校验先于数据库访问，不需要真实数据库
+	var calls []string
+	m := migrations.NewMigrator(nil, migrations.WithScripts(noopScripts(&calls)))
+	ctx := context.Background()
+
+	err := m.UpTo(ctx, 4)
+	assert.True(t, errors.Is(err, migrations.ErrUnknownVersion), "got %v", err)
+
+	err = m.UpTo(ctx, 0)
+	assert.True(t, errors.Is(err, migrations.ErrUnknownVersion), "got %v", err)
+
+	err = m.DownTo(ctx, 99)
+	assert.True(t, errors.Is(err, migrations.ErrUnknownVersion), "got %v", err)
+
+	assert.Empty(t, calls)
+}
+
+// 以下测试依赖真实的 MySQL 数据库，无法连接时跳过
+
+func TestMigrator_UpToAndDownTo(t *testing.T) {
+	client, err := mysql.NewClient()
+	if err != nil {
+		t.Skipf("跳过测试: 无法连接到MySQL数据库: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	var calls []string
+	m := migrations.NewMigrator(client.GetDB(), migrations.WithScripts(noopScripts(&calls)))
+	require.NoError(t, m.DownTo(ctx, 0))
+	t.Cleanup(func() { m.DownTo(ctx, 0) })
+	calls = nil
+
+	applied := func() []bool {
+		statuses, err := m.Status(ctx)
+		require.NoError(t, err)
+		result := make([]bool, len(statuses))
+		for i, s := range statuses {
+			result[i] = s.Applied
+		}
+		return result
+	}
+
+	require.NoError(t, m.UpTo(ctx, 2))
+	assert.Equal(t, []string{"up:noop_one", "up:noop_two"}, calls)
+	assert.Equal(t, []bool{true, true, false}, applied())
+
+	// 已执行的迁移不会重复执行
+	calls = nil
+	require.NoError(t, m.UpTo(ctx, 3))
+	assert.Equal(t, []string{"up:noop_three"}, calls)
+
+	// 按版本倒序回滚，目标版本保留
+	calls = nil
+	require.NoError(t, m.DownTo(ctx, 1))
+	assert.Equal(t, []string{"down:noop_three", "down:noop_two"}, calls)
+	assert.Equal(t, []bool{true, false, false}, applied())
+
+	calls = nil
+	require.NoError(t, m.DownTo(ctx, 0))
+	assert.Equal(t, []string{"down:noop_one"}, calls)
+	assert.Equal(t, []bool{false, false, false}, applied())
+}
+
+func TestMigrator_UpIsIdempotent(t *testing.T) {
+	client, err := mysql.NewClient()
+	if err != nil {
+		t.Skipf("跳过测试: 无法连接到MySQL数据库: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	m := migrations.NewMigrator(client.GetDB())
+
+	require.NoError(t, m.Up(ctx))
+	before, err := m.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range before {
+		assert.True(t, s.Applied, "migration %d (%s) should be applied", s.Version, s.Name)
+	}
+
+	// 再次执行不应报错，也不应改变任何迁移记录
+	require.NoError(t, m.Up(ctx))
+	after, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
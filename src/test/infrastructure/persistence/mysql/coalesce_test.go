@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/interfaces/do"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingExecutor 统计 GetContext 调用次数的执行器，查询阻塞到 release 关闭后返回固定用户
+type countingExecutor struct {
+	fakeExecutor
+	gets    atomic.Int32
+	release chan struct{}
+}
+
+func (e *countingExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	e.gets.Add(1)
+	select {
+	case <-e.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	u, ok := dest.(*do.User)
+	if !ok {
+		return errors.New("unexpected dest")
+	}
+	now := time.Now()
+	*u = do.User{ID: args[0].(int64), Username: "alice", Email: "alice@example.com", Status: string(user.UserStatusActive),
+		CreatedAt: now, UpdatedAt: now}
+	return nil
+}
+
+// findConcurrently 并发调用 n 次 FindByID，所有调用都已发起后放行查询
+func findConcurrently(t *testing.T, ctx context.Context, exec *countingExecutor, repo *mysql.UserRepository, n int) []user.UserEntity {
+	t.Helper()
+	results := make([]user.UserEntity, n)
+	var started, done sync.WaitGroup
+	started.Add(n)
+	done.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			found, err := repo.FindByID(ctx, 7)
+			assert.NoError(t, err)
+			results[i] = found
+		}(i)
+	}
+	started.Wait()
+	// 给所有 goroutine 留出进入 FindByID 的时间
+	time.Sleep(50 * time.Millisecond)
+	close(exec.release)
+	done.Wait()
+	return results
+}
+
+// TestFindByID_CoalescesConcurrentReads 测试相同 ID 的并发查询合并为一次数据库查询
+func TestFindByID_CoalescesConcurrentReads(t *testing.T) {
+	const n = 50
+
+	t.Run("concurrent misses share one query", func(t *testing.T) {
+		exec := &countingExecutor{release: make(chan struct{})}
+		repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+		results := findConcurrently(t, context.Background(), exec, repo, n)
+		assert.Equal(t, int32(1), exec.gets.Load())
+
+		// 每个调用方得到各自的实体，修改互不影响
+		require.NotNil(t, results[0])
+		require.NotNil(t, results[1])
+		assert.Equal(t, int64(7), results[0].GetID())
+		assert.NotSame(t, results[0], results[1])
+	})
+
+	t.Run("primary reads not coalesced", func(t *testing.T) {
+		exec := &countingExecutor{release: make(chan struct{})}
+		repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+		findConcurrently(t, mysql.WithPrimary(context.Background()), exec, repo, n)
+		assert.Equal(t, int32(n), exec.gets.Load())
+	})
+
+	t.Run("canceled caller does not cancel shared query", func(t *testing.T) {
+		exec := &countingExecutor{release: make(chan struct{})}
+		repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := repo.FindByID(ctx, 7)
+			leaderErr <- err
+		}()
+		require.Eventually(t, func() bool { return exec.gets.Load() == 1 }, time.Second, time.Millisecond)
+
+		followerDone := make(chan user.UserEntity, 1)
+		go func() {
+			found, err := repo.FindByID(context.Background(), 7)
+			assert.NoError(t, err)
+			followerDone <- found
+		}()
+
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+		// 给跟随者留出加入共享查询的时间
+		time.Sleep(50 * time.Millisecond)
+		close(exec.release)
+		found := <-followerDone
+		require.NotNil(t, found)
+		assert.Equal(t, "alice", found.GetUsername())
+		assert.Equal(t, int32(1), exec.gets.Load())
+	})
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"errors"
 	"testing"
 	mysql "todolist/internal/infrastructure/persistence/mysql"
 
@@ -25,3 +26,19 @@ func TestMySQLConnection(t *testing.T) {
 
 	t.Log("成功连接到MySQL数据库")
 }
+
+func TestGetClient_Unavailable(t *testing.T) {
+	// 数据库不可用时 GetClient 返回 ErrDatabaseUnavailable 而不是 panic
+	db_client, err := mysql.GetClient()
+	if err == nil {
+		db_client.Close()
+		t.Skip("跳过测试: MySQL数据库可用")
+	}
+
+	if !errors.Is(err, mysql.ErrDatabaseUnavailable) {
+		t.Fatalf("expected ErrDatabaseUnavailable, got %v", err)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Error("expected connection error to be wrapped")
+	}
+}
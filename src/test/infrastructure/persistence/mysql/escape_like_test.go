@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"testing"
+
+	mysql "todolist/internal/infrastructure/persistence/mysql"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"alice", "alice"},
+		{"50%", `50\%`},
+		{"a_b", `a\_b`},
+		{`a\b`, `a\\b`},
+		{`%_\`, `\%\_\\`},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, mysql.EscapeLike(tt.input), "input %q", tt.input)
+	}
+}
@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"todolist/internal/infrastructure/persistence/mysql"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingTx 按顺序返回预设错误的事务函数，记录调用次数
+type failingTx struct {
+	errs  []error
+	calls int
+}
+
+func (f *failingTx) run() error {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+	return nil
+}
+
+// TestRetryOnLockConflict 测试死锁和锁等待超时时重试，其他错误立即返回
+func TestRetryOnLockConflict(t *testing.T) {
+	deadlock := &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	lockWait := &mysqldriver.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+	ctx := context.Background()
+
+	t.Run("retries until success", func(t *testing.T) {
+		tx := &failingTx{errs: []error{deadlock, fmt.Errorf("failed to insert daily note: %w", lockWait)}}
+		err := mysql.RetryOnLockConflict(ctx, 3, tx.run)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, tx.calls)
+	})
+
+	t.Run("returns last error after max retries", func(t *testing.T) {
+		tx := &failingTx{errs: []error{deadlock, deadlock, deadlock, lockWait}}
+		err := mysql.RetryOnLockConflict(ctx, 2, tx.run)
+		assert.ErrorIs(t, err, deadlock)
+		assert.Equal(t, 3, tx.calls)
+	})
+
+	t.Run("zero retries", func(t *testing.T) {
+		tx := &failingTx{errs: []error{deadlock}}
+		err := mysql.RetryOnLockConflict(ctx, 0, tx.run)
+		assert.ErrorIs(t, err, deadlock)
+		assert.Equal(t, 1, tx.calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		dup := &mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"}
+		for _, want := range []error{dup, errors.New("boom")} {
+			tx := &failingTx{errs: []error{want}}
+			err := mysql.RetryOnLockConflict(ctx, 3, tx.run)
+			assert.ErrorIs(t, err, want)
+			assert.Equal(t, 1, tx.calls)
+		}
+	})
+
+	t.Run("stops when context is canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		tx := &failingTx{errs: []error{deadlock, deadlock}}
+		err := mysql.RetryOnLockConflict(canceled, 3, tx.run)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, tx.calls)
+	})
+}
@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/persistence/mysql"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// execCall 一次 ExecContext 调用
+type execCall struct {
+	query string
+	args  []interface{}
+}
+
+// fakeResult 固定影响行数的执行结果
+type fakeResult struct {
+	affected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+// fakeExecutor 记录 ExecContext 调用的执行器，查询方法不应被调用
+type fakeExecutor struct {
+	calls    []execCall
+	affected int64
+	err      error
+}
+
+func (e *fakeExecutor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return errors.New("unexpected select")
+}
+
+func (e *fakeExecutor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return errors.New("unexpected get")
+}
+
+func (e *fakeExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (interface {
+	LastInsertId() (int64, error)
+	RowsAffected() (int64, error)
+}, error) {
+	e.calls = append(e.calls, execCall{query: query, args: args})
+	if e.err != nil {
+		return nil, e.err
+	}
+	return fakeResult{affected: e.affected}, nil
+}
+
+// TestPurgeSoftDeletedUsers 测试非正数保留期在开启事务前即被拒绝
+//
+// 清理本身在事务中执行，由 SQLite 集成测试覆盖。
+func TestPurgeSoftDeletedUsers(t *testing.T) {
+	ctx := context.Background()
+
+	for _, olderThan := range []time.Duration{0, -time.Hour} {
+		exec := &fakeExecutor{}
+		repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+		_, err := repo.PurgeSoftDeletedUsers(ctx, olderThan)
+		assert.Error(t, err)
+		assert.Empty(t, exec.calls)
+	}
+}
@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todolist/internal/infrastructure/persistence/mysql"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithQueryTimeout 测试默认查询超时仅在上下文无截止时间时生效
+func TestWithQueryTimeout(t *testing.T) {
+	t.Run("derive deadline when absent", func(t *testing.T) {
+		ctx, cancel := mysql.WithQueryTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+	})
+
+	t.Run("keep existing deadline", func(t *testing.T) {
+		parent, parentCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer parentCancel()
+
+		ctx, cancel := mysql.WithQueryTimeout(parent, time.Second)
+		defer cancel()
+
+		assert.Equal(t, parent, ctx)
+	})
+
+	t.Run("zero timeout disables", func(t *testing.T) {
+		ctx, cancel := mysql.WithQueryTimeout(context.Background(), 0)
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
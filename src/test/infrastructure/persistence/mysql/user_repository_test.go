@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+	mysql "todolist/internal/infrastructure/persistence/mysql"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserRepository_UsernameCaseCollision 测试仅大小写不同的用户名被视为同一用户名
+//
+// 依赖真实的MySQL数据库及 username_canonical 迁移，没有MySQL服务时自动跳过。
+func TestUserRepository_UsernameCaseCollision(t *testing.T) {
+	client, err := mysql.NewClient()
+	if err != nil {
+		t.Skipf("跳过测试: 无法连接到MySQL数据库: %v", err)
+	}
+	defer client.Close()
+
+	repo, err := mysql.NewUserRepository()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	suffix := time.Now().UnixNano() % 1_000_000_000
+	display := fmt.Sprintf("CaseUser_%d", suffix)
+	newUser := func(username, email string) user.UserEntity {
+		now := time.Now()
+		return user.ReconstructUser(0, username, email, "hash", "", "", user.UserStatusActive, false, "", 0,
+			time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)
+	}
+
+	require.NoError(t, repo.Save(ctx, newUser(display, fmt.Sprintf("case_%d@example.com", suffix))))
+	saved, err := repo.FindByUsername(ctx, display)
+	require.NoError(t, err)
+	defer repo.Delete(ctx, saved.GetID())
+
+	// 查找和存在性检查不区分大小写，返回的用户名保留原有大小写
+	found, err := repo.FindByUsername(ctx, strings.ToUpper(display))
+	require.NoError(t, err)
+	assert.Equal(t, display, found.GetUsername())
+
+	exists, err := repo.ExistsByUsername(ctx, strings.ToLower(display))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// 注册时按规范形式检查占用
+	username, err := user.NewUsername(strings.ToLower(display))
+	require.NoError(t, err)
+	email, err := user.NewEmail(fmt.Sprintf("case2_%d@example.com", suffix))
+	require.NoError(t, err)
+	password, err := user.NewPassword("Passw0rd!")
+	require.NoError(t, err)
+	_, err = user.NewService(repo, nil).RegisterUser(ctx, username, email, password)
+	assert.ErrorIs(t, err, user.ErrUsernameTaken)
+
+	// 绕过检查直接写入时由 uk_username_canonical 唯一索引拒绝
+	err = repo.Save(ctx, newUser(strings.ToLower(display), fmt.Sprintf("case3_%d@example.com", suffix)))
+	assert.Error(t, err)
+}
+
+// TestUserRepository_InsertDuplicateKey 测试并发注册触发唯一索引冲突时按索引返回业务错误
+func TestUserRepository_InsertDuplicateKey(t *testing.T) {
+	now := time.Now()
+	entity := user.ReconstructUser(0, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, false, "", 0,
+		time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)
+
+	cases := []struct {
+		name    string
+		message string
+		want    error
+	}{
+		{"username mysql 5.7", "Duplicate entry 'alice' for key 'uk_username'", user.ErrUsernameTaken},
+		{"canonical username mysql 8", "Duplicate entry 'alice' for key 'users.uk_username_canonical'", user.ErrUsernameTaken},
+		{"email mysql 8", "Duplicate entry 'alice@example.com' for key 'users.uk_email'", user.ErrEmailAlreadyExists},
+		{"unknown key", "Duplicate entry 'x' for key 'PRIMARY'", user.ErrUserAlreadyExists},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exec := &fakeExecutor{err: &mysqldriver.MySQLError{Number: 1062, Message: tc.message}}
+			repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+			err := repo.Save(context.Background(), entity)
+			assert.ErrorIs(t, err, tc.want)
+			require.Len(t, exec.calls, 1)
+		})
+	}
+
+	t.Run("other errors unchanged", func(t *testing.T) {
+		exec := &fakeExecutor{err: &mysqldriver.MySQLError{Number: 1406, Message: "Data too long for column 'email'"}}
+		repo := mysql.NewUserRepositoryWithExecutor(exec, mysql.MySQLDialect)
+
+		err := repo.Save(context.Background(), entity)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, user.ErrUserAlreadyExists)
+	})
+}
@@ -0,0 +1,467 @@
+package sqlite
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/domain/user"
+	mysql "todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/infrastructure/persistence/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openClient 打开内存 SQLite 数据库，测试结束时关闭
+func openClient(t *testing.T) *mysql.Client {
+	t.Helper()
+	client, err := sqlite.Open(sqlite.MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// saveUser 写入一个活跃用户并返回保存后的实体
+func saveUser(t *testing.T, repo *mysql.UserRepository, username, email string) user.UserEntity {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, repo.Save(ctx, user.ReconstructUser(0, username, email, "hash", "", "", user.UserStatusActive, false, "", 0,
+		time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)))
+	saved, err := repo.FindByUsername(ctx, username)
+	require.NoError(t, err)
+	return saved
+}
+
+// TestSQLite_UserRepository 测试用户仓储在 SQLite 方言下的行为
+func TestSQLite_UserRepository(t *testing.T) {
+	repo := mysql.NewUserRepositoryWithClient(openClient(t))
+	ctx := context.Background()
+
+	alice := saveUser(t, repo, "Alice_1", "alice@example.com")
+	saveUser(t, repo, "alicex1", "alicex@example.com")
+
+	t.Run("prefix search escapes wildcards", func(t *testing.T) {
+		users, err := repo.SearchByUsername(ctx, "ALICE_", 10, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "Alice_1", users[0].GetUsername())
+
+		count, err := repo.CountByUsernamePrefix(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("soft delete and restore", func(t *testing.T) {
+		require.NoError(t, repo.SoftDelete(ctx, alice.GetID()))
+		_, err := repo.FindByID(ctx, alice.GetID())
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+		deleted, err := repo.FindDeletedByID(ctx, alice.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "Alice_1", deleted.GetUsername())
+
+		require.NoError(t, repo.Restore(ctx, alice.GetID()))
+		_, err = repo.FindByID(ctx, alice.GetID())
+		assert.NoError(t, err)
+	})
+
+	t.Run("canonical username is unique", func(t *testing.T) {
+		err := repo.Save(ctx, user.ReconstructUser(0, "ALICE_1", "other@example.com", "hash", "", "", user.UserStatusActive, false, "", 0,
+			time.Time{}, time.Time{}, time.Time{}, time.Now(), time.Now(), user.UserRoleUser))
+		assert.Error(t, err)
+	})
+
+	t.Run("insert batch skips duplicates", func(t *testing.T) {
+		newUser := func(username, email string) user.UserEntity {
+			return user.ReconstructUser(0, username, email, "hash", "", "", user.UserStatusActive, false, "", 0,
+				time.Time{}, time.Time{}, time.Time{}, time.Now(), time.Now(), user.UserRoleUser)
+		}
+		duplicate := newUser("ALICEX1", "new@example.com")
+
+		skipped, err := repo.InsertBatch(ctx, []user.UserEntity{newUser("carol", "carol@example.com"), duplicate}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []user.UserEntity{duplicate}, skipped)
+		exists, err := repo.ExistsByUsername(ctx, "carol")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		_, err = repo.InsertBatch(ctx, []user.UserEntity{newUser("dave", "dave@example.com"), duplicate}, true)
+		assert.ErrorIs(t, err, user.ErrUserAlreadyExists)
+		exists, err = repo.ExistsByUsername(ctx, "dave")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+// TestSQLite_SoftDeletedUsersExcluded 测试已软删除的用户不出现在任何查找、列表和统计结果中
+func TestSQLite_SoftDeletedUsersExcluded(t *testing.T) {
+	repo := mysql.NewUserRepositoryWithClient(openClient(t))
+	ctx := context.Background()
+
+	active := saveUser(t, repo, "active_user", "active@example.com")
+	deleted := saveUser(t, repo, "deleted_user", "deleted@example.com")
+	require.NoError(t, repo.SoftDelete(ctx, deleted.GetID()))
+
+	ids := func(users []user.UserEntity) []int64 {
+		result := make([]int64, len(users))
+		for i, u := range users {
+			result[i] = u.GetID()
+		}
+		return result
+	}
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	t.Run("find", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, deleted.GetID())
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		_, err = repo.FindByEmail(ctx, "deleted@example.com")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		_, err = repo.FindByUsername(ctx, "deleted_user")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		users, err := repo.List(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{active.GetID()}, ids(users))
+
+		users, err = repo.ListByStatus(ctx, user.UserStatusActive, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{active.GetID()}, ids(users))
+
+		users, err = repo.ListByDateRange(ctx, from, to, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{active.GetID()}, ids(users))
+
+		users, err = repo.SearchByUsername(ctx, "", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{active.GetID()}, ids(users))
+	})
+
+	t.Run("exists and count", func(t *testing.T) {
+		exists, err := repo.ExistsByEmail(ctx, "deleted@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = repo.ExistsByUsername(ctx, "deleted_user")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		counts := map[string]func() (int64, error){
+			"count":           func() (int64, error) { return repo.Count(ctx) },
+			"count by status": func() (int64, error) { return repo.CountByStatus(ctx, user.UserStatusActive) },
+			"count by date":   func() (int64, error) { return repo.CountByDateRange(ctx, from, to) },
+			"count by prefix": func() (int64, error) { return repo.CountByUsernamePrefix(ctx, "") },
+		}
+		for name, count := range counts {
+			n, err := count()
+			require.NoError(t, err, name)
+			assert.Equal(t, int64(1), n, name)
+		}
+
+		grouped, err := repo.CountGroupByStatus(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[user.UserStatus]int64{user.UserStatusActive: 1}, grouped)
+	})
+
+	t.Run("update skips deleted user", func(t *testing.T) {
+		err := repo.UpdateStatusBatch(ctx, []user.UserEntity{deleted})
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("deleted paths only return deleted users", func(t *testing.T) {
+		found, err := repo.FindDeletedByID(ctx, deleted.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, deleted.GetID(), found.GetID())
+		_, err = repo.FindDeletedByID(ctx, active.GetID())
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+		users, err := repo.ListDeleted(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{deleted.GetID()}, ids(users))
+
+		count, err := repo.CountDeleted(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+// TestSQLite_RecordFailedLogin 测试并发的登录失败逐次累加，达到阈值时锁定
+func TestSQLite_RecordFailedLogin(t *testing.T) {
+	repo := mysql.NewUserRepositoryWithClient(openClient(t))
+	ctx := context.Background()
+	alice := saveUser(t, repo, "alice", "alice@example.com")
+	now := time.Now()
+
+	const n = 8
+	policy := user.LockoutPolicy{MaxAttempts: n + 1, Window: time.Hour, LockDuration: time.Hour}
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			locked, err := repo.RecordFailedLogin(ctx, alice.GetID(), now, policy)
+			assert.NoError(t, err)
+			assert.False(t, locked)
+		}()
+	}
+	wg.Wait()
+
+	found, err := repo.FindByID(mysql.WithPrimary(ctx), alice.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, n, found.GetFailedLoginAttempts())
+	assert.False(t, found.IsLocked(now))
+
+	// 第 n+1 次失败触发锁定
+	locked, err := repo.RecordFailedLogin(ctx, alice.GetID(), now, policy)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	found, err = repo.FindByID(mysql.WithPrimary(ctx), alice.GetID())
+	require.NoError(t, err)
+	assert.True(t, found.IsLocked(now))
+
+	_, err = repo.RecordFailedLogin(ctx, alice.GetID()+100, now, policy)
+	assert.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+// TestSQLite_UpdateStatusBatch 测试批量修改状态只写入状态，不覆盖读取后的其他修改
+func TestSQLite_UpdateStatusBatch(t *testing.T) {
+	repo := mysql.NewUserRepositoryWithClient(openClient(t))
+	ctx := mysql.WithPrimary(context.Background())
+	stale := saveUser(t, repo, "alice", "alice@example.com")
+
+	// 读取后其他请求修改了头像
+	current, err := repo.FindByID(ctx, stale.GetID())
+	require.NoError(t, err)
+	require.NoError(t, current.UpdateAvatar("https://example.com/a.png"))
+	require.NoError(t, repo.Save(ctx, current))
+
+	require.NoError(t, stale.Ban())
+	require.NoError(t, repo.UpdateStatusBatch(ctx, []user.UserEntity{stale}))
+
+	found, err := repo.FindByID(ctx, stale.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, user.UserStatusBanned, found.GetStatus())
+	assert.Equal(t, "https://example.com/a.png", found.GetAvatarURL())
+}
+
+// TestSQLite_DailyNoteRepository 测试每日笔记仓储在 SQLite 方言下的行为
+func TestSQLite_DailyNoteRepository(t *testing.T) {
+	client := openClient(t)
+	owner := saveUser(t, mysql.NewUserRepositoryWithClient(client), "noteowner", "owner@example.com")
+	repo := mysql.NewDailyNoteRepositoryWithClient(client)
+	ctx := context.Background()
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	note, err := daily_note.NewDailyNote(owner.GetID(), day, "first")
+	require.NoError(t, err)
+
+	created, err := repo.Create(ctx, note)
+	require.NoError(t, err)
+
+	t.Run("create rejects same day", func(t *testing.T) {
+		_, err := repo.Create(ctx, note)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteAlreadyExists)
+	})
+
+	t.Run("find by date", func(t *testing.T) {
+		found, err := repo.FindByUserIDAndDate(ctx, owner.GetID(), day.Add(15*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, created.GetID(), found.GetID())
+		assert.Equal(t, day.Format(daily_note.NoteDateLayout), found.GetNoteDate().Format(daily_note.NoteDateLayout))
+
+		exists, err := repo.ExistsByUserIDAndDate(ctx, owner.GetID(), day)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("batch skips duplicates", func(t *testing.T) {
+		next, err := daily_note.NewDailyNote(owner.GetID(), day.AddDate(0, 0, 1), "second")
+		require.NoError(t, err)
+
+		skipped, err := repo.SaveBatch(ctx, []daily_note.DailyNoteEntity{note, next})
+		require.NoError(t, err)
+		require.Len(t, skipped, 1)
+		assert.Equal(t, "first", skipped[0].GetContent())
+
+		count, err := repo.CountByUserID(ctx, owner.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("calendar days within month", func(t *testing.T) {
+		for _, n := range []struct {
+			date    time.Time
+			content string
+		}{
+			{day.AddDate(0, 0, 30), "你好 world"},
+			{day.AddDate(0, 1, 0), "next month"},
+		} {
+			entity, err := daily_note.NewDailyNote(owner.GetID(), n.date, n.content)
+			require.NoError(t, err)
+			_, err = repo.Create(ctx, entity)
+			require.NoError(t, err)
+		}
+
+		from, to := daily_note.MonthRange(2024, time.March)
+		days, err := repo.FindCalendarDaysByUserIDAndDateRange(ctx, owner.GetID(), from, to)
+		require.NoError(t, err)
+		require.Len(t, days, 3)
+		assert.Equal(t, "2024-03-01", days[0].Date.Format(daily_note.NoteDateLayout))
+		assert.Equal(t, "2024-03-31", days[2].Date.Format(daily_note.NoteDateLayout))
+		assert.True(t, days[2].HasContent)
+		assert.Equal(t, 8, days[2].CharCount)
+	})
+
+	t.Run("tags are removed with note", func(t *testing.T) {
+		require.NoError(t, created.SetTags([]string{"b", "a"}))
+		require.NoError(t, repo.UpdateTags(ctx, created))
+
+		notes, _, err := repo.FindByUserIDAndTagAfter(ctx, owner.GetID(), "a", time.Time{}, 10)
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, []string{"a", "b"}, notes[0].GetTags())
+
+		require.NoError(t, repo.Delete(ctx, created.GetID()))
+		notes, _, err = repo.FindByUserIDAndTagAfter(ctx, owner.GetID(), "a", time.Time{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, notes)
+	})
+}
+
+// TestSQLite_PurgeSoftDeletedUsers 测试只清理软删除超过保留期的用户，并连带删除其笔记
+func TestSQLite_PurgeSoftDeletedUsers(t *testing.T) {
+	client := openClient(t)
+	users := mysql.NewUserRepositoryWithClient(client)
+	notes := mysql.NewDailyNoteRepositoryWithClient(client)
+	ctx := context.Background()
+
+	expired := saveUser(t, users, "expired", "expired@example.com")
+	recent := saveUser(t, users, "recent", "recent@example.com")
+	active := saveUser(t, users, "active", "active@example.com")
+	for _, u := range []user.UserEntity{expired, recent, active} {
+		note, err := daily_note.NewDailyNote(u.GetID(), time.Now(), "note")
+		require.NoError(t, err)
+		_, err = notes.Create(ctx, note)
+		require.NoError(t, err)
+	}
+
+	_, err := client.Exec(ctx, `UPDATE users SET deleted_at = ? WHERE id = ?`, time.Now().Add(-31*24*time.Hour), expired.GetID())
+	require.NoError(t, err)
+	require.NoError(t, users.SoftDelete(ctx, recent.GetID()))
+
+	purged, err := users.PurgeSoftDeletedUsers(ctx, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = users.FindDeletedByID(ctx, expired.GetID())
+	assert.ErrorIs(t, err, user.ErrUserNotFound)
+	count, err := notes.CountByUserID(ctx, expired.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	// 保留期内的已注销用户和未注销用户不受影响
+	_, err = users.FindDeletedByID(ctx, recent.GetID())
+	assert.NoError(t, err)
+	for _, u := range []user.UserEntity{recent, active} {
+		count, err := notes.CountByUserID(ctx, u.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	}
+}
+
+// TestSQLite_DeleteAllDailyNotesByUserID 测试只删除指定用户的笔记，标签随笔记删除
+func TestSQLite_DeleteAllDailyNotesByUserID(t *testing.T) {
+	client := openClient(t)
+	users := mysql.NewUserRepositoryWithClient(client)
+	notes := mysql.NewDailyNoteRepositoryWithClient(client)
+	ctx := context.Background()
+
+	owner := saveUser(t, users, "owner", "owner@example.com")
+	other := saveUser(t, users, "other", "other@example.com")
+	for i := 0; i < 3; i++ {
+		note, err := daily_note.NewDailyNote(owner.GetID(), time.Now().AddDate(0, 0, -i), "note")
+		require.NoError(t, err)
+		require.NoError(t, note.SetTags([]string{"work"}))
+		_, err = notes.Create(ctx, note)
+		require.NoError(t, err)
+	}
+	note, err := daily_note.NewDailyNote(other.GetID(), time.Now(), "note")
+	require.NoError(t, err)
+	_, err = notes.Create(ctx, note)
+	require.NoError(t, err)
+
+	deleted, err := notes.DeleteAllByUserID(ctx, owner.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	count, err := notes.CountByUserID(ctx, owner.GetID())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	tags, err := client.Count(ctx, `SELECT COUNT(*) FROM daily_note_tags`)
+	require.NoError(t, err)
+	assert.Zero(t, tags)
+
+	count, err = notes.CountByUserID(ctx, other.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	deleted, err = notes.DeleteAllByUserID(ctx, owner.GetID())
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
+// TestSQLite_ReadReplica 测试读写分离：两个内存数据库分别作为主库和只读副本
+func TestSQLite_ReadReplica(t *testing.T) {
+	primary := openClient(t)
+	replica := openClient(t)
+	client := mysql.NewClientWithReplica(primary.GetDB(), replica.GetDB(), sqlite.Dialect)
+	repo := mysql.NewUserRepositoryWithClient(client)
+	assert.Same(t, replica.GetDB(), client.ReadReplica())
+
+	// 副本尚未同步到新写入的用户
+	ctx := mysql.WithReadYourWrites(context.Background())
+	_, err := repo.FindByUsername(ctx, "bob")
+	assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+	now := time.Now()
+	require.NoError(t, repo.Save(ctx, user.ReconstructUser(0, "bob", "bob@example.com", "hash", "", "", user.UserStatusActive, false, "", 0,
+		time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)))
+
+	t.Run("plain context reads replica", func(t *testing.T) {
+		_, err := repo.FindByUsername(context.Background(), "bob")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+		count, err := client.Count(context.Background(), "SELECT COUNT(*) FROM users")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("forced primary", func(t *testing.T) {
+		found, err := repo.FindByUsername(mysql.WithPrimary(context.Background()), "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", found.GetEmail())
+	})
+
+	t.Run("reads after write stick to primary", func(t *testing.T) {
+		found, err := repo.FindByUsername(ctx, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "bob", found.GetUsername())
+
+		exists, err := client.Exists(ctx, "SELECT COUNT(*) FROM users WHERE username = ?", "bob")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("no replica falls back to primary", func(t *testing.T) {
+		single := mysql.NewClientWithReplica(primary.GetDB(), nil, sqlite.Dialect)
+		assert.Same(t, primary.GetDB(), single.ReadReplica())
+
+		found, err := mysql.NewUserRepositoryWithClient(single).FindByUsername(context.Background(), "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "bob", found.GetUsername())
+	})
+}
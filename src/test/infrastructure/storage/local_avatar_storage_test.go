@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLocalAvatarStorage_Save 测试头像文件本地存储
+func TestLocalAvatarStorage_Save(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "avatars")
+	s := storage.NewLocalAvatarStorage(dir, "http://localhost:8080/uploads/avatars")
+
+	t.Run("save png", func(t *testing.T) {
+		data := []byte("\x89PNG\r\n\x1a\nfake")
+
+		url, err := s.Save(ctx, 1, "image/png", data)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(url, "http://localhost:8080/uploads/avatars/1-"))
+		assert.True(t, strings.HasSuffix(url, ".png"))
+
+		saved, err := os.ReadFile(filepath.Join(dir, filepath.Base(url)))
+		assert.NoError(t, err)
+		assert.Equal(t, data, saved)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := s.Save(ctx, 1, "image/gif", []byte("GIF89a"))
+
+		assert.ErrorIs(t, err, user.ErrAvatarTypeUnsupported)
+	})
+}
@@ -0,0 +1,121 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/audit"
+	"todolist/internal/domain/user"
+	"todolist/internal/pkg/contextx"
+)
+
+// auditUserService 只实现审计相关用例所需方法的用户领域服务
+type auditUserService struct {
+	user.UserService
+	err error
+}
+
+func (s *auditUserService) ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword user.Password) error {
+	return s.err
+}
+
+func (s *auditUserService) UpdateEmail(ctx context.Context, userID int64, newEmail user.Email) error {
+	return s.err
+}
+
+func (s *auditUserService) ConfirmEmailChange(ctx context.Context, userID int64, newEmail user.Email) error {
+	return s.err
+}
+
+func (s *auditUserService) ChangeUsersStatus(ctx context.Context, ids []int64, status user.UserStatus) error {
+	return s.err
+}
+
+// memoryAuditLogger 内存审计日志，用于断言写入的条目
+type memoryAuditLogger struct {
+	entries []audit.Entry
+	err     error
+}
+
+func (l *memoryAuditLogger) Record(ctx context.Context, entry audit.Entry) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+// TestAuditLogging 测试敏感操作成功后写入审计日志，并记录操作者和目标用户
+func TestAuditLogging(t *testing.T) {
+	admin := contextx.WithUser(context.Background(), contextx.UserContext{UserID: 1, Username: "root", Role: "admin"})
+
+	t.Run("status change records each target with actor", func(t *testing.T) {
+		logger := &memoryAuditLogger{}
+		svc := appuser.NewUserApplicationService(&auditUserService{}, appuser.WithAuditLogger(logger))
+
+		assert.NoError(t, svc.ChangeUsersStatus(admin, []int64{2, 3}, "banned"))
+
+		assert.Len(t, logger.entries, 2)
+		for i, id := range []int64{2, 3} {
+			entry := logger.entries[i]
+			assert.Equal(t, id, entry.UserID)
+			assert.Equal(t, int64(1), entry.ActorID)
+			assert.Equal(t, audit.ActionStatusChanged, entry.Action)
+			assert.Equal(t, map[string]string{"status": "banned"}, entry.Metadata)
+			assert.False(t, entry.CreatedAt.IsZero())
+		}
+	})
+
+	t.Run("self service action", func(t *testing.T) {
+		logger := &memoryAuditLogger{}
+		svc := appuser.NewUserApplicationService(&auditUserService{}, appuser.WithAuditLogger(logger))
+		ctx := contextx.WithUser(context.Background(), contextx.UserContext{UserID: 7})
+
+		assert.NoError(t, svc.UpdateEmail(ctx, 7, "New@Example.com"))
+
+		assert.Len(t, logger.entries, 1)
+		assert.Equal(t, int64(7), logger.entries[0].UserID)
+		assert.Equal(t, int64(7), logger.entries[0].ActorID)
+		assert.Equal(t, audit.ActionEmailChangeRequested, logger.entries[0].Action)
+		assert.Equal(t, "new@example.com", logger.entries[0].Metadata["new_email"])
+	})
+
+	t.Run("email change confirmed without session", func(t *testing.T) {
+		logger := &memoryAuditLogger{}
+		svc := appuser.NewUserApplicationService(&auditUserService{}, appuser.WithAuditLogger(logger))
+
+		assert.NoError(t, svc.ConfirmEmailChange(context.Background(), 7, "New@Example.com"))
+
+		assert.Len(t, logger.entries, 1)
+		assert.Equal(t, int64(7), logger.entries[0].UserID)
+		assert.Equal(t, int64(0), logger.entries[0].ActorID)
+		assert.Equal(t, audit.ActionEmailChanged, logger.entries[0].Action)
+		assert.Equal(t, "new@example.com", logger.entries[0].Metadata["new_email"])
+	})
+
+	t.Run("failed operation not recorded", func(t *testing.T) {
+		logger := &memoryAuditLogger{}
+		svc := appuser.NewUserApplicationService(&auditUserService{err: user.ErrPasswordIncorrect}, appuser.WithAuditLogger(logger))
+
+		err := svc.ChangePassword(admin, 1, "OldPass123", "NewPass456")
+		assert.ErrorIs(t, err, user.ErrPasswordIncorrect)
+		assert.Empty(t, logger.entries)
+	})
+
+	t.Run("audit failure does not fail operation", func(t *testing.T) {
+		logger := &memoryAuditLogger{err: errors.New("audit store down")}
+		svc := appuser.NewUserApplicationService(&auditUserService{}, appuser.WithAuditLogger(logger))
+
+		assert.NoError(t, svc.ChangePassword(admin, 1, "OldPass123", "NewPass456"))
+	})
+
+	t.Run("disabled without logger", func(t *testing.T) {
+		svc := appuser.NewUserApplicationService(&auditUserService{})
+
+		assert.NoError(t, svc.ChangePassword(admin, 1, "OldPass123", "NewPass456"))
+	})
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/event"
+	"todolist/internal/domain/user"
+)
+
+// eventUserService 只实现事件相关用例所需方法的用户领域服务
+type eventUserService struct {
+	user.UserService
+	err error
+}
+
+func (s *eventUserService) RegisterUser(ctx context.Context, username user.Username, email user.Email, password user.Password) (user.UserEntity, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	now := time.Now()
+	return user.ReconstructUser(42, username.String(), email.String(), "hash", "", "", user.UserStatusActive, false, "", 0,
+		time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser), nil
+}
+
+func (s *eventUserService) ConfirmEmailChange(ctx context.Context, userID int64, newEmail user.Email) error {
+	return s.err
+}
+
+// recordingBus 记录发布的事件
+type recordingBus struct {
+	mu     sync.Mutex
+	events []event.Event
+}
+
+func (b *recordingBus) Publish(ctx context.Context, e event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, e)
+}
+
+// rejectingGuard 拒绝所有凭证的注册防护
+type rejectingGuard struct{}
+
+func (rejectingGuard) Verify(ctx context.Context, token string) error {
+	return user.ErrCaptchaFailed
+}
+
+// TestLifecycleEvents 测试注册和邮箱变更成功后发布事件，失败时不发布
+func TestLifecycleEvents(t *testing.T) {
+	t.Run("register publishes user.registered", func(t *testing.T) {
+		bus := &recordingBus{}
+		svc := appuser.NewUserApplicationService(&eventUserService{}, appuser.WithEventBus(bus))
+
+		_, err := svc.RegisterUser(context.Background(), "alice", "alice@example.com", "Password123", "")
+		assert.NoError(t, err)
+
+		assert.Len(t, bus.events, 1)
+		assert.Equal(t, event.TypeUserRegistered, bus.events[0].Type)
+		assert.Equal(t, int64(42), bus.events[0].UserID)
+		assert.False(t, bus.events[0].OccurredAt.IsZero())
+	})
+
+	t.Run("email change publishes user.email_changed", func(t *testing.T) {
+		bus := &recordingBus{}
+		svc := appuser.NewUserApplicationService(&eventUserService{}, appuser.WithEventBus(bus))
+
+		assert.NoError(t, svc.ConfirmEmailChange(context.Background(), 7, "new@example.com"))
+
+		assert.Len(t, bus.events, 1)
+		assert.Equal(t, event.TypeUserEmailChanged, bus.events[0].Type)
+		assert.Equal(t, int64(7), bus.events[0].UserID)
+	})
+
+	t.Run("failed operation not published", func(t *testing.T) {
+		bus := &recordingBus{}
+		svc := appuser.NewUserApplicationService(&eventUserService{err: user.ErrUserAlreadyExists}, appuser.WithEventBus(bus))
+
+		_, err := svc.RegisterUser(context.Background(), "alice", "alice@example.com", "Password123", "")
+		assert.ErrorIs(t, err, user.ErrUserAlreadyExists)
+		assert.ErrorIs(t, svc.ConfirmEmailChange(context.Background(), 7, "new@example.com"), user.ErrUserAlreadyExists)
+		assert.Empty(t, bus.events)
+	})
+
+	t.Run("captcha rejection stops registration", func(t *testing.T) {
+		bus := &recordingBus{}
+		svc := appuser.NewUserApplicationService(&eventUserService{}, appuser.WithEventBus(bus),
+			appuser.WithRegistrationGuard(rejectingGuard{}))
+
+		_, err := svc.RegisterUser(context.Background(), "alice", "alice@example.com", "Password123", "forged")
+		assert.ErrorIs(t, err, user.ErrCaptchaFailed)
+		assert.Empty(t, bus.events)
+	})
+
+	t.Run("disabled without bus", func(t *testing.T) {
+		svc := appuser.NewUserApplicationService(&eventUserService{})
+
+		assert.NoError(t, svc.ConfirmEmailChange(context.Background(), 7, "new@example.com"))
+	})
+}
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/domain/user"
+)
+
+// exportUserService 只实现导出用例所需方法的用户领域服务
+type exportUserService struct {
+	user.UserService
+	entity user.UserEntity
+}
+
+func (s *exportUserService) GetUserByID(ctx context.Context, userID int64) (user.UserEntity, error) {
+	if s.entity == nil || s.entity.GetID() != userID {
+		return nil, user.ErrUserNotFound
+	}
+	return s.entity, nil
+}
+
+// cursorDailyNoteService 按固定批次返回笔记的每日笔记领域服务
+type cursorDailyNoteService struct {
+	daily_note.DailyNoteService
+	pages   [][]daily_note.DailyNoteEntity
+	failAt  int
+	cursors []time.Time
+}
+
+func (s *cursorDailyNoteService) GetDailyNoteListAfter(ctx context.Context, userID int64, cursor time.Time, limit int) ([]daily_note.DailyNoteEntity, time.Time, error) {
+	call := len(s.cursors)
+	s.cursors = append(s.cursors, cursor)
+	if s.failAt > 0 && call+1 == s.failAt {
+		return nil, time.Time{}, errors.New("db down")
+	}
+
+	page := s.pages[call]
+	var next time.Time
+	if call < len(s.pages)-1 {
+		next = page[len(page)-1].GetNoteDate()
+	}
+	return page, next, nil
+}
+
+// TestExportUserData 测试导出按游标分批读取全部笔记
+func TestExportUserData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	alice := user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	note := func(id int64, d int) daily_note.DailyNoteEntity {
+		return daily_note.ReconstructDailyNote(id, 1, day(d), "note", now, now, 1, false, nil)
+	}
+
+	t.Run("iterates all pages", func(t *testing.T) {
+		notes := &cursorDailyNoteService{pages: [][]daily_note.DailyNoteEntity{
+			{note(3, 3), note(2, 2)},
+			{note(1, 1)},
+		}}
+		svc := appuser.NewUserApplicationService(&exportUserService{entity: alice}, appuser.WithDailyNoteService(notes))
+
+		export, err := svc.ExportUserData(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", export.User.Username)
+		assert.Empty(t, notes.cursors, "notes are read lazily")
+
+		var ids []int64
+		for n, err := range export.DailyNotes {
+			require.NoError(t, err)
+			ids = append(ids, n.ID)
+		}
+		assert.Equal(t, []int64{3, 2, 1}, ids)
+		assert.Equal(t, []time.Time{{}, day(2)}, notes.cursors)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		notes := &cursorDailyNoteService{pages: [][]daily_note.DailyNoteEntity{{note(3, 3)}, {note(2, 2)}}, failAt: 2}
+		svc := appuser.NewUserApplicationService(&exportUserService{entity: alice}, appuser.WithDailyNoteService(notes))
+
+		export, err := svc.ExportUserData(ctx, 1)
+		require.NoError(t, err)
+
+		var ids []int64
+		var iterErr error
+		for n, err := range export.DailyNotes {
+			if err != nil {
+				iterErr = err
+				break
+			}
+			ids = append(ids, n.ID)
+		}
+		assert.Equal(t, []int64{3}, ids)
+		assert.EqualError(t, iterErr, "db down")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		svc := appuser.NewUserApplicationService(&exportUserService{entity: alice}, appuser.WithDailyNoteService(&cursorDailyNoteService{}))
+		_, err := svc.ExportUserData(ctx, 2)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
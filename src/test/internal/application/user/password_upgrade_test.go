@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/user"
+)
+
+// upgradeUserService 认证成功、升级密码哈希结果可配置的用户领域服务
+type upgradeUserService struct {
+	user.UserService
+	upgradeErr error
+	upgrades   int
+}
+
+func (s *upgradeUserService) AuthenticateUser(ctx context.Context, email user.Email, password user.Password) (user.UserEntity, error) {
+	now := time.Now()
+	return user.ReconstructUser(1, "alice", email.String(), "hash", "", "", user.UserStatusActive, true, "", 0,
+		time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser), nil
+}
+
+func (s *upgradeUserService) UpgradePasswordHash(ctx context.Context, entity user.UserEntity, password user.Password) (bool, error) {
+	s.upgrades++
+	return s.upgradeErr == nil, s.upgradeErr
+}
+
+// TestLogin_PasswordHashUpgrade 测试登录成功后尝试升级密码哈希，升级失败不影响登录
+func TestLogin_PasswordHashUpgrade(t *testing.T) {
+	t.Run("upgrade attempted", func(t *testing.T) {
+		userService := &upgradeUserService{}
+		svc := appuser.NewUserApplicationService(userService)
+
+		_, err := svc.Login(context.Background(), "alice@example.com", "Password123")
+		assert.NoError(t, err)
+		_, err = svc.AuthenticateUser(context.Background(), "alice@example.com", "Password123")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, userService.upgrades)
+	})
+
+	t.Run("upgrade failure does not fail login", func(t *testing.T) {
+		userService := &upgradeUserService{upgradeErr: errors.New("database unavailable")}
+		svc := appuser.NewUserApplicationService(userService)
+
+		userDTO, err := svc.Login(context.Background(), "alice@example.com", "Password123")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), userDTO.ID)
+		assert.Equal(t, 1, userService.upgrades)
+	})
+}
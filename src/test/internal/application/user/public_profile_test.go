@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/user"
+)
+
+// profileUserService 只实现公开资料用例所需方法的用户领域服务
+type profileUserService struct {
+	user.UserService
+	users map[string]user.UserEntity
+}
+
+func (s *profileUserService) GetUserByUsername(ctx context.Context, username user.Username) (user.UserEntity, error) {
+	entity, ok := s.users[username.String()]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+// TestGetPublicProfile 测试公开资料只暴露活跃用户的公开字段
+func TestGetPublicProfile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newUser := func(id int64, username string, status user.UserStatus) user.UserEntity {
+		return user.ReconstructUser(id, username, username+"@example.com", "hash", "https://example.com/a.png", "", status, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)
+	}
+	svc := appuser.NewUserApplicationService(&profileUserService{users: map[string]user.UserEntity{
+		"alice": newUser(1, "alice", user.UserStatusActive),
+		"bob":   newUser(2, "bob", user.UserStatusBanned),
+		"carol": newUser(3, "carol", user.UserStatusInactive),
+	}})
+
+	t.Run("active user", func(t *testing.T) {
+		profile, err := svc.GetPublicProfile(ctx, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), profile.ID)
+		assert.Equal(t, "alice", profile.Username)
+		assert.Equal(t, "https://example.com/a.png", profile.AvatarURL)
+	})
+
+	for _, username := range []string{"bob", "carol", "nobody", "x"} {
+		t.Run("hidden "+username, func(t *testing.T) {
+			_, err := svc.GetPublicProfile(ctx, username)
+			assert.ErrorIs(t, err, user.ErrUserNotFound)
+		})
+	}
+}
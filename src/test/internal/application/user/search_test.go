@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appuser "todolist/internal/application/user"
+	"todolist/internal/domain/user"
+)
+
+// searchUserService 记录搜索参数的用户领域服务
+type searchUserService struct {
+	user.UserService
+	users  []user.UserEntity
+	calls  int
+	prefix string
+	limit  int
+	offset int
+}
+
+func (s *searchUserService) SearchUsersByUsername(ctx context.Context, prefix string, limit, offset int) ([]user.UserEntity, int64, error) {
+	s.calls++
+	s.prefix, s.limit, s.offset = prefix, limit, offset
+	return s.users, int64(len(s.users)), nil
+}
+
+// TestSearchUsers 测试用户名搜索的分页参数和空搜索词处理
+func TestSearchUsers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	alice := user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)
+
+	t.Run("trims query and converts page to offset", func(t *testing.T) {
+		svc := &searchUserService{users: []user.UserEntity{alice}}
+		page, err := appuser.NewUserApplicationService(svc).SearchUsers(ctx, "  ali ", 3, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, "ali", svc.prefix)
+		assert.Equal(t, 10, svc.limit)
+		assert.Equal(t, 20, svc.offset)
+		assert.Equal(t, int64(1), page.Pagination.Total)
+		assert.Len(t, page.Data, 1)
+	})
+
+	t.Run("invalid page size falls back to default", func(t *testing.T) {
+		svc := &searchUserService{}
+		_, err := appuser.NewUserApplicationService(svc).SearchUsers(ctx, "ali", 0, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, user.DefaultPageSize, svc.limit)
+		assert.Equal(t, 0, svc.offset)
+	})
+
+	t.Run("blank query returns empty page without searching", func(t *testing.T) {
+		svc := &searchUserService{users: []user.UserEntity{alice}}
+		page, err := appuser.NewUserApplicationService(svc).SearchUsers(ctx, "   ", 1, 20)
+		assert.NoError(t, err)
+		assert.Zero(t, svc.calls)
+		assert.Zero(t, page.Pagination.Total)
+		assert.Empty(t, page.Data)
+	})
+}
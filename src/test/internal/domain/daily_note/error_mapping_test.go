@@ -0,0 +1,51 @@
+package daily_note
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/domainerr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrorMapping 测试每日笔记错误均已注册并映射到正确的 HTTP 状态码
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		err    domainerr.BusinessError
+		status int
+	}{
+		{daily_note.ErrDailyNoteNotFound, http.StatusNotFound},
+		{daily_note.ErrDailyNoteAlreadyExists, http.StatusConflict},
+		{daily_note.ErrDailyNotePreviousNotFound, http.StatusNotFound},
+		{daily_note.ErrDailyNoteConflict, http.StatusConflict},
+		{daily_note.ErrDailyNoteContentEmpty, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteContentTooLong, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteDateInvalid, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteDateRangeInvalid, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteSortInvalid, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteCursorInvalid, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteBatchInvalid, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteTooManyTags, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteTagTooLong, http.StatusBadRequest},
+		{daily_note.ErrDailyNoteUpdateFailed, http.StatusInternalServerError},
+		{daily_note.ErrDailyNoteDeleteFailed, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Code, func(t *testing.T) {
+			registered, ok := domainerr.Lookup(tt.err.Code)
+			assert.True(t, ok)
+			assert.Equal(t, tt.err, registered)
+
+			// 仓储层包装后的错误也应映射到相同状态码
+			rec := httptest.NewRecorder()
+			response.WriteError(rec, fmt.Errorf("repository: %w", tt.err))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
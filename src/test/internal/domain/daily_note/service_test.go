@@ -0,0 +1,796 @@
+package daily_note
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseNoteDate 测试笔记日期解析
+func TestParseNoteDate(t *testing.T) {
+	date, err := daily_note.ParseNoteDate("2024-01-15")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-15", date.Format(daily_note.NoteDateLayout))
+
+	// 非法格式
+	for _, value := range []string{"", "2024/01/15", "2024-13-01", "15-01-2024"} {
+		_, err := daily_note.ParseNoteDate(value)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteDateInvalid, value)
+	}
+}
+
+// TestGetDailyNoteListByRange_InvalidRange 测试开始日期晚于结束日期时返回错误
+func TestGetDailyNoteListByRange_InvalidRange(t *testing.T) {
+	// 区间校验先于仓储调用，无需仓储实现
+	service := daily_note.NewService(nil)
+
+	from, _ := daily_note.ParseNoteDate("2024-02-01")
+	to, _ := daily_note.ParseNoteDate("2024-01-01")
+
+	notes, total, err := service.GetDailyNoteListByRange(context.Background(), 1, from, to, 1, 10)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteDateRangeInvalid)
+	assert.Nil(t, notes)
+	assert.Equal(t, int64(0), total)
+}
+
+// TestParseSortOrder 测试排序方式解析
+func TestParseSortOrder(t *testing.T) {
+	// 为空时使用默认排序
+	order, err := daily_note.ParseSortOrder("")
+	assert.NoError(t, err)
+	assert.Equal(t, daily_note.SortDateDesc, order)
+
+	for _, value := range []string{"date_asc", "date_desc", "updated_desc", "pinned_first"} {
+		order, err := daily_note.ParseSortOrder(value)
+		assert.NoError(t, err)
+		assert.Equal(t, daily_note.SortOrder(value), order)
+	}
+
+	// 不在允许列表中
+	for _, value := range []string{"DATE_ASC", "note_date; DROP TABLE daily_notes", "created_desc"} {
+		_, err := daily_note.ParseSortOrder(value)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteSortInvalid, value)
+	}
+}
+
+// TestGetDailyNoteList_InvalidSort 测试排序方式无效时返回错误
+func TestGetDailyNoteList_InvalidSort(t *testing.T) {
+	// 排序校验先于仓储调用，无需仓储实现
+	service := daily_note.NewService(nil)
+
+	notes, total, err := service.GetDailyNoteList(context.Background(), 1, daily_note.SortOrder("id"), 1, 10)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteSortInvalid)
+	assert.Nil(t, notes)
+	assert.Equal(t, int64(0), total)
+}
+
+// TestNewDailyNote_ContentLength 测试笔记内容长度限制按字符计算
+func TestNewDailyNote_ContentLength(t *testing.T) {
+	today := time.Now()
+
+	// 中文字符按单个字符计数，恰好达到上限时允许创建
+	note, err := daily_note.NewDailyNote(1, today, strings.Repeat("笔", daily_note.MaxContentLength))
+	assert.NoError(t, err)
+
+	// 超出上限
+	_, err = daily_note.NewDailyNote(1, today, strings.Repeat("a", daily_note.MaxContentLength+1))
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteContentTooLong)
+
+	// 更新内容同样校验
+	err = note.UpdateContent(strings.Repeat("记", daily_note.MaxContentLength+1))
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteContentTooLong)
+	assert.Equal(t, daily_note.MaxContentLength, utf8.RuneCountInString(note.GetContent()))
+}
+
+// TestDailyNote_WordAndCharCount 测试词数按空白分隔、字符数按 rune 计算
+func TestDailyNote_WordAndCharCount(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		words   int
+		chars   int
+	}{
+		{"english", "hello  world\nfrom go", 4, 20},
+		{"chinese", "今天天气很好", 1, 6},
+		{"mixed", "今天 写了 Go 代码 🎉", 5, 13},
+		{"japanese and korean", "こんにちは 안녕하세요", 2, 11},
+		{"whitespace only words", " \t今天\u3000明天 ", 2, 8},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			note, err := daily_note.NewDailyNote(1, time.Now(), tc.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.words, note.WordCount())
+			assert.Equal(t, tc.chars, note.CharCount())
+		})
+	}
+}
+
+// TestDailyNote_Version 测试乐观锁版本号
+func TestDailyNote_Version(t *testing.T) {
+	note, err := daily_note.NewDailyNote(1, time.Now(), "初始内容")
+	assert.NoError(t, err)
+	assert.Equal(t, daily_note.InitialVersion, note.GetVersion())
+
+	// 未指定版本或版本一致时通过检查
+	assert.NoError(t, note.CheckVersion(0))
+	assert.NoError(t, note.CheckVersion(daily_note.InitialVersion))
+
+	// 更新内容后版本号加一，旧版本号产生冲突
+	assert.NoError(t, note.UpdateContent("新内容"))
+	assert.Equal(t, daily_note.InitialVersion+1, note.GetVersion())
+	assert.ErrorIs(t, note.CheckVersion(daily_note.InitialVersion), daily_note.ErrDailyNoteConflict)
+
+	// 内容未变化时版本号不变
+	assert.NoError(t, note.UpdateContent("新内容"))
+	assert.Equal(t, daily_note.InitialVersion+1, note.GetVersion())
+}
+
+// updateRepo 实现更新今日笔记所需方法的仓储桩，记录写入次数
+type updateRepo struct {
+	daily_note.DailyNoteRepository
+	note    daily_note.DailyNoteEntity
+	updates int
+}
+
+func (r *updateRepo) FindByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (daily_note.DailyNoteEntity, error) {
+	return r.note, nil
+}
+
+func (r *updateRepo) Update(ctx context.Context, entity daily_note.DailyNoteEntity) error {
+	r.updates++
+	return nil
+}
+
+// TestUpdateDailyNote_Unchanged 测试内容未变化时不写数据库且不修改更新时间
+func TestUpdateDailyNote_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	repo := &updateRepo{
+		note: daily_note.ReconstructDailyNote(1, 1, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), "原内容",
+			updatedAt, updatedAt, 3, false, nil),
+	}
+	service := daily_note.NewService(repo)
+
+	note, err := service.UpdateDailyNote(ctx, 1, time.UTC, "原内容", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, repo.updates)
+	assert.Equal(t, updatedAt, note.GetUpdatedAt())
+	assert.Equal(t, 3, note.GetVersion())
+
+	// 内容未变化但版本号过期时仍返回冲突
+	_, err = service.UpdateDailyNote(ctx, 1, time.UTC, "原内容", 2)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteConflict)
+
+	note, err = service.UpdateDailyNote(ctx, 1, time.UTC, "新内容", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, repo.updates)
+	assert.True(t, note.GetUpdatedAt().After(updatedAt))
+	assert.Equal(t, 4, note.GetVersion())
+}
+
+// TestBatchCreateDailyNotes_InvalidSize 测试批量导入条数校验
+func TestBatchCreateDailyNotes_InvalidSize(t *testing.T) {
+	// 条数校验先于仓储调用，无需仓储实现
+	service := daily_note.NewService(nil)
+
+	_, err := service.BatchCreateDailyNotes(context.Background(), 1, nil)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteBatchInvalid)
+
+	notes := make([]daily_note.BatchNote, daily_note.MaxBatchSize+1)
+	for i := range notes {
+		notes[i] = daily_note.BatchNote{NoteDate: time.Now().AddDate(0, 0, -i), Content: "内容"}
+	}
+	_, err = service.BatchCreateDailyNotes(context.Background(), 1, notes)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteBatchInvalid)
+}
+
+// TestCalculateStreak 测试连续天数计算
+func TestCalculateStreak(t *testing.T) {
+	today := time.Date(2024, 3, 10, 15, 0, 0, 0, time.Local)
+	day := func(offset int) time.Time {
+		return time.Date(2024, 3, 10+offset, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name    string
+		dates   []time.Time
+		current int
+		longest int
+	}{
+		{"no notes", nil, 0, 0},
+		{"only today", []time.Time{day(0)}, 1, 1},
+		{"ending today", []time.Time{day(-2), day(-1), day(0)}, 3, 3},
+		{"ending yesterday", []time.Time{day(-3), day(-2), day(-1)}, 3, 3},
+		{"broken before yesterday", []time.Time{day(-4), day(-3), day(-2)}, 0, 3},
+		{"longest in history", []time.Time{day(-10), day(-9), day(-8), day(-7), day(-1), day(0)}, 2, 4},
+		{"across month boundary", []time.Time{day(-10), day(-9)}, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := daily_note.CalculateStreak(tt.dates, today)
+			assert.Equal(t, tt.current, streak.Current)
+			assert.Equal(t, tt.longest, streak.Longest)
+			assert.Equal(t, len(tt.dates), streak.TotalDays)
+		})
+	}
+}
+
+// TestTodayIn 测试按用户时区确定今天
+func TestTodayIn(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// 固定时钟：UTC 2024-03-09 23:30，上海已是 3 月 10 日早上 7:30，纽约仍是 3 月 9 日晚上
+	now := time.Date(2024, 3, 9, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		{"utc", time.UTC, "2024-03-09"},
+		{"ahead of utc", shanghai, "2024-03-10"},
+		{"behind utc", newYork, "2024-03-09"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			today := daily_note.TodayIn(now, tt.loc)
+			assert.Equal(t, tt.want, today.Format(daily_note.NoteDateLayout))
+			assert.Equal(t, tt.loc, today.Location())
+			assert.Zero(t, today.Hour())
+		})
+	}
+
+	// 未指定时区时使用服务器本地时区
+	assert.Equal(t, time.Local, daily_note.TodayIn(now, nil).Location())
+}
+
+// TestCalculateStreak_UserTimezone 测试用户时区的今天与笔记日期按日历日比较
+func TestCalculateStreak_UserTimezone(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	// 笔记日期从数据库读出时位于 UTC 零点
+	dates := []time.Time{
+		time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+	}
+	now := time.Date(2024, 3, 9, 23, 30, 0, 0, time.UTC)
+
+	// 上海已是 3 月 10 日，今天已写，连续 2 天
+	assert.Equal(t, 2, daily_note.CalculateStreak(dates, daily_note.TodayIn(now, shanghai)).Current)
+	// 只写了 3 月 9 日时，在上海视为昨天已写，连续记录保持
+	assert.Equal(t, 1, daily_note.CalculateStreak(dates[:1], daily_note.TodayIn(now, shanghai)).Current)
+}
+
+// createRepo 仅实现 Create 的仓储桩，记录写入的实体
+type createRepo struct {
+	daily_note.DailyNoteRepository
+	created []daily_note.DailyNoteEntity
+	err     error
+}
+
+func (r *createRepo) Create(ctx context.Context, entity daily_note.DailyNoteEntity) (daily_note.DailyNoteEntity, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.created = append(r.created, entity)
+	return daily_note.ReconstructDailyNote(int64(len(r.created)), entity.GetUserID(), entity.GetNoteDate(),
+		entity.GetContent(), entity.GetCreatedAt(), entity.GetUpdatedAt(), entity.GetVersion(), entity.IsPinned(), entity.GetTags()), nil
+}
+
+// TestCreateDailyNote 测试创建笔记通过仓储 Create 一次完成检查与写入
+func TestCreateDailyNote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("created", func(t *testing.T) {
+		repo := &createRepo{}
+		entity, err := daily_note.NewService(repo).CreateDailyNote(ctx, 1, time.Local, "today")
+
+		assert.NoError(t, err)
+		assert.Len(t, repo.created, 1)
+		assert.Equal(t, int64(1), entity.GetID())
+		assert.Equal(t, "today", entity.GetContent())
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		repo := &createRepo{err: daily_note.ErrDailyNoteAlreadyExists}
+		_, err := daily_note.NewService(repo).CreateDailyNote(ctx, 1, time.Local, "today")
+
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteAlreadyExists)
+	})
+
+	t.Run("invalid content skips repository", func(t *testing.T) {
+		repo := &createRepo{}
+		_, err := daily_note.NewService(repo).CreateDailyNote(ctx, 1, time.Local, "")
+
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteContentEmpty)
+		assert.Empty(t, repo.created)
+	})
+}
+
+// TestCreateDailyNote_UserTimezone 测试今日笔记的日期按用户时区确定
+func TestCreateDailyNote_UserTimezone(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// UTC 2024-03-09 23:30：上海 3 月 10 日早上，纽约 3 月 9 日晚上
+	fake := clock.NewFakeClock(time.Date(2024, 3, 9, 23, 30, 0, 0, time.UTC))
+	repo := &createRepo{}
+	service := daily_note.NewService(repo, daily_note.WithClock(fake))
+
+	note, err := service.CreateDailyNote(context.Background(), 1, shanghai, "shanghai")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-10", note.GetNoteDate().Format(daily_note.NoteDateLayout))
+	assert.Equal(t, shanghai, note.GetNoteDate().Location())
+
+	note, err = service.CreateDailyNote(context.Background(), 1, newYork, "new york")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-09", note.GetNoteDate().Format(daily_note.NoteDateLayout))
+}
+
+// todayRepo 记录按日期查询参数的仓储桩
+type todayRepo struct {
+	daily_note.DailyNoteRepository
+	dates []string
+}
+
+func (r *todayRepo) FindByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (daily_note.DailyNoteEntity, error) {
+	r.dates = append(r.dates, noteDate.Format(daily_note.NoteDateLayout))
+	return nil, daily_note.ErrDailyNoteNotFound
+}
+
+// TestGetTodayDailyNote_Clock 测试今日笔记查询使用注入的时钟与用户时区
+func TestGetTodayDailyNote_Clock(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	fake := clock.NewFakeClock(time.Date(2024, 3, 9, 15, 59, 0, 0, time.UTC))
+	repo := &todayRepo{}
+	service := daily_note.NewService(repo, daily_note.WithClock(fake))
+	ctx := context.Background()
+
+	// 上海 23:59 仍是 3 月 9 日，推进两分钟后跨过零点
+	_, err = service.GetTodayDailyNote(ctx, 1, shanghai)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+	fake.Advance(2 * time.Minute)
+	_, _ = service.GetTodayDailyNote(ctx, 1, shanghai)
+	_, _ = service.UpdateDailyNote(ctx, 1, shanghai, "content", 0)
+	_, _ = service.GetTodayDailyNote(ctx, 1, time.UTC)
+
+	assert.Equal(t, []string{"2024-03-09", "2024-03-10", "2024-03-10", "2024-03-09"}, repo.dates)
+}
+
+// copyRepo 实现复制历史笔记所需方法的仓储桩
+type copyRepo struct {
+	createRepo
+	todayExists bool
+	previous    []daily_note.DailyNoteEntity
+	cursor      time.Time
+}
+
+func (r *copyRepo) ExistsByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (bool, error) {
+	return r.todayExists, nil
+}
+
+func (r *copyRepo) FindByUserIDAfter(ctx context.Context, userID int64, afterNoteDate time.Time, limit int) ([]daily_note.DailyNoteEntity, time.Time, error) {
+	r.cursor = afterNoteDate
+	return r.previous, time.Time{}, nil
+}
+
+// TestCopyPreviousDayNote 测试以最近一篇历史笔记的内容创建今日笔记
+func TestCopyPreviousDayNote(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+	fake := clock.NewFakeClock(now)
+	today := time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)
+	previous := daily_note.ReconstructDailyNote(7, 1, today.AddDate(0, 0, -3), "three days ago",
+		now, now, 2, true, nil)
+
+	t.Run("copied", func(t *testing.T) {
+		repo := &copyRepo{previous: []daily_note.DailyNoteEntity{previous}}
+		entity, err := daily_note.NewService(repo, daily_note.WithClock(fake)).CopyPreviousDayNote(ctx, 1, time.Local)
+
+		assert.NoError(t, err)
+		assert.Equal(t, today, repo.cursor)
+		assert.Len(t, repo.created, 1)
+		assert.Equal(t, "three days ago", entity.GetContent())
+		assert.Equal(t, today, entity.GetNoteDate())
+		assert.False(t, entity.IsPinned())
+	})
+
+	t.Run("today exists", func(t *testing.T) {
+		repo := &copyRepo{todayExists: true, previous: []daily_note.DailyNoteEntity{previous}}
+		_, err := daily_note.NewService(repo, daily_note.WithClock(fake)).CopyPreviousDayNote(ctx, 1, time.Local)
+
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteAlreadyExists)
+		assert.Empty(t, repo.created)
+	})
+
+	t.Run("no previous note", func(t *testing.T) {
+		repo := &copyRepo{}
+		_, err := daily_note.NewService(repo, daily_note.WithClock(fake)).CopyPreviousDayNote(ctx, 1, time.Local)
+
+		assert.ErrorIs(t, err, daily_note.ErrDailyNotePreviousNotFound)
+		assert.Empty(t, repo.created)
+	})
+}
+
+// TestParseCursor 测试分页游标解析与格式化
+func TestParseCursor(t *testing.T) {
+	cursor, err := daily_note.ParseCursor("")
+	assert.NoError(t, err)
+	assert.True(t, cursor.IsZero())
+
+	cursor, err = daily_note.ParseCursor("2024-01-21")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-21", daily_note.FormatCursor(cursor))
+
+	_, err = daily_note.ParseCursor("not-a-date")
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteCursorInvalid)
+
+	assert.Equal(t, "", daily_note.FormatCursor(time.Time{}))
+}
+
+// afterRepo 仅实现 FindByUserIDAfter 的仓储桩，记录查询参数
+type afterRepo struct {
+	daily_note.DailyNoteRepository
+	cursor time.Time
+	limit  int
+}
+
+func (r *afterRepo) FindByUserIDAfter(ctx context.Context, userID int64, afterNoteDate time.Time, limit int) ([]daily_note.DailyNoteEntity, time.Time, error) {
+	r.cursor = afterNoteDate
+	r.limit = limit
+	return nil, time.Time{}, nil
+}
+
+// TestGetDailyNoteListAfter 测试游标分页参数传递与每页大小校验
+func TestGetDailyNoteListAfter(t *testing.T) {
+	ctx := context.Background()
+	cursor := time.Date(2024, 1, 21, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{20, 20},
+		{0, daily_note.DefaultPageSize},
+		{daily_note.MaxPageSize + 1, daily_note.DefaultPageSize},
+	}
+	for _, tt := range tests {
+		repo := &afterRepo{}
+		_, _, err := daily_note.NewService(repo).GetDailyNoteListAfter(ctx, 1, cursor, tt.limit)
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, repo.cursor)
+		assert.Equal(t, tt.want, repo.limit)
+	}
+}
+
+// summaryRepo 实现概览所需查询的仓储桩
+type summaryRepo struct {
+	daily_note.DailyNoteRepository
+	count    int64
+	hasToday bool
+	dates    []time.Time
+}
+
+func (r *summaryRepo) CountByUserID(ctx context.Context, userID int64) (int64, error) {
+	return r.count, nil
+}
+
+func (r *summaryRepo) ExistsByUserIDAndDate(ctx context.Context, userID int64, noteDate time.Time) (bool, error) {
+	return r.hasToday, nil
+}
+
+func (r *summaryRepo) FindNoteDatesByUserID(ctx context.Context, userID int64) ([]time.Time, error) {
+	return r.dates, nil
+}
+
+// TestGetSummary 测试概览合并笔记总数、今日是否已写和连续天数
+func TestGetSummary(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	repo := &summaryRepo{
+		count:    3,
+		hasToday: true,
+		dates:    []time.Time{day(5), day(9), day(10)},
+	}
+	fake := clock.NewFakeClock(time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC))
+	service := daily_note.NewService(repo, daily_note.WithClock(fake))
+
+	summary, err := service.GetSummary(context.Background(), 1, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), summary.TotalNotes)
+	assert.True(t, summary.HasTodayNote)
+	assert.Equal(t, 2, summary.Streak.Current)
+	assert.Equal(t, 3, summary.Streak.TotalDays)
+
+	// 两天后未再写笔记，当前连续天数清零，历史最长保留
+	fake.Advance(48 * time.Hour)
+	streak, err := service.GetStreak(context.Background(), 1, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, streak.Current)
+	assert.Equal(t, 2, streak.Longest)
+}
+
+// deleteRepo 实现按 ID 查询与删除的仓储桩
+type deleteRepo struct {
+	daily_note.DailyNoteRepository
+	note    daily_note.DailyNoteEntity
+	deleted int64
+}
+
+func (r *deleteRepo) FindByID(ctx context.Context, id int64) (daily_note.DailyNoteEntity, error) {
+	if r.note == nil || r.note.GetID() != id {
+		return nil, daily_note.ErrDailyNoteNotFound
+	}
+	return r.note, nil
+}
+
+func (r *deleteRepo) Delete(ctx context.Context, id int64) error {
+	r.deleted = id
+	return nil
+}
+
+// TestDeleteDailyNoteByID 测试按 ID 删除笔记时的归属校验
+func TestDeleteDailyNoteByID(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	note := daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 1, false, nil)
+
+	t.Run("owner", func(t *testing.T) {
+		repo := &deleteRepo{note: note}
+		err := daily_note.NewService(repo).DeleteDailyNoteByID(ctx, 1, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), repo.deleted)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		repo := &deleteRepo{note: note}
+		err := daily_note.NewService(repo).DeleteDailyNoteByID(ctx, 2, 7)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+		assert.Zero(t, repo.deleted)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := &deleteRepo{note: note}
+		err := daily_note.NewService(repo).DeleteDailyNoteByID(ctx, 1, 8)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+		assert.Zero(t, repo.deleted)
+	})
+}
+
+// deleteAllRepo 记录按用户删除全部笔记调用的仓储桩
+type deleteAllRepo struct {
+	daily_note.DailyNoteRepository
+	userID int64
+	calls  int
+}
+
+func (r *deleteAllRepo) DeleteAllByUserID(ctx context.Context, userID int64) (int64, error) {
+	r.userID = userID
+	r.calls++
+	return 3, nil
+}
+
+// TestDeleteAllDailyNotes 测试删除全部笔记必须确认
+func TestDeleteAllDailyNotes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("confirmed", func(t *testing.T) {
+		repo := &deleteAllRepo{}
+		deleted, err := daily_note.NewService(repo).DeleteAllDailyNotes(ctx, 1, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		assert.Equal(t, int64(1), repo.userID)
+	})
+
+	t.Run("not confirmed", func(t *testing.T) {
+		repo := &deleteAllRepo{}
+		_, err := daily_note.NewService(repo).DeleteAllDailyNotes(ctx, 1, false)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteDeleteAllNotConfirmed)
+		assert.Zero(t, repo.calls)
+	})
+}
+
+type pinRepo struct {
+	daily_note.DailyNoteRepository
+	note    daily_note.DailyNoteEntity
+	updated daily_note.DailyNoteEntity
+}
+
+func (r *pinRepo) FindByID(ctx context.Context, id int64) (daily_note.DailyNoteEntity, error) {
+	if r.note.GetID() != id {
+		return nil, daily_note.ErrDailyNoteNotFound
+	}
+	return r.note, nil
+}
+
+func (r *pinRepo) UpdatePinned(ctx context.Context, entity daily_note.DailyNoteEntity) error {
+	r.updated = entity
+	return nil
+}
+
+// TestSetDailyNotePinned 测试置顶笔记时的归属校验且不改变版本号
+func TestSetDailyNotePinned(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("pin and unpin", func(t *testing.T) {
+		repo := &pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 3, false, nil)}
+		service := daily_note.NewService(repo)
+
+		entity, err := service.SetDailyNotePinned(ctx, 1, 7, true)
+		assert.NoError(t, err)
+		assert.True(t, entity.IsPinned())
+		assert.Equal(t, 3, entity.GetVersion())
+		assert.Equal(t, now, entity.GetUpdatedAt())
+		assert.Same(t, entity, repo.updated)
+
+		entity, err = service.SetDailyNotePinned(ctx, 1, 7, false)
+		assert.NoError(t, err)
+		assert.False(t, entity.IsPinned())
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		repo := &pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 1, false, nil)}
+		_, err := daily_note.NewService(repo).SetDailyNotePinned(ctx, 2, 7, true)
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+		assert.Nil(t, repo.updated)
+	})
+}
+
+// TestGetDailyNoteByID 测试按 ID 获取笔记时的归属校验
+func TestGetDailyNoteByID(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := &pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 1, false, nil)}
+	service := daily_note.NewService(repo)
+
+	entity, err := service.GetDailyNoteByID(ctx, 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", entity.GetContent())
+
+	_, err = service.GetDailyNoteByID(ctx, 2, 7)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+
+	_, err = service.GetDailyNoteByID(ctx, 1, 8)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+}
+
+// TestNormalizeTags 测试标签去空白、转小写、去重、排序及数量和长度限制
+func TestNormalizeTags(t *testing.T) {
+	tags, err := daily_note.NormalizeTags([]string{" Work ", "idea", "", "WORK", "  ", "读书"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"idea", "work", "读书"}, tags)
+
+	tags, err = daily_note.NormalizeTags(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	// 长度按字符计算，去重后未超过数量上限即可
+	_, err = daily_note.NormalizeTags([]string{strings.Repeat("标", daily_note.MaxTagLength)})
+	assert.NoError(t, err)
+	_, err = daily_note.NormalizeTags([]string{strings.Repeat("a", daily_note.MaxTagLength+1)})
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteTagTooLong)
+
+	many := make([]string, 0, daily_note.MaxTagsPerNote+1)
+	for i := 0; i <= daily_note.MaxTagsPerNote; i++ {
+		many = append(many, fmt.Sprintf("tag%d", i))
+	}
+	_, err = daily_note.NormalizeTags(many)
+	assert.ErrorIs(t, err, daily_note.ErrDailyNoteTooManyTags)
+
+	// 重复标签不计入数量
+	many[daily_note.MaxTagsPerNote] = "TAG0"
+	_, err = daily_note.NormalizeTags(many)
+	assert.NoError(t, err)
+}
+
+type tagRepo struct {
+	pinRepo
+}
+
+func (r *tagRepo) UpdateTags(ctx context.Context, entity daily_note.DailyNoteEntity) error {
+	r.updated = entity
+	return nil
+}
+
+// TestSetDailyNoteTags 测试设置标签时的归属校验，超出限制时不写入仓储
+func TestSetDailyNoteTags(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("replace", func(t *testing.T) {
+		repo := &tagRepo{pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 3, false, []string{"old"})}}
+		entity, err := daily_note.NewService(repo).SetDailyNoteTags(ctx, 1, 7, []string{"Work", "work", "idea"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"idea", "work"}, entity.GetTags())
+		assert.Equal(t, 3, entity.GetVersion())
+		assert.Same(t, entity, repo.updated)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		repo := &tagRepo{pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 1, false, []string{"old"})}}
+		_, err := daily_note.NewService(repo).SetDailyNoteTags(ctx, 1, 7, []string{strings.Repeat("a", daily_note.MaxTagLength+1)})
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteTagTooLong)
+		assert.Nil(t, repo.updated)
+		assert.Equal(t, []string{"old"}, repo.note.GetTags())
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		repo := &tagRepo{pinRepo{note: daily_note.ReconstructDailyNote(7, 1, now, "content", now, now, 1, false, nil)}}
+		_, err := daily_note.NewService(repo).SetDailyNoteTags(ctx, 2, 7, []string{"work"})
+		assert.ErrorIs(t, err, daily_note.ErrDailyNoteNotFound)
+		assert.Nil(t, repo.updated)
+	})
+}
+
+// calendarRepo 记录月历查询区间的仓储桩
+type calendarRepo struct {
+	daily_note.DailyNoteRepository
+	from, to time.Time
+}
+
+func (r *calendarRepo) FindCalendarDaysByUserIDAndDateRange(ctx context.Context, userID int64, from, to time.Time) ([]daily_note.CalendarDay, error) {
+	r.from, r.to = from, to
+	return []daily_note.CalendarDay{{Date: from, HasContent: true, CharCount: 5}}, nil
+}
+
+// TestGetNotesForMonth 测试月历的年月校验、月份边界和按用户时区确定当前月份
+func TestGetNotesForMonth(t *testing.T) {
+	ctx := context.Background()
+	// UTC 时间 2024-02-29 20:00，在东八区已是 3 月 1 日
+	fake := clock.NewFakeClock(time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC))
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+
+	cases := []struct {
+		name        string
+		loc         *time.Location
+		year, month int
+		from, to    string
+		err         error
+	}{
+		{"leap february", time.UTC, 2024, 2, "2024-02-01", "2024-02-29", nil},
+		{"december", time.UTC, 2023, 12, "2023-12-01", "2023-12-31", nil},
+		{"current month in user timezone", shanghai, 0, 0, "2024-03-01", "2024-03-31", nil},
+		{"current month in utc", time.UTC, 0, 0, "2024-02-01", "2024-02-29", nil},
+		{"month zero", time.UTC, 2024, 0, "", "", daily_note.ErrDailyNoteMonthInvalid},
+		{"month thirteen", time.UTC, 2024, 13, "", "", daily_note.ErrDailyNoteMonthInvalid},
+		{"year too early", time.UTC, 1899, 1, "", "", daily_note.ErrDailyNoteMonthInvalid},
+		{"year too late", time.UTC, 10000, 1, "", "", daily_note.ErrDailyNoteMonthInvalid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &calendarRepo{}
+			service := daily_note.NewService(repo, daily_note.WithClock(fake))
+
+			days, err := service.GetNotesForMonth(ctx, 1, tc.loc, tc.year, tc.month)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.True(t, repo.from.IsZero())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, days, 1)
+			assert.Equal(t, tc.from, repo.from.Format(daily_note.NoteDateLayout))
+			assert.Equal(t, tc.to, repo.to.Format(daily_note.NoteDateLayout))
+		})
+	}
+}
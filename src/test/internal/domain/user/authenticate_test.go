@@ -0,0 +1,258 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// emailRepo 仅实现按邮箱查询的仓储桩
+type emailRepo struct {
+	user.Repository
+	users map[string]user.UserEntity
+}
+
+func (r emailRepo) FindByEmail(ctx context.Context, email string) (user.UserEntity, error) {
+	entity, ok := r.users[email]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+// countingHasher 记录 Verify 调用次数的哈希桩
+type countingHasher struct {
+	user.Hasher
+	verified int
+}
+
+func (h *countingHasher) Hash(value string) (string, error) {
+	return "hash:" + value, nil
+}
+
+func (h *countingHasher) Verify(hash, value string) bool {
+	h.verified++
+	return false
+}
+
+// newAuthFixture 创建包含一个已注册用户的仓储和对应的登录参数
+func newAuthFixture(t testing.TB, hasher user.Hasher) (emailRepo, user.Email, user.Email, user.Password) {
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	now := time.Now()
+	repo := emailRepo{users: map[string]user.UserEntity{
+		"alice@example.com": user.ReconstructUser(1, "alice", "alice@example.com", hash, "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+	}}
+
+	known, err := user.NewEmail("alice@example.com")
+	require.NoError(t, err)
+	unknown, err := user.NewEmail("nobody@example.com")
+	require.NoError(t, err)
+	wrong, err := user.ParsePassword("WrongPassword1")
+	require.NoError(t, err)
+	return repo, known, unknown, wrong
+}
+
+// TestAuthenticateUser_UnknownEmail 测试邮箱未注册时仍执行一次密码校验
+func TestAuthenticateUser_UnknownEmail(t *testing.T) {
+	hasher := &countingHasher{}
+	repo := emailRepo{users: map[string]user.UserEntity{}}
+	email, _ := user.NewEmail("nobody@example.com")
+	password, _ := user.ParsePassword("Password123")
+
+	_, err := user.NewService(repo, hasher).AuthenticateUser(context.Background(), email, password)
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	assert.Equal(t, 1, hasher.verified)
+}
+
+// recordingHasher 记录 Verify 收到的哈希值的哈希工具
+type recordingHasher struct {
+	user.Hasher
+	verifiedHashes []string
+}
+
+func (h *recordingHasher) Verify(hash, value string) bool {
+	h.verifiedHashes = append(h.verifiedHashes, hash)
+	return h.Hasher.Verify(hash, value)
+}
+
+// TestAuthenticateUser_DummyHashCost 测试邮箱未注册时按当前计算成本校验占位哈希
+func TestAuthenticateUser_DummyHashCost(t *testing.T) {
+	for _, cost := range []int{4, 6} {
+		inner, err := auth.NewHasherWithCost(cost)
+		require.NoError(t, err)
+		hasher := &recordingHasher{Hasher: inner}
+		repo := emailRepo{users: map[string]user.UserEntity{}}
+		email, _ := user.NewEmail("nobody@example.com")
+		password, _ := user.ParsePassword("Password123")
+
+		_, err = user.NewService(repo, hasher).AuthenticateUser(context.Background(), email, password)
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+		require.Len(t, hasher.verifiedHashes, 1)
+		got, err := bcrypt.Cost([]byte(hasher.verifiedHashes[0]))
+		require.NoError(t, err)
+		assert.Equal(t, cost, got)
+	}
+}
+
+// failingEmailRepo 按邮箱查询总是失败的仓储桩
+type failingEmailRepo struct {
+	user.Repository
+}
+
+func (failingEmailRepo) FindByEmail(ctx context.Context, email string) (user.UserEntity, error) {
+	return nil, errors.New("database unavailable")
+}
+
+// TestAuthenticateUser_LookupError 测试查询失败不被当作凭证错误
+func TestAuthenticateUser_LookupError(t *testing.T) {
+	hasher, err := auth.NewHasherWithCost(4)
+	require.NoError(t, err)
+	service := user.NewService(failingEmailRepo{}, hasher)
+	email, _ := user.NewEmail("alice@example.com")
+	password, _ := user.ParsePassword("Password123")
+
+	_, err = service.AuthenticateUser(context.Background(), email, password)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, user.ErrInvalidCredentials)
+
+	_, err = service.ReactivateUser(context.Background(), email, password)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, user.ErrInvalidCredentials)
+}
+
+// TestAuthenticateUser_Timing 测试“邮箱未注册”与“密码错误”的耗时处于同一量级
+func TestAuthenticateUser_Timing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping bcrypt timing test in short mode")
+	}
+
+	hasher, err := auth.NewHasherWithCost(config.DefaultBcryptCost)
+	require.NoError(t, err)
+	repo, known, unknown, wrong := newAuthFixture(t, hasher)
+	service := user.NewService(repo, hasher)
+	ctx := context.Background()
+
+	measure := func(email user.Email) time.Duration {
+		// 取多次中的最小值，减少调度抖动的影响
+		best := time.Duration(0)
+		for i := 0; i < 3; i++ {
+			start := time.Now()
+			_, err := service.AuthenticateUser(ctx, email, wrong)
+			elapsed := time.Since(start)
+			assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+			if best == 0 || elapsed < best {
+				best = elapsed
+			}
+		}
+		return best
+	}
+
+	missing := measure(unknown)
+	mismatch := measure(known)
+	ratio := float64(missing) / float64(mismatch)
+	assert.Greater(t, ratio, 0.5, "unknown email: %v, wrong password: %v", missing, mismatch)
+	assert.Less(t, ratio, 2.0, "unknown email: %v, wrong password: %v", missing, mismatch)
+}
+
+// BenchmarkAuthenticateUser 对比“邮箱未注册”与“密码错误”两种失败路径的耗时
+func BenchmarkAuthenticateUser(b *testing.B) {
+	hasher, err := auth.NewHasherWithCost(config.DefaultBcryptCost)
+	require.NoError(b, err)
+	repo, known, unknown, wrong := newAuthFixture(b, hasher)
+	service := user.NewService(repo, hasher)
+	ctx := context.Background()
+
+	b.Run("unknown email", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			service.AuthenticateUser(ctx, unknown, wrong)
+		}
+	})
+	b.Run("wrong password", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			service.AuthenticateUser(ctx, known, wrong)
+		}
+	})
+}
+
+// savingEmailRepo 记录保存操作的按邮箱查询仓储桩
+type savingEmailRepo struct {
+	emailRepo
+	saved   []user.UserEntity
+	saveErr error
+}
+
+func (r *savingEmailRepo) Save(ctx context.Context, entity user.UserEntity) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.saved = append(r.saved, entity)
+	return nil
+}
+
+// TestUpgradePasswordHash 测试登录成功后将低计算成本的哈希升级到当前配置
+func TestUpgradePasswordHash(t *testing.T) {
+	ctx := context.Background()
+	oldHasher, err := auth.NewHasherWithCost(4)
+	require.NoError(t, err)
+	newHasher, err := auth.NewHasherWithCost(5)
+	require.NoError(t, err)
+	password, err := user.ParsePassword("Password123")
+	require.NoError(t, err)
+
+	t.Run("low cost hash upgraded", func(t *testing.T) {
+		base, email, _, _ := newAuthFixture(t, oldHasher)
+		repo := &savingEmailRepo{emailRepo: base}
+		service := user.NewService(repo, newHasher)
+
+		entity, err := service.AuthenticateUser(ctx, email, password)
+		require.NoError(t, err)
+
+		upgraded, err := service.UpgradePasswordHash(ctx, entity, password)
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+
+		require.Len(t, repo.saved, 1)
+		hash := repo.saved[0].GetPasswordHash()
+		cost, err := bcrypt.Cost([]byte(hash))
+		require.NoError(t, err)
+		assert.Equal(t, 5, cost)
+		assert.True(t, newHasher.Verify(hash, "Password123"))
+	})
+
+	t.Run("current cost left unchanged", func(t *testing.T) {
+		base, email, _, _ := newAuthFixture(t, newHasher)
+		repo := &savingEmailRepo{emailRepo: base}
+		service := user.NewService(repo, newHasher)
+
+		entity, err := service.AuthenticateUser(ctx, email, password)
+		require.NoError(t, err)
+
+		upgraded, err := service.UpgradePasswordHash(ctx, entity, password)
+		require.NoError(t, err)
+		assert.False(t, upgraded)
+		assert.Empty(t, repo.saved)
+	})
+
+	t.Run("save failure reported", func(t *testing.T) {
+		base, email, _, _ := newAuthFixture(t, oldHasher)
+		repo := &savingEmailRepo{emailRepo: base, saveErr: errors.New("database unavailable")}
+		service := user.NewService(repo, newHasher)
+
+		entity, err := service.AuthenticateUser(ctx, email, password)
+		require.NoError(t, err)
+
+		upgraded, err := service.UpgradePasswordHash(ctx, entity, password)
+		assert.Error(t, err)
+		assert.False(t, upgraded)
+	})
+}
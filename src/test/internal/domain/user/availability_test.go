@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// existsRepo 仅实现存在性检查的仓储桩
+type existsRepo struct {
+	user.Repository
+	usernames map[string]bool
+	emails    map[string]bool
+}
+
+func (r existsRepo) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	return r.usernames[username], nil
+}
+
+func (r existsRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.emails[email], nil
+}
+
+// TestAvailability 测试用户名/邮箱可用性检查
+func TestAvailability(t *testing.T) {
+	ctx := context.Background()
+	service := user.NewService(existsRepo{
+		usernames: map[string]bool{"alice": true},
+		emails:    map[string]bool{"alice@example.com": true},
+	}, nil)
+
+	username, _ := user.NewUsername("alice")
+	available, err := service.IsUsernameAvailable(ctx, username)
+	assert.NoError(t, err)
+	assert.False(t, available)
+
+	username, _ = user.NewUsername("bob")
+	available, err = service.IsUsernameAvailable(ctx, username)
+	assert.NoError(t, err)
+	assert.True(t, available)
+
+	// 邮箱按注册时相同规则规范化后检查
+	email, err := user.NewEmail("  Alice@Example.COM ")
+	assert.NoError(t, err)
+	available, err = service.IsEmailAvailable(ctx, email)
+	assert.NoError(t, err)
+	assert.False(t, available)
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAvatarExtension 测试头像类型到文件扩展名的映射
+func TestAvatarExtension(t *testing.T) {
+	tests := []struct {
+		contentType string
+		ext         string
+		ok          bool
+	}{
+		{"image/png", ".png", true},
+		{"image/jpeg", ".jpg", true},
+		{"image/webp", ".webp", true},
+		{"image/gif", "", false},
+		{"text/plain; charset=utf-8", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			ext, ok := user.AvatarExtension(tt.contentType)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.ext, ext)
+		})
+	}
+}
+
+// TestUploadAvatar_UnsupportedType 测试上传非图片内容时在访问仓储前被拒绝
+func TestUploadAvatar_UnsupportedType(t *testing.T) {
+	service := user.NewService(nil, nil)
+
+	_, err := service.UploadAvatar(context.Background(), 1, "text/plain; charset=utf-8", []byte("hello"))
+
+	assert.ErrorIs(t, err, user.ErrAvatarTypeUnsupported)
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// emailChangeRepo 邮箱变更测试用仓储桩
+type emailChangeRepo struct {
+	existsRepo
+	users map[int64]user.UserEntity
+	saved user.UserEntity
+}
+
+func (r *emailChangeRepo) FindByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	entity, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+func (r *emailChangeRepo) Save(ctx context.Context, entity user.UserEntity) error {
+	r.saved = entity
+	return nil
+}
+
+// TestEmailChange 测试发起和确认邮箱变更
+func TestEmailChange(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newRepo := func(pendingEmail string) *emailChangeRepo {
+		return &emailChangeRepo{
+			existsRepo: existsRepo{emails: map[string]bool{"alice@example.com": true, "taken@example.com": true}},
+			users: map[int64]user.UserEntity{
+				1: user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, false, pendingEmail, 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+			},
+		}
+	}
+	email := func(value string) user.Email {
+		e, err := user.NewEmail(value)
+		assert.NoError(t, err)
+		return e
+	}
+
+	t.Run("request keeps login email", func(t *testing.T) {
+		repo := newRepo("")
+		err := user.NewService(repo, nil).UpdateEmail(ctx, 1, email("new@example.com"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice@example.com", repo.saved.GetEmail())
+		assert.Equal(t, "new@example.com", repo.saved.GetPendingEmail())
+	})
+
+	t.Run("request rejects taken email", func(t *testing.T) {
+		repo := newRepo("")
+		err := user.NewService(repo, nil).UpdateEmail(ctx, 1, email("taken@example.com"))
+
+		assert.ErrorIs(t, err, user.ErrEmailAlreadyExists)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("confirm moves pending email", func(t *testing.T) {
+		repo := newRepo("new@example.com")
+		err := user.NewService(repo, nil).ConfirmEmailChange(ctx, 1, email("new@example.com"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", repo.saved.GetEmail())
+		assert.Empty(t, repo.saved.GetPendingEmail())
+		assert.True(t, repo.saved.IsEmailVerified())
+	})
+
+	t.Run("confirm with superseded email", func(t *testing.T) {
+		repo := newRepo("newer@example.com")
+		err := user.NewService(repo, nil).ConfirmEmailChange(ctx, 1, email("new@example.com"))
+
+		assert.ErrorIs(t, err, user.ErrEmailChangeTokenInvalid)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("confirm without pending change", func(t *testing.T) {
+		repo := newRepo("")
+		err := user.NewService(repo, nil).ConfirmEmailChange(ctx, 1, email("new@example.com"))
+
+		assert.ErrorIs(t, err, user.ErrEmailChangeTokenInvalid)
+	})
+
+	t.Run("confirm after email taken by another user", func(t *testing.T) {
+		repo := newRepo("taken@example.com")
+		err := user.NewService(repo, nil).ConfirmEmailChange(ctx, 1, email("taken@example.com"))
+
+		assert.ErrorIs(t, err, user.ErrEmailAlreadyExists)
+		assert.Nil(t, repo.saved)
+	})
+}
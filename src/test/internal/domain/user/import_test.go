@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importRepo 批量导入测试用仓储桩
+type importRepo struct {
+	existsRepo
+	inserted []user.UserEntity
+	strict   bool
+}
+
+func (r *importRepo) InsertBatch(ctx context.Context, users []user.UserEntity, strict bool) ([]user.UserEntity, error) {
+	r.inserted = users
+	r.strict = strict
+	return nil, nil
+}
+
+// TestBulkRegisterUsers 测试批量导入用户
+func TestBulkRegisterUsers(t *testing.T) {
+	ctx := context.Background()
+	hasher, err := auth.NewHasherWithCost(4)
+	require.NoError(t, err)
+	newRepo := func() *importRepo {
+		return &importRepo{existsRepo: existsRepo{
+			usernames: map[string]bool{"alice": true},
+			emails:    map[string]bool{"taken@example.com": true},
+		}}
+	}
+	users := []user.ImportUser{
+		{Username: "bob", Email: "bob@example.com", Password: "Passw0rd!"},
+		{Username: "alice", Email: "alice2@example.com", Password: "Passw0rd!"},
+		{Username: "b", Email: "short@example.com", Password: "Passw0rd!"},
+		{Username: "BOB", Email: "bob2@example.com", Password: "Passw0rd!"},
+		{Username: "carol", Email: "Taken@Example.com", Password: "Passw0rd!"},
+		{Username: "admin", Email: "admin@example.com", Password: "Passw0rd!"},
+	}
+
+	t.Run("per row results", func(t *testing.T) {
+		repo := newRepo()
+		results, err := user.NewService(repo, hasher).BulkRegisterUsers(ctx, users, false)
+		require.NoError(t, err)
+		require.Len(t, results, len(users))
+
+		assert.Equal(t, user.ImportStatusCreated, results[0].Status)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, user.ImportStatusSkipped, results[1].Status)
+		assert.ErrorIs(t, results[1].Err, user.ErrUsernameTaken)
+		assert.Equal(t, user.ImportStatusError, results[2].Status)
+		assert.Error(t, results[2].Err)
+		assert.Equal(t, user.ImportStatusSkipped, results[3].Status, "duplicate within batch")
+		assert.ErrorIs(t, results[3].Err, user.ErrUsernameTaken)
+		assert.Equal(t, user.ImportStatusSkipped, results[4].Status)
+		assert.ErrorIs(t, results[4].Err, user.ErrEmailAlreadyExists)
+		assert.Equal(t, user.ImportStatusError, results[5].Status)
+		assert.ErrorIs(t, results[5].Err, user.ErrUsernameReserved)
+
+		require.Len(t, repo.inserted, 1)
+		assert.Equal(t, "bob", repo.inserted[0].GetUsername())
+		assert.True(t, hasher.Verify(repo.inserted[0].GetPasswordHash(), "Passw0rd!"))
+		assert.False(t, repo.strict)
+	})
+
+	t.Run("strict rejects whole batch", func(t *testing.T) {
+		repo := newRepo()
+		results, err := user.NewService(repo, hasher).BulkRegisterUsers(ctx, users, true)
+		require.NoError(t, err)
+		assert.Equal(t, user.ImportStatusSkipped, results[0].Status)
+		assert.ErrorIs(t, results[0].Err, user.ErrUserImportAborted)
+		assert.Nil(t, repo.inserted)
+	})
+
+	t.Run("strict valid batch", func(t *testing.T) {
+		repo := newRepo()
+		results, err := user.NewService(repo, hasher).BulkRegisterUsers(ctx, users[:1], true)
+		require.NoError(t, err)
+		assert.Equal(t, user.ImportStatusCreated, results[0].Status)
+		assert.Len(t, repo.inserted, 1)
+		assert.True(t, repo.strict)
+	})
+
+	t.Run("batch size", func(t *testing.T) {
+		_, err := user.NewService(newRepo(), hasher).BulkRegisterUsers(ctx, nil, false)
+		assert.ErrorIs(t, err, user.ErrUserImportBatchInvalid)
+
+		_, err = user.NewService(newRepo(), hasher).BulkRegisterUsers(ctx, make([]user.ImportUser, user.MaxImportBatchSize+1), false)
+		assert.ErrorIs(t, err, user.ErrUserImportBatchInvalid)
+	})
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecordFailedLogin 测试登录失败计数与账户锁定
+func TestRecordFailedLogin(t *testing.T) {
+	policy := user.LockoutPolicy{MaxAttempts: 3, Window: 15 * time.Minute, LockDuration: 10 * time.Minute}
+	now := time.Date(2024, 1, 21, 10, 0, 0, 0, time.Local)
+
+	t.Run("lock after max attempts within window", func(t *testing.T) {
+		u, _ := user.NewUser("alice", "alice@example.com", "hash")
+
+		assert.False(t, u.RecordFailedLogin(now, policy))
+		assert.False(t, u.RecordFailedLogin(now.Add(time.Minute), policy))
+		assert.True(t, u.RecordFailedLogin(now.Add(2*time.Minute), policy))
+
+		assert.True(t, u.IsLocked(now.Add(5*time.Minute)))
+		assert.False(t, u.IsLocked(now.Add(13*time.Minute)))
+	})
+
+	t.Run("counter resets outside window", func(t *testing.T) {
+		u, _ := user.NewUser("bob", "bob@example.com", "hash")
+
+		u.RecordFailedLogin(now, policy)
+		u.RecordFailedLogin(now.Add(time.Minute), policy)
+		assert.False(t, u.RecordFailedLogin(now.Add(20*time.Minute), policy))
+		assert.Equal(t, 1, u.GetFailedLoginAttempts())
+		assert.False(t, u.IsLocked(now.Add(20*time.Minute)))
+	})
+
+	t.Run("reset clears lockout", func(t *testing.T) {
+		u, _ := user.NewUser("carol", "carol@example.com", "hash")
+		for i := 0; i < policy.MaxAttempts; i++ {
+			u.RecordFailedLogin(now, policy)
+		}
+		assert.True(t, u.IsLocked(now))
+
+		u.ResetFailedLogins()
+		assert.False(t, u.IsLocked(now))
+		assert.Equal(t, 0, u.GetFailedLoginAttempts())
+		assert.True(t, u.GetLockedUntil().IsZero())
+	})
+
+	t.Run("disabled policy", func(t *testing.T) {
+		u, _ := user.NewUser("dave", "dave@example.com", "hash")
+		for i := 0; i < 10; i++ {
+			assert.False(t, u.RecordFailedLogin(now, user.LockoutPolicy{}))
+		}
+		assert.Equal(t, 0, u.GetFailedLoginAttempts())
+	})
+}
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewPasswordWithPolicy 测试按密码策略校验密码强度
+func TestNewPasswordWithPolicy(t *testing.T) {
+	strict := user.PasswordPolicy{MinLength: 12, RequiredClasses: 3, DisallowCommon: true}
+
+	tests := []struct {
+		name     string
+		password string
+		policy   user.PasswordPolicy
+		valid    bool
+	}{
+		{"default accepts two classes", "abcdefg1", user.DefaultPasswordPolicy(), true},
+		{"default rejects short", "abc1", user.DefaultPasswordPolicy(), false},
+		{"default rejects single class", "abcdefgh", user.DefaultPasswordPolicy(), false},
+		{"default allows common password", "password1", user.DefaultPasswordPolicy(), true},
+		{"too long", strings.Repeat("a1", user.MaxPasswordLength), user.DefaultPasswordPolicy(), false},
+		{"strict rejects below min length", "Abcdefgh1!", strict, false},
+		{"strict rejects two classes", "abcdefghijk1", strict, false},
+		{"strict accepts three classes", "Abcdefghijk1", strict, true},
+		{"common password rejected", "password1", user.PasswordPolicy{MinLength: 8, RequiredClasses: 2, DisallowCommon: true}, false},
+		{"common check ignores case", "PassWord123", user.PasswordPolicy{MinLength: 8, RequiredClasses: 2, DisallowCommon: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := user.NewPasswordWithPolicy(tt.password, tt.policy)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, user.ErrPasswordTooWeak)
+			}
+		})
+	}
+}
+
+// TestSetPasswordPolicy 测试 NewPassword 使用当前设置的密码策略
+func TestSetPasswordPolicy(t *testing.T) {
+	original := user.CurrentPasswordPolicy()
+	defer user.SetPasswordPolicy(original)
+
+	_, err := user.NewPassword("password1")
+	assert.NoError(t, err)
+
+	user.SetPasswordPolicy(user.PasswordPolicy{MinLength: 8, RequiredClasses: 2, DisallowCommon: true})
+	_, err = user.NewPassword("password1")
+	assert.ErrorIs(t, err, user.ErrPasswordTooWeak)
+
+	// 校验已有密码不受策略影响
+	_, err = user.ParsePassword("password1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reactivateRepo 按邮箱和 ID 查询、记录保存次数的仓储桩
+type reactivateRepo struct {
+	user.Repository
+	entity user.UserEntity
+	saves  int
+}
+
+func (r *reactivateRepo) FindByEmail(ctx context.Context, email string) (user.UserEntity, error) {
+	if r.entity.GetEmail() != email {
+		return nil, user.ErrUserNotFound
+	}
+	return r.entity, nil
+}
+
+func (r *reactivateRepo) FindByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	if r.entity.GetID() != id {
+		return nil, user.ErrUserNotFound
+	}
+	return r.entity, nil
+}
+
+func (r *reactivateRepo) Save(ctx context.Context, entity user.UserEntity) error {
+	r.entity = entity
+	r.saves++
+	return nil
+}
+
+// TestReactivateUser 测试停用账户可自助恢复，封禁账户不能
+func TestReactivateUser(t *testing.T) {
+	ctx := context.Background()
+	hasher, err := auth.NewHasherWithCost(4)
+	require.NoError(t, err)
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	email, err := user.NewEmail("alice@example.com")
+	require.NoError(t, err)
+	password, err := user.ParsePassword("Password123")
+	require.NoError(t, err)
+
+	newRepo := func(status user.UserStatus) *reactivateRepo {
+		now := time.Now()
+		return &reactivateRepo{entity: user.ReconstructUser(1, "alice", "alice@example.com", hash, "", "", status, true, "", 0,
+			time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser)}
+	}
+
+	t.Run("inactive user reactivated", func(t *testing.T) {
+		repo := newRepo(user.UserStatusInactive)
+		service := user.NewService(repo, hasher)
+
+		_, err := service.AuthenticateUser(ctx, email, password)
+		require.ErrorIs(t, err, user.ErrAccountInactive)
+
+		reactivated, err := service.ReactivateUser(ctx, email, password)
+		require.NoError(t, err)
+		assert.Equal(t, user.UserStatusActive, reactivated.GetStatus())
+		assert.Equal(t, user.UserStatusActive, repo.entity.GetStatus())
+
+		// 恢复后可正常登录
+		_, err = service.AuthenticateUser(ctx, email, password)
+		assert.NoError(t, err)
+	})
+
+	t.Run("banned user cannot reactivate", func(t *testing.T) {
+		repo := newRepo(user.UserStatusBanned)
+
+		_, err := user.NewService(repo, hasher).ReactivateUser(ctx, email, password)
+		assert.ErrorIs(t, err, user.ErrAccountBanned)
+		assert.Equal(t, user.UserStatusBanned, repo.entity.GetStatus())
+		assert.Zero(t, repo.saves)
+	})
+
+	t.Run("active user rejected", func(t *testing.T) {
+		repo := newRepo(user.UserStatusActive)
+
+		_, err := user.NewService(repo, hasher).ReactivateUser(ctx, email, password)
+		assert.ErrorIs(t, err, user.ErrAccountNotInactive)
+		assert.Zero(t, repo.saves)
+	})
+
+	t.Run("wrong password keeps account inactive", func(t *testing.T) {
+		repo := newRepo(user.UserStatusInactive)
+		wrong, err := user.ParsePassword("WrongPassword1")
+		require.NoError(t, err)
+
+		_, err = user.NewService(repo, hasher).ReactivateUser(ctx, email, wrong)
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+		assert.Equal(t, user.UserStatusInactive, repo.entity.GetStatus())
+	})
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsReservedUsername 测试保留用户名按规范形式比较，相近的用户名不受影响
+func TestIsReservedUsername(t *testing.T) {
+	cases := []struct {
+		name     string
+		reserved bool
+	}{
+		{"admin", true},
+		{"Admin", true},
+		{"ROOT", true},
+		{" api ", true},
+		{"support", true},
+		{"admin1", false},
+		{"admin_alice", false},
+		{"myroot", false},
+		{"apis", false},
+		{"alice", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reserved, user.IsReservedUsername(tc.name))
+		})
+	}
+}
+
+// TestSetReservedUsernames 测试配置的列表替换默认列表，传入空列表时恢复默认
+func TestSetReservedUsernames(t *testing.T) {
+	defer user.SetReservedUsernames(nil)
+
+	user.SetReservedUsernames([]string{"Staff_Only", ""})
+	assert.True(t, user.IsReservedUsername("staff_only"))
+	assert.False(t, user.IsReservedUsername("admin"))
+
+	user.SetReservedUsernames(nil)
+	assert.True(t, user.IsReservedUsername("admin"))
+	assert.False(t, user.IsReservedUsername("staff_only"))
+}
+
+// TestReservedUsername_Service 测试注册、修改用户名和可用性检查拒绝保留用户名
+func TestReservedUsername_Service(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	username := func(value string) user.Username {
+		u, err := user.NewUsername(value)
+		assert.NoError(t, err)
+		return u
+	}
+
+	t.Run("register", func(t *testing.T) {
+		service := user.NewService(existsRepo{}, nil)
+		email, err := user.NewEmail("admin@example.com")
+		assert.NoError(t, err)
+		password, err := user.NewPassword("Passw0rd!")
+		assert.NoError(t, err)
+
+		_, err = service.RegisterUser(ctx, username("Admin"), email, password)
+		assert.ErrorIs(t, err, user.ErrUsernameReserved)
+	})
+
+	t.Run("change username", func(t *testing.T) {
+		repo := &usernameRepo{users: map[int64]user.UserEntity{
+			1: user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+		}}
+		err := user.NewService(repo, nil).ChangeUsername(ctx, 1, username("support"))
+		assert.ErrorIs(t, err, user.ErrUsernameReserved)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("existing reserved name may change case", func(t *testing.T) {
+		repo := &usernameRepo{users: map[int64]user.UserEntity{
+			1: user.ReconstructUser(1, "root", "root@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+		}}
+		err := user.NewService(repo, nil).ChangeUsername(ctx, 1, username("Root"))
+		assert.NoError(t, err)
+		assert.NotNil(t, repo.saved)
+	})
+
+	t.Run("availability", func(t *testing.T) {
+		service := user.NewService(existsRepo{}, nil)
+		available, err := service.IsUsernameAvailable(ctx, username("API"))
+		assert.NoError(t, err)
+		assert.False(t, available)
+
+		available, err = service.IsUsernameAvailable(ctx, username("api_user"))
+		assert.NoError(t, err)
+		assert.True(t, available)
+	})
+}
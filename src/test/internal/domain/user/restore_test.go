@@ -0,0 +1,79 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// restoreRepo 恢复用户测试用仓储桩
+type restoreRepo struct {
+	existsRepo
+	deleted  map[int64]user.UserEntity
+	restored []int64
+}
+
+func (r *restoreRepo) FindDeletedByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	entity, ok := r.deleted[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+func (r *restoreRepo) Restore(ctx context.Context, id int64) error {
+	r.restored = append(r.restored, id)
+	return nil
+}
+
+// TestRestoreUser 测试恢复软删除用户
+func TestRestoreUser(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newRepo := func() *restoreRepo {
+		return &restoreRepo{
+			existsRepo: existsRepo{
+				usernames: map[string]bool{"taken": true},
+				emails:    map[string]bool{"taken@example.com": true},
+			},
+			deleted: map[int64]user.UserEntity{
+				1: user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+				2: user.ReconstructUser(2, "taken", "bob@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+				3: user.ReconstructUser(3, "carol", "taken@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+			},
+		}
+	}
+
+	t.Run("restore deleted user", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).RestoreUser(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1}, repo.restored)
+	})
+
+	t.Run("username reused by another account", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).RestoreUser(ctx, 2)
+		assert.True(t, errors.Is(err, user.ErrUserRestoreConflict))
+		assert.Empty(t, repo.restored)
+	})
+
+	t.Run("email reused by another account", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).RestoreUser(ctx, 3)
+		assert.True(t, errors.Is(err, user.ErrUserRestoreConflict))
+		assert.Empty(t, repo.restored)
+	})
+
+	t.Run("user not deleted", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).RestoreUser(ctx, 99)
+		assert.True(t, errors.Is(err, user.ErrUserNotFound))
+		assert.Empty(t, repo.restored)
+	})
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// statusRepo 批量修改状态测试用仓储桩
+type statusRepo struct {
+	user.Repository
+	users map[int64]user.UserEntity
+	saved []user.UserEntity
+}
+
+func (r *statusRepo) FindByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	entity, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+func (r *statusRepo) UpdateStatusBatch(ctx context.Context, users []user.UserEntity) error {
+	r.saved = users
+	return nil
+}
+
+// TestChangeUsersStatus 测试批量修改用户状态
+func TestChangeUsersStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newRepo := func() *statusRepo {
+		return &statusRepo{users: map[int64]user.UserEntity{
+			1: user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+			2: user.ReconstructUser(2, "bob", "bob@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+		}}
+	}
+
+	t.Run("ban users in one batch", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).ChangeUsersStatus(ctx, []int64{1, 2, 1}, user.UserStatusBanned)
+		assert.NoError(t, err)
+		assert.Len(t, repo.saved, 2)
+		for _, u := range repo.saved {
+			assert.Equal(t, user.UserStatusBanned, u.GetStatus())
+		}
+	})
+
+	t.Run("unknown user saves nothing", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).ChangeUsersStatus(ctx, []int64{1, 3}, user.UserStatusInactive)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).ChangeUsersStatus(ctx, []int64{1}, user.UserStatus("deleted"))
+		assert.ErrorIs(t, err, user.ErrUserStatusInvalid)
+	})
+
+	t.Run("batch size", func(t *testing.T) {
+		repo := newRepo()
+		err := user.NewService(repo, nil).ChangeUsersStatus(ctx, nil, user.UserStatusActive)
+		assert.ErrorIs(t, err, user.ErrUserStatusBatchInvalid)
+
+		ids := make([]int64, user.MaxStatusBatchSize+1)
+		err = user.NewService(repo, nil).ChangeUsersStatus(ctx, ids, user.UserStatusActive)
+		assert.ErrorIs(t, err, user.ErrUserStatusBatchInvalid)
+	})
+}
+
+// TestParseUserStatus 测试用户状态解析
+func TestParseUserStatus(t *testing.T) {
+	status, err := user.ParseUserStatus(" banned ")
+	assert.NoError(t, err)
+	assert.Equal(t, user.UserStatusBanned, status)
+
+	_, err = user.ParseUserStatus("deleted")
+	assert.ErrorIs(t, err, user.ErrUserStatusInvalid)
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"todolist/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// usernameRepo 修改用户名测试用仓储桩
+type usernameRepo struct {
+	user.Repository
+	users map[int64]user.UserEntity
+	saved user.UserEntity
+}
+
+func (r *usernameRepo) FindByID(ctx context.Context, id int64) (user.UserEntity, error) {
+	entity, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return entity, nil
+}
+
+func (r *usernameRepo) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	for _, u := range r.users {
+		if strings.EqualFold(u.GetUsername(), username) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *usernameRepo) Save(ctx context.Context, entity user.UserEntity) error {
+	r.saved = entity
+	return nil
+}
+
+// TestChangeUsername 测试修改用户名的占用检查与冷却期
+func TestChangeUsername(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newRepo := func(changedAt time.Time) *usernameRepo {
+		return &usernameRepo{users: map[int64]user.UserEntity{
+			1: user.ReconstructUser(1, "alice", "alice@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, changedAt, now, now, user.UserRoleUser),
+			2: user.ReconstructUser(2, "bob", "bob@example.com", "hash", "", "", user.UserStatusActive, true, "", 0, time.Time{}, time.Time{}, time.Time{}, now, now, user.UserRoleUser),
+		}}
+	}
+	username := func(value string) user.Username {
+		u, err := user.NewUsername(value)
+		assert.NoError(t, err)
+		return u
+	}
+	cooldown := user.WithUsernameChangeCooldown(30 * 24 * time.Hour)
+
+	t.Run("change username", func(t *testing.T) {
+		repo := newRepo(time.Time{})
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("alice_new"))
+		assert.NoError(t, err)
+		assert.Equal(t, "alice_new", repo.saved.GetUsername())
+		assert.False(t, repo.saved.GetUsernameChangedAt().IsZero())
+	})
+
+	t.Run("username taken", func(t *testing.T) {
+		repo := newRepo(time.Time{})
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("BOB"))
+		assert.ErrorIs(t, err, user.ErrUsernameTaken)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("case change of own username", func(t *testing.T) {
+		repo := newRepo(time.Time{})
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("Alice"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Alice", repo.saved.GetUsername())
+	})
+
+	t.Run("unchanged username is a no-op", func(t *testing.T) {
+		repo := newRepo(now.Add(-time.Hour))
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("alice"))
+		assert.NoError(t, err)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("within cooldown", func(t *testing.T) {
+		repo := newRepo(now.Add(-24 * time.Hour))
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("alice_new"))
+		assert.ErrorIs(t, err, user.ErrUsernameChangeTooSoon)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("after cooldown", func(t *testing.T) {
+		repo := newRepo(now.Add(-31 * 24 * time.Hour))
+		err := user.NewService(repo, nil, cooldown).ChangeUsername(ctx, 1, username("alice_new"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("cooldown disabled", func(t *testing.T) {
+		repo := newRepo(now.Add(-time.Minute))
+		err := user.NewService(repo, nil).ChangeUsername(ctx, 1, username("alice_new"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/interfaces/dto"
+	"todolist/internal/interfaces/http/response"
+)
+
+// TestToDailyNotePageDTO_ZeroPageSize 测试 pageSize 为 0 时不会除零崩溃
+func TestToDailyNotePageDTO_ZeroPageSize(t *testing.T) {
+	assert.NotPanics(t, func() {
+		page := dto.ToDailyNotePageDTO(nil, 25, 1, 0)
+		assert.Equal(t, daily_note.DefaultPageSize, page.Pagination.PageSize)
+		assert.Equal(t, 3, page.Pagination.TotalPages)
+	})
+}
+
+// TestToDailyNoteResponse_Counts 测试词数和字符数从实体传递到响应
+func TestToDailyNoteResponse_Counts(t *testing.T) {
+	note, err := daily_note.NewDailyNote(1, time.Now(), "今天 写了 Go 代码")
+	assert.NoError(t, err)
+
+	resp := response.ToDailyNoteResponse(dto.ToDailyNoteDTO(note))
+	assert.Equal(t, 4, resp.WordCount)
+	assert.Equal(t, 11, resp.CharCount)
+}
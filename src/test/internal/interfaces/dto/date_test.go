@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/interfaces/dto"
+	"todolist/internal/interfaces/http/response"
+)
+
+// TestDate_JSON 测试日期序列化为 YYYY-MM-DD，解析兼容完整时间戳
+func TestDate_JSON(t *testing.T) {
+	// 东八区零点换算为 UTC 是前一天，日期不应随之偏移
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+	date := dto.NewDate(time.Date(2024, 1, 17, 0, 0, 0, 0, shanghai))
+
+	data, err := json.Marshal(date)
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-01-17"`, string(data))
+
+	data, err = json.Marshal(dto.Date{})
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+
+	for _, input := range []string{`"2024-01-17"`, `"2024-01-17T00:00:00Z"`} {
+		var parsed dto.Date
+		require.NoError(t, json.Unmarshal([]byte(input), &parsed), input)
+		assert.Equal(t, "2024-01-17", parsed.Format(dto.DateLayout))
+	}
+
+	var parsed dto.Date
+	assert.Error(t, json.Unmarshal([]byte(`"2024/01/17"`), &parsed))
+	assert.Error(t, json.Unmarshal([]byte(`20240117`), &parsed))
+}
+
+// TestToDailyNoteResponse_NoteDate 测试笔记响应中的日期只输出日期部分
+func TestToDailyNoteResponse_NoteDate(t *testing.T) {
+	note, err := daily_note.NewDailyNote(1, time.Date(2024, 1, 17, 0, 0, 0, 0, time.Local), "content")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(response.ToDailyNoteResponse(dto.ToDailyNoteDTO(note)))
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, "2024-01-17", body["note_date"])
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/dto"
+)
+
+// TestNewPage 测试分页结果总页数计算
+func TestNewPage(t *testing.T) {
+	cases := []struct {
+		name       string
+		total      int64
+		pageSize   int
+		totalPages int
+	}{
+		{"empty", 0, 10, 0},
+		{"exact multiple", 20, 10, 2},
+		{"remainder", 21, 10, 3},
+		{"less than one page", 3, 10, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := dto.NewPage([]string{"a"}, tc.total, 1, tc.pageSize)
+			assert.Equal(t, tc.totalPages, page.Pagination.TotalPages)
+			assert.Equal(t, tc.total, page.Pagination.Total)
+			assert.Equal(t, []string{"a"}, page.Data)
+		})
+	}
+}
+
+// TestNewPaginationDTO_NonPositivePageSize 测试 pageSize 非正数时不会除零崩溃
+func TestNewPaginationDTO_NonPositivePageSize(t *testing.T) {
+	assert.NotPanics(t, func() {
+		pagination := dto.NewPaginationDTO(25, 1, 0)
+		assert.Equal(t, 0, pagination.TotalPages)
+	})
+}
+
+// TestNewPaginationDTO_Links 测试上一页、下一页在边界处的取值
+func TestNewPaginationDTO_Links(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	cases := []struct {
+		name     string
+		total    int64
+		page     int
+		hasNext  bool
+		hasPrev  bool
+		nextPage *int
+		prevPage *int
+	}{
+		{"first page", 25, 1, true, false, intPtr(2), nil},
+		{"middle page", 25, 2, true, true, intPtr(3), intPtr(1)},
+		{"last page", 25, 3, false, true, nil, intPtr(2)},
+		{"single page", 5, 1, false, false, nil, nil},
+		{"empty", 0, 1, false, false, nil, nil},
+		{"beyond last page", 25, 7, false, true, nil, intPtr(3)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pagination := dto.NewPaginationDTO(tc.total, tc.page, 10)
+			assert.Equal(t, tc.hasNext, pagination.HasNext)
+			assert.Equal(t, tc.hasPrev, pagination.HasPrev)
+			assert.Equal(t, tc.nextPage, pagination.NextPage)
+			assert.Equal(t, tc.prevPage, pagination.PrevPage)
+		})
+	}
+}
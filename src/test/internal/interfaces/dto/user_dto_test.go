@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/interfaces/dto"
+)
+
+// TestToUserStatsDTO 测试总数为各状态之和，缺失的状态计为 0
+func TestToUserStatsDTO(t *testing.T) {
+	stats := dto.ToUserStatsDTO(map[user.UserStatus]int64{
+		user.UserStatusActive: 7,
+		user.UserStatusBanned: 2,
+	})
+
+	assert.Equal(t, dto.UserStatsDTO{Total: 9, Active: 7, Inactive: 0, Banned: 2}, stats)
+	assert.Equal(t, dto.UserStatsDTO{}, dto.ToUserStatsDTO(nil))
+}
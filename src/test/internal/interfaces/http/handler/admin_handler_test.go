@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/request"
+	"todolist/internal/routes"
+)
+
+// registerAndLogin 注册用户并登录，role 不为空时先在数据库中设置用户角色
+func registerAndLogin(t *testing.T, username, email, role string) string {
+	t.Helper()
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: username,
+		Email:    email,
+		Password: "Admin123!",
+	})
+	require.NoError(t, err)
+	if role != "" {
+		_, err = testClient.ExecContext(ctx, "UPDATE users SET role = ? WHERE email = ?", role, email)
+		require.NoError(t, err)
+	}
+
+	login, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{Email: email, Password: "Admin123!"})
+	require.NoError(t, err)
+	return login.Token
+}
+
+// TestAdminRoutes_SQLite 测试持久化的管理员角色写入 Token 后可访问管理端路由
+func TestAdminRoutes_SQLite(t *testing.T) {
+	mux := http.NewServeMux()
+	routes.InitUserRoute(mux, &config.AvatarConfig{StorageDir: t.TempDir()})
+
+	adminToken := registerAndLogin(t, "AdminUser", "admin-role@example.com", "admin")
+	userToken := registerAndLogin(t, "PlainUser", "plain-role@example.com", "")
+
+	cases := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"admin allowed", adminToken, http.StatusOK},
+		{"user forbidden", userToken, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/stats", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+		})
+	}
+}
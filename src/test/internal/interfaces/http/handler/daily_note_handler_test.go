@@ -3,13 +3,19 @@ package handler
 import (
 	"context"
 	"testing"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql" // 导入MySQL驱动
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	dailynote "todolist/internal/domain/daily_note"
+	domainuser "todolist/internal/domain/user"
+	"todolist/internal/infrastructure/persistence"
 	"todolist/internal/interfaces/http/handler"
 	"todolist/internal/interfaces/http/request"
+	"todolist/internal/pkg/contextx"
 )
 
 // TestCreateDailyNoteHandler 测试创建每日笔记接口
@@ -43,7 +49,7 @@ func TestGetTodayDailyNoteHandler(t *testing.T) {
 func TestGetDailyNoteListHandler(t *testing.T) {
 	// 测试用例1：无效上下文 - 没有用户信息
 	t.Run("invalid context - no user", func(t *testing.T) {
-		_, err := handler.GetDailyNoteListHandler(context.Background(), request.EmptyRequest{})
+		_, err := handler.GetDailyNoteListHandler(context.Background(), request.DailyNoteListRequest{})
 		// 由于没有用户信息，应该返回错误
 		assert.Error(t, err)
 		assert.Equal(t, "unauthorized: invalid user context", err.Error())
@@ -76,3 +82,76 @@ func TestDeleteDailyNoteHandler(t *testing.T) {
 		assert.Equal(t, "unauthorized: invalid user context", err.Error())
 	})
 }
+
+// TestDailyNoteHandlers_SQLite 测试笔记接口的完整流程（内存 SQLite）
+func TestDailyNoteHandlers_SQLite(t *testing.T) {
+	_, err := handler.RegisterUserHandler(context.Background(), request.RegisterUserRequest{
+		Username: "NoteFlowUser",
+		Email:    "noteflow@example.com",
+		Password: "NoteFlow123!",
+	})
+	require.NoError(t, err)
+
+	// 注册响应不含生成的 ID，从仓储中查出用户
+	repo, err := persistence.GetFactory().UserRepository()
+	require.NoError(t, err)
+	u, err := repo.FindByUsername(context.Background(), "noteflowuser")
+	require.NoError(t, err)
+	ctx := contextx.WithUser(context.Background(), contextx.UserContext{
+		UserID:   u.GetID(),
+		Username: u.GetUsername(),
+	})
+
+	created, err := handler.CreateDailyNoteHandler(ctx, request.DailyNoteRequest{Content: "今天的内容"})
+	require.NoError(t, err)
+	assert.Equal(t, "今天的内容", created.Data.Content)
+
+	// 同一天重复创建由唯一索引拦截
+	_, err = handler.CreateDailyNoteHandler(ctx, request.DailyNoteRequest{Content: "重复"})
+	assert.ErrorIs(t, err, dailynote.ErrDailyNoteAlreadyExists)
+
+	today, err := handler.GetTodayDailyNoteHandler(ctx, request.EmptyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, created.Data.ID, today.ID)
+
+	tagged, err := handler.SetDailyNoteTagsHandler(ctx, request.SetDailyNoteTagsRequest{
+		ID:   created.Data.ID,
+		Tags: []string{"Work", "ideas"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ideas", "work"}, tagged.Tags)
+
+	rendered, err := handler.RenderDailyNoteHandler(ctx, request.DailyNoteIDRequest{ID: created.Data.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "<p>今天的内容</p>\n", rendered.HTML)
+
+	// 其他用户的笔记按不存在处理
+	otherCtx := contextx.WithUser(context.Background(), contextx.UserContext{UserID: u.GetID() + 1000})
+	_, err = handler.RenderDailyNoteHandler(otherCtx, request.DailyNoteIDRequest{ID: created.Data.ID})
+	assert.ErrorIs(t, err, dailynote.ErrDailyNoteNotFound)
+
+	list, err := handler.GetDailyNoteListByCursorHandler(ctx, request.DailyNoteCursorRequest{Tag: "WORK"})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, created.Data.ID, list.Data[0].ID)
+
+	_, err = handler.DeleteDailyNoteHandler(ctx, request.EmptyRequest{})
+	require.NoError(t, err)
+	_, err = handler.GetTodayDailyNoteHandler(ctx, request.EmptyRequest{})
+	assert.ErrorIs(t, err, dailynote.ErrDailyNoteNotFound)
+
+	// 设置时区后按用户时区的日历日创建今日笔记
+	timezone := "Pacific/Kiritimati"
+	_, err = handler.UpdateTimezoneHandler(ctx, request.UpdateTimezoneRequest{Timezone: &timezone})
+	require.NoError(t, err)
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	created, err = handler.CreateDailyNoteHandler(ctx, request.DailyNoteRequest{Content: "时区"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().In(loc).Format(dailynote.NoteDateLayout), created.Data.NoteDate.Format(dailynote.NoteDateLayout))
+
+	invalid := "Mars/Olympus"
+	_, err = handler.UpdateTimezoneHandler(ctx, request.UpdateTimezoneRequest{Timezone: &invalid})
+	assert.ErrorIs(t, err, domainuser.ErrTimezoneInvalid)
+}
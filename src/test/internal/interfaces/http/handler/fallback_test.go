@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/handler"
+)
+
+// newFallbackServer 创建带兜底处理器的测试路由
+func newFallbackServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return handler.WithFallback(mux)
+}
+
+// TestWithFallback 测试未匹配路由与方法不允许时返回统一 JSON 结构
+func TestWithFallback(t *testing.T) {
+	h := newFallbackServer()
+
+	t.Run("unknown path", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusNotFound, body.Code)
+		assert.NotEmpty(t, body.Message)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/items", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Contains(t, rec.Header().Get("Allow"), http.MethodGet)
+		var body struct {
+			Code int `json:"code"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusMethodNotAllowed, body.Code)
+	})
+
+	t.Run("matched route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/request"
+	"todolist/internal/interfaces/http/response"
+)
+
+// queryRequest 查询参数绑定测试用请求
+type queryRequest struct {
+	Token    string `form:"token"`
+	Page     int    `form:"page"`
+	Detailed bool   `form:"detailed"`
+}
+
+// TestWrap_DecodeQuery 测试 GET 请求查询参数绑定
+func TestWrap_DecodeQuery(t *testing.T) {
+	h := handler.Wrap(func(ctx context.Context, req queryRequest) (queryRequest, error) {
+		return req, nil
+	})
+
+	t.Run("bind query parameters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test?token=abc&page=3&detailed=true", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Data struct {
+				Token    string
+				Page     int
+				Detailed bool
+			} `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "abc", body.Data.Token)
+		assert.Equal(t, 3, body.Data.Page)
+		assert.True(t, body.Data.Detailed)
+	})
+
+	t.Run("invalid integer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test?page=abc", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+// TestWrap_ETag 测试 GET 响应的 ETag 与条件请求
+func TestWrap_ETag(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 21, 10, 0, 0, 0, time.UTC)
+	h := handler.Wrap(func(ctx context.Context, req struct{}) (response.UserResponse, error) {
+		return response.UserResponse{ID: 1, Username: "alice", UpdatedAt: updatedAt}, nil
+	})
+	etag := response.WeakETag(1, updatedAt)
+
+	cases := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		status      int
+	}{
+		{"no condition", http.MethodGet, "", http.StatusOK},
+		{"match", http.MethodGet, etag, http.StatusNotModified},
+		{"strong form matches weakly", http.MethodGet, etag[2:], http.StatusNotModified},
+		{"match in list", http.MethodGet, `"other", ` + etag, http.StatusNotModified},
+		{"wildcard", http.MethodGet, "*", http.StatusNotModified},
+		{"stale", http.MethodGet, response.WeakETag(1, updatedAt.Add(-time.Second)), http.StatusOK},
+		{"non-GET ignored", http.MethodPost, etag, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/test", nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.method == http.MethodGet {
+				assert.Equal(t, etag, rec.Header().Get("ETag"))
+			}
+			if tc.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+// TestWrap_Fields 测试按 fields 查询参数裁剪响应字段
+func TestWrap_Fields(t *testing.T) {
+	note := response.DailyNoteResponse{ID: 7, Content: "内容", Tags: []string{"work"}, Version: 2}
+	single := handler.Wrap(func(ctx context.Context, req struct{}) (response.DailyNoteResponse, error) {
+		return note, nil
+	})
+	list := handler.Wrap(func(ctx context.Context, req struct{}) (response.DailyNoteCursorListResponse, error) {
+		return response.DailyNoteCursorListResponse{Data: []response.DailyNoteResponse{note}, NextCursor: "next"}, nil
+	})
+
+	get := func(h http.Handler, target string) map[string]any {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Data map[string]any `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	t.Run("selected fields only", func(t *testing.T) {
+		data := get(single, "/test?fields=id,%20content,id")
+		assert.Equal(t, map[string]any{"id": float64(7), "content": "内容"}, data)
+	})
+
+	t.Run("unknown fields ignored", func(t *testing.T) {
+		data := get(single, "/test?fields=id,password_hash")
+		assert.Equal(t, map[string]any{"id": float64(7)}, data)
+	})
+
+	t.Run("no known fields returns full response", func(t *testing.T) {
+		data := get(single, "/test?fields=password_hash")
+		assert.Contains(t, data, "content")
+		assert.Contains(t, data, "version")
+	})
+
+	t.Run("absent returns full response", func(t *testing.T) {
+		data := get(single, "/test")
+		assert.Contains(t, data, "tags")
+		assert.Contains(t, data, "updated_at")
+	})
+
+	t.Run("list items projected", func(t *testing.T) {
+		data := get(list, "/test?fields=id,tags")
+		assert.Equal(t, "next", data["next_cursor"])
+		assert.Equal(t, []any{map[string]any{"id": float64(7), "tags": []any{"work"}}}, data["data"])
+	})
+}
+
+// TestParseFields 测试 fields 参数解析
+func TestParseFields(t *testing.T) {
+	assert.Equal(t, []string{"id", "content"}, response.ParseFields(" id ,content,,id"))
+	assert.Empty(t, response.ParseFields(""))
+	assert.Empty(t, response.ParseFields(" , "))
+}
+
+// TestWrap_ContentType 测试非 GET 请求体的 Content-Type 校验
+func TestWrap_ContentType(t *testing.T) {
+	h := handler.Wrap(func(ctx context.Context, req queryRequest) (queryRequest, error) {
+		return req, nil
+	})
+
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"json", "application/json", `{"Token":"abc"}`, http.StatusOK},
+		{"json with charset", "application/json; charset=utf-8", `{"Token":"abc"}`, http.StatusOK},
+		{"text plain", "text/plain", `{"Token":"abc"}`, http.StatusUnsupportedMediaType},
+		{"form", "application/x-www-form-urlencoded", "token=abc", http.StatusUnsupportedMediaType},
+		{"missing content type", "", `{"Token":"abc"}`, http.StatusUnsupportedMediaType},
+		{"empty body", "", "", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+		})
+	}
+}
+
+// pathRequest 路径参数绑定测试用请求
+type pathRequest struct {
+	ID      int64  `json:"-" path:"id"`
+	Content string `json:"content"`
+}
+
+// pathResponse 回显绑定结果
+type pathResponse struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
+}
+
+// TestWrap_PathParams 测试按 path 标签绑定路由通配段
+func TestWrap_PathParams(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/items/{id}", handler.Wrap(func(ctx context.Context, req pathRequest) (pathResponse, error) {
+		return pathResponse{ID: req.ID, Content: req.Content}, nil
+	}))
+
+	t.Run("bind path and body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/items/42", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Data pathResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, int64(42), body.Data.ID)
+		assert.Equal(t, "hello", body.Data.Content)
+	})
+
+	t.Run("id in body is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/items/42", strings.NewReader(`{"id":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid integer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/items/abc", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+// TestWrap_Validation 测试按 validate 标签校验请求并返回字段级错误
+func TestWrap_Validation(t *testing.T) {
+	h := handler.Wrap(func(ctx context.Context, req request.RegisterUserRequest) (response.MessageResponse, error) {
+		return response.MessageResponse{Message: "ok"}, nil
+	})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("valid request", func(t *testing.T) {
+		rec := post(`{"username":"user_01","email":"user@example.com","password":"Passw0rd!"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("per-field errors", func(t *testing.T) {
+		rec := post(`{"username":"ab","email":"not-an-email"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body response.BaseResponse[response.ValidationErrorResponse]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusBadRequest, body.Code)
+		assert.Equal(t, []response.FieldError{
+			{Field: "username", Message: "must be at least 3 characters"},
+			{Field: "email", Message: "must be a valid email address"},
+			{Field: "password", Message: "is required"},
+		}, body.Data.Errors)
+	})
+
+	t.Run("batch items", func(t *testing.T) {
+		batch := handler.Wrap(func(ctx context.Context, req request.BatchCreateDailyNotesRequest) (response.MessageResponse, error) {
+			return response.MessageResponse{}, nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`[{"date":"2024-01-01","content":"a"},{"date":"2024-01-02"}]`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		batch.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var body response.BaseResponse[response.ValidationErrorResponse]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []response.FieldError{
+			{Field: "[1].content", Message: "is required"},
+		}, body.Data.Errors)
+	})
+}
+
+// TestWrap_OptionalFields 测试指针字段区分请求体中省略的字段与显式设为零值的字段
+func TestWrap_OptionalFields(t *testing.T) {
+	send := func(h http.Handler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("avatar", func(t *testing.T) {
+		var got *string
+		h := handler.Wrap(func(ctx context.Context, req request.UpdateAvatarRequest) (response.MessageResponse, error) {
+			got = req.AvatarURL
+			return response.MessageResponse{}, nil
+		})
+
+		got = nil
+		rec := send(h, `{"avatar_url":""}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, got) {
+			assert.Equal(t, "", *got)
+		}
+
+		for _, body := range []string{`{}`, `{"avatar_url":null}`} {
+			got = nil
+			rec = send(h, body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+			assert.Nil(t, got, body)
+		}
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		var got *bool
+		h := handler.Wrap(func(ctx context.Context, req request.PinDailyNoteRequest) (response.MessageResponse, error) {
+			got = req.Pinned
+			return response.MessageResponse{}, nil
+		})
+
+		rec := send(h, `{"pinned":false}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, got) {
+			assert.False(t, *got)
+		}
+
+		got = nil
+		rec = send(h, `{}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, got)
+
+		var body response.BaseResponse[response.ValidationErrorResponse]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []response.FieldError{{Field: "pinned", Message: "is required"}}, body.Data.Errors)
+	})
+}
+
+// TestWrap_Created 测试返回 response.Created 时以 201 写出
+func TestWrap_Created(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		h := handler.Wrap(func(ctx context.Context, req struct{}) (response.Created[response.UserResponse], error) {
+			return response.NewCreated(response.UserResponse{ID: 7, Username: "alice"}), nil
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/test", nil))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var body struct {
+			Code int                   `json:"code"`
+			Data response.UserResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusCreated, body.Code)
+		assert.Equal(t, int64(7), body.Data.ID)
+		assert.Equal(t, "alice", body.Data.Username)
+	})
+
+	t.Run("default ok", func(t *testing.T) {
+		h := handler.Wrap(func(ctx context.Context, req struct{}) (response.UserResponse, error) {
+			return response.UserResponse{ID: 7}, nil
+		})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":200`)
+	})
+}
+
+// TestWrap_Canceled 测试查询过程中请求上下文被取消或超时时的响应
+func TestWrap_Canceled(t *testing.T) {
+	h := handler.Wrap(func(ctx context.Context, req struct{}) (response.UserResponse, error) {
+		repo, err := persistence.GetFactory().UserRepository()
+		if err != nil {
+			return response.UserResponse{}, err
+		}
+		if _, err := repo.FindByID(ctx, 1); err != nil {
+			return response.UserResponse{}, err
+		}
+		return response.UserResponse{ID: 1}, nil
+	})
+
+	t.Run("client canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, response.StatusClientClosedRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"message":"request canceled"`)
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"message":"request timeout"`)
+	})
+}
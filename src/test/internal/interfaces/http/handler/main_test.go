@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/infrastructure/persistence/mysql"
+	"todolist/internal/infrastructure/persistence/sqlite"
+)
+
+// testClient 处理器测试共用的内存 SQLite 客户端，用于直接准备测试数据
+var testClient *mysql.Client
+
+// TestMain 使用内存 SQLite 数据库运行处理器测试，无需 MySQL 实例
+func TestMain(m *testing.M) {
+	client, err := sqlite.Open(sqlite.MemoryPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open sqlite: %v\n", err)
+		os.Exit(1)
+	}
+	testClient = client
+	persistence.SetFactory(persistence.NewClientFactory(client))
+
+	code := m.Run()
+	client.Close()
+	os.Exit(code)
+}
@@ -2,13 +2,26 @@ package handler
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"todolist/internal/domain/user"
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/infrastructure/mail"
 	"todolist/internal/interfaces/http/handler"
 	"todolist/internal/interfaces/http/request"
 	"todolist/internal/interfaces/http/response"
+	appauth "todolist/internal/pkg/auth"
+	"todolist/internal/pkg/contextx"
+	"todolist/internal/routes"
 )
 
 // TestRegisterUserHandler 测试用户注册接口
@@ -25,10 +38,58 @@ func TestRegisterUserHandler(t *testing.T) {
 		resp, err := handler.RegisterUserHandler(context.Background(), req)
 		// 由于密码太短，应该返回错误
 		assert.Error(t, err)
-		assert.Equal(t, response.UserResponse{}, resp)
+		assert.Equal(t, response.Created[response.UserResponse]{}, resp)
 	})
 }
 
+// captureSender 记录发送的邮件，用于测试中读取验证链接
+type captureSender struct {
+	messages []mail.Message
+}
+
+// Send 记录邮件
+func (s *captureSender) Send(_ context.Context, msg mail.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// useCaptureSender 在测试期间将邮件发送器替换为 captureSender
+func useCaptureSender(t *testing.T) *captureSender {
+	t.Helper()
+	sender := &captureSender{}
+	mail.SetSender(sender)
+	t.Cleanup(func() { mail.SetSender(mail.NopSender{}) })
+	return sender
+}
+
+// linkToken 从邮件正文的链接中取出 token 参数
+func linkToken(t *testing.T, body string) string {
+	t.Helper()
+	_, query, ok := strings.Cut(body, "?")
+	require.True(t, ok, body)
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+	return values.Get("token")
+}
+
+// TestRegisterUserHandler_VerificationEmail 测试注册后验证链接通过邮件发送器发往注册邮箱（内存 SQLite）
+func TestRegisterUserHandler_VerificationEmail(t *testing.T) {
+	sender := useCaptureSender(t)
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "VerifyMailUser",
+		Email:    "verify-mail@example.com",
+		Password: "Verify123!",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sender.messages, 1)
+	assert.Equal(t, "verify-mail@example.com", sender.messages[0].To)
+	token := linkToken(t, sender.messages[0].Body)
+	_, err = handler.VerifyEmailHandler(ctx, request.VerifyEmailRequest{Token: token})
+	assert.NoError(t, err)
+}
+
 // TestChangePasswordHandler 测试修改密码接口
 func TestChangePasswordHandler(t *testing.T) {
 	// 测试用例：无效的上下文（没有用户信息）
@@ -60,12 +121,37 @@ func TestUpdateEmailHandler(t *testing.T) {
 	})
 }
 
+// TestUpdateEmailHandler_ConfirmationEmail 测试邮箱变更确认链接通过邮件发送器发往新邮箱（内存 SQLite）
+func TestUpdateEmailHandler_ConfirmationEmail(t *testing.T) {
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "ChangeMailUser",
+		Email:    "change-mail@example.com",
+		Password: "Change123!",
+	})
+	require.NoError(t, err)
+	login, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{Email: "change-mail@example.com", Password: "Change123!"})
+	require.NoError(t, err)
+
+	sender := useCaptureSender(t)
+	userCtx := contextx.WithUser(ctx, contextx.UserContext{UserID: login.User.ID, Username: login.User.Username})
+	_, err = handler.UpdateEmailHandler(userCtx, request.UpdateEmailRequest{NewEmail: "changed-mail@example.com"})
+	require.NoError(t, err)
+
+	require.Len(t, sender.messages, 1)
+	assert.Equal(t, "changed-mail@example.com", sender.messages[0].To)
+	token := linkToken(t, sender.messages[0].Body)
+	_, err = handler.ConfirmEmailChangeHandler(ctx, request.ConfirmEmailChangeRequest{Token: token})
+	assert.NoError(t, err)
+}
+
 // TestUpdateAvatarHandler 测试更新头像接口
 func TestUpdateAvatarHandler(t *testing.T) {
 	// 测试用例：无效的上下文（没有用户信息）
 	t.Run("invalid context - no user", func(t *testing.T) {
+		avatarURL := "https://example.com/avatar.jpg"
 		req := request.UpdateAvatarRequest{
-			AvatarURL: "https://example.com/avatar.jpg",
+			AvatarURL: &avatarURL,
 		}
 
 		resp, err := handler.UpdateAvatarHandler(context.Background(), req)
@@ -74,3 +160,215 @@ func TestUpdateAvatarHandler(t *testing.T) {
 		assert.Equal(t, response.MessageResponse{}, resp)
 	})
 }
+
+// TestSessionHandlers_SQLite 测试记住登录的会话列表和吊销（内存 SQLite）
+func TestSessionHandlers_SQLite(t *testing.T) {
+	_, err := handler.RegisterUserHandler(context.Background(), request.RegisterUserRequest{
+		Username: "SessionUser",
+		Email:    "session@example.com",
+		Password: "Session123!",
+	})
+	require.NoError(t, err)
+
+	client := contextx.WithClient(context.Background(), contextx.ClientInfo{IP: "203.0.113.7", UserAgent: "test-agent/1.0"})
+	login, err := handler.LoginUserHandler(client, request.LoginUserRequest{
+		Email:      "session@example.com",
+		Password:   "Session123!",
+		RememberMe: true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, login.RefreshToken)
+
+	ctx := contextx.WithUser(context.Background(), contextx.UserContext{UserID: login.User.ID, Username: login.User.Username})
+	sessions, err := handler.ListSessionsHandler(ctx, request.EmptyRequest{})
+	require.NoError(t, err)
+	require.Len(t, sessions.Data, 1)
+	session := sessions.Data[0]
+	assert.NotEmpty(t, session.ID)
+	assert.Equal(t, "203.0.113.7", session.IP)
+	assert.Equal(t, "test-agent/1.0", session.UserAgent)
+	assert.True(t, session.ExpiresAt.After(session.CreatedAt))
+
+	_, err = handler.RefreshTokenHandler(context.Background(), request.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.NoError(t, err)
+
+	// 其他用户无法吊销
+	other := contextx.WithUser(context.Background(), contextx.UserContext{UserID: login.User.ID + 1000})
+	_, err = handler.RevokeSessionHandler(other, request.RevokeSessionRequest{ID: session.ID})
+	assert.ErrorIs(t, err, user.ErrSessionNotFound)
+
+	_, err = handler.RevokeSessionHandler(ctx, request.RevokeSessionRequest{ID: session.ID})
+	require.NoError(t, err)
+
+	// 吊销后刷新 Token 失效，会话不再列出
+	_, err = handler.RefreshTokenHandler(context.Background(), request.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, user.ErrRefreshTokenInvalid)
+
+	sessions, err = handler.ListSessionsHandler(ctx, request.EmptyRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, sessions.Data)
+
+	_, err = handler.RevokeSessionHandler(ctx, request.RevokeSessionRequest{ID: session.ID})
+	assert.ErrorIs(t, err, user.ErrSessionNotFound)
+}
+
+// TestRevokeSession_AccessToken 测试会话吊销后，该会话签发和刷新得到的访问 Token 返回 401（内存 SQLite）
+func TestRevokeSession_AccessToken(t *testing.T) {
+	mux := http.NewServeMux()
+	routes.InitUserRoute(mux, &config.AvatarConfig{StorageDir: t.TempDir()})
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "RevokeUser",
+		Email:    "revoke@example.com",
+		Password: "Revoke123!",
+	})
+	require.NoError(t, err)
+	login := func() response.LoginResponse {
+		resp, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{
+			Email:      "revoke@example.com",
+			Password:   "Revoke123!",
+			RememberMe: true,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	revoked, kept := login(), login()
+	refreshed, err := handler.RefreshTokenHandler(ctx, request.RefreshTokenRequest{RefreshToken: revoked.RefreshToken})
+	require.NoError(t, err)
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	claims, err := appauth.NewTokenTool(config.GetJWTConfig()).ParseRefreshToken(revoked.RefreshToken)
+	require.NoError(t, err)
+	rec := serve(http.MethodDelete, "/api/v1/users/me/sessions/"+claims.ID, revoked.Token)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/users/me", revoked.Token).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/users/me", refreshed.Token).Code)
+	// 其他会话不受影响
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/users/me", kept.Token).Code)
+}
+
+// TestDeleteAccount_RevokesTokens 测试注销账户后访问 Token 和刷新 Token 均失效（内存 SQLite）
+func TestDeleteAccount_RevokesTokens(t *testing.T) {
+	mux := http.NewServeMux()
+	routes.InitUserRoute(mux, &config.AvatarConfig{StorageDir: t.TempDir()})
+	routes.InitDailyNoteRoute(mux)
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "DeletedUser",
+		Email:    "deleted@example.com",
+		Password: "Delete123!",
+	})
+	require.NoError(t, err)
+	login, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{
+		Email:      "deleted@example.com",
+		Password:   "Delete123!",
+		RememberMe: true,
+	})
+	require.NoError(t, err)
+	plain, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{Email: "deleted@example.com", Password: "Delete123!"})
+	require.NoError(t, err)
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	rec := serve(http.MethodDelete, "/api/v1/users/me", plain.Token, `{"password":"Delete123!"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/daily-notes", plain.Token, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/users/me", login.Token, "").Code)
+	_, err = handler.RefreshTokenHandler(ctx, request.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	assert.ErrorIs(t, err, user.ErrRefreshTokenInvalid)
+}
+
+// TestRefreshTokenHandler_UnknownSession 测试签名有效但不在会话存储中的刷新 Token 被拒绝
+func TestRefreshTokenHandler_UnknownSession(t *testing.T) {
+	// 模拟服务重启前签发、或会话记录缺失的刷新 Token
+	token, err := appauth.NewTokenTool(config.GetJWTConfig()).GenerateRefreshToken(1, "alice", "user")
+	require.NoError(t, err)
+
+	_, err = handler.RefreshTokenHandler(context.Background(), request.RefreshTokenRequest{RefreshToken: token})
+	assert.ErrorIs(t, err, user.ErrRefreshTokenInvalid)
+}
+
+// TestRefreshTokenHandler_ReloadsUser 测试刷新 Token 按用户当前信息签发访问 Token（内存 SQLite）
+func TestRefreshTokenHandler_ReloadsUser(t *testing.T) {
+	ctx := context.Background()
+	_, err := handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "RefreshUser",
+		Email:    "refresh@example.com",
+		Password: "Refresh123!",
+	})
+	require.NoError(t, err)
+	login, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{
+		Email:      "refresh@example.com",
+		Password:   "Refresh123!",
+		RememberMe: true,
+	})
+	require.NoError(t, err)
+	refresh := request.RefreshTokenRequest{RefreshToken: login.RefreshToken}
+
+	// 登录后角色变更，刷新得到的访问 Token 携带新角色
+	_, err = testClient.ExecContext(ctx, "UPDATE users SET role = 'admin' WHERE id = ?", login.User.ID)
+	require.NoError(t, err)
+	resp, err := handler.RefreshTokenHandler(ctx, refresh)
+	require.NoError(t, err)
+	claims := struct {
+		Data contextx.UserContext
+		jwt.RegisteredClaims
+	}{}
+	_, _, err = jwt.NewParser().ParseUnverified(resp.Token, &claims)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", claims.Data.Role)
+
+	// 封禁后不能再刷新
+	_, err = testClient.ExecContext(ctx, "UPDATE users SET status = 'banned' WHERE id = ?", login.User.ID)
+	require.NoError(t, err)
+	_, err = handler.RefreshTokenHandler(ctx, refresh)
+	assert.ErrorIs(t, err, user.ErrAccountBanned)
+
+	// 用户删除后不能再刷新
+	_, err = testClient.ExecContext(ctx, "DELETE FROM users WHERE id = ?", login.User.ID)
+	require.NoError(t, err)
+	_, err = handler.RefreshTokenHandler(ctx, refresh)
+	assert.ErrorIs(t, err, user.ErrRefreshTokenInvalid)
+}
+
+// TestIntrospectTokenHandler 测试返回 Token 声明，缺少声明时返回错误
+func TestIntrospectTokenHandler(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	claims := contextx.Claims{
+		UserContext: contextx.UserContext{UserID: 42, Username: "alice", Role: "active"},
+		IssuedAt:    issuedAt,
+		ExpiresAt:   issuedAt.Add(time.Hour),
+	}
+
+	resp, err := handler.IntrospectTokenHandler(contextx.WithClaims(context.Background(), claims), request.EmptyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), resp.UserID)
+	assert.Equal(t, "alice", resp.Username)
+	assert.Equal(t, "active", resp.Role)
+	require.NotNil(t, resp.IssuedAt)
+	assert.Equal(t, issuedAt, *resp.IssuedAt)
+	assert.Equal(t, issuedAt.Add(time.Hour), resp.ExpiresAt)
+
+	// 旧版本签发的 Token 没有 iat 声明
+	claims.IssuedAt = time.Time{}
+	resp, err = handler.IntrospectTokenHandler(contextx.WithClaims(context.Background(), claims), request.EmptyRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, resp.IssuedAt)
+
+	_, err = handler.IntrospectTokenHandler(context.Background(), request.EmptyRequest{})
+	assert.Error(t, err)
+}
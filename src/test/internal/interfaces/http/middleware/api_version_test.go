@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+)
+
+// TestAPIVersionMiddleware 测试 Accept-Version 协商
+func TestAPIVersionMiddleware(t *testing.T) {
+	var got string
+	h := middleware.APIVersionMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = contextx.GetAPIVersionFromContext(r.Context())
+	}))
+
+	cases := []struct {
+		name    string
+		header  string
+		status  int
+		version string
+	}{
+		{"default", "", http.StatusOK, contextx.APIVersionV1},
+		{"v1", "v1", http.StatusOK, contextx.APIVersionV1},
+		{"v2 case insensitive", " V2 ", http.StatusOK, contextx.APIVersionV2},
+		{"unsupported", "v9", http.StatusBadRequest, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.header != "" {
+				req.Header.Set("Accept-Version", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, tc.version, got)
+			assert.Equal(t, tc.version, rec.Header().Get("X-API-Version"))
+			assert.Equal(t, "Accept-Version", rec.Header().Get("Vary"))
+		})
+	}
+}
+
+// TestAPIVersionMiddleware_UserResponse 测试 v2 用户响应的 status 为结构化对象
+func TestAPIVersionMiddleware_UserResponse(t *testing.T) {
+	h := middleware.APIVersionMiddleware(handler.Wrap(func(ctx context.Context, req struct{}) (response.UserResponse, error) {
+		return response.UserResponse{ID: 1, Username: "alice", Status: "banned", UpdatedAt: time.Now()}, nil
+	}))
+
+	get := func(version string) map[string]any {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if version != "" {
+			req.Header.Set("Accept-Version", version)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	assert.Equal(t, "banned", get("")["status"])
+	assert.Equal(t, "banned", get("v1")["status"])
+	assert.Equal(t, map[string]any{"value": "banned", "active": false, "banned": true}, get("v2")["status"])
+	assert.Equal(t, "alice", get("v2")["username"])
+}
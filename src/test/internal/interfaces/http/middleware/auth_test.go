@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/pkg/auth"
+	"todolist/internal/pkg/contextx"
+)
+
+// 测试用认证中间件的签发者和受众
+const (
+	testIssuer   = "todolist"
+	testAudience = "todolist-api"
+)
+
+// TestGenerateTokenPair 测试刷新 Token 不能用于访问受保护接口
+func TestGenerateTokenPair(t *testing.T) {
+	pair, err := middleware.GenerateTokenPair(context.Background(), 42, "alice", "active")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.NotEqual(t, pair.AccessToken, pair.RefreshToken)
+
+	h := middleware.GetAuthMiddleware().Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"access token accepted", pair.AccessToken, http.StatusOK},
+		{"refresh token rejected", pair.RefreshToken, http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/daily-notes/today", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+// TestGenerateAccessTokenWithExpiry 测试返回的过期时间与 Token 中的 exp 声明一致
+func TestGenerateAccessTokenWithExpiry(t *testing.T) {
+	before := time.Now()
+	token, expiresAt, err := middleware.GenerateAccessTokenWithExpiry(42, "alice", "active")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	expected := before.Add(config.GetJWTConfig().GetExpireDuration())
+	assert.WithinDuration(t, expected, expiresAt, 2*time.Second)
+
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(token, claims)
+	assert.NoError(t, err)
+	exp, err := claims.GetExpirationTime()
+	assert.NoError(t, err)
+	assert.WithinDuration(t, exp.Time, expiresAt, time.Second)
+
+	// 携带配置的签发者、受众和生效时间
+	iss, err := claims.GetIssuer()
+	assert.NoError(t, err)
+	assert.Equal(t, config.GetJWTConfig().GetIssuer(), iss)
+	aud, err := claims.GetAudience()
+	assert.NoError(t, err)
+	assert.Equal(t, jwt.ClaimStrings{config.GetJWTConfig().GetAudience()}, aud)
+	nbf, err := claims.GetNotBefore()
+	assert.NoError(t, err)
+	assert.NotNil(t, nbf)
+}
+
+// TestAuthenticate 测试缺失、过期或 iss/aud/nbf 不符的 Token 返回 401，有效 Token 写入用户上下文
+func TestAuthenticate(t *testing.T) {
+	mw := middleware.NewAuthMiddleware(auth.StaticTokenSecret, auth.StaticTokenExpiration, testIssuer, testAudience)
+	want := contextx.UserContext{UserID: 42, Username: "alice", Role: "active"}
+
+	var got contextx.UserContext
+	var ok bool
+	h := mw.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = contextx.GetDataFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	valid, err := mw.GenerateTokenWithDuration(want, time.Hour)
+	assert.NoError(t, err)
+	expired, err := mw.GenerateToken(want, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	otherKey, err := middleware.NewAuthMiddleware("another-secret-key", time.Hour, testIssuer, testAudience).GenerateTokenWithDuration(want, time.Hour)
+	assert.NoError(t, err)
+	otherIssuer, err := middleware.NewAuthMiddleware(auth.StaticTokenSecret, time.Hour, "other-service", testAudience).GenerateTokenWithDuration(want, time.Hour)
+	assert.NoError(t, err)
+	otherAudience, err := middleware.NewAuthMiddleware(auth.StaticTokenSecret, time.Hour, testIssuer, "other-api").GenerateTokenWithDuration(want, time.Hour)
+	assert.NoError(t, err)
+	// 只带 exp 的 Token（底层库默认签发方式）缺少 iss、aud、nbf
+	bare, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"Data": want,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(auth.StaticTokenSecret))
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"expired token", "Bearer " + expired, http.StatusUnauthorized},
+		{"wrong signature", "Bearer " + otherKey, http.StatusUnauthorized},
+		{"wrong issuer", "Bearer " + otherIssuer, http.StatusUnauthorized},
+		{"wrong audience", "Bearer " + otherAudience, http.StatusUnauthorized},
+		{"missing registered claims", "Bearer " + bare, http.StatusUnauthorized},
+		{"valid token", "Bearer " + valid, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok = contextx.UserContext{}, false
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				assert.True(t, ok)
+				assert.Equal(t, want, got)
+			} else {
+				assert.False(t, ok)
+			}
+		})
+	}
+}
+
+// TestAuthenticate_ContextKeyCollision 测试上游以字符串键写入的值不会被当作认证用户
+func TestAuthenticate_ContextKeyCollision(t *testing.T) {
+	mw := middleware.NewAuthMiddleware(auth.StaticTokenSecret, auth.StaticTokenExpiration, testIssuer, testAudience)
+	fake := contextx.UserContext{UserID: 1, Username: "mallory", Role: middleware.RoleAdmin}
+
+	// 模拟无关代码以字符串键写入看似用户信息的值
+	polluted := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), "user_id", fake.UserID)
+			ctx = context.WithValue(ctx, "claimsdata", fake)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	var got contextx.UserContext
+	var ok bool
+	record := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = contextx.GetDataFromContext(r.Context())
+	})
+
+	t.Run("optional without token stays anonymous", func(t *testing.T) {
+		got, ok = contextx.UserContext{}, false
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		polluted(mw.OptionalAuthenticate(record)).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("authenticated user wins", func(t *testing.T) {
+		want := contextx.UserContext{UserID: 42, Username: "alice", Role: "active"}
+		token, err := mw.GenerateTokenWithDuration(want, time.Hour)
+		assert.NoError(t, err)
+
+		got, ok = contextx.UserContext{}, false
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		polluted(mw.Authenticate(record)).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+	})
+}
+
+// TestWithAuthContext 测试辅助函数写入的上下文可被认证读取方式识别
+func TestWithAuthContext(t *testing.T) {
+	ctx := middleware.WithAuthContext(7, "bob", middleware.RoleAdmin)
+
+	user, ok := contextx.GetDataFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, contextx.UserContext{UserID: 7, Username: "bob", Role: middleware.RoleAdmin}, user)
+}
+
+// TestAuthenticate_Claims 测试认证通过后 Token 声明写入上下文
+func TestAuthenticate_Claims(t *testing.T) {
+	before := time.Now().Truncate(time.Second)
+	token, expiresAt, err := middleware.GenerateAccessTokenWithExpiry(42, "alice", "active")
+	assert.NoError(t, err)
+
+	var got contextx.Claims
+	var ok bool
+	h := middleware.GetAuthMiddleware().Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = contextx.GetClaims(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/introspect", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, ok)
+	assert.Equal(t, contextx.UserContext{UserID: 42, Username: "alice", Role: "active"}, got.UserContext)
+	assert.False(t, got.IssuedAt.Before(before))
+	assert.WithinDuration(t, expiresAt, got.ExpiresAt, time.Second)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/domain/daily_note"
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// TestRequireOwnerOrRole 测试资源所有者或指定角色才能访问
+func TestRequireOwnerOrRole(t *testing.T) {
+	// 从路径参数解析资源所有者，ID 为 404 时模拟资源不存在
+	ownerFromPath := func(r *http.Request) (int64, error) {
+		id, err := strconv.ParseInt(r.PathValue("owner"), 10, 64)
+		if err != nil || id == 404 {
+			return 0, daily_note.ErrDailyNoteNotFound
+		}
+		return id, nil
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /resources/{owner}", middleware.GetAuthMiddleware().Authenticate(
+		middleware.RequireOwnerOrRole(ownerFromPath, middleware.RoleAdmin)(ok),
+	))
+
+	ownerToken, err := middleware.GenerateAccessToken(1, "alice", "active")
+	assert.NoError(t, err)
+	adminToken, err := middleware.GenerateAccessToken(2, "root", middleware.RoleAdmin)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		token  string
+		path   string
+		status int
+	}{
+		{"owner allowed", ownerToken, "/resources/1", http.StatusOK},
+		{"other user forbidden", ownerToken, "/resources/3", http.StatusForbidden},
+		{"admin allowed", adminToken, "/resources/3", http.StatusOK},
+		{"owner lookup error", ownerToken, "/resources/404", http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		h := middleware.RequireOwnerOrRole(ownerFromPath)(ok)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/resources/1", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+// TestRequireRole 测试仅指定角色才能访问
+func TestRequireRole(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := middleware.GetAuthMiddleware().Authenticate(middleware.RequireRole(middleware.RoleAdmin)(ok))
+
+	userToken, err := middleware.GenerateAccessToken(1, "alice", "active")
+	assert.NoError(t, err)
+	adminToken, err := middleware.GenerateAccessToken(2, "root", middleware.RoleAdmin)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"admin allowed", adminToken, http.StatusOK},
+		{"user forbidden", userToken, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		middleware.RequireRole(middleware.RoleAdmin)(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
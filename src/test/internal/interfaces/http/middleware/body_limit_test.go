@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// bodyRequest 请求体大小限制测试用请求
+type bodyRequest struct {
+	Content string `json:"content"`
+}
+
+// TestMaxBodyBytes 测试请求体超出上限时返回 413
+func TestMaxBodyBytes(t *testing.T) {
+	h := middleware.MaxBodyBytes(64)(handler.Wrap(func(ctx context.Context, req bodyRequest) (bodyRequest, error) {
+		return req, nil
+	}))
+
+	t.Run("body within limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		body := `{"content":"` + strings.Repeat("a", 128) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "request body too large")
+	})
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// TestGzip 测试按 Accept-Encoding、大小阈值和内容类型决定是否压缩
+func TestGzip(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("daily note ", 200) + `"}`
+
+	handlerFor := func(contentType, body string, status int) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if contentType != "" {
+				w.Header().Set("Content-Type", contentType)
+			}
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, body)
+		})
+	}
+
+	cases := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		compressed     bool
+	}{
+		{"large json compressed", "gzip, deflate", "application/json", large, true},
+		{"small response not compressed", "gzip", "application/json", `{"ok":true}`, false},
+		{"client without gzip", "", "application/json", large, false},
+		{"gzip refused with q=0", "gzip;q=0, deflate", "application/json", large, false},
+		{"already compressed type", "gzip", "image/png", large, false},
+		{"sniffed content type", "gzip", "", large, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := middleware.Gzip(1024)(handlerFor(tc.contentType, tc.body, http.StatusCreated))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/daily-notes/list", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+			if !tc.compressed {
+				assert.Empty(t, rec.Header().Get("Content-Encoding"))
+				assert.Equal(t, tc.body, rec.Body.String())
+				return
+			}
+
+			assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+			assert.NotEmpty(t, rec.Header().Get("Content-Type"))
+			assert.Less(t, rec.Body.Len(), len(tc.body))
+
+			zr, err := gzip.NewReader(rec.Body)
+			assert.NoError(t, err)
+			plain, err := io.ReadAll(zr)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.body, string(plain))
+		})
+	}
+}
+
+// TestGzip_WithLogging 测试与访问日志中间件组合时状态码和字节数记录正确
+func TestGzip_WithLogging(t *testing.T) {
+	buf := captureLog(t)
+	body := strings.Repeat("a", 4096)
+
+	h := middleware.LoggingMiddleware(middleware.Gzip(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, body[:1000])
+		_, _ = io.WriteString(w, body[1000:])
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/daily-notes/list", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, buf.String(), `"status":202`)
+	assert.NotContains(t, buf.String(), `"bytes":4096`)
+}
+
+// TestGzip_Flush 测试未达到阈值时 Flush 按原样输出
+func TestGzip_Flush(t *testing.T) {
+	h := middleware.Gzip(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "data: hello\n\n")
+		http.NewResponseController(w).Flush()
+		_, _ = io.WriteString(w, "data: world\n\n")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.True(t, rec.Flushed)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "data: hello\n\ndata: world\n\n", rec.Body.String())
+}
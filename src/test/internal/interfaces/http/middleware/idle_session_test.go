@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/pkg/auth"
+	"todolist/internal/pkg/contextx"
+)
+
+// TestSessionTracker 测试空闲超过窗口的会话被拒绝，活跃会话持续续期
+func TestSessionTracker(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		tracker := middleware.NewSessionTracker(0, time.Hour)
+		tracker.Touch(1)
+
+		assert.False(t, tracker.Enabled())
+		assert.True(t, tracker.Check(1))
+		assert.Equal(t, 0, tracker.Len())
+	})
+
+	t.Run("activity keeps session alive", func(t *testing.T) {
+		tracker := middleware.NewSessionTracker(50*time.Millisecond, time.Hour)
+		tracker.Touch(1)
+
+		for i := 0; i < 4; i++ {
+			time.Sleep(20 * time.Millisecond)
+			assert.True(t, tracker.Check(1))
+		}
+	})
+
+	t.Run("idle session rejected until touched again", func(t *testing.T) {
+		tracker := middleware.NewSessionTracker(20*time.Millisecond, time.Hour)
+		tracker.Touch(1)
+		time.Sleep(40 * time.Millisecond)
+
+		assert.False(t, tracker.Check(1))
+		assert.False(t, tracker.Check(1))
+		assert.True(t, tracker.Check(2))
+
+		tracker.Touch(1)
+		assert.True(t, tracker.Check(1))
+	})
+
+	t.Run("records kept for token lifetime", func(t *testing.T) {
+		tracker := middleware.NewSessionTracker(10*time.Millisecond, 30*time.Millisecond)
+		tracker.Touch(1)
+		time.Sleep(15 * time.Millisecond)
+
+		// 超过空闲时间但未超过 Token 有效期，记录保留，会话仍被拒绝
+		assert.False(t, tracker.Check(1))
+
+		time.Sleep(30 * time.Millisecond)
+		tracker.Touch(2)
+		assert.Equal(t, 1, tracker.Len())
+	})
+}
+
+// TestWithIdleTimeout 测试空闲超时的会话在认证中间件中返回 401
+func TestWithIdleTimeout(t *testing.T) {
+	tracker := middleware.NewSessionTracker(20*time.Millisecond, time.Hour)
+	mw := middleware.WithIdleTimeout(middleware.NewAuthMiddleware(auth.StaticTokenSecret, auth.StaticTokenExpiration, testIssuer, testAudience), tracker)
+
+	h := mw.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	token, err := mw.GenerateTokenWithDuration(contextx.UserContext{UserID: 42, Username: "alice", Role: "active"}, time.Hour)
+	assert.NoError(t, err)
+	tracker.Touch(42)
+
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve())
+
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, http.StatusUnauthorized, serve())
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// captureLog 将默认 logger 输出重定向到缓冲区
+func captureLog(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return buf
+}
+
+// TestLoggingMiddleware 测试访问日志记录状态码和请求 ID
+func TestLoggingMiddleware(t *testing.T) {
+	buf := captureLog(t)
+
+	h := middleware.LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/daily-notes", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, buf.String(), `"status":201`)
+	assert.Contains(t, buf.String(), `"path":"/api/v1/daily-notes"`)
+	assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+}
+
+// TestLoggingMiddleware_SkipHealth 测试健康检查成功时不记录日志
+func TestLoggingMiddleware_SkipHealth(t *testing.T) {
+	buf := captureLog(t)
+
+	h := middleware.LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Empty(t, buf.String())
+}
+
+// TestLoggingMiddleware_Flusher 测试包装后的 ResponseWriter 仍支持 Flush
+func TestLoggingMiddleware_Flusher(t *testing.T) {
+	captureLog(t)
+
+	h := middleware.LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		assert.True(t, ok)
+		flusher.Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.True(t, rec.Flushed)
+}
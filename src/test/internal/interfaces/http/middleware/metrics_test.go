@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/pkg/metrics"
+)
+
+// TestMetricsMiddleware 测试指标中间件按路由模式和状态码计数
+func TestMetricsMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	h := middleware.MetricsMiddleware(mux)
+
+	t.Run("matched route uses pattern label", func(t *testing.T) {
+		counter := metrics.HTTPRequestsTotal.WithLabelValues("GET /api/v1/items/{id}", http.MethodGet, "404")
+		before := testutil.ToFloat64(counter)
+
+		for _, id := range []string{"1", "2"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/items/"+id, nil)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		assert.Equal(t, before+2, testutil.ToFloat64(counter))
+	})
+
+	t.Run("unmatched route", func(t *testing.T) {
+		counter := metrics.HTTPRequestsTotal.WithLabelValues("unmatched", http.MethodGet, "404")
+		before := testutil.ToFloat64(counter)
+
+		req := httptest.NewRequest(http.MethodGet, "/not-exist", nil)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+}
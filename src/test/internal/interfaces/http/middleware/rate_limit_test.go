@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// TestRateLimiter_Allow 测试令牌桶耗尽后拒绝请求
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := middleware.NewRateLimiter(1, 2, time.Minute)
+
+	ok, _ := limiter.Allow("ip:1.1.1.1")
+	assert.True(t, ok)
+	ok, _ = limiter.Allow("ip:1.1.1.1")
+	assert.True(t, ok)
+
+	ok, retryAfter := limiter.Allow("ip:1.1.1.1")
+	assert.False(t, ok)
+	assert.Greater(t, retryAfter, time.Duration(0))
+
+	// 不同 key 互不影响
+	ok, _ = limiter.Allow("ip:2.2.2.2")
+	assert.True(t, ok)
+}
+
+// TestRateLimiter_Limit 测试超限时返回 429 和 Retry-After
+func TestRateLimiter_Limit(t *testing.T) {
+	limiter := middleware.NewRateLimiter(1, 1, time.Minute)
+	h := limiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/login", nil)
+	req.RemoteAddr = "10.0.0.1:12345"
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+}
+
+// TestRateLimiter_EvictIdle 测试空闲令牌桶被回收
+func TestRateLimiter_EvictIdle(t *testing.T) {
+	limiter := middleware.NewRateLimiter(1, 1, 10*time.Millisecond)
+
+	limiter.Allow("ip:1.1.1.1")
+	limiter.Allow("ip:2.2.2.2")
+	assert.Equal(t, 2, limiter.Len())
+
+	time.Sleep(20 * time.Millisecond)
+	limiter.Allow("ip:3.3.3.3")
+	assert.Equal(t, 1, limiter.Len())
+}
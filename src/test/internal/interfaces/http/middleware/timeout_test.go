@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+)
+
+// TestTimeoutMiddleware 测试请求整体超时
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Run("completes within timeout", func(t *testing.T) {
+		h := middleware.TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", "1")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("ok"))
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("X-Test"))
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("deadline exceeded returns 503", func(t *testing.T) {
+		ctxErr := make(chan error, 1)
+		h := middleware.TimeoutMiddleware(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 模拟等待数据库查询，取消应传递到处理器上下文
+			<-r.Context().Done()
+			ctxErr <- r.Context().Err()
+			w.Write([]byte("too late"))
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), "request timeout")
+		assert.NotContains(t, rec.Body.String(), "too late")
+		assert.ErrorIs(t, <-ctxErr, context.DeadlineExceeded)
+	})
+}
+
+// TestRouteTimeoutMiddleware 测试按路由覆盖超时时间
+func TestRouteTimeoutMiddleware(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			w.Write([]byte("done"))
+		case <-r.Context().Done():
+		}
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /short", slow)
+	mux.Handle("POST /long", slow)
+	mux.Handle("GET /stream", slow)
+
+	h := middleware.RouteTimeoutMiddleware(mux, 10*time.Millisecond, map[string]time.Duration{
+		"POST /long":  time.Second,
+		"GET /stream": 0,
+	})(mux)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/short", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/long", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "done", rec.Body.String())
+
+	// 不设置整体超时的路由直接写出，不经过缓冲
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.Equal(t, "done", rec.Body.String())
+}
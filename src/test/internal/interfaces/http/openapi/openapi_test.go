@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/interfaces/http/openapi"
+)
+
+// TestBuild 测试文档覆盖现有路由并复用请求/响应结构体
+func TestBuild(t *testing.T) {
+	doc := openapi.Build()
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	t.Run("routes are covered", func(t *testing.T) {
+		for path, method := range map[string]string{
+			"/api/v1/users/login":  "post",
+			"/api/v1/users/me":     "get",
+			"/api/v1/daily-notes":  "post",
+			"/api/v1/auth/refresh": "post",
+		} {
+			item, ok := doc.Paths[path]
+			require.True(t, ok, path)
+			assert.Contains(t, *item, method, path)
+		}
+		assert.Len(t, *doc.Paths["/api/v1/users/me"], 2)
+	})
+
+	t.Run("request body schema from struct tags", func(t *testing.T) {
+		op := (*doc.Paths["/api/v1/users/register"])["post"]
+		require.NotNil(t, op.RequestBody)
+		schema := op.RequestBody.Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/RegisterUserRequest", schema.Ref)
+
+		component := doc.Components.Schemas["RegisterUserRequest"]
+		require.NotNil(t, component)
+		assert.ElementsMatch(t, []string{"username", "email", "password"}, component.Required)
+		assert.Equal(t, "email", component.Properties["email"].Format)
+		assert.Equal(t, 3, *component.Properties["username"].MinLength)
+	})
+
+	t.Run("get request uses query parameters", func(t *testing.T) {
+		op := (*doc.Paths["/api/v1/daily-notes/range"])["get"]
+		assert.Nil(t, op.RequestBody)
+		names := make([]string, 0, len(op.Parameters))
+		for _, p := range op.Parameters {
+			assert.Equal(t, "query", p.In)
+			names = append(names, p.Name)
+		}
+		assert.ElementsMatch(t, []string{"from", "to", "page", "page_size", "fields"}, names)
+	})
+
+	t.Run("fields parameter only for selectable responses", func(t *testing.T) {
+		today := (*doc.Paths["/api/v1/daily-notes/today"])["get"]
+		require.Len(t, today.Parameters, 1)
+		assert.Equal(t, "fields", today.Parameters[0].Name)
+
+		me := (*doc.Paths["/api/v1/users/me"])["get"]
+		assert.Empty(t, me.Parameters)
+	})
+
+	t.Run("response wrapped in envelope", func(t *testing.T) {
+		op := (*doc.Paths["/api/v1/daily-notes/list"])["get"]
+		schema := op.Responses["200"].Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/DailyNoteListResponse", schema.Properties["data"].Ref)
+		assert.Contains(t, doc.Components.Schemas, "DailyNoteResponse")
+		assert.Equal(t, "date", doc.Components.Schemas["DailyNoteResponse"].Properties["note_date"].Format)
+		assert.Equal(t, "date-time", doc.Components.Schemas["DailyNoteResponse"].Properties["created_at"].Format)
+		assert.NotEmpty(t, op.Security)
+	})
+
+	t.Run("created routes respond 201", func(t *testing.T) {
+		op := (*doc.Paths["/api/v1/users/register"])["post"]
+		assert.Contains(t, op.Responses, "201")
+		assert.NotContains(t, op.Responses, "200")
+
+		op = (*doc.Paths["/api/v1/users/login"])["post"]
+		assert.Contains(t, op.Responses, "200")
+	})
+
+	t.Run("attachment response not wrapped", func(t *testing.T) {
+		op := (*doc.Paths["/api/v1/users/me/export"])["post"]
+		assert.Nil(t, op.RequestBody)
+		schema := op.Responses["200"].Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/UserExportResponse", schema.Ref)
+	})
+
+	t.Run("path parameter types from request struct", func(t *testing.T) {
+		byID := (*doc.Paths["/api/v1/admin/users/{id}/restore"])["post"]
+		require.Len(t, byID.Parameters, 1)
+		assert.Equal(t, "path", byID.Parameters[0].In)
+		assert.Equal(t, "integer", byID.Parameters[0].Schema.Type)
+
+		byName := (*doc.Paths["/api/v1/users/{username}"])["get"]
+		require.Len(t, byName.Parameters, 1)
+		assert.Equal(t, "username", byName.Parameters[0].Name)
+		assert.Equal(t, "path", byName.Parameters[0].In)
+		assert.Equal(t, "string", byName.Parameters[0].Schema.Type)
+		assert.Empty(t, byName.Security)
+	})
+
+	t.Run("all refs resolve", func(t *testing.T) {
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		for _, part := range strings.Split(string(data), `"$ref":"#/components/schemas/`)[1:] {
+			name := part[:strings.Index(part, `"`)]
+			assert.Contains(t, doc.Components.Schemas, name)
+		}
+	})
+}
+
+// TestHandler 测试文档与 Swagger UI 处理器
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapi.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc["openapi"])
+
+	rec = httptest.NewRecorder()
+	openapi.DocsHandler("/openapi.json").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"/openapi.json"`)
+}
@@ -0,0 +1,41 @@
+package response
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/response"
+)
+
+// TestDailyNoteResponse_ETag 测试不刷新 updated_at 的笔记修改同样改变 ETag
+func TestDailyNoteResponse_ETag(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 17, 8, 0, 0, 0, time.UTC)
+	note := response.DailyNoteResponse{ID: 1, UpdatedAt: updatedAt, Content: "hello"}
+
+	assert.Equal(t, note.ETag(), note.ETag())
+
+	t.Run("pinned", func(t *testing.T) {
+		pinned := note
+		pinned.IsPinned = true
+		assert.NotEqual(t, note.ETag(), pinned.ETag())
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		tagged := note
+		tagged.Tags = []string{"work"}
+		assert.NotEqual(t, note.ETag(), tagged.ETag())
+
+		retagged := note
+		retagged.Tags = []string{"home"}
+		assert.NotEqual(t, tagged.ETag(), retagged.ETag())
+
+		// 标签边界参与哈希，["ab"] 与 ["a", "b"] 不同
+		split := note
+		split.Tags = []string{"a", "b"}
+		joined := note
+		joined.Tags = []string{"ab"}
+		assert.NotEqual(t, split.ETag(), joined.ETag())
+	})
+}
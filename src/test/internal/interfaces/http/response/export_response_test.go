@@ -0,0 +1,72 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/interfaces/dto"
+	"todolist/internal/interfaces/http/response"
+)
+
+// notesOf 返回依次产出给定笔记的迭代器，err 非空时在最后产出
+func notesOf(err error, notes ...dto.DailyNoteDTO) func(func(dto.DailyNoteDTO, error) bool) {
+	return func(yield func(dto.DailyNoteDTO, error) bool) {
+		for _, n := range notes {
+			if !yield(n, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(dto.DailyNoteDTO{}, err)
+		}
+	}
+}
+
+// TestWriteUserExport 测试导出文档的结构和错误处理
+func TestWriteUserExport(t *testing.T) {
+	exportedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	userDTO := dto.UserDTO{ID: 1, Username: "alice", Email: "alice@example.com", Status: "active"}
+
+	t.Run("valid document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := response.WriteUserExport(&buf, dto.UserExportDTO{
+			ExportedAt: exportedAt,
+			User:       userDTO,
+			DailyNotes: notesOf(nil, dto.DailyNoteDTO{ID: 2, Content: "b"}, dto.DailyNoteDTO{ID: 1, Content: "a"}),
+		})
+		require.NoError(t, err)
+
+		var doc response.UserExportResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		assert.True(t, exportedAt.Equal(doc.ExportedAt))
+		assert.Equal(t, "alice", doc.User.Username)
+		require.Len(t, doc.DailyNotes, 2)
+		assert.Equal(t, int64(2), doc.DailyNotes[0].ID)
+		assert.Equal(t, "a", doc.DailyNotes[1].Content)
+	})
+
+	t.Run("no notes", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := response.WriteUserExport(&buf, dto.UserExportDTO{ExportedAt: exportedAt, User: userDTO, DailyNotes: notesOf(nil)})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"daily_notes":[]`)
+		assert.True(t, json.Valid(buf.Bytes()))
+	})
+
+	t.Run("iteration error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := response.WriteUserExport(&buf, dto.UserExportDTO{
+			ExportedAt: exportedAt,
+			User:       userDTO,
+			DailyNotes: notesOf(errors.New("db down"), dto.DailyNoteDTO{ID: 1}),
+		})
+		assert.EqualError(t, err, "db down")
+		assert.False(t, json.Valid(buf.Bytes()), "truncated output must not look complete")
+	})
+}
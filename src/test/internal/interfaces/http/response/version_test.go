@@ -0,0 +1,42 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/contextx"
+)
+
+// TestForVersion 测试按 API 版本调整包含用户信息的响应
+func TestForVersion(t *testing.T) {
+	user := response.UserResponse{ID: 1, Username: "alice", Status: "active"}
+
+	t.Run("v1 unchanged", func(t *testing.T) {
+		assert.Equal(t, user, response.ForVersion(user, contextx.APIVersionV1))
+		assert.Equal(t, user, response.ForVersion(user, "unknown"))
+	})
+
+	t.Run("non versioned response unchanged", func(t *testing.T) {
+		msg := response.MessageResponse{Message: "ok"}
+		assert.Equal(t, msg, response.ForVersion(msg, contextx.APIVersionV2))
+	})
+
+	cases := map[string]any{
+		"user":    user,
+		"login":   response.LoginResponse{Token: "t", User: user},
+		"summary": response.UserSummaryResponse{User: user, TotalNotes: 3},
+		"list":    response.UserListResponse{Data: []response.UserResponse{user}},
+	}
+	for name, resp := range cases {
+		t.Run(name+" v2", func(t *testing.T) {
+			data, err := json.Marshal(response.ForVersion(resp, contextx.APIVersionV2))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"status":{"value":"active","active":true,"banned":false}`)
+			assert.NotContains(t, string(data), `"status":"active"`)
+		})
+	}
+}
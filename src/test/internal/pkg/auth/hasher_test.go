@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/pkg/auth"
+)
+
+// TestNewHasherWithCost 测试计算成本范围校验
+func TestNewHasherWithCost(t *testing.T) {
+	t.Run("valid cost", func(t *testing.T) {
+		hasher, err := auth.NewHasherWithCost(4)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, hasher.Cost())
+	})
+
+	t.Run("cost too low", func(t *testing.T) {
+		_, err := auth.NewHasherWithCost(3)
+		assert.Error(t, err)
+	})
+
+	t.Run("cost too high", func(t *testing.T) {
+		_, err := auth.NewHasherWithCost(32)
+		assert.Error(t, err)
+	})
+
+	t.Run("hash and verify", func(t *testing.T) {
+		hasher, err := auth.NewHasherWithCost(4)
+		assert.NoError(t, err)
+
+		hash, err := hasher.Hash("Password123")
+		assert.NoError(t, err)
+		assert.True(t, hasher.Verify(hash, "Password123"))
+		assert.False(t, hasher.Verify(hash, "wrong"))
+	})
+}
+
+// TestHasher_NeedsRehash 测试按计算成本判断是否需要重新生成哈希
+func TestHasher_NeedsRehash(t *testing.T) {
+	low, err := auth.NewHasherWithCost(4)
+	assert.NoError(t, err)
+	high, err := auth.NewHasherWithCost(5)
+	assert.NoError(t, err)
+
+	hash, err := low.Hash("Password123")
+	assert.NoError(t, err)
+
+	assert.True(t, high.NeedsRehash(hash))
+	assert.False(t, low.NeedsRehash(hash))
+	assert.False(t, high.NeedsRehash("not-a-bcrypt-hash"))
+}
+
+// BenchmarkHasher_Hash 演示计算成本对哈希耗时的影响
+func BenchmarkHasher_Hash(b *testing.B) {
+	for _, cost := range []int{4, 8, 10, 12} {
+		hasher, err := auth.NewHasherWithCost(cost)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("cost=%d", cost), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := hasher.Hash("Password123"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/pkg/auth"
+	"todolist/internal/pkg/clock"
+)
+
+// TestSessionStore 测试会话列出、白名单校验、吊销和过期回收
+func TestSessionStore(t *testing.T) {
+	start := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	newSession := func(id string, userID int64, createdAt time.Time) auth.Session {
+		return auth.Session{ID: id, UserID: userID, CreatedAt: createdAt, ExpiresAt: createdAt.Add(7 * 24 * time.Hour)}
+	}
+
+	t.Run("list own sessions newest first", func(t *testing.T) {
+		fake := clock.NewFakeClock(start)
+		store := auth.NewSessionStore(fake)
+		store.Add(newSession("a", 1, start.Add(-time.Hour)))
+		store.Add(newSession("b", 1, start))
+		store.Add(newSession("c", 2, start))
+
+		sessions := store.List(1)
+		assert.Len(t, sessions, 2)
+		assert.Equal(t, "b", sessions[0].ID)
+		assert.Equal(t, "a", sessions[1].ID)
+		assert.Equal(t, sessions[1].CreatedAt, sessions[1].LastUsedAt)
+	})
+
+	t.Run("touch updates last used", func(t *testing.T) {
+		fake := clock.NewFakeClock(start)
+		store := auth.NewSessionStore(fake)
+		store.Add(newSession("a", 1, start))
+
+		fake.Advance(time.Hour)
+		store.Touch("a")
+		store.Touch("missing")
+		assert.Equal(t, start.Add(time.Hour), store.List(1)[0].LastUsedAt)
+	})
+
+	t.Run("only recorded sessions are active", func(t *testing.T) {
+		store := auth.NewSessionStore(clock.NewFakeClock(start))
+		store.Add(newSession("a", 1, start))
+
+		assert.True(t, store.IsActive(1, "a"))
+		assert.False(t, store.IsActive(2, "a"), "other user's session")
+		assert.False(t, store.IsActive(1, "unknown"), "not issued by this store")
+		assert.False(t, store.IsActive(1, ""), "tokens without id")
+	})
+
+	t.Run("revoke deactivates token", func(t *testing.T) {
+		store := auth.NewSessionStore(clock.NewFakeClock(start))
+		store.Add(newSession("a", 1, start))
+
+		assert.False(t, store.Revoke(2, "a"), "other user's session")
+		assert.True(t, store.IsActive(1, "a"))
+
+		assert.True(t, store.Revoke(1, "a"))
+		assert.False(t, store.IsActive(1, "a"))
+		assert.Empty(t, store.List(1))
+		assert.False(t, store.Revoke(1, "a"), "already revoked")
+	})
+
+	t.Run("revoke user invalidates earlier tokens", func(t *testing.T) {
+		fake := clock.NewFakeClock(start.Add(500 * time.Millisecond))
+		store := auth.NewSessionStore(fake)
+		store.Add(newSession("a", 1, start))
+		store.Add(newSession("b", 1, start))
+		store.Add(newSession("c", 2, start))
+
+		assert.False(t, store.IsUserRevoked(1, start))
+		assert.Equal(t, 2, store.RevokeUser(1, start.Add(time.Hour)))
+		assert.Empty(t, store.List(1))
+		assert.True(t, store.IsActive(2, "c"), "other user's session")
+
+		assert.True(t, store.IsUserRevoked(1, start), "issued before revocation")
+		assert.True(t, store.IsUserRevoked(1, time.Time{}), "tokens without iat")
+		assert.False(t, store.IsUserRevoked(1, start.Add(time.Second)), "issued after revocation")
+		assert.False(t, store.IsUserRevoked(2, start), "other user")
+
+		fake.Advance(time.Hour)
+		assert.False(t, store.IsUserRevoked(1, start), "earlier tokens expired")
+	})
+
+	t.Run("expired sessions hidden and collected", func(t *testing.T) {
+		fake := clock.NewFakeClock(start)
+		store := auth.NewSessionStore(fake)
+		store.Add(newSession("a", 1, start))
+
+		fake.Advance(7 * 24 * time.Hour)
+		assert.Empty(t, store.List(1))
+		assert.False(t, store.IsActive(1, "a"))
+		assert.False(t, store.Revoke(1, "a"), "expired session")
+	})
+}
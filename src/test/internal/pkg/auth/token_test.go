@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/pkg/auth"
+)
+
+// TestPurposeToken 测试用途 Token 的生成与解析
+func TestPurposeToken(t *testing.T) {
+	tokenTool := auth.NewTokenTool(config.GetJWTConfig())
+
+	t.Run("round trip", func(t *testing.T) {
+		token, err := tokenTool.GeneratePurposeToken(42, "alice", auth.TokenPurposeVerify, time.Hour)
+		assert.NoError(t, err)
+
+		claims, err := tokenTool.ParsePurposeToken(token, auth.TokenPurposeVerify)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), claims.UserID)
+		assert.Equal(t, auth.TokenPurposeVerify, claims.Purpose)
+	})
+
+	t.Run("purpose token cannot be used as access token", func(t *testing.T) {
+		token, err := tokenTool.GeneratePurposeToken(42, "alice", auth.TokenPurposeVerify, time.Hour)
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParseToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("access token cannot be used as purpose token", func(t *testing.T) {
+		token, err := tokenTool.GenerateToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParsePurposeToken(token, auth.TokenPurposeVerify)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token, err := tokenTool.GeneratePurposeToken(42, "alice", auth.TokenPurposeVerify, -time.Minute)
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParsePurposeToken(token, auth.TokenPurposeVerify)
+		assert.Error(t, err)
+	})
+}
+
+// TestEmailChangeToken 测试邮箱变更确认 Token 携带新邮箱且不能与其他用途混用
+func TestEmailChangeToken(t *testing.T) {
+	tokenTool := auth.NewTokenTool(config.GetJWTConfig())
+
+	token, err := tokenTool.GenerateEmailChangeToken(42, "alice", "new@example.com", time.Hour)
+	assert.NoError(t, err)
+
+	claims, err := tokenTool.ParseEmailChangeToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), claims.UserID)
+	assert.Equal(t, "new@example.com", claims.Email)
+
+	_, err = tokenTool.ParsePurposeToken(token, auth.TokenPurposeVerify)
+	assert.Error(t, err)
+
+	verifyToken, err := tokenTool.GeneratePurposeToken(42, "alice", auth.TokenPurposeVerify, time.Hour)
+	assert.NoError(t, err)
+	_, err = tokenTool.ParseEmailChangeToken(verifyToken)
+	assert.Error(t, err)
+
+	_, err = tokenTool.GenerateEmailChangeToken(42, "alice", "", time.Hour)
+	assert.Error(t, err)
+}
+
+// TestRefreshToken 测试刷新 Token 的生成与解析
+func TestRefreshToken(t *testing.T) {
+	tokenTool := auth.NewTokenTool(config.GetJWTConfig())
+
+	token, err := tokenTool.GenerateRefreshToken(42, "alice", "active")
+	assert.NoError(t, err)
+
+	claims, err := tokenTool.ParseRefreshToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), claims.UserID)
+	assert.Equal(t, "active", claims.Role)
+	assert.Equal(t, auth.TokenPurposeRefresh, claims.Purpose)
+
+	// 每个刷新 Token 带有唯一 ID，用于记录和吊销会话
+	other, err := tokenTool.GenerateRefreshToken(42, "alice", "active")
+	assert.NoError(t, err)
+	otherClaims, err := tokenTool.ParseRefreshToken(other)
+	assert.NoError(t, err)
+	assert.Len(t, claims.ID, 32)
+	assert.NotEqual(t, claims.ID, otherClaims.ID)
+
+	// 其他用途的 Token 不能作为刷新 Token 使用
+	verifyToken, err := tokenTool.GeneratePurposeToken(42, "alice", auth.TokenPurposeVerify, time.Hour)
+	assert.NoError(t, err)
+	_, err = tokenTool.ParseRefreshToken(verifyToken)
+	assert.Error(t, err)
+}
+
+// stubJWTConfig 测试用 JWT 配置
+type stubJWTConfig struct {
+	issuer   string
+	audience string
+}
+
+func (c stubJWTConfig) GetSecretKey() string {
+	return "test-secret-key-at-least-32-characters-long"
+}
+func (c stubJWTConfig) GetExpireDuration() time.Duration        { return time.Hour }
+func (c stubJWTConfig) GetRefreshExpireDuration() time.Duration { return time.Hour * 24 }
+func (c stubJWTConfig) GetIssuer() string                       { return c.issuer }
+func (c stubJWTConfig) GetAudience() string                     { return c.audience }
+
+// TestTokenIssuerAudience 测试签发者和受众校验
+func TestTokenIssuerAudience(t *testing.T) {
+	tokenTool := auth.NewTokenTool(stubJWTConfig{issuer: "todolist", audience: "todolist-api"})
+
+	t.Run("claims are set and accepted", func(t *testing.T) {
+		token, err := tokenTool.GenerateToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		claims, err := tokenTool.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "todolist", claims.Issuer)
+		assert.Equal(t, []string{"todolist-api"}, []string(claims.Audience))
+		assert.NotNil(t, claims.NotBefore)
+	})
+
+	t.Run("mismatched issuer is rejected", func(t *testing.T) {
+		other := auth.NewTokenTool(stubJWTConfig{issuer: "other-service", audience: "todolist-api"})
+		token, err := other.GenerateToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParseToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("mismatched audience is rejected", func(t *testing.T) {
+		other := auth.NewTokenTool(stubJWTConfig{issuer: "todolist", audience: "other-api"})
+		token, err := other.GenerateToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParseToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing issuer is rejected", func(t *testing.T) {
+		other := auth.NewTokenTool(stubJWTConfig{})
+		token, err := other.GenerateToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		_, err = tokenTool.ParseToken(token)
+		assert.Error(t, err)
+	})
+}
+
+// TestStaticTokenTool 测试固定密钥 Token 工具不依赖配置即可签发和解析 Token
+func TestStaticTokenTool(t *testing.T) {
+	tokenTool := auth.NewStaticTokenTool()
+
+	token, err := tokenTool.GenerateToken(42, "alice", "active")
+	assert.NoError(t, err)
+
+	claims, err := tokenTool.ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), claims.UserID)
+	assert.Equal(t, "alice", claims.Username)
+	assert.WithinDuration(t, time.Now().Add(auth.StaticTokenExpiration), claims.ExpiresAt.Time, 2*time.Second)
+
+	_, err = auth.NewTokenTool(config.GetJWTConfig()).ParseToken(token)
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"todolist/internal/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFakeClock 测试固定时钟只在显式设置或推进时变化
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	fake := clock.NewFakeClock(start)
+	assert.Equal(t, start, fake.Now())
+	assert.Equal(t, start, fake.Now())
+
+	fake.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), fake.Now())
+
+	fake.Advance(-time.Hour)
+	assert.Equal(t, start.Add(30*time.Minute), fake.Now())
+
+	later := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+	fake.Set(later)
+	assert.Equal(t, later, fake.Now())
+}
+
+// TestRealClock 测试系统时钟返回当前时间
+func TestRealClock(t *testing.T) {
+	before := time.Now()
+	now := clock.RealClock{}.Now()
+	assert.False(t, now.Before(before))
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+}
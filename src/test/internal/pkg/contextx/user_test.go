@@ -0,0 +1,66 @@
+package contextx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/interfaces/http/middleware"
+	"todolist/internal/pkg/contextx"
+)
+
+// TestGetDataFromContext 测试从上下文读取认证用户信息
+func TestGetDataFromContext(t *testing.T) {
+	t.Run("unauthenticated context", func(t *testing.T) {
+		_, ok := contextx.GetDataFromContext(context.Background())
+		assert.False(t, ok)
+	})
+
+	t.Run("with user", func(t *testing.T) {
+		want := contextx.UserContext{UserID: 42, Username: "alice", Role: "active"}
+		got, ok := contextx.GetDataFromContext(contextx.WithUser(context.Background(), want))
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("plain string keys ignored", func(t *testing.T) {
+		// 其他代码以字符串为键写入的值不会被当作认证用户
+		ctx := context.WithValue(context.Background(), "user_id", contextx.UserContext{UserID: 1})
+		ctx = context.WithValue(ctx, "claimsdata", contextx.UserContext{UserID: 1})
+
+		_, ok := contextx.GetDataFromContext(ctx)
+		assert.False(t, ok)
+	})
+
+	t.Run("set by auth middleware", func(t *testing.T) {
+		token, err := middleware.GenerateAccessToken(42, "alice", "active")
+		assert.NoError(t, err)
+
+		var (
+			got contextx.UserContext
+			ok  bool
+		)
+		h := middleware.GetAuthMiddleware().Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok = contextx.GetDataFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, ok)
+		assert.Equal(t, int64(42), got.UserID)
+		assert.Equal(t, "alice", got.Username)
+	})
+}
+
+// TestAPIVersionContext 测试 API 版本的读写及默认值
+func TestAPIVersionContext(t *testing.T) {
+	assert.Equal(t, contextx.APIVersionV1, contextx.GetAPIVersionFromContext(context.Background()))
+
+	ctx := contextx.WithAPIVersion(context.Background(), contextx.APIVersionV2)
+	assert.Equal(t, contextx.APIVersionV2, contextx.GetAPIVersionFromContext(ctx))
+}
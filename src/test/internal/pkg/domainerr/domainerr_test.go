@@ -0,0 +1,54 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todolist/internal/domain/user"
+	"todolist/internal/interfaces/http/response"
+	"todolist/internal/pkg/domainerr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBusinessError_Is 测试业务错误按 Code 比较
+func TestBusinessError_Is(t *testing.T) {
+	t.Run("wrapped sentinel", func(t *testing.T) {
+		err := fmt.Errorf("authenticate: %w", user.ErrInvalidCredentials)
+
+		assert.True(t, errors.Is(err, user.ErrInvalidCredentials))
+		assert.False(t, errors.Is(err, user.ErrUserNotFound))
+	})
+
+	t.Run("sentinel with internal error", func(t *testing.T) {
+		withCause := user.ErrInvalidCredentials
+		withCause.InternalError = errors.New("bcrypt: hash mismatch")
+		err := fmt.Errorf("authenticate: %w", withCause)
+
+		assert.NotEqual(t, user.ErrInvalidCredentials, withCause)
+		assert.True(t, errors.Is(err, user.ErrInvalidCredentials))
+	})
+
+	t.Run("non business error target", func(t *testing.T) {
+		assert.False(t, user.ErrInvalidCredentials.Is(errors.New("invalid credentials")))
+	})
+
+	t.Run("wrapped error keeps HTTP mapping", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		response.WriteError(rec, fmt.Errorf("handler: %w", fmt.Errorf("service: %w", user.ErrInvalidCredentials)))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+// TestBusinessError_IsDistinctCodes 测试不同 Code 的业务错误不相等
+func TestBusinessError_IsDistinctCodes(t *testing.T) {
+	a := domainerr.BusinessError{Code: "A", Type: domainerr.ValidationError, Message: "same"}
+	b := domainerr.BusinessError{Code: "B", Type: domainerr.ValidationError, Message: "same"}
+
+	assert.False(t, errors.Is(a, b))
+	assert.True(t, errors.Is(a, domainerr.BusinessError{Code: "A"}))
+}
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"todolist/internal/pkg/logger"
+)
+
+// TestInit_FilePath 测试配置文件路径时日志写入文件
+func TestInit_FilePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger.Init(logger.Config{
+		Level:    logger.LevelInfo,
+		Format:   logger.FormatJSON,
+		FilePath: path,
+	})
+	// 恢复默认配置并关闭日志文件
+	defer logger.Init(logger.DefaultConfig())
+
+	logger.Info("写入文件", logger.String("key", "value"))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"msg":"写入文件"`)
+	assert.Contains(t, string(content), `"key":"value"`)
+}
+
+// TestConfigFromEnv 测试从环境变量读取日志文件配置
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("LOG_FILE", "/var/log/todolist/app.log")
+	t.Setenv("LOG_FILE_MAX_SIZE_MB", "50")
+	t.Setenv("LOG_FILE_MAX_BACKUPS", "3")
+	t.Setenv("LOG_FILE_MAX_AGE_DAYS", "invalid")
+
+	cfg := logger.ConfigFromEnv(logger.Config{MaxAgeDays: 14})
+	assert.Equal(t, "/var/log/todolist/app.log", cfg.FilePath)
+	assert.Equal(t, 50, cfg.MaxSizeMB)
+	assert.Equal(t, 3, cfg.MaxBackups)
+	// 格式无效时保留原值
+	assert.Equal(t, 14, cfg.MaxAgeDays)
+}
+
+// TestInit_RedactsSensitiveFields 测试敏感字段在输出前被脱敏
+func TestInit_RedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger.Init(logger.Config{
+		Level:  logger.LevelInfo,
+		Format: logger.FormatJSON,
+		Output: &buf,
+	})
+	defer logger.Init(logger.DefaultConfig())
+
+	logger.Info("用户登录",
+		logger.String("email", "test@example.com"),
+		logger.String("password", "SuperSecret123"),
+	)
+	logger.With(logger.String("token", "jwt-token-value")).
+		Info("带预设字段", slog.Group("user", slog.String("Password_Hash", "$2a$10$hash")))
+
+	output := buf.String()
+	assert.Contains(t, output, `"password":"***"`)
+	assert.Contains(t, output, `"token":"***"`)
+	assert.Contains(t, output, `"Password_Hash":"***"`)
+	assert.Contains(t, output, "test@example.com")
+	assert.NotContains(t, output, "SuperSecret123")
+	assert.NotContains(t, output, "jwt-token-value")
+	assert.NotContains(t, output, "$2a$10$hash")
+}
+
+// TestInit_AggregatorFormats 测试 ECS 和 Stackdriver 格式重命名标准字段并映射级别
+func TestInit_AggregatorFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   logger.Format
+		contains []string
+		absent   []string
+	}{
+		{
+			name:     "ecs",
+			format:   logger.FormatECS,
+			contains: []string{`"@timestamp":`, `"log.level":"warn"`, `"message":"磁盘空间不足"`, `"ecs.version":`, `"log.origin":{"file":{"name":`},
+			absent:   []string{`"time":`, `"level":`, `"msg":"磁盘空间不足"`},
+		},
+		{
+			name:     "stackdriver",
+			format:   logger.FormatStackdriver,
+			contains: []string{`"timestamp":`, `"severity":"WARNING"`, `"message":"磁盘空间不足"`, `"logging.googleapis.com/sourceLocation":{"file":`},
+			absent:   []string{`"time":`, `"level":`, `"msg":"磁盘空间不足"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger.Init(logger.Config{
+				Level:     logger.LevelInfo,
+				Format:    tt.format,
+				Output:    &buf,
+				AddSource: true,
+			})
+			defer logger.Init(logger.DefaultConfig())
+
+			logger.Warn("磁盘空间不足", logger.String("password", "secret"), slog.Group("disk", slog.String("msg", "nested")))
+
+			output := buf.String()
+			for _, s := range tt.contains {
+				assert.Contains(t, output, s)
+			}
+			for _, s := range tt.absent {
+				assert.NotContains(t, output, s)
+			}
+			// 分组内的同名字段不被重命名，脱敏仍然生效
+			assert.Contains(t, output, `"disk":{"msg":"nested"}`)
+			assert.Contains(t, output, `"password":"***"`)
+		})
+	}
+}
+
+// TestParseFormat 测试日志格式名称解析
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]logger.Format{
+		"json":         logger.FormatJSON,
+		"TEXT":         logger.FormatText,
+		"ecs":          logger.FormatECS,
+		" stackdriver": logger.FormatStackdriver,
+	} {
+		got, err := logger.ParseFormat(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := logger.ParseFormat("logfmt")
+	assert.Error(t, err)
+}
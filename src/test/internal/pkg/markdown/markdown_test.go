@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/pkg/markdown"
+)
+
+// TestRender 测试 Markdown 渲染及 GitHub 风格扩展
+func TestRender(t *testing.T) {
+	r := markdown.NewRenderer()
+
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+	}{
+		{"heading and emphasis", "# 标题\n\n**粗体** _斜体_", []string{"<h1>标题</h1>", "<strong>粗体</strong>", "<em>斜体</em>"}},
+		{"link", "[链接](https://example.com)", []string{`href="https://example.com"`, `rel="nofollow noopener"`, `target="_blank"`}},
+		{"table", "| a | b |\n|---|---|\n| 1 | 2 |", []string{"<table>", "<td>1</td>"}},
+		{"task list", "- [x] done\n- [ ] todo", []string{`<input checked="" disabled="" type="checkbox">`, `<input disabled="" type="checkbox">`}},
+		{"strikethrough", "~~删除~~", []string{"<del>删除</del>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.Render(tt.source)
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, html, want)
+			}
+		})
+	}
+}
+
+// TestRender_Sanitize 测试渲染结果移除可能导致 XSS 的内容
+func TestRender_Sanitize(t *testing.T) {
+	r := markdown.NewRenderer()
+
+	tests := []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{
+		{"script tag", "<script>alert(1)</script>\n\nok", []string{"<script", "alert(1)"}},
+		{"event handler", `<img src="x" onerror="alert(1)">`, []string{"onerror"}},
+		{"javascript link", "[x](javascript:alert(1))", []string{"javascript:"}},
+		{"iframe", `<iframe src="https://evil.example.com"></iframe>`, []string{"<iframe"}},
+		{"non checkbox input", `<input type="text" value="x">`, []string{"<input"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.Render(tt.source)
+			require.NoError(t, err)
+			for _, bad := range tt.forbidden {
+				assert.NotContains(t, html, bad)
+			}
+		})
+	}
+}
+
+// TestGetRenderer 测试默认渲染器为单例
+func TestGetRenderer(t *testing.T) {
+	assert.Same(t, markdown.GetRenderer(), markdown.GetRenderer())
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"todolist/internal/pkg/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEvery 测试任务启动时立即执行、按间隔重复执行，上下文取消后停止
+func TestEvery(t *testing.T) {
+	t.Run("runs immediately and repeats", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var runs atomic.Int32
+		done := scheduler.Every(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+
+		assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+		cancel()
+		<-done
+
+		stopped := runs.Load()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, stopped, runs.Load())
+	})
+
+	t.Run("first run does not wait for interval", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ran := make(chan struct{}, 1)
+		done := scheduler.Every(ctx, "test", time.Hour, func(ctx context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run at start")
+		}
+		cancel()
+		<-done
+	})
+
+	t.Run("errors and panics do not stop schedule", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var runs atomic.Int32
+		done := scheduler.Every(ctx, "test", 5*time.Millisecond, func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				panic("boom")
+			}
+			return errors.New("database unavailable")
+		})
+
+		assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+		cancel()
+		<-done
+	})
+
+	t.Run("cancelled before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var runs atomic.Int32
+		<-scheduler.Every(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+		assert.Equal(t, int32(0), runs.Load())
+	})
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"todolist/internal/infrastructure/config"
+	"todolist/internal/infrastructure/persistence"
+	"todolist/internal/infrastructure/persistence/sqlite"
+	"todolist/internal/interfaces/http/handler"
+	"todolist/internal/interfaces/http/request"
+	"todolist/internal/routes"
+)
+
+// TestInitRoutes_SQLite 冒烟测试：通过 InitRoutes 注册的路由表访问各模块接口（内存 SQLite）
+func TestInitRoutes_SQLite(t *testing.T) {
+	client, err := sqlite.Open(sqlite.MemoryPath)
+	require.NoError(t, err)
+	defer client.Close()
+	persistence.SetFactory(persistence.NewClientFactory(client))
+
+	mux := http.NewServeMux()
+	routes.InitRoutes(mux, &config.AvatarConfig{StorageDir: t.TempDir()})
+
+	ctx := context.Background()
+	_, err = handler.RegisterUserHandler(ctx, request.RegisterUserRequest{
+		Username: "RouteUser",
+		Email:    "route@example.com",
+		Password: "Route123!",
+	})
+	require.NoError(t, err)
+	login, err := handler.LoginUserHandler(ctx, request.LoginUserRequest{Email: "route@example.com", Password: "Route123!"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("health", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/health", "", "").Code)
+	})
+
+	t.Run("current user", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1/users/me", "", login.Token)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+
+	t.Run("daily notes require authentication", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/daily-notes/today", "", "").Code)
+	})
+
+	t.Run("daily notes", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/daily-notes", `{"content":"hello"}`, login.Token)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		rec = serve(http.MethodGet, "/api/v1/daily-notes/today", "", login.Token)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body struct {
+			Data struct {
+				Content string `json:"content"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "hello", body.Data.Content)
+
+		rec = serve(http.MethodGet, "/api/v1/daily-notes/stats", "", login.Token)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+}